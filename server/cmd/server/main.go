@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log/slog"
 	"net/http"
 	"os"
@@ -12,21 +13,26 @@ import (
 
 	"github.com/goncalo-marques/ecomap/server/internal/authn"
 	"github.com/goncalo-marques/ecomap/server/internal/authz"
+	"github.com/goncalo-marques/ecomap/server/internal/breach"
 	"github.com/goncalo-marques/ecomap/server/internal/config"
+	"github.com/goncalo-marques/ecomap/server/internal/domain"
 	"github.com/goncalo-marques/ecomap/server/internal/logging"
+	"github.com/goncalo-marques/ecomap/server/internal/mail"
+	"github.com/goncalo-marques/ecomap/server/internal/oidc"
 	"github.com/goncalo-marques/ecomap/server/internal/service"
 	"github.com/goncalo-marques/ecomap/server/internal/store"
 	transporthttp "github.com/goncalo-marques/ecomap/server/internal/transport/http"
 )
 
-// Environment variable keys.
+// Default configuration values.
 const (
-	envKeyJWTSigningKey = "JWT_SIGNING_KEY"
+	defaultAddressHTTP = ":8080"
 )
 
-// Default configuration values.
+// Mail senders.
 const (
-	defaultAddressHTTP = ":8080"
+	mailSenderSMTP = "smtp"
+	mailSenderFile = "file"
 )
 
 // Server metadata.
@@ -75,18 +81,166 @@ func main() {
 	defer store.Close()
 
 	// Set up authentication service.
-	jwtSigningKey, ok := os.LookupEnv(envKeyJWTSigningKey)
-	if !ok {
-		logging.Logger.WarnContext(ctx, "main: failed to get jwt signing key")
+	authnKeys, err := loadAuthnKeys(serviceConfig.Authn.Keys)
+	if err != nil {
+		logging.Logger.ErrorContext(ctx, "main: failed to load authentication keys", logging.Error(err))
+		return
 	}
 
-	authnService := authn.New([]byte(jwtSigningKey))
+	var authnRotationGracePeriod time.Duration
+	if len(serviceConfig.Authn.RotationGracePeriod) != 0 {
+		authnRotationGracePeriod, err = time.ParseDuration(serviceConfig.Authn.RotationGracePeriod)
+		if err != nil {
+			logging.Logger.ErrorContext(ctx, "main: failed to parse authentication rotation grace period configuration", logging.Error(err))
+		}
+	}
 
-	// Set up authorization service.
-	authzService := authz.New(transporthttp.AuthzRoles, authnService)
+	authnService, err := authn.New(authnKeys, authnRotationGracePeriod)
+	if err != nil {
+		logging.Logger.ErrorContext(ctx, "main: failed to set up authentication service", logging.Error(err))
+		return
+	}
+
+	// Set up single sign-on identity provider.
+	var idpService service.IdentityProvider
+	if len(serviceConfig.Authn.SSO.Issuer) != 0 {
+		idpService, err = oidc.New(oidc.Options{
+			Issuer:       serviceConfig.Authn.SSO.Issuer,
+			ClientID:     serviceConfig.Authn.SSO.ClientID,
+			ClientSecret: serviceConfig.Authn.SSO.ClientSecret,
+			RedirectURL:  serviceConfig.Authn.SSO.RedirectURL,
+			Scopes:       serviceConfig.Authn.SSO.Scopes,
+			GroupsClaim:  serviceConfig.Authn.SSO.GroupsClaim,
+		})
+		if err != nil {
+			logging.Logger.ErrorContext(ctx, "main: failed to set up single sign-on identity provider", logging.Error(err))
+			return
+		}
+	}
+
+	ssoGroupsRoles := make(map[string]domain.EmployeeRole)
+	for _, group := range serviceConfig.Authn.SSO.Roles.WasteOperator {
+		ssoGroupsRoles[group] = domain.EmployeeRoleWasteOperator
+	}
+	for _, group := range serviceConfig.Authn.SSO.Roles.Manager {
+		ssoGroupsRoles[group] = domain.EmployeeRoleManager
+	}
+
+	totpRequiredRoles := make([]domain.EmployeeRole, len(serviceConfig.Authn.TOTP.RequiredRoles))
+	for i, role := range serviceConfig.Authn.TOTP.RequiredRoles {
+		totpRequiredRoles[i] = domain.EmployeeRole(role)
+		if !totpRequiredRoles[i].Valid() {
+			logging.Logger.ErrorContext(ctx, "main: invalid authentication totp required role configuration")
+			return
+		}
+	}
+
+	// Set up breached password checker.
+	var breachedPasswords service.BreachedPasswordChecker
+	if len(serviceConfig.Authn.PasswordPolicy.BreachedPasswordsFile) != 0 {
+		breachedPasswords = breach.NewFile(serviceConfig.Authn.PasswordPolicy.BreachedPasswordsFile)
+	}
+
+	var employeePasswordMaxAge time.Duration
+	if len(serviceConfig.Authn.PasswordPolicy.Employees.MaxAge) != 0 {
+		employeePasswordMaxAge, err = time.ParseDuration(serviceConfig.Authn.PasswordPolicy.Employees.MaxAge)
+		if err != nil {
+			logging.Logger.ErrorContext(ctx, "main: failed to parse authentication employees password max age configuration", logging.Error(err))
+		}
+	}
+
+	// Set up mail sender.
+	var mailSender service.MailSender
+	switch serviceConfig.Mail.Sender {
+	case mailSenderSMTP:
+		mailSender = mail.NewSMTP(mail.SMTPOptions{
+			Host:     serviceConfig.Mail.SMTP.Host,
+			Port:     serviceConfig.Mail.SMTP.Port,
+			Username: serviceConfig.Mail.SMTP.Username,
+			Password: serviceConfig.Mail.SMTP.Password,
+			From:     serviceConfig.Mail.From,
+		})
+	case mailSenderFile, "":
+		mailSender = mail.NewFile(serviceConfig.Mail.File, serviceConfig.Mail.From)
+	default:
+		logging.Logger.ErrorContext(ctx, "main: invalid mail sender configuration")
+		return
+	}
+
+	var usersTokensLimitPeriod time.Duration
+	if len(serviceConfig.Users.Tokens.LimitPeriod) != 0 {
+		usersTokensLimitPeriod, err = time.ParseDuration(serviceConfig.Users.Tokens.LimitPeriod)
+		if err != nil {
+			logging.Logger.ErrorContext(ctx, "main: failed to parse users tokens limit period configuration", logging.Error(err))
+		}
+	}
+
+	var signInFailureWindow time.Duration
+	if len(serviceConfig.Authn.SignIn.FailureWindow) != 0 {
+		signInFailureWindow, err = time.ParseDuration(serviceConfig.Authn.SignIn.FailureWindow)
+		if err != nil {
+			logging.Logger.ErrorContext(ctx, "main: failed to parse authentication sign-in failure window configuration", logging.Error(err))
+		}
+	}
+
+	var signInBackoffBase time.Duration
+	if len(serviceConfig.Authn.SignIn.Backoff.Base) != 0 {
+		signInBackoffBase, err = time.ParseDuration(serviceConfig.Authn.SignIn.Backoff.Base)
+		if err != nil {
+			logging.Logger.ErrorContext(ctx, "main: failed to parse authentication sign-in backoff base configuration", logging.Error(err))
+		}
+	}
+
+	var signInBackoffMax time.Duration
+	if len(serviceConfig.Authn.SignIn.Backoff.Max) != 0 {
+		signInBackoffMax, err = time.ParseDuration(serviceConfig.Authn.SignIn.Backoff.Max)
+		if err != nil {
+			logging.Logger.ErrorContext(ctx, "main: failed to parse authentication sign-in backoff max configuration", logging.Error(err))
+		}
+	}
+
+	var signInLockoutDuration time.Duration
+	if len(serviceConfig.Authn.SignIn.Lockout.Duration) != 0 {
+		signInLockoutDuration, err = time.ParseDuration(serviceConfig.Authn.SignIn.Lockout.Duration)
+		if err != nil {
+			logging.Logger.ErrorContext(ctx, "main: failed to parse authentication sign-in lockout duration configuration", logging.Error(err))
+		}
+	}
 
 	// Set up service.
-	service := service.New(authnService, store)
+	var collectionDetectionDwellTime time.Duration
+	if len(serviceConfig.Routes.CollectionDetection.DwellTime) != 0 {
+		collectionDetectionDwellTime, err = time.ParseDuration(serviceConfig.Routes.CollectionDetection.DwellTime)
+		if err != nil {
+			logging.Logger.ErrorContext(ctx, "main: failed to parse routes collection detection dwell time configuration", logging.Error(err))
+		}
+	}
+
+	service := service.New(authnService, idpService, mailSender, breachedPasswords, store, service.Options{
+		CollectionDetectionRadius:    serviceConfig.Routes.CollectionDetection.Radius,
+		CollectionDetectionDwellTime: collectionDetectionDwellTime,
+		DeviationRadius:              serviceConfig.Routes.DeviationRadius,
+		SSOGroupsRoles:               ssoGroupsRoles,
+		UserPasswordResetURL:         serviceConfig.Users.PasswordResetURL,
+		UserEmailVerificationURL:     serviceConfig.Users.EmailVerificationURL,
+		UserTokensLimit:              serviceConfig.Users.Tokens.Limit,
+		UserTokensLimitPeriod:        usersTokensLimitPeriod,
+		SignInFailureWindow:          signInFailureWindow,
+		SignInBackoffThreshold:       serviceConfig.Authn.SignIn.Backoff.Threshold,
+		SignInBackoffBase:            signInBackoffBase,
+		SignInBackoffMax:             signInBackoffMax,
+		SignInLockoutThreshold:       serviceConfig.Authn.SignIn.Lockout.Threshold,
+		SignInLockoutDuration:        signInLockoutDuration,
+		SignInIPAddressThreshold:     serviceConfig.Authn.SignIn.Lockout.IPAddressThreshold,
+		TOTPIssuer:                   serviceConfig.Authn.TOTP.Issuer,
+		TOTPRequiredRoles:            totpRequiredRoles,
+		UserPasswordPolicy:           passwordPolicyFromConfig(serviceConfig.Authn.PasswordPolicy.Users),
+		EmployeePasswordPolicy:       passwordPolicyFromConfig(serviceConfig.Authn.PasswordPolicy.Employees.AuthnPasswordPolicy),
+		EmployeePasswordMaxAge:       employeePasswordMaxAge,
+	})
+
+	// Set up authorization service.
+	authzService := authz.New(transporthttp.AuthzPolicy, authnService, service, service, service)
 
 	// Handle signals.
 	sigs := make(chan os.Signal, 1)
@@ -98,7 +252,9 @@ func main() {
 		addressHTTP = serviceConfig.ServerHTTP.Address
 	}
 
-	handlerHTTP := transporthttp.New(authzService, service)
+	handlerHTTP := transporthttp.New(authzService, service, transporthttp.Options{
+		ClientIPHeader: serviceConfig.ServerHTTP.ClientIPHeader,
+	})
 
 	var writeTimeoutHTTP time.Duration
 	if len(serviceConfig.ServerHTTP.WriteTimeout) != 0 {
@@ -115,6 +271,9 @@ func main() {
 		ErrorLog:     slog.NewLogLogger(logging.Logger.Handler(), slog.LevelError),
 	}
 
+	// Close the live subscriptions on shutdown, so that streaming requests can terminate.
+	serverHTTP.RegisterOnShutdown(service.Close)
+
 	go func() {
 		err := serverHTTP.ListenAndServe()
 		if errors.Is(err, http.ErrServerClosed) {
@@ -139,3 +298,51 @@ func main() {
 
 	logging.Logger.InfoContext(ctx, "main: server terminated")
 }
+
+// loadAuthnKeys returns the authentication signing keys defined in the given configuration, reading the private keys
+// from their files.
+func loadAuthnKeys(keysConfig []config.AuthnKey) ([]authn.Key, error) {
+	keys := make([]authn.Key, len(keysConfig))
+
+	for i, keyConfig := range keysConfig {
+		data, err := os.ReadFile(keyConfig.PrivateKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("key %s: failed to read private key file: %w", keyConfig.ID, err)
+		}
+
+		privateKey, err := authn.ParsePrivateKeyPEM(data)
+		if err != nil {
+			return nil, fmt.Errorf("key %s: failed to parse private key: %w", keyConfig.ID, err)
+		}
+
+		var activeFrom time.Time
+		if len(keyConfig.ActiveFrom) != 0 {
+			activeFrom, err = time.Parse(time.RFC3339, keyConfig.ActiveFrom)
+			if err != nil {
+				return nil, fmt.Errorf("key %s: failed to parse active from: %w", keyConfig.ID, err)
+			}
+		}
+
+		keys[i] = authn.Key{
+			ID:         keyConfig.ID,
+			Algorithm:  authn.KeyAlgorithm(keyConfig.Algorithm),
+			PrivateKey: privateKey,
+			ActiveFrom: activeFrom,
+		}
+	}
+
+	return keys, nil
+}
+
+// passwordPolicyFromConfig returns the password policy based on the given configuration.
+func passwordPolicyFromConfig(policyConfig config.AuthnPasswordPolicy) domain.PasswordPolicy {
+	return domain.PasswordPolicy{
+		MinLength:       policyConfig.MinLength,
+		MaxLength:       policyConfig.MaxLength,
+		MinDigits:       policyConfig.MinDigits,
+		MinLetters:      policyConfig.MinLetters,
+		MinSpecialChars: policyConfig.MinSpecialChars,
+		HistorySize:     policyConfig.HistorySize,
+		CheckBreached:   policyConfig.CheckBreached,
+	}
+}
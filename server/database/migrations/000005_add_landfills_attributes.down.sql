@@ -0,0 +1,8 @@
+-- Landfills.
+ALTER TABLE landfills
+    DROP CONSTRAINT landfills_daily_capacity_positive_check,
+    DROP COLUMN daily_capacity,
+    DROP COLUMN closing_time,
+    DROP COLUMN opening_time,
+    DROP COLUMN accepted_categories,
+    DROP COLUMN name;
@@ -0,0 +1,8 @@
+-- Landfills.
+ALTER TABLE landfills
+    ADD COLUMN name                 varchar(50),
+    ADD COLUMN accepted_categories  containers_category[]   NOT NULL    DEFAULT enum_range(NULL::containers_category),
+    ADD COLUMN opening_time         time,
+    ADD COLUMN closing_time         time,
+    ADD COLUMN daily_capacity       double precision,
+    ADD CONSTRAINT landfills_daily_capacity_positive_check CHECK (daily_capacity >= 0);
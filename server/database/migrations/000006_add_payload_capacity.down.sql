@@ -0,0 +1,11 @@
+-- Trucks.
+ALTER TABLE trucks
+    DROP CONSTRAINT trucks_weight_capacity_positive_check,
+    DROP CONSTRAINT trucks_volume_capacity_positive_check,
+    DROP COLUMN weight_capacity,
+    DROP COLUMN volume_capacity;
+
+-- Containers.
+ALTER TABLE containers
+    DROP CONSTRAINT containers_volume_positive_check,
+    DROP COLUMN volume;
@@ -0,0 +1,11 @@
+-- Containers.
+ALTER TABLE containers
+    ADD COLUMN volume   double precision,
+    ADD CONSTRAINT containers_volume_positive_check CHECK (volume > 0);
+
+-- Trucks.
+ALTER TABLE trucks
+    ADD COLUMN volume_capacity  double precision,
+    ADD COLUMN weight_capacity  double precision,
+    ADD CONSTRAINT trucks_volume_capacity_positive_check CHECK (volume_capacity > 0),
+    ADD CONSTRAINT trucks_weight_capacity_positive_check CHECK (weight_capacity > 0);
@@ -0,0 +1,8 @@
+-- Routes.
+ALTER TABLE routes
+    DROP COLUMN departure_time;
+
+-- Containers.
+ALTER TABLE containers
+    DROP COLUMN collection_end_time,
+    DROP COLUMN collection_start_time;
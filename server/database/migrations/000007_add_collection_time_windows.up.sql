@@ -0,0 +1,8 @@
+-- Containers.
+ALTER TABLE containers
+    ADD COLUMN collection_start_time    time,
+    ADD COLUMN collection_end_time      time;
+
+-- Routes.
+ALTER TABLE routes
+    ADD COLUMN departure_time   time;
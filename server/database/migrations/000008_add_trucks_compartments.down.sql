@@ -0,0 +1,2 @@
+-- Truck compartments.
+DROP TABLE trucks_compartments;
@@ -0,0 +1,10 @@
+-- Truck compartments.
+CREATE TABLE trucks_compartments (
+    truck_id    uuid                    NOT NULL,
+    position    integer                 NOT NULL,
+    categories  containers_category[]   NOT NULL,
+    created_at  timestamp               NOT NULL    DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT trucks_compartments_pkey                 PRIMARY KEY (truck_id, position),
+    CONSTRAINT trucks_compartments_truck_id_fkey        FOREIGN KEY (truck_id)      REFERENCES trucks (id)  ON DELETE CASCADE,
+    CONSTRAINT trucks_compartments_categories_not_empty CHECK (cardinality(categories) > 0)
+);
@@ -0,0 +1,2 @@
+-- Truck positions.
+DROP TABLE trucks_positions;
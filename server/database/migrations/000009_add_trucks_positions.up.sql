@@ -0,0 +1,13 @@
+-- Truck positions.
+CREATE TABLE trucks_positions (
+    truck_id    uuid                    NOT NULL,
+    geom        geometry('POINT', 4326) NOT NULL,
+    speed       double precision,
+    heading     double precision,
+    recorded_at timestamp               NOT NULL,
+    created_at  timestamp               NOT NULL    DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT trucks_positions_pkey            PRIMARY KEY (truck_id, recorded_at),
+    CONSTRAINT trucks_positions_truck_id_fkey   FOREIGN KEY (truck_id)  REFERENCES trucks (id)  ON DELETE CASCADE,
+    CONSTRAINT trucks_positions_speed_check     CHECK (speed >= 0),
+    CONSTRAINT trucks_positions_heading_check   CHECK (heading >= 0 AND heading < 360)
+);
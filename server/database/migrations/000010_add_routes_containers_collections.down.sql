@@ -0,0 +1,8 @@
+-- Route container collections.
+DROP TABLE routes_containers_collections;
+DROP TYPE routes_containers_collections_source;
+
+-- Routes.
+ALTER TABLE routes
+    DROP COLUMN started_at,
+    DROP COLUMN finished_at;
@@ -0,0 +1,27 @@
+-- Routes.
+ALTER TABLE routes
+    ADD COLUMN started_at   timestamp,
+    ADD COLUMN finished_at  timestamp;
+
+-- Route container collections.
+CREATE TYPE routes_containers_collections_source AS ENUM ('automatic', 'manual');
+
+CREATE TABLE routes_containers_collections (
+    route_id            uuid                                    NOT NULL,
+    container_id        uuid                                    NOT NULL,
+    route_started_at    timestamp                               NOT NULL,
+    collected           boolean                                 NOT NULL,
+    confidence          double precision                        NOT NULL,
+    source              routes_containers_collections_source    NOT NULL,
+    collected_at        timestamp                               NOT NULL,
+    created_at          timestamp                               NOT NULL    DEFAULT CURRENT_TIMESTAMP,
+    modified_at         timestamp                               NOT NULL    DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT routes_containers_collections_pkey                       PRIMARY KEY (route_id, container_id, route_started_at),
+    CONSTRAINT routes_containers_collections_route_id_container_id_fkey FOREIGN KEY (route_id, container_id)    REFERENCES routes_containers (route_id, container_id)   ON DELETE CASCADE,
+    CONSTRAINT routes_containers_collections_confidence_check           CHECK (confidence >= 0 AND confidence <= 1)
+);
+
+CREATE TRIGGER routes_containers_collections_update_modified_at
+    BEFORE UPDATE ON routes_containers_collections
+    FOR EACH ROW
+    EXECUTE PROCEDURE update_modified_at();
@@ -0,0 +1,2 @@
+-- Route tracks.
+DROP TABLE routes_tracks;
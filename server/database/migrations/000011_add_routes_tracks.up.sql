@@ -0,0 +1,27 @@
+-- Route tracks.
+CREATE TABLE routes_tracks (
+    route_id                uuid                                NOT NULL,
+    date                    date                                NOT NULL,
+    geom                    geometry('LINESTRING', 4326)        NOT NULL,
+    timestamps              timestamp[]                         NOT NULL,
+    planned_distance        double precision                    NOT NULL,
+    actual_distance         double precision                    NOT NULL,
+    off_route_distance      double precision                    NOT NULL,
+    off_route_geom          geometry('MULTILINESTRING', 4326),
+    planned_duration        integer                             NOT NULL,
+    actual_duration         integer                             NOT NULL,
+    missed_container_ids    uuid[]                              NOT NULL,
+    deviation               double precision                    NOT NULL    GENERATED ALWAYS AS (CASE WHEN actual_distance > 0 THEN off_route_distance / actual_distance ELSE 0 END) STORED,
+    created_at              timestamp                           NOT NULL    DEFAULT CURRENT_TIMESTAMP,
+    modified_at             timestamp                           NOT NULL    DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT routes_tracks_pkey               PRIMARY KEY (route_id, date),
+    CONSTRAINT routes_tracks_route_id_fkey      FOREIGN KEY (route_id)  REFERENCES routes (id)  ON DELETE CASCADE,
+    CONSTRAINT routes_tracks_timestamps_check   CHECK (cardinality(timestamps) = ST_NPoints(geom))
+);
+
+CREATE INDEX routes_tracks_deviation_idx ON routes_tracks (deviation);
+
+CREATE TRIGGER routes_tracks_update_modified_at
+    BEFORE UPDATE ON routes_tracks
+    FOR EACH ROW
+    EXECUTE PROCEDURE update_modified_at();
@@ -0,0 +1,14 @@
+-- Truck odometer readings.
+DROP TABLE trucks_odometer_readings;
+
+-- Truck maintenances.
+DROP TABLE trucks_maintenances;
+DROP TYPE trucks_maintenances_type;
+
+-- Trucks.
+ALTER TABLE trucks
+    DROP COLUMN status,
+    DROP COLUMN service_interval_distance,
+    DROP COLUMN service_interval_period;
+
+DROP TYPE trucks_status;
@@ -0,0 +1,46 @@
+-- Trucks.
+CREATE TYPE trucks_status AS ENUM ('active', 'maintenance', 'retired');
+
+ALTER TABLE trucks
+    ADD COLUMN status                       trucks_status       NOT NULL    DEFAULT 'active',
+    ADD COLUMN service_interval_distance    double precision,
+    ADD COLUMN service_interval_period      integer,
+    ADD CONSTRAINT trucks_service_interval_distance_check   CHECK (service_interval_distance > 0),
+    ADD CONSTRAINT trucks_service_interval_period_check     CHECK (service_interval_period > 0);
+
+-- Truck maintenances.
+CREATE TYPE trucks_maintenances_type AS ENUM ('service', 'repair', 'inspection', 'tires', 'other');
+
+CREATE TABLE trucks_maintenances (
+    id          uuid                        NOT NULL    DEFAULT GEN_RANDOM_UUID(),
+    truck_id    uuid                        NOT NULL,
+    type        trucks_maintenances_type    NOT NULL,
+    date        date                        NOT NULL,
+    cost        double precision,
+    notes       varchar(500),
+    odometer    double precision,
+    created_at  timestamp                   NOT NULL    DEFAULT CURRENT_TIMESTAMP,
+    modified_at timestamp                   NOT NULL    DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT trucks_maintenances_pkey             PRIMARY KEY (id),
+    CONSTRAINT trucks_maintenances_truck_id_fkey    FOREIGN KEY (truck_id)  REFERENCES trucks (id)  ON DELETE CASCADE,
+    CONSTRAINT trucks_maintenances_cost_check       CHECK (cost >= 0),
+    CONSTRAINT trucks_maintenances_odometer_check   CHECK (odometer >= 0)
+);
+
+CREATE INDEX trucks_maintenances_truck_id_date_idx ON trucks_maintenances (truck_id, date);
+
+CREATE TRIGGER trucks_maintenances_update_modified_at
+    BEFORE UPDATE ON trucks_maintenances
+    FOR EACH ROW
+    EXECUTE PROCEDURE update_modified_at();
+
+-- Truck odometer readings.
+CREATE TABLE trucks_odometer_readings (
+    truck_id    uuid                NOT NULL,
+    value       double precision    NOT NULL,
+    recorded_at timestamp           NOT NULL,
+    created_at  timestamp           NOT NULL    DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT trucks_odometer_readings_pkey            PRIMARY KEY (truck_id, recorded_at),
+    CONSTRAINT trucks_odometer_readings_truck_id_fkey   FOREIGN KEY (truck_id)  REFERENCES trucks (id)  ON DELETE CASCADE,
+    CONSTRAINT trucks_odometer_readings_value_check     CHECK (value >= 0)
+);
@@ -0,0 +1,6 @@
+-- Trucks.
+ALTER TABLE trucks
+    DROP COLUMN fuel_type,
+    DROP COLUMN fuel_consumption;
+
+DROP TYPE trucks_fuel_type;
@@ -0,0 +1,7 @@
+-- Trucks.
+CREATE TYPE trucks_fuel_type AS ENUM ('diesel', 'gasoline', 'lpg');
+
+ALTER TABLE trucks
+    ADD COLUMN fuel_type            trucks_fuel_type    NOT NULL    DEFAULT 'diesel',
+    ADD COLUMN fuel_consumption     double precision,
+    ADD CONSTRAINT trucks_fuel_consumption_check    CHECK (fuel_consumption > 0);
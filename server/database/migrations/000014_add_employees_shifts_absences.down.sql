@@ -0,0 +1,6 @@
+-- Employee absences.
+DROP TABLE employees_absences;
+DROP TYPE employees_absences_type;
+
+-- Employee shifts.
+DROP TABLE employees_shifts;
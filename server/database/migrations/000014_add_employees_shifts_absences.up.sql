@@ -0,0 +1,36 @@
+-- Employee shifts.
+CREATE TABLE employees_shifts (
+    employee_id uuid        NOT NULL,
+    weekday     smallint    NOT NULL,
+    start_time  time        NOT NULL,
+    end_time    time        NOT NULL,
+    created_at  timestamp   NOT NULL    DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT employees_shifts_pkey                PRIMARY KEY (employee_id, weekday),
+    CONSTRAINT employees_shifts_employee_id_fkey    FOREIGN KEY (employee_id)   REFERENCES employees (id)   ON DELETE CASCADE,
+    CONSTRAINT employees_shifts_weekday_check       CHECK (weekday BETWEEN 0 AND 6),
+    CONSTRAINT employees_shifts_time_check          CHECK (start_time <> end_time)
+);
+
+-- Employee absences.
+CREATE TYPE employees_absences_type AS ENUM ('vacation', 'sick_leave', 'training', 'other');
+
+CREATE TABLE employees_absences (
+    id          uuid                        NOT NULL    DEFAULT GEN_RANDOM_UUID(),
+    employee_id uuid                        NOT NULL,
+    type        employees_absences_type     NOT NULL,
+    start_date  date                        NOT NULL,
+    end_date    date                        NOT NULL,
+    notes       varchar(500),
+    created_at  timestamp                   NOT NULL    DEFAULT CURRENT_TIMESTAMP,
+    modified_at timestamp                   NOT NULL    DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT employees_absences_pkey              PRIMARY KEY (id),
+    CONSTRAINT employees_absences_employee_id_fkey  FOREIGN KEY (employee_id)   REFERENCES employees (id)   ON DELETE CASCADE,
+    CONSTRAINT employees_absences_dates_check       CHECK (start_date <= end_date)
+);
+
+CREATE INDEX employees_absences_employee_id_dates_idx ON employees_absences (employee_id, start_date, end_date);
+
+CREATE TRIGGER employees_absences_update_modified_at
+    BEFORE UPDATE ON employees_absences
+    FOR EACH ROW
+    EXECUTE PROCEDURE update_modified_at();
@@ -0,0 +1,10 @@
+-- Employee qualifications.
+DROP TABLE employees_qualifications;
+DROP TYPE employees_qualifications_type;
+
+-- Trucks.
+ALTER TABLE trucks
+    DROP COLUMN license_category;
+
+-- License categories.
+DROP TYPE license_category;
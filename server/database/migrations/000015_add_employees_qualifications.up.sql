@@ -0,0 +1,30 @@
+-- License categories.
+CREATE TYPE license_category AS ENUM ('B', 'C1', 'C1E', 'C', 'CE');
+
+-- Trucks.
+ALTER TABLE trucks
+    ADD COLUMN license_category license_category NOT NULL DEFAULT 'C';
+
+-- Employee qualifications.
+CREATE TYPE employees_qualifications_type AS ENUM ('license', 'hazardous_materials');
+
+CREATE TABLE employees_qualifications (
+    id                  uuid                            NOT NULL    DEFAULT GEN_RANDOM_UUID(),
+    employee_id         uuid                            NOT NULL,
+    type                employees_qualifications_type   NOT NULL,
+    license_category    license_category,
+    expiry_date         date                            NOT NULL,
+    created_at          timestamp                       NOT NULL    DEFAULT CURRENT_TIMESTAMP,
+    modified_at         timestamp                       NOT NULL    DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT employees_qualifications_pkey                    PRIMARY KEY (id),
+    CONSTRAINT employees_qualifications_employee_id_fkey        FOREIGN KEY (employee_id)   REFERENCES employees (id)   ON DELETE CASCADE,
+    CONSTRAINT employees_qualifications_license_category_check  CHECK ((type = 'license') = (license_category IS NOT NULL))
+);
+
+CREATE UNIQUE INDEX employees_qualifications_employee_id_type_license_category_key ON employees_qualifications (employee_id, type, coalesce(license_category::text, ''));
+CREATE INDEX employees_qualifications_expiry_date_idx ON employees_qualifications (expiry_date);
+
+CREATE TRIGGER employees_qualifications_update_modified_at
+    BEFORE UPDATE ON employees_qualifications
+    FOR EACH ROW
+    EXECUTE PROCEDURE update_modified_at();
@@ -0,0 +1,6 @@
+-- Revoked access tokens.
+DROP TABLE revoked_tokens;
+
+-- Refresh tokens.
+DROP TABLE refresh_tokens;
+DROP TYPE refresh_tokens_subject_type;
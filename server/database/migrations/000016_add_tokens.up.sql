@@ -0,0 +1,32 @@
+-- Refresh tokens.
+CREATE TYPE refresh_tokens_subject_type AS ENUM ('user', 'employee');
+
+CREATE TABLE refresh_tokens (
+    id                      uuid                        NOT NULL    DEFAULT GEN_RANDOM_UUID(),
+    family_id               uuid                        NOT NULL,
+    subject_type            refresh_tokens_subject_type NOT NULL,
+    subject_id              uuid                        NOT NULL,
+    hash                    bytea                       NOT NULL,
+    access_token_id         uuid                        NOT NULL,
+    access_token_expires_at timestamp                   NOT NULL,
+    expires_at              timestamp                   NOT NULL,
+    used_at                 timestamp,
+    revoked_at              timestamp,
+    created_at              timestamp                   NOT NULL    DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT refresh_tokens_pkey      PRIMARY KEY (id),
+    CONSTRAINT refresh_tokens_hash_key  UNIQUE (hash)
+);
+
+CREATE INDEX refresh_tokens_family_id_idx ON refresh_tokens (family_id);
+CREATE INDEX refresh_tokens_subject_id_idx ON refresh_tokens (subject_id);
+CREATE INDEX refresh_tokens_expires_at_idx ON refresh_tokens (expires_at);
+
+-- Revoked access tokens.
+CREATE TABLE revoked_tokens (
+    id          uuid        NOT NULL,
+    expires_at  timestamp   NOT NULL,
+    created_at  timestamp   NOT NULL    DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT revoked_tokens_pkey PRIMARY KEY (id)
+);
+
+CREATE INDEX revoked_tokens_expires_at_idx ON revoked_tokens (expires_at);
@@ -0,0 +1,6 @@
+-- Single sign-on authorizations.
+DROP TABLE sso_authorizations;
+
+-- Employees single sign-on subject.
+ALTER TABLE employees DROP CONSTRAINT employees_sso_subject_key;
+ALTER TABLE employees DROP COLUMN sso_subject;
@@ -0,0 +1,15 @@
+-- Employees single sign-on subject.
+ALTER TABLE employees ADD COLUMN sso_subject varchar(255);
+ALTER TABLE employees ADD CONSTRAINT employees_sso_subject_key UNIQUE (sso_subject);
+
+-- Single sign-on authorizations.
+CREATE TABLE sso_authorizations (
+    state           varchar(64)     NOT NULL,
+    nonce           varchar(64)     NOT NULL,
+    code_verifier   varchar(128)    NOT NULL,
+    expires_at      timestamp       NOT NULL,
+    created_at      timestamp       NOT NULL    DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT sso_authorizations_pkey PRIMARY KEY (state)
+);
+
+CREATE INDEX sso_authorizations_expires_at_idx ON sso_authorizations (expires_at);
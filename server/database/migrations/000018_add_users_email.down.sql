@@ -0,0 +1,7 @@
+-- Users tokens.
+DROP TABLE users_tokens;
+DROP TYPE users_tokens_purpose;
+
+-- Users email.
+ALTER TABLE users DROP COLUMN email_verified_at;
+ALTER TABLE users DROP COLUMN email;
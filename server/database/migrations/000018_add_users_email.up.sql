@@ -0,0 +1,23 @@
+-- Users email.
+ALTER TABLE users ADD COLUMN email varchar(254);
+ALTER TABLE users ADD COLUMN email_verified_at timestamp;
+
+-- Users tokens.
+CREATE TYPE users_tokens_purpose AS ENUM ('password_reset', 'email_verification');
+
+CREATE TABLE users_tokens (
+    id          uuid                    NOT NULL    DEFAULT GEN_RANDOM_UUID(),
+    user_id     uuid                    NOT NULL,
+    purpose     users_tokens_purpose    NOT NULL,
+    hash        bytea                   NOT NULL,
+    email       varchar(254)            NOT NULL,
+    expires_at  timestamp               NOT NULL,
+    used_at     timestamp,
+    created_at  timestamp               NOT NULL    DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT users_tokens_pkey            PRIMARY KEY (id),
+    CONSTRAINT users_tokens_hash_key        UNIQUE (hash),
+    CONSTRAINT users_tokens_user_id_fkey    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+
+CREATE INDEX users_tokens_user_id_purpose_idx ON users_tokens (user_id, purpose);
+CREATE INDEX users_tokens_expires_at_idx ON users_tokens (expires_at);
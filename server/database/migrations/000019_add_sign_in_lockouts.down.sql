@@ -0,0 +1,4 @@
+-- Sign-in lockouts.
+DROP TABLE sign_in_lockouts;
+DROP TYPE sign_in_lockouts_key_type;
+DROP TYPE sign_in_lockouts_subject_type;
@@ -0,0 +1,20 @@
+-- Sign-in lockouts.
+CREATE TYPE sign_in_lockouts_subject_type AS ENUM ('user', 'employee');
+CREATE TYPE sign_in_lockouts_key_type AS ENUM ('username', 'ip_address');
+
+CREATE TABLE sign_in_lockouts (
+    id              uuid                            NOT NULL    DEFAULT GEN_RANDOM_UUID(),
+    subject_type    sign_in_lockouts_subject_type   NOT NULL,
+    key_type        sign_in_lockouts_key_type       NOT NULL,
+    key             varchar(255)                    NOT NULL,
+    failures        integer                         NOT NULL,
+    last_failure_at timestamp                       NOT NULL,
+    blocked_until   timestamp,
+    locked_until    timestamp,
+    created_at      timestamp                       NOT NULL    DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT sign_in_lockouts_pkey                            PRIMARY KEY (id),
+    CONSTRAINT sign_in_lockouts_subject_type_key_type_key_key   UNIQUE (subject_type, key_type, key),
+    CONSTRAINT sign_in_lockouts_failures_check                  CHECK (failures > 0)
+);
+
+CREATE INDEX sign_in_lockouts_last_failure_at_idx ON sign_in_lockouts (last_failure_at);
@@ -0,0 +1,8 @@
+-- Employees MFA challenges.
+DROP TABLE employees_mfa_challenges;
+
+-- Employees recovery codes.
+DROP TABLE employees_recovery_codes;
+
+-- Employees TOTP.
+DROP TABLE employees_totp;
@@ -0,0 +1,38 @@
+-- Employees TOTP.
+CREATE TABLE employees_totp (
+    employee_id     uuid        NOT NULL,
+    secret          varchar(64) NOT NULL,
+    confirmed_at    timestamp,
+    last_used_step  bigint,
+    created_at      timestamp   NOT NULL    DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT employees_totp_pkey              PRIMARY KEY (employee_id),
+    CONSTRAINT employees_totp_employee_id_fkey  FOREIGN KEY (employee_id) REFERENCES employees (id) ON DELETE CASCADE
+);
+
+-- Employees recovery codes.
+CREATE TABLE employees_recovery_codes (
+    id          uuid        NOT NULL    DEFAULT GEN_RANDOM_UUID(),
+    employee_id uuid        NOT NULL,
+    hash        bytea       NOT NULL,
+    used_at     timestamp,
+    created_at  timestamp   NOT NULL    DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT employees_recovery_codes_pkey                    PRIMARY KEY (id),
+    CONSTRAINT employees_recovery_codes_employee_id_hash_key    UNIQUE (employee_id, hash),
+    CONSTRAINT employees_recovery_codes_employee_id_fkey        FOREIGN KEY (employee_id) REFERENCES employees (id) ON DELETE CASCADE
+);
+
+-- Employees MFA challenges.
+CREATE TABLE employees_mfa_challenges (
+    id                  uuid        NOT NULL    DEFAULT GEN_RANDOM_UUID(),
+    employee_id         uuid        NOT NULL,
+    hash                bytea       NOT NULL,
+    enrolment_required  boolean     NOT NULL,
+    attempts            integer     NOT NULL    DEFAULT 0,
+    expires_at          timestamp   NOT NULL,
+    created_at          timestamp   NOT NULL    DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT employees_mfa_challenges_pkey                PRIMARY KEY (id),
+    CONSTRAINT employees_mfa_challenges_hash_key            UNIQUE (hash),
+    CONSTRAINT employees_mfa_challenges_employee_id_fkey    FOREIGN KEY (employee_id) REFERENCES employees (id) ON DELETE CASCADE
+);
+
+CREATE INDEX employees_mfa_challenges_expires_at_idx ON employees_mfa_challenges (expires_at);
@@ -0,0 +1,2 @@
+-- API keys.
+DROP TABLE api_keys;
@@ -0,0 +1,14 @@
+-- API keys.
+CREATE TABLE api_keys (
+    id                      uuid                NOT NULL    DEFAULT GEN_RANDOM_UUID(),
+    name                    varchar(50)         NOT NULL,
+    hash                    bytea               NOT NULL,
+    roles                   employees_role[]    NOT NULL,
+    allowed_ip_addresses    cidr[]              NOT NULL    DEFAULT '{}',
+    expires_at              timestamp,
+    last_used_at            timestamp,
+    created_at              timestamp           NOT NULL    DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT api_keys_pkey        PRIMARY KEY (id),
+    CONSTRAINT api_keys_hash_key    UNIQUE (hash),
+    CONSTRAINT api_keys_roles_check CHECK (CARDINALITY(roles) > 0)
+);
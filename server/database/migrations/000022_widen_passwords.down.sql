@@ -0,0 +1,5 @@
+-- Employees.
+ALTER TABLE employees ALTER COLUMN password TYPE varchar(60);
+
+-- Users.
+ALTER TABLE users ALTER COLUMN password TYPE varchar(60);
@@ -0,0 +1,5 @@
+-- Users.
+ALTER TABLE users ALTER COLUMN password TYPE varchar(255);
+
+-- Employees.
+ALTER TABLE employees ALTER COLUMN password TYPE varchar(255);
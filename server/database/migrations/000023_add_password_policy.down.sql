@@ -0,0 +1,8 @@
+-- Employees password age.
+ALTER TABLE employees DROP COLUMN password_changed_at;
+
+-- Employees password history.
+DROP TABLE employees_password_history;
+
+-- Users password history.
+DROP TABLE users_password_history;
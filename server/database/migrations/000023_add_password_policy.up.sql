@@ -0,0 +1,26 @@
+-- Users password history.
+CREATE TABLE users_password_history (
+    id          uuid            NOT NULL    DEFAULT GEN_RANDOM_UUID(),
+    user_id     uuid            NOT NULL,
+    password    varchar(255)    NOT NULL,
+    created_at  timestamp       NOT NULL    DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT users_password_history_pkey          PRIMARY KEY (id),
+    CONSTRAINT users_password_history_user_id_fkey  FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX users_password_history_user_id_idx ON users_password_history (user_id);
+
+-- Employees password history.
+CREATE TABLE employees_password_history (
+    id          uuid            NOT NULL    DEFAULT GEN_RANDOM_UUID(),
+    employee_id uuid            NOT NULL,
+    password    varchar(255)    NOT NULL,
+    created_at  timestamp       NOT NULL    DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT employees_password_history_pkey              PRIMARY KEY (id),
+    CONSTRAINT employees_password_history_employee_id_fkey  FOREIGN KEY (employee_id) REFERENCES employees(id) ON DELETE CASCADE
+);
+
+CREATE INDEX employees_password_history_employee_id_idx ON employees_password_history (employee_id);
+
+-- Employees password age.
+ALTER TABLE employees ADD COLUMN password_changed_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP;
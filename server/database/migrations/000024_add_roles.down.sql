@@ -0,0 +1,6 @@
+-- API keys roles.
+DELETE FROM api_keys WHERE NOT (ARRAY_REPLACE(roles::text[], 'wasteOperator', 'waste_operator') <@ ARRAY['waste_operator', 'manager']);
+ALTER TABLE api_keys ALTER COLUMN roles TYPE employees_role[] USING ARRAY_REPLACE(roles::text[], 'wasteOperator', 'waste_operator')::employees_role[];
+
+-- Roles.
+DROP TABLE roles;
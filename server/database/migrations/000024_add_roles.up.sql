@@ -0,0 +1,69 @@
+-- Roles.
+CREATE TABLE roles (
+    id          uuid            NOT NULL    DEFAULT GEN_RANDOM_UUID(),
+    name        varchar(50)     NOT NULL,
+    permissions varchar(50)[]   NOT NULL    DEFAULT '{}',
+    built_in    boolean         NOT NULL    DEFAULT FALSE,
+    created_at  timestamp       NOT NULL    DEFAULT CURRENT_TIMESTAMP,
+    modified_at timestamp       NOT NULL    DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT roles_pkey       PRIMARY KEY (id),
+    CONSTRAINT roles_name_key   UNIQUE (name)
+);
+
+CREATE TRIGGER roles_update_modified_at
+    BEFORE UPDATE ON roles
+    FOR EACH ROW
+    EXECUTE PROCEDURE update_modified_at();
+
+-- Built-in roles, with the permissions previously granted to each role.
+INSERT INTO roles (name, permissions, built_in) VALUES
+    ('user', ARRAY[
+        'users:read:own',
+        'users:write:own',
+        'bookmarks:read:own',
+        'bookmarks:write:own',
+        'containers:read'
+    ], TRUE),
+    ('wasteOperator', ARRAY[
+        'employees:read:own',
+        'employees:write:own',
+        'containers:read',
+        'trucks:read',
+        'trucks:operate',
+        'warehouses:read',
+        'landfills:read',
+        'routes:read',
+        'routes:operate'
+    ], TRUE),
+    ('manager', ARRAY[
+        'users:read',
+        'users:write',
+        'employees:read',
+        'employees:write',
+        'containers:read',
+        'containers:write',
+        'trucks:read',
+        'trucks:write',
+        'trucks:operate',
+        'maintenances:read',
+        'maintenances:write',
+        'warehouses:read',
+        'warehouses:write',
+        'landfills:read',
+        'landfills:write',
+        'routes:read',
+        'routes:plan',
+        'routes:operate',
+        'routes:monitor',
+        'geocoding:read',
+        'emissions:read',
+        'signInLockouts:read',
+        'signInLockouts:write',
+        'apiKeys:read',
+        'apiKeys:write',
+        'roles:read',
+        'roles:write'
+    ], TRUE);
+
+-- API keys roles, which are now the names of the roles.
+ALTER TABLE api_keys ALTER COLUMN roles TYPE varchar(50)[] USING ARRAY_REPLACE(roles::text[], 'waste_operator', 'wasteOperator')::varchar(50)[];
@@ -0,0 +1,5 @@
+-- Employees roles.
+CREATE TYPE employees_role AS ENUM ('waste_operator', 'manager');
+
+ALTER TABLE employees DROP CONSTRAINT employees_role_fkey;
+ALTER TABLE employees ALTER COLUMN role TYPE employees_role USING (CASE role WHEN 'manager' THEN 'manager' ELSE 'waste_operator' END)::employees_role;
@@ -0,0 +1,5 @@
+-- Employees roles, which reference the stored roles.
+ALTER TABLE employees ALTER COLUMN role TYPE varchar(50) USING (CASE role WHEN 'waste_operator' THEN 'wasteOperator' ELSE role::text END);
+ALTER TABLE employees ADD CONSTRAINT employees_role_fkey FOREIGN KEY (role) REFERENCES roles (name);
+
+DROP TYPE employees_role;
@@ -0,0 +1,30 @@
+package authn
+
+// apiKeyPrefix defines the prefix of the API keys, which identifies them in secret scanners.
+const apiKeyPrefix = "ecomap_"
+
+// APIKey defines the API key structure. Only the hash of the key is meant to be stored.
+type APIKey struct {
+	Key  string
+	Hash []byte
+}
+
+// NewAPIKey returns a new random API key.
+func (s *service) NewAPIKey() (APIKey, error) {
+	token, err := newRandomToken()
+	if err != nil {
+		return APIKey{}, err
+	}
+
+	key := apiKeyPrefix + token
+
+	return APIKey{
+		Key:  key,
+		Hash: s.HashAPIKey(key),
+	}, nil
+}
+
+// HashAPIKey returns the hash of the given API key.
+func (s *service) HashAPIKey(key string) []byte {
+	return hashToken(key)
+}
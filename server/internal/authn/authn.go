@@ -1,13 +1,37 @@
 package authn
 
+import (
+	"sort"
+	"time"
+)
+
 // service defines the authentication service structure.
 type service struct {
-	jwtSigningKey []byte
+	keys                []Key
+	rotationGracePeriod time.Duration
 }
 
-// New returns a new authentication service.
-func New(jwtSigningKey []byte) *service {
-	return &service{
-		jwtSigningKey: jwtSigningKey,
+// New returns a new authentication service, or returns an error if the given keys cannot be used to sign tokens. The
+// rotation grace period defines for how long a key keeps verifying tokens after the next key becomes active, and is
+// never shorter than the expiration time of the tokens.
+func New(keys []Key, rotationGracePeriod time.Duration) (*service, error) {
+	err := validateKeys(keys, time.Now())
+	if err != nil {
+		return nil, err
 	}
+
+	sortedKeys := make([]Key, len(keys))
+	copy(sortedKeys, keys)
+	sort.SliceStable(sortedKeys, func(i, j int) bool {
+		return sortedKeys[i].ActiveFrom.Before(sortedKeys[j].ActiveFrom)
+	})
+
+	if rotationGracePeriod < jwtExpirationTime {
+		rotationGracePeriod = jwtExpirationTime
+	}
+
+	return &service{
+		keys:                sortedKeys,
+		rotationGracePeriod: rotationGracePeriod,
+	}, nil
 }
@@ -5,13 +5,15 @@ import (
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 )
 
 const (
 	jwtIssuer         = "server"
-	jwtExpirationTime = time.Hour * 24
+	jwtExpirationTime = time.Minute * 15
 
 	descriptionFailedToParseJWTWithClaims = "authn: failed to parse jwt with claims"
+	descriptionFailedToSignJWT            = "authn: failed to sign jwt"
 )
 
 // SubjectRole defines the role of the subject.
@@ -29,35 +31,74 @@ type Claims struct {
 	Roles []SubjectRole `json:"roles,omitempty"`
 }
 
-// NewJWT returns a new signed JSON Web Token with an expiration time of 24 hours and the specified claims.
-func (s *service) NewJWT(subject string, subjectRoles []SubjectRole) (string, error) {
-	expiresAt := time.Now().Add(jwtExpirationTime).UTC()
-	issuedAt := time.Now().UTC()
+// signingMethod returns the signing method of the given key algorithm.
+func signingMethod(algorithm KeyAlgorithm) jwt.SigningMethod {
+	switch algorithm {
+	case KeyAlgorithmRS256:
+		return jwt.SigningMethodRS256
+	case KeyAlgorithmEdDSA:
+		return jwt.SigningMethodEdDSA
+	default:
+		return nil
+	}
+}
+
+// NewJWT returns a new signed JSON Web Token with an expiration time of 15 minutes and the specified claims, along
+// with the claims of the token. Each token is identified by a random identifier, which allows it to be revoked, and
+// is signed by the current signing key, which is identified in the header of the token.
+func (s *service) NewJWT(subject string, subjectRoles []SubjectRole) (string, Claims, error) {
+	now := time.Now()
+	expiresAt := now.Add(jwtExpirationTime).UTC()
+	issuedAt := now.UTC()
+
+	key, ok := s.signingKey(now)
+	if !ok {
+		return "", Claims{}, fmt.Errorf("%s: %w", descriptionFailedToSignJWT, ErrKeyNotActive)
+	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, Claims{
+	claims := Claims{
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.New().String(),
 			Issuer:    jwtIssuer,
 			Subject:   subject,
 			ExpiresAt: jwt.NewNumericDate(expiresAt),
 			IssuedAt:  jwt.NewNumericDate(issuedAt),
 		},
 		Roles: subjectRoles,
-	})
+	}
+
+	token := jwt.NewWithClaims(signingMethod(key.Algorithm), claims)
+	token.Header["kid"] = key.ID
 
-	tokenString, err := token.SignedString(s.jwtSigningKey)
+	tokenString, err := token.SignedString(key.PrivateKey)
 	if err != nil {
-		return "", err
+		return "", Claims{}, fmt.Errorf("%s: %w", descriptionFailedToSignJWT, err)
 	}
 
-	return tokenString, nil
+	return tokenString, claims, nil
 }
 
 // ParseJWT parses the given token and returns the associated subject, or returns an error if the token is invalid.
+// The token must be signed by a key that is active and not retired, with the algorithm of that key.
 func (s *service) ParseJWT(tokenString string) (Claims, error) {
 	var claims Claims
 	_, err := jwt.ParseWithClaims(tokenString, &claims, func(t *jwt.Token) (interface{}, error) {
-		return s.jwtSigningKey, nil
-	})
+		keyID, ok := t.Header["kid"].(string)
+		if !ok {
+			return nil, ErrKeyNotFound
+		}
+
+		key, err := s.verificationKey(keyID, time.Now())
+		if err != nil {
+			return nil, err
+		}
+
+		if t.Method != signingMethod(key.Algorithm) {
+			return nil, ErrKeyNotFound
+		}
+
+		return key.PrivateKey.Public(), nil
+	}, jwt.WithValidMethods([]string{string(KeyAlgorithmRS256), string(KeyAlgorithmEdDSA)}))
 	if err != nil {
 		return Claims{}, fmt.Errorf("%s: %w", descriptionFailedToParseJWTWithClaims, err)
 	}
@@ -0,0 +1,226 @@
+package authn
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+const (
+	keyRSAMinBits = 2048
+
+	jwkUseSignature       = "sig"
+	jwkKeyTypeRSA         = "RSA"
+	jwkKeyTypeOctet       = "OKP"
+	jwkCurveEd25519       = "Ed25519"
+	pemBlockTypeRSA       = "RSA PRIVATE KEY"
+	pemBlockTypeAny       = "PRIVATE KEY"
+	descriptionInvalidKey = "authn: invalid key"
+)
+
+// Key errors.
+var (
+	ErrKeysMissing    = errors.New("no signing keys")                  // Returned when no signing keys are defined.
+	ErrKeyInvalid     = errors.New("invalid signing key")              // Returned when a signing key does not match its algorithm or is too weak.
+	ErrKeyIDDuplicate = errors.New("duplicate signing key identifier") // Returned when multiple signing keys share the same identifier.
+	ErrKeyNotActive   = errors.New("no active signing key")            // Returned when none of the signing keys is active yet.
+	ErrKeyNotFound    = errors.New("signing key not found")            // Returned when a token is signed by an unknown or retired key.
+)
+
+// KeyAlgorithm defines the signing algorithm of a key.
+type KeyAlgorithm string
+
+const (
+	KeyAlgorithmRS256 KeyAlgorithm = "RS256"
+	KeyAlgorithmEdDSA KeyAlgorithm = "EdDSA"
+)
+
+// Key defines the signing key structure. A key signs the tokens from the time it becomes active until the next key
+// becomes active, after which it only verifies tokens until the rotation grace period elapses.
+type Key struct {
+	ID         string
+	Algorithm  KeyAlgorithm
+	PrivateKey crypto.Signer
+	ActiveFrom time.Time
+}
+
+// valid returns an error if the private key does not match the algorithm of the key or is too weak, nil otherwise.
+func (k Key) valid() error {
+	if len(k.ID) == 0 {
+		return ErrKeyInvalid
+	}
+
+	switch k.Algorithm {
+	case KeyAlgorithmRS256:
+		privateKey, ok := k.PrivateKey.(*rsa.PrivateKey)
+		if !ok || privateKey.N.BitLen() < keyRSAMinBits {
+			return ErrKeyInvalid
+		}
+	case KeyAlgorithmEdDSA:
+		if _, ok := k.PrivateKey.(ed25519.PrivateKey); !ok {
+			return ErrKeyInvalid
+		}
+	default:
+		return ErrKeyInvalid
+	}
+
+	return nil
+}
+
+// JWK defines the JSON Web Key structure, which contains the public part of a signing key. The modulus and exponent
+// are only defined for RSA keys, while the curve and the public key are only defined for Ed25519 keys.
+type JWK struct {
+	KeyType   string
+	ID        string
+	Use       string
+	Algorithm KeyAlgorithm
+	Modulus   *string
+	Exponent  *string
+	Curve     *string
+	X         *string
+}
+
+// ParsePrivateKeyPEM returns the private key encoded in the given PEM data, either in the PKCS #8 format or, for RSA
+// keys, in the PKCS #1 format.
+func ParsePrivateKeyPEM(data []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, ErrKeyInvalid
+	}
+
+	switch block.Type {
+	case pemBlockTypeRSA:
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	case pemBlockTypeAny:
+		privateKey, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+
+		signer, ok := privateKey.(crypto.Signer)
+		if !ok {
+			return nil, ErrKeyInvalid
+		}
+
+		return signer, nil
+	default:
+		return nil, ErrKeyInvalid
+	}
+}
+
+// signingKey returns the key that signs the tokens at the given time, which is the last key to become active.
+func (s *service) signingKey(t time.Time) (Key, bool) {
+	for i := len(s.keys) - 1; i >= 0; i-- {
+		if !s.keys[i].ActiveFrom.After(t) {
+			return s.keys[i], true
+		}
+	}
+
+	return Key{}, false
+}
+
+// retired returns true if the key at the given index no longer verifies tokens at the given time, which happens once
+// the rotation grace period elapses after the next key becomes active.
+func (s *service) retired(i int, t time.Time) bool {
+	if i+1 >= len(s.keys) {
+		return false
+	}
+
+	return !t.Before(s.keys[i+1].ActiveFrom.Add(s.rotationGracePeriod))
+}
+
+// verificationKey returns the key with the specified identifier that verifies tokens at the given time. Keys that are
+// not active yet or are retired do not verify tokens.
+func (s *service) verificationKey(id string, t time.Time) (Key, error) {
+	for i, key := range s.keys {
+		if key.ID != id {
+			continue
+		}
+
+		if key.ActiveFrom.After(t) || s.retired(i, t) {
+			return Key{}, ErrKeyNotFound
+		}
+
+		return key, nil
+	}
+
+	return Key{}, ErrKeyNotFound
+}
+
+// JWKS returns the public keys that are not retired at the given time, including the ones that are not active yet, so
+// that verifiers can obtain them before they sign any token.
+func (s *service) JWKS(t time.Time) []JWK {
+	jwks := make([]JWK, 0, len(s.keys))
+
+	for i, key := range s.keys {
+		if s.retired(i, t) {
+			continue
+		}
+
+		jwk := JWK{
+			ID:        key.ID,
+			Use:       jwkUseSignature,
+			Algorithm: key.Algorithm,
+		}
+
+		switch publicKey := key.PrivateKey.Public().(type) {
+		case *rsa.PublicKey:
+			modulus := base64.RawURLEncoding.EncodeToString(publicKey.N.Bytes())
+			exponent := base64.RawURLEncoding.EncodeToString(big.NewInt(int64(publicKey.E)).Bytes())
+
+			jwk.KeyType = jwkKeyTypeRSA
+			jwk.Modulus = &modulus
+			jwk.Exponent = &exponent
+		case ed25519.PublicKey:
+			curve := jwkCurveEd25519
+			x := base64.RawURLEncoding.EncodeToString(publicKey)
+
+			jwk.KeyType = jwkKeyTypeOctet
+			jwk.Curve = &curve
+			jwk.X = &x
+		}
+
+		jwks = append(jwks, jwk)
+	}
+
+	return jwks
+}
+
+// validateKeys returns an error if the given keys cannot be used to sign tokens at the given time.
+func validateKeys(keys []Key, t time.Time) error {
+	if len(keys) == 0 {
+		return ErrKeysMissing
+	}
+
+	ids := make(map[string]struct{}, len(keys))
+	active := false
+
+	for _, key := range keys {
+		err := key.valid()
+		if err != nil {
+			return fmt.Errorf("%s %s: %w", descriptionInvalidKey, key.ID, err)
+		}
+
+		if _, ok := ids[key.ID]; ok {
+			return fmt.Errorf("%s %s: %w", descriptionInvalidKey, key.ID, ErrKeyIDDuplicate)
+		}
+		ids[key.ID] = struct{}{}
+
+		if !key.ActiveFrom.After(t) {
+			active = true
+		}
+	}
+
+	if !active {
+		return ErrKeyNotActive
+	}
+
+	return nil
+}
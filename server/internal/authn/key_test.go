@@ -0,0 +1,182 @@
+package authn
+
+import (
+	"crypto/ed25519"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// newTestKey returns a new Ed25519 signing key with the specified identifier, which becomes active at the given time.
+func newTestKey(t *testing.T, id string, activeFrom time.Time) Key {
+	t.Helper()
+
+	_, privateKey, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+
+	return Key{
+		ID:         id,
+		Algorithm:  KeyAlgorithmEdDSA,
+		PrivateKey: privateKey,
+		ActiveFrom: activeFrom,
+	}
+}
+
+func TestNew(t *testing.T) {
+	now := time.Now()
+	key := newTestKey(t, "key-1", now.Add(-time.Hour))
+
+	tests := []struct {
+		name    string
+		keys    []Key
+		wantErr error
+	}{
+		{name: "active key", keys: []Key{key}},
+		{name: "active key and key not active yet", keys: []Key{key, newTestKey(t, "key-2", now.Add(time.Hour))}},
+		{name: "no keys", wantErr: ErrKeysMissing},
+		{name: "no active key", keys: []Key{newTestKey(t, "key-2", now.Add(time.Hour))}, wantErr: ErrKeyNotActive},
+		{name: "duplicate identifier", keys: []Key{key, newTestKey(t, "key-1", now)}, wantErr: ErrKeyIDDuplicate},
+		{name: "missing identifier", keys: []Key{newTestKey(t, "", now)}, wantErr: ErrKeyInvalid},
+		{name: "algorithm of another key type", keys: []Key{{ID: "key-1", Algorithm: KeyAlgorithmRS256, PrivateKey: key.PrivateKey}}, wantErr: ErrKeyInvalid},
+		{name: "unknown algorithm", keys: []Key{{ID: "key-1", Algorithm: "HS256", PrivateKey: key.PrivateKey}}, wantErr: ErrKeyInvalid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(tt.keys, time.Hour)
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestKeyRotation(t *testing.T) {
+	start := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	gracePeriod := 30 * time.Minute
+
+	// The keys are defined out of order, to check that they are sorted by activation time.
+	s, err := New([]Key{
+		newTestKey(t, "key-3", start.Add(2*time.Hour)),
+		newTestKey(t, "key-1", start),
+		newTestKey(t, "key-2", start.Add(time.Hour)),
+	}, gracePeriod)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name               string
+		time               time.Time
+		wantNoSigningKey   bool
+		wantSigningKeyID   string
+		wantVerifyingIDs   []string
+		wantNotVerifiedIDs []string
+		wantJWKSIDs        []string
+	}{
+		{
+			name:               "before the first key is active",
+			time:               start.Add(-time.Minute),
+			wantNoSigningKey:   true,
+			wantJWKSIDs:        []string{"key-1", "key-2", "key-3"},
+			wantNotVerifiedIDs: []string{"key-1", "key-2", "key-3"},
+		},
+		{
+			name:               "first key active",
+			time:               start,
+			wantSigningKeyID:   "key-1",
+			wantVerifyingIDs:   []string{"key-1"},
+			wantJWKSIDs:        []string{"key-1", "key-2", "key-3"},
+			wantNotVerifiedIDs: []string{"key-2", "key-3"},
+		},
+		{
+			name:               "second key active within the grace period",
+			time:               start.Add(time.Hour + gracePeriod - time.Second),
+			wantSigningKeyID:   "key-2",
+			wantVerifyingIDs:   []string{"key-1", "key-2"},
+			wantJWKSIDs:        []string{"key-1", "key-2", "key-3"},
+			wantNotVerifiedIDs: []string{"key-3"},
+		},
+		{
+			name:               "second key active after the grace period",
+			time:               start.Add(time.Hour + gracePeriod),
+			wantSigningKeyID:   "key-2",
+			wantVerifyingIDs:   []string{"key-2"},
+			wantJWKSIDs:        []string{"key-2", "key-3"},
+			wantNotVerifiedIDs: []string{"key-1", "key-3"},
+		},
+		{
+			name:               "last key active long after the grace period",
+			time:               start.AddDate(1, 0, 0),
+			wantSigningKeyID:   "key-3",
+			wantVerifyingIDs:   []string{"key-3"},
+			wantJWKSIDs:        []string{"key-3"},
+			wantNotVerifiedIDs: []string{"key-1", "key-2", "unknown"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, ok := s.signingKey(tt.time)
+			require.Equal(t, !tt.wantNoSigningKey, ok)
+			require.Equal(t, tt.wantSigningKeyID, key.ID)
+
+			for _, id := range tt.wantVerifyingIDs {
+				key, err := s.verificationKey(id, tt.time)
+				require.NoError(t, err)
+				require.Equal(t, id, key.ID)
+			}
+
+			for _, id := range tt.wantNotVerifiedIDs {
+				_, err := s.verificationKey(id, tt.time)
+				require.ErrorIs(t, err, ErrKeyNotFound)
+			}
+
+			jwks := s.JWKS(tt.time)
+			ids := make([]string, len(jwks))
+			for i, jwk := range jwks {
+				ids[i] = jwk.ID
+			}
+			require.Equal(t, tt.wantJWKSIDs, ids)
+		})
+	}
+}
+
+func TestNewRotationGracePeriod(t *testing.T) {
+	s, err := New([]Key{newTestKey(t, "key-1", time.Now())}, time.Minute)
+	require.NoError(t, err)
+
+	// The grace period never ends before the tokens signed by the previous key expire.
+	require.Equal(t, jwtExpirationTime, s.rotationGracePeriod)
+}
+
+func TestParseJWTKeyRotation(t *testing.T) {
+	now := time.Now()
+	oldKey := newTestKey(t, "key-1", now.Add(-24*time.Hour))
+
+	oldService, err := New([]Key{oldKey}, time.Hour)
+	require.NoError(t, err)
+
+	token, _, err := oldService.NewJWT("subject", nil)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name         string
+		newKeyActive time.Time
+		wantErr      error
+	}{
+		{name: "new key not active yet", newKeyActive: now.Add(time.Hour)},
+		{name: "new key active within the grace period", newKeyActive: now.Add(-time.Minute)},
+		{name: "new key active after the grace period", newKeyActive: now.Add(-2 * time.Hour), wantErr: ErrKeyNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := New([]Key{oldKey, newTestKey(t, "key-2", tt.newKeyActive)}, time.Hour)
+			require.NoError(t, err)
+
+			claims, err := s.ParseJWT(token)
+			require.ErrorIs(t, err, tt.wantErr)
+			if tt.wantErr == nil {
+				require.Equal(t, "subject", claims.Subject)
+			}
+		})
+	}
+}
@@ -1,62 +1,137 @@
 package authn
 
 import (
+	"bytes"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
 	"errors"
-	"unicode"
+	"fmt"
+	"strings"
 
+	"golang.org/x/crypto/argon2"
 	"golang.org/x/crypto/bcrypt"
 )
 
+// Argon2id parameters, as recommended by OWASP.
 const (
-	passwordMinLength       = 14
-	passwordMaxLength       = 72
-	passwordMinDigits       = 1
-	passwordMinChars        = 1
-	passwordMinSpecialChars = 1
+	argon2idMemory     = 19 * 1024
+	argon2idTime       = 2
+	argon2idThreads    = 1
+	argon2idSaltLength = 16
+	argon2idKeyLength  = 32
+	argon2idPrefix     = "$argon2id$"
 )
 
-// ValidPassword returns true if the password matches the requirements, false otherwise.
-func (s *service) ValidPassword(password []byte) bool {
-	// Check password length requirements.
-	if len(password) < passwordMinLength || len(password) > passwordMaxLength {
-		return false
-	}
-
-	// Check password complexity requirements.
-	var digits int
-	var chars int
-	var specialChars int
-	for _, r := range string(password) {
-		if unicode.IsDigit(r) {
-			digits++
-			continue
-		}
-		if unicode.IsLetter(r) {
-			chars++
-		} else {
-			specialChars++
-		}
-	}
+// ErrPasswordHashInvalid is returned when a password hash cannot be decoded.
+var ErrPasswordHashInvalid = errors.New("invalid password hash")
 
-	return digits >= passwordMinDigits && chars >= passwordMinChars && specialChars >= passwordMinSpecialChars
+// argon2idEncoding defines the encoding of the salt and key of the argon2id hashes, as defined by the PHC string
+// format.
+var argon2idEncoding = base64.RawStdEncoding
+
+// argon2idParams defines the argon2id parameters structure.
+type argon2idParams struct {
+	memory  uint32
+	time    uint32
+	threads uint8
 }
 
-// HashPassword returns the hashed password.
+// HashPassword returns the argon2id hash of the password in the PHC string format.
 func (s *service) HashPassword(password []byte) ([]byte, error) {
-	bytes, err := bcrypt.GenerateFromPassword(password, bcrypt.DefaultCost)
-	return bytes, err
+	salt := make([]byte, argon2idSaltLength)
+	_, err := rand.Read(salt)
+	if err != nil {
+		return nil, err
+	}
+
+	key := argon2.IDKey(password, salt, argon2idTime, argon2idMemory, argon2idThreads, argon2idKeyLength)
+
+	hash := fmt.Sprintf("%sv=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2idPrefix,
+		argon2.Version,
+		argon2idMemory,
+		argon2idTime,
+		argon2idThreads,
+		argon2idEncoding.EncodeToString(salt),
+		argon2idEncoding.EncodeToString(key),
+	)
+
+	return []byte(hash), nil
 }
 
-// CheckPasswordHash returns true if the password matches the hashed password, false otherwise.
+// CheckPasswordHash returns true if the password matches the hashed password, false otherwise. Both argon2id and
+// bcrypt hashes are supported.
 func (s *service) CheckPasswordHash(password, hash []byte) (bool, error) {
-	err := bcrypt.CompareHashAndPassword(hash, password)
-	if err != nil {
-		if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
-			return false, nil
+	if !bytes.HasPrefix(hash, []byte(argon2idPrefix)) {
+		err := bcrypt.CompareHashAndPassword(hash, password)
+		if err != nil {
+			if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+				return false, nil
+			}
+
+			return false, err
 		}
 
+		return true, nil
+	}
+
+	params, salt, key, err := decodeArgon2idHash(hash)
+	if err != nil {
 		return false, err
 	}
 
-	return true, nil
+	otherKey := argon2.IDKey(password, salt, params.time, params.memory, params.threads, uint32(len(key)))
+
+	return subtle.ConstantTimeCompare(key, otherKey) == 1, nil
+}
+
+// PasswordHashOutdated returns true if the hashed password was not computed with the current algorithm and
+// parameters, which means that the password should be hashed again, false otherwise.
+func (s *service) PasswordHashOutdated(hash []byte) bool {
+	if !bytes.HasPrefix(hash, []byte(argon2idPrefix)) {
+		return true
+	}
+
+	params, salt, key, err := decodeArgon2idHash(hash)
+	if err != nil {
+		return true
+	}
+
+	return params != argon2idParams{memory: argon2idMemory, time: argon2idTime, threads: argon2idThreads} ||
+		len(salt) != argon2idSaltLength ||
+		len(key) != argon2idKeyLength
+}
+
+// decodeArgon2idHash returns the parameters, salt and key of the given argon2id hash in the PHC string format.
+func decodeArgon2idHash(hash []byte) (argon2idParams, []byte, []byte, error) {
+	// The hash has the format $argon2id$v=<version>$m=<memory>,t=<time>,p=<threads>$<salt>$<key>.
+	parts := strings.Split(string(hash), "$")
+	if len(parts) != 6 {
+		return argon2idParams{}, nil, nil, ErrPasswordHashInvalid
+	}
+
+	var version int
+	_, err := fmt.Sscanf(parts[2], "v=%d", &version)
+	if err != nil || version != argon2.Version {
+		return argon2idParams{}, nil, nil, ErrPasswordHashInvalid
+	}
+
+	var params argon2idParams
+	_, err = fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.memory, &params.time, &params.threads)
+	if err != nil || params.time == 0 || params.threads == 0 {
+		return argon2idParams{}, nil, nil, ErrPasswordHashInvalid
+	}
+
+	salt, err := argon2idEncoding.DecodeString(parts[4])
+	if err != nil {
+		return argon2idParams{}, nil, nil, ErrPasswordHashInvalid
+	}
+
+	key, err := argon2idEncoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return argon2idParams{}, nil, nil, ErrPasswordHashInvalid
+	}
+
+	return params, salt, key, nil
 }
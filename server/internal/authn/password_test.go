@@ -0,0 +1,96 @@
+package authn
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	// argon2idTestHash defines the argon2id hash of "password" with the salt "somesalt" and the parameters m=65536,
+	// t=2 and p=1, from the test vectors of the argon2 reference implementation.
+	argon2idTestHash = "$argon2id$v=19$m=65536,t=2,p=1$c29tZXNhbHQ$CTFhFdXPJO1aFaMaO6Mm5c8y7cJHAph8ArZWb2GRPPc"
+
+	// argon2idTestHashCurrentParams defines a hash with the current parameters, with a 16 byte salt and a 32 byte key.
+	argon2idTestHashCurrentParams = "$argon2id$v=19$m=19456,t=2,p=1$c29tZXNhbHRzb21lc2FsdA$CTFhFdXPJO1aFaMaO6Mm5c8y7cJHAph8ArZWb2GRPPc"
+)
+
+func TestHashPassword(t *testing.T) {
+	s := &service{}
+
+	hash, err := s.HashPassword([]byte("password"))
+	require.NoError(t, err)
+	require.Regexp(t, `^\$argon2id\$v=19\$m=19456,t=2,p=1\$[A-Za-z0-9+/]{22}\$[A-Za-z0-9+/]{43}$`, string(hash))
+
+	otherHash, err := s.HashPassword([]byte("password"))
+	require.NoError(t, err)
+	require.NotEqual(t, hash, otherHash)
+
+	ok, err := s.CheckPasswordHash([]byte("password"), hash)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	ok, err = s.CheckPasswordHash([]byte("Password"), hash)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	require.False(t, s.PasswordHashOutdated(hash))
+}
+
+func TestCheckPasswordHash(t *testing.T) {
+	bcryptHash, err := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.MinCost)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		password string
+		hash     string
+		wantOk   bool
+		wantErr  error
+	}{
+		{name: "argon2id reference vector", password: "password", hash: argon2idTestHash, wantOk: true},
+		{name: "argon2id wrong password", password: "passwore", hash: argon2idTestHash},
+		{name: "bcrypt", password: "password", hash: string(bcryptHash), wantOk: true},
+		{name: "bcrypt wrong password", password: "passwore", hash: string(bcryptHash)},
+		{name: "argon2id unsupported version", password: "password", hash: "$argon2id$v=16$m=65536,t=2,p=1$c29tZXNhbHQ$CTFhFdXPJO1aFaMaO6Mm5c8y7cJHAph8ArZWb2GRPPc", wantErr: ErrPasswordHashInvalid},
+		{name: "argon2id missing key", password: "password", hash: "$argon2id$v=19$m=65536,t=2,p=1$c29tZXNhbHQ", wantErr: ErrPasswordHashInvalid},
+		{name: "argon2id zero time", password: "password", hash: "$argon2id$v=19$m=65536,t=0,p=1$c29tZXNhbHQ$CTFhFdXPJO1aFaMaO6Mm5c8y7cJHAph8ArZWb2GRPPc", wantErr: ErrPasswordHashInvalid},
+		{name: "argon2id invalid salt", password: "password", hash: "$argon2id$v=19$m=65536,t=2,p=1$c29tZXNhbHQ=$CTFhFdXPJO1aFaMaO6Mm5c8y7cJHAph8ArZWb2GRPPc", wantErr: ErrPasswordHashInvalid},
+	}
+
+	s := &service{}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ok, err := s.CheckPasswordHash([]byte(tt.password), []byte(tt.hash))
+			require.ErrorIs(t, err, tt.wantErr)
+			require.Equal(t, tt.wantOk, ok)
+		})
+	}
+}
+
+func TestPasswordHashOutdated(t *testing.T) {
+	tests := []struct {
+		name string
+		hash string
+		want bool
+	}{
+		{name: "current parameters", hash: argon2idTestHashCurrentParams},
+		{name: "different memory", hash: argon2idTestHash, want: true},
+		{name: "different time", hash: "$argon2id$v=19$m=19456,t=3,p=1$c29tZXNhbHRzb21lc2FsdA$CTFhFdXPJO1aFaMaO6Mm5c8y7cJHAph8ArZWb2GRPPc", want: true},
+		{name: "different threads", hash: "$argon2id$v=19$m=19456,t=2,p=2$c29tZXNhbHRzb21lc2FsdA$CTFhFdXPJO1aFaMaO6Mm5c8y7cJHAph8ArZWb2GRPPc", want: true},
+		{name: "short salt", hash: "$argon2id$v=19$m=19456,t=2,p=1$c29tZXNhbHQ$CTFhFdXPJO1aFaMaO6Mm5c8y7cJHAph8ArZWb2GRPPc", want: true},
+		{name: "short key", hash: "$argon2id$v=19$m=19456,t=2,p=1$c29tZXNhbHRzb21lc2FsdA$CTFhFdXPJO1aFaMaO6Mm5c8y7cJHAph8", want: true},
+		{name: "bcrypt", hash: "$2a$14$3YbWepKf4uralK8RS4Gi5eiIwKOrUU0dHWXaTCGpEmWfr.gdjEu96", want: true},
+		{name: "invalid", hash: "$argon2id$v=19$m=19456,t=2,p=1$c29tZXNhbHRzb21lc2FsdA", want: true},
+	}
+
+	s := &service{}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, s.PasswordHashOutdated([]byte(tt.hash)))
+		})
+	}
+}
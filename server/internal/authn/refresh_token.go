@@ -0,0 +1,83 @@
+package authn
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"time"
+)
+
+const (
+	randomTokenLength          = 32
+	refreshTokenExpirationTime = time.Hour * 24 * 30
+)
+
+// RefreshToken defines the refresh token structure. Only the hash of the token is meant to be stored.
+type RefreshToken struct {
+	Token     string
+	Hash      []byte
+	ExpiresAt time.Time
+}
+
+// NewRefreshToken returns a new random refresh token with an expiration time of 30 days.
+func (s *service) NewRefreshToken() (RefreshToken, error) {
+	token, err := newRandomToken()
+	if err != nil {
+		return RefreshToken{}, err
+	}
+
+	return RefreshToken{
+		Token:     token,
+		Hash:      s.HashRefreshToken(token),
+		ExpiresAt: time.Now().Add(refreshTokenExpirationTime).UTC(),
+	}, nil
+}
+
+// HashRefreshToken returns the hash of the given refresh token.
+func (s *service) HashRefreshToken(token string) []byte {
+	return hashToken(token)
+}
+
+// OneTimeToken defines the one-time token structure, which is sent to a subject to confirm an action, such as a
+// password reset. Only the hash of the token is meant to be stored.
+type OneTimeToken struct {
+	Token     string
+	Hash      []byte
+	ExpiresAt time.Time
+}
+
+// NewOneTimeToken returns a new random one-time token with the specified expiration time.
+func (s *service) NewOneTimeToken(expirationTime time.Duration) (OneTimeToken, error) {
+	token, err := newRandomToken()
+	if err != nil {
+		return OneTimeToken{}, err
+	}
+
+	return OneTimeToken{
+		Token:     token,
+		Hash:      s.HashOneTimeToken(token),
+		ExpiresAt: time.Now().Add(expirationTime).UTC(),
+	}, nil
+}
+
+// HashOneTimeToken returns the hash of the given one-time token.
+func (s *service) HashOneTimeToken(token string) []byte {
+	return hashToken(token)
+}
+
+// newRandomToken returns a new random URL-safe token.
+func newRandomToken() (string, error) {
+	b := make([]byte, randomTokenLength)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// hashToken returns the hash of the given token.
+func hashToken(token string) []byte {
+	hash := sha256.Sum256([]byte(token))
+	return hash[:]
+}
@@ -0,0 +1,131 @@
+package authn
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	totpSecretLength = 20
+	totpDigits       = 6
+	totpPeriod       = 30 * time.Second
+	totpSkew         = 1
+
+	recoveryCodeLength    = 10
+	recoveryCodeGroupSize = 5
+	recoveryCodeAlphabet  = "abcdefghijklmnopqrstuvwxyz234567"
+)
+
+// totpEncoding defines the encoding of the TOTP secrets, as expected by authenticator applications.
+var totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// NewTOTPSecret returns a new random base32 encoded secret for time-based one-time passwords (RFC 6238).
+func (s *service) NewTOTPSecret() (string, error) {
+	b := make([]byte, totpSecretLength)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+
+	return totpEncoding.EncodeToString(b), nil
+}
+
+// TOTPURI returns the otpauth URI of the given secret, which authenticator applications read from a QR code.
+func (s *service) TOTPURI(issuer, accountName, secret string) string {
+	query := url.Values{}
+	query.Set("secret", secret)
+	query.Set("issuer", issuer)
+	query.Set("algorithm", "SHA1")
+	query.Set("digits", fmt.Sprint(totpDigits))
+	query.Set("period", fmt.Sprint(int(totpPeriod.Seconds())))
+
+	u := url.URL{
+		Scheme:   "otpauth",
+		Host:     "totp",
+		Path:     "/" + issuer + ":" + accountName,
+		RawQuery: query.Encode(),
+	}
+
+	return u.String()
+}
+
+// CheckTOTP checks whether the code is valid for the given secret at the specified time, allowing one period of clock
+// skew in each direction. The time step of the matching code is returned, so that the code can only be used once.
+func (s *service) CheckTOTP(secret, code string, t time.Time) (int64, bool) {
+	key, err := totpEncoding.DecodeString(strings.ToUpper(secret))
+	if err != nil || len(code) != totpDigits {
+		return 0, false
+	}
+
+	step := t.Unix() / int64(totpPeriod.Seconds())
+	for i := -totpSkew; i <= totpSkew; i++ {
+		expected := totpCode(key, step+int64(i))
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return step + int64(i), true
+		}
+	}
+
+	return 0, false
+}
+
+// NewRecoveryCodes returns the specified amount of new random recovery codes, which can be used once instead of a
+// time-based one-time password.
+func (s *service) NewRecoveryCodes(amount int) ([]string, error) {
+	codes := make([]string, amount)
+
+	for i := range codes {
+		b := make([]byte, recoveryCodeLength)
+		_, err := rand.Read(b)
+		if err != nil {
+			return nil, err
+		}
+
+		var code strings.Builder
+		for j, v := range b {
+			if j != 0 && j%recoveryCodeGroupSize == 0 {
+				code.WriteByte('-')
+			}
+			code.WriteByte(recoveryCodeAlphabet[v&0x1f])
+		}
+
+		codes[i] = code.String()
+	}
+
+	return codes, nil
+}
+
+// HashRecoveryCode returns the hash of the given recovery code, ignoring its case, separators and spaces.
+func (s *service) HashRecoveryCode(code string) []byte {
+	code = strings.ToLower(code)
+	code = strings.NewReplacer("-", "", " ", "").Replace(code)
+	return hashToken(code)
+}
+
+// totpCode returns the time-based one-time password of the given key at the specified time step.
+func totpCode(key []byte, step int64) string {
+	msg := make([]byte, 8)
+	binary.BigEndian.PutUint64(msg, uint64(step))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg)
+	sum := mac.Sum(nil)
+
+	// Dynamic truncation, as defined in RFC 4226.
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	modulo := uint32(1)
+	for range totpDigits {
+		modulo *= 10
+	}
+
+	return fmt.Sprintf("%0*d", totpDigits, value%modulo)
+}
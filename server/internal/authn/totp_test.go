@@ -0,0 +1,47 @@
+package authn
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// totpTestSecret defines the base32 encoded SHA-1 secret of the RFC 6238 test vectors, "12345678901234567890".
+const totpTestSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestCheckTOTP(t *testing.T) {
+	tests := []struct {
+		name     string
+		secret   string
+		code     string
+		time     int64
+		wantStep int64
+		wantOk   bool
+	}{
+		// RFC 6238 test vectors, truncated to 6 digits.
+		{name: "rfc 6238 time 59", secret: totpTestSecret, code: "287082", time: 59, wantStep: 1, wantOk: true},
+		{name: "rfc 6238 time 1111111109", secret: totpTestSecret, code: "081804", time: 1111111109, wantStep: 37037036, wantOk: true},
+		{name: "rfc 6238 time 1111111111", secret: totpTestSecret, code: "050471", time: 1111111111, wantStep: 37037037, wantOk: true},
+		{name: "rfc 6238 time 1234567890", secret: totpTestSecret, code: "005924", time: 1234567890, wantStep: 41152263, wantOk: true},
+		{name: "rfc 6238 time 2000000000", secret: totpTestSecret, code: "279037", time: 2000000000, wantStep: 66666666, wantOk: true},
+		{name: "rfc 6238 time 20000000000", secret: totpTestSecret, code: "353130", time: 20000000000, wantStep: 666666666, wantOk: true},
+		{name: "lowercase secret", secret: "gezdgnbvgy3tqojqgezdgnbvgy3tqojq", code: "287082", time: 59, wantStep: 1, wantOk: true},
+		{name: "code of the previous step", secret: totpTestSecret, code: "081804", time: 1111111111, wantStep: 37037036, wantOk: true},
+		{name: "code of the next step", secret: totpTestSecret, code: "050471", time: 1111111109, wantStep: 37037037, wantOk: true},
+		{name: "code two steps old", secret: totpTestSecret, code: "287082", time: 119},
+		{name: "wrong code", secret: totpTestSecret, code: "287083", time: 59},
+		{name: "code with 8 digits", secret: totpTestSecret, code: "94287082", time: 59},
+		{name: "invalid secret", secret: "not base32!", code: "287082", time: 59},
+	}
+
+	s := &service{}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step, ok := s.CheckTOTP(tt.secret, tt.code, time.Unix(tt.time, 0))
+			require.Equal(t, tt.wantOk, ok)
+			require.Equal(t, tt.wantStep, step)
+		})
+	}
+}
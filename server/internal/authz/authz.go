@@ -1,13 +1,20 @@
 package authz
 
 import (
+	"context"
+	"net/netip"
+
 	"github.com/goncalo-marques/ecomap/server/internal/authn"
+	"github.com/goncalo-marques/ecomap/server/internal/domain"
 )
 
-// Roles defines the authorization roles structure.
-type Roles struct {
-	AuthzWildcards []string // Wildcards that require authorization.
-	AdminRole      string   // Role that can access all identifiers (bypasses AuthzWildcards).
+// permissionsContextKey defines the key of the subject permissions in the request context.
+type permissionsContextKey struct{}
+
+// Policy defines the authorization policy structure.
+type Policy struct {
+	AuthzWildcards      []string // Wildcards that require authorization.
+	OwnPermissionSuffix string   // Suffix of the permissions that only grant access to the subject contained in the AuthzWildcards. Other permissions can access all identifiers.
 }
 
 // AuthenticationService defines the authentication service interface.
@@ -15,16 +22,44 @@ type AuthenticationService interface {
 	ParseJWT(tokenString string) (authn.Claims, error)
 }
 
+// RevocationService defines the token revocation service interface.
+type RevocationService interface {
+	TokenRevoked(ctx context.Context, id string) (bool, error)
+}
+
+// APIKeyService defines the API key service interface.
+type APIKeyService interface {
+	AuthenticateAPIKey(ctx context.Context, key string, ipAddress netip.Addr) (authn.Claims, error)
+}
+
+// PermissionService defines the permission service interface.
+type PermissionService interface {
+	SubjectPermissions(ctx context.Context, roles []authn.SubjectRole) ([]domain.Permission, error)
+}
+
 // service defines the authorization service structure.
 type service struct {
-	roles        Roles
-	authnService AuthenticationService
+	policy            Policy
+	authnService      AuthenticationService
+	revocationService RevocationService
+	apiKeyService     APIKeyService
+	permissionService PermissionService
 }
 
 // New returns a new authorization service.
-func New(roles Roles, authnService AuthenticationService) *service {
+func New(policy Policy, authnService AuthenticationService, revocationService RevocationService, apiKeyService APIKeyService, permissionService PermissionService) *service {
 	return &service{
-		roles:        roles,
-		authnService: authnService,
+		policy:            policy,
+		authnService:      authnService,
+		revocationService: revocationService,
+		apiKeyService:     apiKeyService,
+		permissionService: permissionService,
 	}
 }
+
+// Permissions returns the permissions of the authorized subject of the request context, which are set by the
+// middleware.
+func Permissions(ctx context.Context) []domain.Permission {
+	permissions, _ := ctx.Value(permissionsContextKey{}).([]domain.Permission)
+	return permissions
+}
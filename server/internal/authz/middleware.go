@@ -1,42 +1,56 @@
 package authz
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
+	"net/netip"
 	"slices"
 	"strings"
 
 	spec "github.com/goncalo-marques/ecomap/server/api/ecomap"
+	"github.com/goncalo-marques/ecomap/server/internal/authn"
 )
 
 const (
 	requestHeaderKeyAuthorization = "Authorization"
+	requestHeaderKeyAPIKey        = "X-API-Key"
 	bearerSchemaPrefix            = "Bearer "
 
 	descriptionFailedToFindBearerSchemaPrefix = "authz: failed to find bearer schema prefix"
 	descriptionFailedToParseJWT               = "authz: failed to parse jwt"
-	descriptionFailedToValidateRoles          = "authz: failed to validate roles"
+	descriptionFailedToCheckJWTRevoked        = "authz: failed to check jwt revoked"
+	descriptionFailedToAuthenticateAPIKey     = "authz: failed to authenticate api key"
+	descriptionFailedToListPermissions        = "authz: failed to list permissions"
+	descriptionFailedToValidatePermissions    = "authz: failed to validate permissions"
 )
 
 var (
 	ErrAuthorizationHeaderInvalid = errors.New("invalid authorization header") // Returned when the Authorization header is invalid.
 	ErrJWTInvalid                 = errors.New("invalid jwt")                  // Returned when the JWT is invalid.
-	ErrRolesInvalid               = errors.New("invalid roles")                // Returned when the subject does not contain any of the required roles.
+	ErrJWTRevoked                 = errors.New("revoked jwt")                  // Returned when the JWT was revoked.
+	ErrPermissionsInvalid         = errors.New("invalid permissions")          // Returned when the subject is not granted any of the required permissions.
 	ErrAuthorizationInvalid       = errors.New("invalid authorization")        // Returned when the subject is not the one actually contained in the path wildcard.
 )
 
 // ErrorHandlerFunc defines the function to handle an error in the middleware.
 type ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
 
+// ClientIPAddressFunc defines the function to return the IP address of the client of a request.
+type ClientIPAddressFunc func(r *http.Request) netip.Addr
+
 // MiddlewareOptions defines the middleware options structure.
 type MiddlewareOptions struct {
 	UnauthorizedHandlerFunc ErrorHandlerFunc
 	ForbiddenHandlerFunc    ErrorHandlerFunc
+	ClientIPAddressFunc     ClientIPAddressFunc
 }
 
-// Middleware validates the JWT in the Authorization header and ensures that the associated subject has the necessary
-// roles to access an endpoint based on the configured Roles.
+// Middleware validates the API key in the X-API-Key header or, when it is not defined, the JWT in the Authorization
+// header, rejecting revoked tokens, and ensures that the permissions granted by the roles of the associated subject
+// include one of the permissions required to access an endpoint, based on the configured Policy.
 func (s *service) Middleware(options MiddlewareOptions) func(http.Handler) http.Handler {
 	// unauthorized defines a function to handle an unauthorized HTTP response.
 	unauthorized := options.UnauthorizedHandlerFunc
@@ -54,30 +68,48 @@ func (s *service) Middleware(options MiddlewareOptions) func(http.Handler) http.
 		}
 	}
 
-	// validateRoles defines a function to validate the subject roles to access the endpoint.
-	validateRoles := func(r *http.Request, requiredRoles []string, subjectRoles []string, subject string) error {
-		// Check that the subject contains at least one of the required roles.
-		containedRoles := make([]string, 0, len(requiredRoles))
-		for _, requiredRole := range requiredRoles {
-			if !slices.Contains(subjectRoles, requiredRole) {
-				continue
+	// clientIPAddress defines a function to return the IP address of the client, which API keys may be restricted to.
+	clientIPAddress := options.ClientIPAddressFunc
+	if clientIPAddress == nil {
+		clientIPAddress = func(r *http.Request) netip.Addr {
+			host, _, err := net.SplitHostPort(r.RemoteAddr)
+			if err != nil {
+				return netip.Addr{}
+			}
+
+			addr, err := netip.ParseAddr(host)
+			if err != nil {
+				return netip.Addr{}
 			}
 
-			containedRoles = append(containedRoles, requiredRole)
+			return addr
 		}
+	}
 
-		if len(containedRoles) == 0 {
-			return ErrRolesInvalid
+	// validatePermissions defines a function to validate the subject permissions to access the endpoint.
+	validatePermissions := func(r *http.Request, requiredPermissions []string, subjectPermissions []string, subject string) error {
+		// Check that the subject is granted at least one of the required permissions. Permissions that are not
+		// restricted to the subject can access all identifiers.
+		var grantedOwnPermission bool
+		for _, requiredPermission := range requiredPermissions {
+			if !slices.Contains(subjectPermissions, requiredPermission) {
+				continue
+			}
+
+			if !strings.HasSuffix(requiredPermission, s.policy.OwnPermissionSuffix) {
+				return nil
+			}
+
+			grantedOwnPermission = true
 		}
 
-		// Check that the subject contains the Admin role.
-		if slices.Contains(subjectRoles, s.roles.AdminRole) {
-			return nil
+		if !grantedOwnPermission {
+			return ErrPermissionsInvalid
 		}
 
 		// Check that the subject is the one actually contained in the path wildcard.
 		var requiredAuthorization string
-		for _, wildcard := range s.roles.AuthzWildcards {
+		for _, wildcard := range s.policy.AuthzWildcards {
 			pathValue := r.PathValue(wildcard)
 			if len(pathValue) == 0 {
 				continue
@@ -94,8 +126,8 @@ func (s *service) Middleware(options MiddlewareOptions) func(http.Handler) http.
 			return ErrAuthorizationInvalid
 		}
 
-		// This means that the subject has the necessary roles to access the endpoint and has the authority of the data
-		// they are trying to manipulate.
+		// This means that the subject has the necessary permissions to access the endpoint and has the authority of
+		// the data they are trying to manipulate.
 		return nil
 	}
 
@@ -103,41 +135,76 @@ func (s *service) Middleware(options MiddlewareOptions) func(http.Handler) http.
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			ctx := r.Context()
 
-			// Get required roles.
-			var requiredRoles []string
+			// Get required permissions.
+			var requiredPermissions []string
 			bearerAuthScopes := ctx.Value(spec.BearerAuthScopes)
 			if bearerAuthScopes != nil {
-				requiredRoles = bearerAuthScopes.([]string)
+				requiredPermissions = bearerAuthScopes.([]string)
 			}
 
-			// Check if there are any required roles.
-			if len(requiredRoles) != 0 {
-				// Get token from the Authorization header.
-				authorizationValue := r.Header.Get(requestHeaderKeyAuthorization)
-				token, ok := strings.CutPrefix(authorizationValue, bearerSchemaPrefix)
-				if !ok {
-					unauthorized(w, r, fmt.Errorf("%s: %w", descriptionFailedToFindBearerSchemaPrefix, ErrAuthorizationHeaderInvalid))
-					return
+			// Check if there are any required permissions.
+			if len(requiredPermissions) != 0 {
+				var claims authn.Claims
+				var err error
+
+				apiKey := r.Header.Get(requestHeaderKeyAPIKey)
+				if len(apiKey) != 0 {
+					// Get API key claims.
+					claims, err = s.apiKeyService.AuthenticateAPIKey(ctx, apiKey, clientIPAddress(r))
+					if err != nil {
+						unauthorized(w, r, fmt.Errorf("%s: %w", descriptionFailedToAuthenticateAPIKey, err))
+						return
+					}
+				} else {
+					// Get token from the Authorization header.
+					authorizationValue := r.Header.Get(requestHeaderKeyAuthorization)
+					token, ok := strings.CutPrefix(authorizationValue, bearerSchemaPrefix)
+					if !ok {
+						unauthorized(w, r, fmt.Errorf("%s: %w", descriptionFailedToFindBearerSchemaPrefix, ErrAuthorizationHeaderInvalid))
+						return
+					}
+
+					// Get JWT claims.
+					claims, err = s.authnService.ParseJWT(token)
+					if err != nil {
+						unauthorized(w, r, fmt.Errorf("%s: %w", descriptionFailedToParseJWT, ErrJWTInvalid))
+						return
+					}
+
+					// Check that the token was not revoked.
+					revoked, err := s.revocationService.TokenRevoked(ctx, claims.ID)
+					if err != nil {
+						unauthorized(w, r, fmt.Errorf("%s: %w", descriptionFailedToCheckJWTRevoked, err))
+						return
+					}
+					if revoked {
+						unauthorized(w, r, fmt.Errorf("%s: %w", descriptionFailedToCheckJWTRevoked, ErrJWTRevoked))
+						return
+					}
 				}
 
-				// Get JWT claims.
-				claims, err := s.authnService.ParseJWT(token)
+				// Get the permissions granted by the subject roles, which are stored rather than contained in the claims,
+				// so that changes to the roles apply immediately.
+				permissions, err := s.permissionService.SubjectPermissions(ctx, claims.Roles)
 				if err != nil {
-					unauthorized(w, r, fmt.Errorf("%s: %w", descriptionFailedToParseJWT, ErrJWTInvalid))
+					forbidden(w, r, fmt.Errorf("%s: %w", descriptionFailedToListPermissions, err))
 					return
 				}
 
-				// Evaluate roles.
-				subjectRoles := make([]string, len(claims.Roles))
-				for i, role := range claims.Roles {
-					subjectRoles[i] = string(role)
+				// Evaluate permissions.
+				subjectPermissions := make([]string, len(permissions))
+				for i, permission := range permissions {
+					subjectPermissions[i] = string(permission)
 				}
 
-				err = validateRoles(r, requiredRoles, subjectRoles, claims.Subject)
+				err = validatePermissions(r, requiredPermissions, subjectPermissions, claims.Subject)
 				if err != nil {
-					forbidden(w, r, fmt.Errorf("%s: %w", descriptionFailedToValidateRoles, err))
+					forbidden(w, r, fmt.Errorf("%s: %w", descriptionFailedToValidatePermissions, err))
 					return
 				}
+
+				// Set the subject permissions, so that handlers can restrict what the subject grants to others.
+				r = r.WithContext(context.WithValue(ctx, permissionsContextKey{}, permissions))
 			}
 
 			// Serve next handler.
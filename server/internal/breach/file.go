@@ -0,0 +1,158 @@
+package breach
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+const (
+	descriptionFailedReadFile = "breach: failed to read file"
+
+	// hashPrefixLength defines the length of the hash prefix used to look up a hash, so that only the range of hashes
+	// with the same prefix is read, as defined by the k-anonymity model.
+	hashPrefixLength = 5
+)
+
+// fileChecker defines the breached passwords file checker structure.
+type fileChecker struct {
+	file string
+}
+
+// NewFile returns a new checker of breached passwords based on the specified file, which contains the uppercase
+// hexadecimal SHA-1 hashes of the breached passwords sorted in ascending order, one per line, optionally followed by a
+// colon and the amount of breaches, like the files distributed by Have I Been Pwned.
+func NewFile(file string) *fileChecker {
+	return &fileChecker{
+		file: file,
+	}
+}
+
+// Breached returns true if the password is in the breached passwords file, false otherwise.
+func (c *fileChecker) Breached(ctx context.Context, password []byte) (bool, error) {
+	sum := sha1.Sum(password)
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+
+	suffixes, err := c.Range(hash[:hashPrefixLength])
+	if err != nil {
+		return false, err
+	}
+
+	for _, suffix := range suffixes {
+		if suffix == hash[hashPrefixLength:] {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// Range returns the suffixes of the hashes in the breached passwords file that start with the specified prefix. The
+// file is binary searched, since it is sorted, and only the lines of the range are read.
+func (c *fileChecker) Range(prefix string) ([]string, error) {
+	prefix = strings.ToUpper(prefix)
+
+	file, err := os.Open(c.file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", descriptionFailedReadFile, err)
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", descriptionFailedReadFile, err)
+	}
+
+	size := info.Size()
+
+	// Search for the first line whose hash is greater than or equal to the prefix.
+	var searchErr error
+	offset := sort.Search(int(size), func(i int) bool {
+		if searchErr != nil {
+			return true
+		}
+
+		_, hash, err := readHashAt(file, size, int64(i))
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return true
+			}
+
+			searchErr = err
+			return true
+		}
+
+		return hash >= prefix
+	})
+	if searchErr != nil {
+		return nil, fmt.Errorf("%s: %w", descriptionFailedReadFile, searchErr)
+	}
+
+	start, _, err := readHashAt(file, size, int64(offset))
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("%s: %w", descriptionFailedReadFile, err)
+	}
+
+	var suffixes []string
+
+	scanner := bufio.NewScanner(io.NewSectionReader(file, start, size-start))
+	for scanner.Scan() {
+		hash := hashFromLine(scanner.Text())
+		if !strings.HasPrefix(hash, prefix) {
+			break
+		}
+
+		suffixes = append(suffixes, hash[len(prefix):])
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", descriptionFailedReadFile, err)
+	}
+
+	return suffixes, nil
+}
+
+// readHashAt returns the offset and the hash of the first line of the file that starts at or after the given offset.
+// Returns io.EOF if there is no such line.
+func readHashAt(file *os.File, size, offset int64) (int64, string, error) {
+	start := offset
+
+	if offset > 0 {
+		// Skip the remainder of the line that contains the byte before the offset.
+		reader := bufio.NewReader(io.NewSectionReader(file, offset-1, size-offset+1))
+		skipped, err := reader.ReadString('\n')
+		if err != nil {
+			return 0, "", err
+		}
+
+		start = offset - 1 + int64(len(skipped))
+	}
+
+	if start >= size {
+		return 0, "", io.EOF
+	}
+
+	reader := bufio.NewReader(io.NewSectionReader(file, start, size-start))
+	line, err := reader.ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return 0, "", err
+	}
+
+	return start, hashFromLine(line), nil
+}
+
+// hashFromLine returns the uppercase hash of the given line of the breached passwords file.
+func hashFromLine(line string) string {
+	hash, _, _ := strings.Cut(strings.TrimSpace(line), ":")
+	return strings.ToUpper(hash)
+}
@@ -4,12 +4,17 @@ package config
 type Service struct {
 	ServerHTTP ServerHTTP `yaml:"serverHTTP"`
 	Database   Database   `yaml:"database"`
+	Routes     Routes     `yaml:"routes"`
+	Authn      Authn      `yaml:"authn"`
+	Mail       Mail       `yaml:"mail"`
+	Users      Users      `yaml:"users"`
 }
 
 // ServerHTTP defines the http server configuration structure.
 type ServerHTTP struct {
-	Address      string `yaml:"address"`
-	WriteTimeout string `yaml:"writeTimeout"`
+	Address        string `yaml:"address"`
+	WriteTimeout   string `yaml:"writeTimeout"`
+	ClientIPHeader string `yaml:"clientIpHeader"`
 }
 
 // DatabaseMigrations defines the database migrations configuration structure.
@@ -23,3 +28,144 @@ type Database struct {
 	URL        string             `yaml:"url"`
 	Migrations DatabaseMigrations `yaml:"migrations"`
 }
+
+// RoutesCollectionDetection defines the routes container collection detection configuration structure.
+type RoutesCollectionDetection struct {
+	Radius    float64 `yaml:"radius"`
+	DwellTime string  `yaml:"dwellTime"`
+}
+
+// Routes defines the routes configuration structure.
+type Routes struct {
+	CollectionDetection RoutesCollectionDetection `yaml:"collectionDetection"`
+	DeviationRadius     float64                   `yaml:"deviationRadius"`
+}
+
+// AuthnKey defines the authentication signing key configuration structure. The private key file must be PEM encoded
+// and the active from time must be formatted according to RFC 3339.
+type AuthnKey struct {
+	ID             string `yaml:"id"`
+	Algorithm      string `yaml:"algorithm"`
+	PrivateKeyFile string `yaml:"privateKeyFile"`
+	ActiveFrom     string `yaml:"activeFrom"`
+}
+
+// AuthnSSORoles defines the single sign-on roles configuration structure, which contains the identity provider groups
+// mapped to each employee role.
+type AuthnSSORoles struct {
+	Manager       []string `yaml:"manager"`
+	WasteOperator []string `yaml:"wasteOperator"`
+}
+
+// AuthnSSO defines the employees single sign-on configuration structure. Single sign-on is disabled when the issuer is
+// not defined.
+type AuthnSSO struct {
+	Issuer       string        `yaml:"issuer"`
+	ClientID     string        `yaml:"clientId"`
+	ClientSecret string        `yaml:"clientSecret"`
+	RedirectURL  string        `yaml:"redirectUrl"`
+	Scopes       []string      `yaml:"scopes"`
+	GroupsClaim  string        `yaml:"groupsClaim"`
+	Roles        AuthnSSORoles `yaml:"roles"`
+}
+
+// AuthnSignInBackoff defines the sign-in backoff configuration structure. Once the threshold of failed attempts with
+// the same username is reached, the following attempts are delayed by the base delay, which doubles with each failure
+// up to the maximum delay.
+type AuthnSignInBackoff struct {
+	Threshold int    `yaml:"threshold"`
+	Base      string `yaml:"base"`
+	Max       string `yaml:"max"`
+}
+
+// AuthnSignInLockout defines the sign-in lockout configuration structure. Accounts are locked once the threshold of
+// failed attempts with the same username is reached, and IP addresses are blocked once the IP address threshold of
+// failed attempts from the same IP address is reached.
+type AuthnSignInLockout struct {
+	Threshold          int    `yaml:"threshold"`
+	IPAddressThreshold int    `yaml:"ipAddressThreshold"`
+	Duration           string `yaml:"duration"`
+}
+
+// AuthnSignIn defines the sign-in brute-force protection configuration structure. Failed attempts are only counted
+// within the failure window.
+type AuthnSignIn struct {
+	FailureWindow string             `yaml:"failureWindow"`
+	Backoff       AuthnSignInBackoff `yaml:"backoff"`
+	Lockout       AuthnSignInLockout `yaml:"lockout"`
+}
+
+// AuthnTOTP defines the employees two-factor authentication configuration structure. Employees with the required
+// roles must enrol in two-factor authentication when signing in with a password.
+type AuthnTOTP struct {
+	Issuer        string   `yaml:"issuer"`
+	RequiredRoles []string `yaml:"requiredRoles"`
+}
+
+// AuthnPasswordPolicy defines the password policy configuration structure. The history size is the amount of most
+// recent passwords that cannot be reused, and breached passwords are only rejected when the breached passwords file is
+// defined. The default policy is used when no rule is defined.
+type AuthnPasswordPolicy struct {
+	MinLength       int  `yaml:"minLength"`
+	MaxLength       int  `yaml:"maxLength"`
+	MinDigits       int  `yaml:"minDigits"`
+	MinLetters      int  `yaml:"minLetters"`
+	MinSpecialChars int  `yaml:"minSpecialChars"`
+	HistorySize     int  `yaml:"historySize"`
+	CheckBreached   bool `yaml:"checkBreached"`
+}
+
+// AuthnEmployeesPasswordPolicy defines the employees password policy configuration structure. Employee passwords must
+// be changed once they are older than the maximum age, when defined.
+type AuthnEmployeesPasswordPolicy struct {
+	AuthnPasswordPolicy `yaml:",inline"`
+	MaxAge              string `yaml:"maxAge"`
+}
+
+// AuthnPasswordPolicies defines the password policies configuration structure. The breached passwords file contains
+// the sorted SHA-1 hashes of breached passwords, one per line.
+type AuthnPasswordPolicies struct {
+	Users                 AuthnPasswordPolicy          `yaml:"users"`
+	Employees             AuthnEmployeesPasswordPolicy `yaml:"employees"`
+	BreachedPasswordsFile string                       `yaml:"breachedPasswordsFile"`
+}
+
+// Authn defines the authentication configuration structure.
+type Authn struct {
+	Keys                []AuthnKey            `yaml:"keys"`
+	RotationGracePeriod string                `yaml:"rotationGracePeriod"`
+	SSO                 AuthnSSO              `yaml:"sso"`
+	SignIn              AuthnSignIn           `yaml:"signIn"`
+	TOTP                AuthnTOTP             `yaml:"totp"`
+	PasswordPolicy      AuthnPasswordPolicies `yaml:"passwordPolicy"`
+}
+
+// MailSMTP defines the SMTP mail sender configuration structure.
+type MailSMTP struct {
+	Host     string `yaml:"host"`
+	Port     int    `yaml:"port"`
+	Username string `yaml:"username"`
+	Password string `yaml:"password"`
+}
+
+// Mail defines the mail configuration structure. The sender is either `smtp` or `file`, which writes the messages to
+// the file, or to the log when the file is not defined.
+type Mail struct {
+	Sender string   `yaml:"sender"`
+	From   string   `yaml:"from"`
+	SMTP   MailSMTP `yaml:"smtp"`
+	File   string   `yaml:"file"`
+}
+
+// UsersTokens defines the users tokens configuration structure.
+type UsersTokens struct {
+	Limit       int    `yaml:"limit"`
+	LimitPeriod string `yaml:"limitPeriod"`
+}
+
+// Users defines the users configuration structure.
+type Users struct {
+	PasswordResetURL     string      `yaml:"passwordResetUrl"`
+	EmailVerificationURL string      `yaml:"emailVerificationUrl"`
+	Tokens               UsersTokens `yaml:"tokens"`
+}
@@ -0,0 +1,86 @@
+package domain
+
+import (
+	"errors"
+	"net/netip"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// API key errors.
+var (
+	ErrAPIKeyNotFound = errors.New("api key not found") // Returned when an API key is not found.
+	ErrAPIKeyInvalid  = errors.New("invalid api key")   // Returned when an API key is not found, has expired or is used from an IP address that is not allowed.
+)
+
+// APIKeyRoles defines the names of the roles granted to the API key.
+type APIKeyRoles []RoleName
+
+// Valid returns true if the roles are valid, false otherwise. At least one role must be granted, and the roles must
+// exist.
+func (r APIKeyRoles) Valid() bool {
+	if len(r) == 0 {
+		return false
+	}
+
+	for i, role := range r {
+		if !role.Valid() || slices.Contains(r[:i], role) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// EditableAPIKey defines the editable API key structure. When no IP address prefixes are allowed, the API key can be
+// used from any IP address, and when the expiration time is not defined, the API key never expires.
+type EditableAPIKey struct {
+	Name               Name
+	Roles              APIKeyRoles
+	AllowedIPAddresses []netip.Prefix
+	ExpiresAt          *time.Time
+}
+
+// EditableAPIKeyWithHash defines the editable API key structure with the hash of the key. Only the hash of the key is
+// stored.
+type EditableAPIKeyWithHash struct {
+	EditableAPIKey
+	Hash []byte
+}
+
+// APIKey defines the API key structure.
+type APIKey struct {
+	EditableAPIKey
+	ID         uuid.UUID
+	LastUsedAt *time.Time
+	CreatedAt  time.Time
+}
+
+// Expired returns true if the API key has expired at the given time, false otherwise.
+func (k APIKey) Expired(at time.Time) bool {
+	return k.ExpiresAt != nil && !at.Before(*k.ExpiresAt)
+}
+
+// AllowsIPAddress returns true if the API key can be used from the given IP address, false otherwise.
+func (k APIKey) AllowsIPAddress(ipAddress netip.Addr) bool {
+	if len(k.AllowedIPAddresses) == 0 {
+		return true
+	}
+
+	ipAddress = ipAddress.Unmap()
+	for _, prefix := range k.AllowedIPAddresses {
+		if prefix.Contains(ipAddress) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// APIKeyWithKey defines the API key structure with the key, which is only known when the API key is created.
+type APIKeyWithKey struct {
+	APIKey
+	Key string
+}
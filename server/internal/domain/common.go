@@ -3,6 +3,8 @@ package domain
 import (
 	"errors"
 	"fmt"
+	"net/mail"
+	"time"
 )
 
 // Field constraints.
@@ -14,6 +16,8 @@ const (
 	nameMaxLength = 50
 
 	phoneNumberMaxLength = 20
+
+	emailMaxLength = 254
 )
 
 // Common errors.
@@ -43,8 +47,9 @@ type Password string
 
 // SignIn defines the sign-in structure.
 type SignIn struct {
-	Username Username
-	Password Password
+	Username          Username
+	Password          Password
+	PasswordChangedAt *time.Time
 }
 
 // Name defines the name type.
@@ -62,3 +67,23 @@ type PhoneNumber string
 func (pn PhoneNumber) Valid() bool {
 	return len(pn) <= phoneNumberMaxLength
 }
+
+// Email defines the email address type.
+type Email string
+
+// Valid returns true if the email address is valid, false otherwise. Only bare addresses are valid, without a display
+// name.
+func (e Email) Valid() bool {
+	if len(e) > emailMaxLength {
+		return false
+	}
+
+	address, err := mail.ParseAddress(string(e))
+	return err == nil && address.Address == string(e)
+}
+
+// TimeOfDay returns the time elapsed since midnight of the given time.
+func TimeOfDay(t time.Time) time.Duration {
+	hour, minute, second := t.Clock()
+	return time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute + time.Duration(second)*time.Second + time.Duration(t.Nanosecond())
+}
@@ -7,6 +7,11 @@ import (
 	"github.com/google/uuid"
 )
 
+// Container constraints.
+const (
+	containerVolumeMinValue = 0
+)
+
 // Container errors.
 var (
 	ErrContainerNotFound                            = errors.New("container not found")                     // Returned when a container is not found.
@@ -43,16 +48,65 @@ func (c ContainerCategory) Valid() bool {
 	}
 }
 
+// Density returns the typical bulk density of the waste of the category in kilograms per cubic meter.
+func (c ContainerCategory) Density() float64 {
+	switch c {
+	case ContainerCategoryGeneral:
+		return 150
+	case ContainerCategoryPaper:
+		return 90
+	case ContainerCategoryPlastic:
+		return 35
+	case ContainerCategoryMetal:
+		return 200
+	case ContainerCategoryGlass:
+		return 300
+	case ContainerCategoryOrganic:
+		return 500
+	case ContainerCategoryHazardous:
+		return 250
+	default:
+		return 0
+	}
+}
+
+// ContainerCategories returns all the container categories.
+func ContainerCategories() []ContainerCategory {
+	return []ContainerCategory{
+		ContainerCategoryGeneral,
+		ContainerCategoryPaper,
+		ContainerCategoryPlastic,
+		ContainerCategoryMetal,
+		ContainerCategoryGlass,
+		ContainerCategoryOrganic,
+		ContainerCategoryHazardous,
+	}
+}
+
+// ContainerVolume defines the nominal volume of the container in cubic meters.
+type ContainerVolume float64
+
+// Valid returns true if the volume is valid, false otherwise.
+func (v ContainerVolume) Valid() bool {
+	return v > containerVolumeMinValue
+}
+
 // EditableContainer defines the editable container structure.
 type EditableContainer struct {
-	Category ContainerCategory
-	GeoJSON  GeoJSON
+	Category            ContainerCategory
+	Volume              *ContainerVolume
+	CollectionStartTime *time.Time
+	CollectionEndTime   *time.Time
+	GeoJSON             GeoJSON
 }
 
 // EditableContainerPatch defines the patchable container structure.
 type EditableContainerPatch struct {
-	Category *ContainerCategory
-	GeoJSON  GeoJSON
+	Category            *ContainerCategory
+	Volume              *ContainerVolume
+	CollectionStartTime *time.Time
+	CollectionEndTime   *time.Time
+	GeoJSON             GeoJSON
 }
 
 // Container defines the container structure.
@@ -63,6 +117,57 @@ type Container struct {
 	ModifiedAt time.Time
 }
 
+// Load returns the estimated volume and weight of the container when full, in cubic meters and kilograms
+// respectively. The weight is estimated based on the typical density of the container category. Containers without a
+// nominal volume are considered empty.
+func (c Container) Load() (float64, float64) {
+	if c.Volume == nil {
+		return 0, 0
+	}
+
+	volume := float64(*c.Volume)
+	return volume, volume * c.Category.Density()
+}
+
+// CollectionWindow returns the start and end of the container collection window as offsets since midnight. A missing
+// start defaults to midnight and a missing end defaults to the end of the day. When the start is after the end, the
+// window spans midnight and the end is moved to the following day.
+func (c Container) CollectionWindow() (time.Duration, time.Duration) {
+	start := time.Duration(0)
+	end := 24 * time.Hour
+
+	if c.CollectionStartTime != nil {
+		start = TimeOfDay(*c.CollectionStartTime)
+	}
+	if c.CollectionEndTime != nil {
+		end = TimeOfDay(*c.CollectionEndTime)
+	}
+
+	if start > end {
+		end += 24 * time.Hour
+	}
+
+	return start, end
+}
+
+// CollectionWindowAt returns the start and end, as offsets since midnight, of the container collection window that
+// applies to an arrival at the given offset since midnight. A window that spans midnight also covers the arrivals
+// after midnight and before it ends, in which case the window of the previous day is returned.
+func (c Container) CollectionWindowAt(arrival time.Duration) (time.Duration, time.Duration) {
+	start, end := c.CollectionWindow()
+
+	if arrival < start && arrival+24*time.Hour <= end {
+		return start - 24*time.Hour, end - 24*time.Hour
+	}
+
+	return start, end
+}
+
+// HasCollectionWindow returns true if the container can only be collected within a time window, false otherwise.
+func (c Container) HasCollectionWindow() bool {
+	return c.CollectionStartTime != nil || c.CollectionEndTime != nil
+}
+
 // ContainerPaginatedSort defines the field of the container to sort.
 type ContainerPaginatedSort string
 
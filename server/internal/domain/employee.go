@@ -2,6 +2,7 @@ package domain
 
 import (
 	"errors"
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
@@ -14,23 +15,51 @@ var (
 	ErrEmployeeAssociatedWithRouteEmployee = errors.New("employee associated with route") // Returned when an employee is associated with a route.
 )
 
-// EmployeeRole defines the role of the employee.
+// EmployeeRole defines the role of the employee, which is the name of a stored role.
 type EmployeeRole string
 
+// Built-in employee roles.
 const (
 	EmployeeRoleWasteOperator EmployeeRole = "wasteOperator"
 	EmployeeRoleManager       EmployeeRole = "manager"
 )
 
-// Valid returns true if the role is valid, false otherwise.
+// Valid returns true if the role is a valid role name, false otherwise. The role must also exist.
 func (r EmployeeRole) Valid() bool {
-	switch r {
-	case EmployeeRoleWasteOperator,
-		EmployeeRoleManager:
-		return true
-	default:
-		return false
+	return RoleName(r).Valid()
+}
+
+// EmployeeShift defines the employee shift structure, which contains the working hours of the employee on a weekday.
+// When the start time is after the end time, the shift spans midnight and ends on the following day.
+type EmployeeShift struct {
+	Weekday   time.Weekday
+	StartTime time.Time
+	EndTime   time.Time
+}
+
+// Valid returns true if the shift is valid, false otherwise.
+func (s EmployeeShift) Valid() bool {
+	return s.Weekday >= time.Sunday && s.Weekday <= time.Saturday && TimeOfDay(s.StartTime) != TimeOfDay(s.EndTime)
+}
+
+// EmployeeShifts defines the employee shifts type, which contains at most one shift per weekday.
+type EmployeeShifts []EmployeeShift
+
+// Valid returns true if the shifts are valid, false otherwise.
+func (s EmployeeShifts) Valid() bool {
+	seen := make(map[time.Weekday]struct{}, len(s))
+	for _, shift := range s {
+		if !shift.Valid() {
+			return false
+		}
+
+		if _, ok := seen[shift.Weekday]; ok {
+			return false
+		}
+		seen[shift.Weekday] = struct{}{}
 	}
+
+	return true
 }
 
 // EditableEmployee defines the editable employee structure.
@@ -44,6 +73,7 @@ type EditableEmployee struct {
 	GeoJSON       GeoJSON
 	ScheduleStart time.Time
 	ScheduleEnd   time.Time
+	Shifts        EmployeeShifts
 }
 
 // EditableEmployeeWithPassword defines the editable employee structure with a password.
@@ -57,11 +87,13 @@ type EditableEmployeePatch struct {
 	Username      *Username
 	FirstName     *Name
 	LastName      *Name
+	Role          *EmployeeRole
 	DateOfBirth   *time.Time
 	PhoneNumber   *PhoneNumber
 	GeoJSON       GeoJSON
 	ScheduleStart *time.Time
 	ScheduleEnd   *time.Time
+	Shifts        *EmployeeShifts
 }
 
 // Employee defines the employee structure.
@@ -72,6 +104,52 @@ type Employee struct {
 	ModifiedAt time.Time
 }
 
+// Shift returns the start and end of the employee shift on the given weekday as offsets since midnight, and true if
+// the employee works on that weekday. Employees without shifts work the daily schedule on every weekday. When the start
+// is after the end, the shift spans midnight and the end is moved to the following day.
+func (e Employee) Shift(weekday time.Weekday) (time.Duration, time.Duration, bool) {
+	start, end := TimeOfDay(e.ScheduleStart), TimeOfDay(e.ScheduleEnd)
+
+	if len(e.Shifts) != 0 {
+		i := slices.IndexFunc(e.Shifts, func(shift EmployeeShift) bool {
+			return shift.Weekday == weekday
+		})
+		if i == -1 {
+			return 0, 0, false
+		}
+
+		start, end = TimeOfDay(e.Shifts[i].StartTime), TimeOfDay(e.Shifts[i].EndTime)
+	}
+
+	if start > end {
+		end += 24 * time.Hour
+	}
+
+	return start, end, true
+}
+
+// OnShift returns true if the employee is on shift during the whole period between the given times, false otherwise.
+// The period is covered either by the shift of the weekday it starts on, or by the shift of the previous weekday when
+// that shift spans midnight.
+func (e Employee) OnShift(start, end time.Time) bool {
+	periodStart := TimeOfDay(start)
+	periodEnd := periodStart + end.Sub(start)
+
+	if shiftStart, shiftEnd, ok := e.Shift(start.Weekday()); ok && shiftStart <= periodStart && periodEnd <= shiftEnd {
+		return true
+	}
+
+	// Shifts of the previous weekday are offset by a day.
+	periodStart += 24 * time.Hour
+	periodEnd += 24 * time.Hour
+
+	if shiftStart, shiftEnd, ok := e.Shift(start.AddDate(0, 0, -1).Weekday()); ok && shiftStart <= periodStart && periodEnd <= shiftEnd {
+		return true
+	}
+
+	return false
+}
+
 // EmployeePaginatedSort defines the field of the employee to sort.
 type EmployeePaginatedSort string
 
@@ -127,3 +205,27 @@ type EmployeesPaginatedFilter struct {
 	ScheduleEnd   *string
 	LocationName  *string
 }
+
+// EmployeesAvailabilityFilter defines the employees availability filter structure. The employees available are the
+// ones on shift and not absent on the date between the start and end times. When the start time is after the end time,
+// the period spans midnight and ends on the following day.
+type EmployeesAvailabilityFilter struct {
+	Date      time.Time
+	StartTime time.Time
+	EndTime   time.Time
+	Role      *EmployeeRole
+}
+
+// Period returns the start and end of the period to check the availability of the employees.
+func (f EmployeesAvailabilityFilter) Period() (time.Time, time.Time) {
+	year, month, day := f.Date.Date()
+	midnight := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+
+	start := midnight.Add(TimeOfDay(f.StartTime))
+	end := midnight.Add(TimeOfDay(f.EndTime))
+	if end.Before(start) {
+		end = end.AddDate(0, 0, 1)
+	}
+
+	return start, end
+}
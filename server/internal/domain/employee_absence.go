@@ -0,0 +1,102 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Employee absence constraints.
+const (
+	employeeAbsenceNotesMaxLength = 500
+)
+
+// Employee absence errors.
+var (
+	ErrEmployeeAbsenceNotFound = errors.New("employee absence not found")                    // Returned when an employee absence is not found.
+	ErrEmployeeAbsenceOverlap  = errors.New("employee absence overlaps an existing absence") // Returned when an employee absence overlaps another absence of the same employee.
+)
+
+// EmployeeAbsenceType defines the type of the employee absence.
+type EmployeeAbsenceType string
+
+const (
+	EmployeeAbsenceTypeVacation  EmployeeAbsenceType = "vacation"
+	EmployeeAbsenceTypeSickLeave EmployeeAbsenceType = "sickLeave"
+	EmployeeAbsenceTypeTraining  EmployeeAbsenceType = "training"
+	EmployeeAbsenceTypeOther     EmployeeAbsenceType = "other"
+)
+
+// Valid returns true if the type is valid, false otherwise.
+func (t EmployeeAbsenceType) Valid() bool {
+	switch t {
+	case EmployeeAbsenceTypeVacation,
+		EmployeeAbsenceTypeSickLeave,
+		EmployeeAbsenceTypeTraining,
+		EmployeeAbsenceTypeOther:
+		return true
+	default:
+		return false
+	}
+}
+
+// EmployeeAbsenceNotes defines the notes of the employee absence.
+type EmployeeAbsenceNotes string
+
+// Valid returns true if the notes are valid, false otherwise.
+func (n EmployeeAbsenceNotes) Valid() bool {
+	return len(n) <= employeeAbsenceNotesMaxLength
+}
+
+// EditableEmployeeAbsence defines the editable employee absence structure. The absence includes both the start and end
+// dates.
+type EditableEmployeeAbsence struct {
+	Type      EmployeeAbsenceType
+	StartDate time.Time
+	EndDate   time.Time
+	Notes     *EmployeeAbsenceNotes
+}
+
+// EmployeeAbsence defines the employee absence structure.
+type EmployeeAbsence struct {
+	EditableEmployeeAbsence
+	ID         uuid.UUID
+	EmployeeID uuid.UUID
+	CreatedAt  time.Time
+	ModifiedAt time.Time
+}
+
+// EmployeeAbsencePaginatedSort defines the field of the employee absence to sort.
+type EmployeeAbsencePaginatedSort string
+
+const (
+	EmployeeAbsencePaginatedSortType      EmployeeAbsencePaginatedSort = "type"
+	EmployeeAbsencePaginatedSortStartDate EmployeeAbsencePaginatedSort = "startDate"
+	EmployeeAbsencePaginatedSortEndDate   EmployeeAbsencePaginatedSort = "endDate"
+	EmployeeAbsencePaginatedSortCreatedAt EmployeeAbsencePaginatedSort = "createdAt"
+)
+
+// Field returns the name of the field to sort by.
+func (s EmployeeAbsencePaginatedSort) Field() EmployeeAbsencePaginatedSort {
+	return s
+}
+
+// Valid returns true if the field is valid, false otherwise.
+func (s EmployeeAbsencePaginatedSort) Valid() bool {
+	switch s {
+	case EmployeeAbsencePaginatedSortType,
+		EmployeeAbsencePaginatedSortStartDate,
+		EmployeeAbsencePaginatedSortEndDate,
+		EmployeeAbsencePaginatedSortCreatedAt:
+		return true
+	default:
+		return false
+	}
+}
+
+// EmployeeAbsencesPaginatedFilter defines the employee absences filter structure.
+type EmployeeAbsencesPaginatedFilter struct {
+	PaginatedRequest[EmployeeAbsencePaginatedSort]
+	Type *EmployeeAbsenceType
+}
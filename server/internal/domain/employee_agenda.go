@@ -0,0 +1,25 @@
+package domain
+
+import (
+	"time"
+)
+
+// EmployeeAgendaRoute defines the employee agenda route structure, which includes the role of the employee in the
+// route, the departure of the route on the agenda date, the sequential stops of the route plan and the roads that
+// connect them. The departure is only defined when the route has a departure time.
+type EmployeeAgendaRoute struct {
+	Route
+	RouteRole   RouteEmployeeRole
+	DepartureAt *time.Time
+	Stops       []RouteStop
+	Roads       GeoJSON
+}
+
+// EmployeeAgenda defines the employee agenda structure, which contains the routes the employee works on a date. Routes
+// depart daily, so the agenda contains every route the employee is associated with, unless the employee is absent on
+// the date.
+type EmployeeAgenda struct {
+	Date   time.Time
+	Absent bool
+	Routes []EmployeeAgendaRoute
+}
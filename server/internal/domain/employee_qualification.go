@@ -0,0 +1,137 @@
+package domain
+
+import (
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Employee qualification errors.
+var (
+	ErrEmployeeQualificationNotFound      = errors.New("employee qualification not found")      // Returned when an employee qualification is not found.
+	ErrEmployeeQualificationAlreadyExists = errors.New("employee qualification already exists") // Returned when the employee already holds a qualification of the same type and license category.
+)
+
+// LicenseCategory defines the driving license category.
+type LicenseCategory string
+
+const (
+	LicenseCategoryB   LicenseCategory = "B"
+	LicenseCategoryC1  LicenseCategory = "C1"
+	LicenseCategoryC1E LicenseCategory = "C1E"
+	LicenseCategoryC   LicenseCategory = "C"
+	LicenseCategoryCE  LicenseCategory = "CE"
+)
+
+// licenseCategoriesCovered maps each license category to the categories its holder is allowed to drive.
+var licenseCategoriesCovered = map[LicenseCategory][]LicenseCategory{
+	LicenseCategoryB:   {LicenseCategoryB},
+	LicenseCategoryC1:  {LicenseCategoryC1},
+	LicenseCategoryC1E: {LicenseCategoryC1E, LicenseCategoryC1},
+	LicenseCategoryC:   {LicenseCategoryC, LicenseCategoryC1},
+	LicenseCategoryCE:  {LicenseCategoryCE, LicenseCategoryC1E, LicenseCategoryC, LicenseCategoryC1},
+}
+
+// Valid returns true if the license category is valid, false otherwise.
+func (c LicenseCategory) Valid() bool {
+	_, ok := licenseCategoriesCovered[c]
+	return ok
+}
+
+// Covers returns true if the holder of the license category is allowed to drive vehicles that require the given
+// category, false otherwise.
+func (c LicenseCategory) Covers(category LicenseCategory) bool {
+	return slices.Contains(licenseCategoriesCovered[c], category)
+}
+
+// EmployeeQualificationType defines the type of the employee qualification.
+type EmployeeQualificationType string
+
+const (
+	EmployeeQualificationTypeLicense            EmployeeQualificationType = "license"
+	EmployeeQualificationTypeHazardousMaterials EmployeeQualificationType = "hazardousMaterials"
+)
+
+// Valid returns true if the type is valid, false otherwise.
+func (t EmployeeQualificationType) Valid() bool {
+	switch t {
+	case EmployeeQualificationTypeLicense,
+		EmployeeQualificationTypeHazardousMaterials:
+		return true
+	default:
+		return false
+	}
+}
+
+// EditableEmployeeQualification defines the editable employee qualification structure. The license category is only
+// defined for license qualifications. The qualification is valid up to and including the expiry date.
+type EditableEmployeeQualification struct {
+	Type            EmployeeQualificationType
+	LicenseCategory *LicenseCategory
+	ExpiryDate      time.Time
+}
+
+// EmployeeQualification defines the employee qualification structure.
+type EmployeeQualification struct {
+	EditableEmployeeQualification
+	ID         uuid.UUID
+	EmployeeID uuid.UUID
+	CreatedAt  time.Time
+	ModifiedAt time.Time
+}
+
+// ValidOn returns true if the qualification has not expired on the date of the given time, false otherwise.
+func (q EmployeeQualification) ValidOn(t time.Time) bool {
+	year, month, day := t.Date()
+	date := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+
+	return !date.After(q.ExpiryDate)
+}
+
+// EmployeeQualifications defines the employee qualifications type.
+type EmployeeQualifications []EmployeeQualification
+
+// HasLicense returns true if any of the qualifications is a license that covers the given category and is valid on the
+// date of the given time, false otherwise.
+func (q EmployeeQualifications) HasLicense(category LicenseCategory, t time.Time) bool {
+	for _, qualification := range q {
+		if qualification.Type != EmployeeQualificationTypeLicense || qualification.LicenseCategory == nil {
+			continue
+		}
+
+		if qualification.LicenseCategory.Covers(category) && qualification.ValidOn(t) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// HasHazardousMaterialsCertification returns true if any of the qualifications is a hazardous materials certification
+// valid on the date of the given time, false otherwise.
+func (q EmployeeQualifications) HasHazardousMaterialsCertification(t time.Time) bool {
+	for _, qualification := range q {
+		if qualification.Type == EmployeeQualificationTypeHazardousMaterials && qualification.ValidOn(t) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// EmployeeQualificationExpiring defines the expiring employee qualification structure, which includes the employee that
+// holds the qualification. The remaining days are negative when the qualification has already expired.
+type EmployeeQualificationExpiring struct {
+	Qualification EmployeeQualification
+	Employee      Employee
+	RemainingDays int
+}
+
+// EmployeeQualificationsExpiringFilter defines the expiring employee qualifications filter structure. Qualifications are
+// expiring when they expire within the number of days, including the ones that have already expired.
+type EmployeeQualificationsExpiringFilter struct {
+	WithinDays int
+	Type       *EmployeeQualificationType
+}
@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// Employee single sign-on errors.
+var (
+	ErrSSONotConfigured        = errors.New("single sign-on not configured")                   // Returned when the single sign-on identity provider is not configured.
+	ErrSSOAuthorizationInvalid = errors.New("invalid single sign-on authorization")            // Returned when the authorization state is unknown or expired, or the identity provider rejects the authorization code.
+	ErrSSORoleNotMapped        = errors.New("identity provider groups not mapped to any role") // Returned when none of the groups of the identity is mapped to an employee role.
+)
+
+// SSOAuthorization defines the single sign-on authorization structure, which contains the values that bind the
+// authorization request to the identity provider response.
+type SSOAuthorization struct {
+	State        string
+	Nonce        string
+	CodeVerifier string
+	ExpiresAt    time.Time
+}
+
+// SSOIdentity defines the single sign-on identity structure, which contains the subject authenticated by the identity
+// provider.
+type SSOIdentity struct {
+	Subject   string
+	Username  Username
+	FirstName Name
+	LastName  Name
+	Groups    []string
+}
@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Employee two-factor authentication errors.
+var (
+	ErrEmployeeTOTPNotFound       = errors.New("employee two-factor authentication not found")           // Returned when an employee has no two-factor authentication enrolment.
+	ErrEmployeeTOTPAlreadyEnabled = errors.New("employee two-factor authentication already enabled")     // Returned when an employee enrols or confirms two-factor authentication that is already enabled.
+	ErrEmployeeTOTPCodeInvalid    = errors.New("invalid two-factor authentication code")                 // Returned when a code is neither a valid time-based one-time password nor an unused recovery code.
+	ErrMFAChallengeInvalid        = errors.New("invalid or expired two-factor authentication challenge") // Returned when a two-factor authentication challenge is not found, has expired or exceeded the attempts.
+)
+
+// EmployeeTOTP defines the employee time-based one-time password structure. The enrolment is pending until the
+// employee confirms it with a valid code, and the time step of the last used code prevents the same code from being
+// used again.
+type EmployeeTOTP struct {
+	EmployeeID   uuid.UUID
+	Secret       string
+	ConfirmedAt  *time.Time
+	LastUsedStep *int64
+	CreatedAt    time.Time
+}
+
+// Enabled returns true if the enrolment was confirmed, false otherwise.
+func (t EmployeeTOTP) Enabled() bool {
+	return t.ConfirmedAt != nil
+}
+
+// TOTPEnrolment defines the time-based one-time password enrolment structure, which contains the secret and the
+// otpauth URI to add to an authenticator application.
+type TOTPEnrolment struct {
+	Secret string
+	URI    string
+}
+
+// EditableEmployeeMFAChallenge defines the editable employee two-factor authentication challenge structure, which is
+// created after the password of an employee is verified. Only the hash of the challenge token is stored.
+type EditableEmployeeMFAChallenge struct {
+	EmployeeID        uuid.UUID
+	Hash              []byte
+	EnrolmentRequired bool
+	ExpiresAt         time.Time
+}
+
+// EmployeeMFAChallenge defines the employee two-factor authentication challenge structure.
+type EmployeeMFAChallenge struct {
+	EditableEmployeeMFAChallenge
+	ID        uuid.UUID
+	Attempts  int
+	CreatedAt time.Time
+}
+
+// Valid returns true if the challenge has not expired at the given time and has attempts left, false otherwise.
+func (c EmployeeMFAChallenge) Valid(at time.Time, maxAttempts int) bool {
+	return at.Before(c.ExpiresAt) && c.Attempts < maxAttempts
+}
+
+// MFAChallenge defines the two-factor authentication challenge structure, which is returned instead of the tokens
+// when the sign-in requires a second factor. When the enrolment is required, the employee must enrol before answering
+// the challenge.
+type MFAChallenge struct {
+	Token             string
+	ExpiresAt         time.Time
+	EnrolmentRequired bool
+}
+
+// EmployeeSignInResult defines the employee sign-in result structure, which contains either the tokens or the
+// two-factor authentication challenge to answer to obtain them.
+type EmployeeSignInResult struct {
+	Tokens       Tokens
+	MFAChallenge *MFAChallenge
+}
+
+// EmployeeMFASignInResult defines the employee two-factor authentication sign-in result structure, which contains the
+// tokens and, when answering the challenge confirms the enrolment, the recovery codes of the employee.
+type EmployeeMFASignInResult struct {
+	Tokens        Tokens
+	RecoveryCodes []string
+}
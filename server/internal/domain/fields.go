@@ -6,27 +6,76 @@ const (
 	FieldParamUserID      = "userId"
 	FieldParamCoordinates = "coordinates"
 
-	FieldUsername       = "username"
-	FieldPassword       = "password"
-	FieldNewPassword    = "newPassword"
-	FieldFirstName      = "firstName"
-	FieldLastName       = "lastName"
-	FieldRole           = "role"
-	FieldPhoneNumber    = "phoneNumber"
-	FieldGeoJSON        = "geoJson"
-	FieldScheduleStart  = "scheduleStart"
-	FieldScheduleEnd    = "scheduleEnd"
-	FieldCategory       = "category"
-	FieldTruckCapacity  = "truckCapacity"
-	FieldMake           = "make"
-	FieldModel          = "model"
-	FieldLicensePlate   = "licensePlate"
-	FieldPersonCapacity = "personCapacity"
-	FieldName           = "name"
-	FieldRouteRole      = "routeRole"
+	FieldUsername                = "username"
+	FieldFirstName               = "firstName"
+	FieldLastName                = "lastName"
+	FieldRole                    = "role"
+	FieldPhoneNumber             = "phoneNumber"
+	FieldEmail                   = "email"
+	FieldGeoJSON                 = "geoJson"
+	FieldScheduleStart           = "scheduleStart"
+	FieldScheduleEnd             = "scheduleEnd"
+	FieldCategory                = "category"
+	FieldTruckCapacity           = "truckCapacity"
+	FieldMake                    = "make"
+	FieldModel                   = "model"
+	FieldLicensePlate            = "licensePlate"
+	FieldPersonCapacity          = "personCapacity"
+	FieldName                    = "name"
+	FieldRouteRole               = "routeRole"
+	FieldSources                 = "sources"
+	FieldTargets                 = "targets"
+	FieldAcceptedCategories      = "acceptedCategories"
+	FieldOpeningTime             = "openingTime"
+	FieldClosingTime             = "closingTime"
+	FieldDailyCapacity           = "dailyCapacity"
+	FieldVolume                  = "volume"
+	FieldVolumeCapacity          = "volumeCapacity"
+	FieldWeightCapacity          = "weightCapacity"
+	FieldCollectionStartTime     = "collectionStartTime"
+	FieldCollectionEndTime       = "collectionEndTime"
+	FieldDepartureTime           = "departureTime"
+	FieldCompartments            = "compartments"
+	FieldPositions               = "positions"
+	FieldSpeed                   = "speed"
+	FieldHeading                 = "heading"
+	FieldRecordedAt              = "recordedAt"
+	FieldGeometry                = "geometry"
+	FieldTimestamps              = "timestamps"
+	FieldStatus                  = "status"
+	FieldServiceIntervalDistance = "serviceIntervalDistance"
+	FieldServiceIntervalPeriod   = "serviceIntervalPeriod"
+	FieldType                    = "type"
+	FieldDate                    = "date"
+	FieldCost                    = "cost"
+	FieldNotes                   = "notes"
+	FieldOdometer                = "odometer"
+	FieldValue                   = "value"
+	FieldFuelType                = "fuelType"
+	FieldFuelConsumption         = "fuelConsumption"
+	FieldShifts                  = "shifts"
+	FieldStartDate               = "startDate"
+	FieldEndDate                 = "endDate"
+	FieldLicenseCategory         = "licenseCategory"
+	FieldExpiryDate              = "expiryDate"
+	FieldSubjectType             = "subjectType"
+	FieldKeyType                 = "keyType"
+	FieldRoles                   = "roles"
+	FieldAllowedIPAddresses      = "allowedIpAddresses"
+	FieldExpiresAt               = "expiresAt"
+	FieldPermissions             = "permissions"
 
 	FieldFilterSort   = "sort"
 	FieldFilterOrder  = "order"
 	FieldFilterLimit  = "limit"
 	FieldFilterOffset = "offset"
+
+	FieldFilterFrom            = "from"
+	FieldFilterTo              = "to"
+	FieldFilterStopMinDuration = "stopMinDuration"
+	FieldFilterTolerance       = "tolerance"
+	FieldFilterWithinDistance  = "withinDistance"
+	FieldFilterWithinDays      = "withinDays"
+	FieldFilterStartTime       = "startTime"
+	FieldFilterEndTime         = "endTime"
 )
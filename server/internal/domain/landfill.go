@@ -7,19 +7,62 @@ import (
 	"github.com/google/uuid"
 )
 
+// Landfill constraints.
+const (
+	landfillDailyCapacityMinValue = 0
+)
+
 // Landfill errors.
 var (
 	ErrLandfillNotFound = errors.New("landfill not found") // Returned when a landfill is not found.
 )
 
+// LandfillAcceptedCategories defines the container categories accepted by the landfill.
+type LandfillAcceptedCategories []ContainerCategory
+
+// Valid returns true if the accepted categories are valid, false otherwise.
+func (c LandfillAcceptedCategories) Valid() bool {
+	seen := make(map[ContainerCategory]struct{}, len(c))
+	for _, category := range c {
+		if !category.Valid() {
+			return false
+		}
+
+		if _, ok := seen[category]; ok {
+			return false
+		}
+		seen[category] = struct{}{}
+	}
+
+	return true
+}
+
+// LandfillDailyCapacity defines the daily intake capacity of the landfill in tonnes.
+type LandfillDailyCapacity float64
+
+// Valid returns true if the daily capacity is valid, false otherwise.
+func (dc LandfillDailyCapacity) Valid() bool {
+	return dc >= landfillDailyCapacityMinValue
+}
+
 // EditableLandfill defines the editable landfill structure.
 type EditableLandfill struct {
-	GeoJSON GeoJSON
+	Name               *Name
+	AcceptedCategories LandfillAcceptedCategories
+	OpeningTime        *time.Time
+	ClosingTime        *time.Time
+	DailyCapacity      *LandfillDailyCapacity
+	GeoJSON            GeoJSON
 }
 
 // EditableLandfillPatch defines the patchable landfill structure.
 type EditableLandfillPatch struct {
-	GeoJSON GeoJSON
+	Name               *Name
+	AcceptedCategories LandfillAcceptedCategories
+	OpeningTime        *time.Time
+	ClosingTime        *time.Time
+	DailyCapacity      *LandfillDailyCapacity
+	GeoJSON            GeoJSON
 }
 
 // Landfill defines the landfill structure.
@@ -34,6 +77,7 @@ type Landfill struct {
 type LandfillPaginatedSort string
 
 const (
+	LandfillPaginatedSortName             LandfillPaginatedSort = "name"
 	LandfillPaginatedSortWayName          LandfillPaginatedSort = "wayName"
 	LandfillPaginatedSortMunicipalityName LandfillPaginatedSort = "municipalityName"
 	LandfillPaginatedSortCreatedAt        LandfillPaginatedSort = "createdAt"
@@ -48,7 +92,8 @@ func (s LandfillPaginatedSort) Field() LandfillPaginatedSort {
 // Valid returns true if the field is valid, false otherwise.
 func (s LandfillPaginatedSort) Valid() bool {
 	switch s {
-	case LandfillPaginatedSortWayName,
+	case LandfillPaginatedSortName,
+		LandfillPaginatedSortWayName,
 		LandfillPaginatedSortMunicipalityName,
 		LandfillPaginatedSortCreatedAt,
 		LandfillPaginatedSortModifiedAt:
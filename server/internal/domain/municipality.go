@@ -19,3 +19,16 @@ type Municipality struct {
 	Area      float64 // Area of the municipality in the metric unit hectare.
 	Perimeter float64 // Perimeter of the municipality in kilometers.
 }
+
+// MunicipalityEmissions defines the municipality emissions structure, which aggregates the emissions of the routes
+// departing from warehouses in the municipality. The estimated routes are the routes whose truck defines the fuel
+// consumption, and the only ones accounted for the fuel and carbon dioxide. The distance, fuel and carbon dioxide are in
+// kilometers, liters and kilograms respectively.
+type MunicipalityEmissions struct {
+	Municipality    Municipality
+	Routes          int
+	EstimatedRoutes int
+	Distance        float64
+	Fuel            float64
+	CO2             float64
+}
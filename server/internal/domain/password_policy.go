@@ -0,0 +1,83 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"unicode"
+)
+
+// Password policy errors.
+var (
+	ErrPasswordExpired = errors.New("password expired") // Returned when the password is older than the maximum password age and must be changed.
+)
+
+// PasswordRule defines a rule of the password policy.
+type PasswordRule string
+
+const (
+	PasswordRuleMinLength       PasswordRule = "minLength"
+	PasswordRuleMaxLength       PasswordRule = "maxLength"
+	PasswordRuleMinDigits       PasswordRule = "minDigits"
+	PasswordRuleMinLetters      PasswordRule = "minLetters"
+	PasswordRuleMinSpecialChars PasswordRule = "minSpecialChars"
+	PasswordRuleNotBreached     PasswordRule = "notBreached"
+	PasswordRuleNotReused       PasswordRule = "notReused"
+)
+
+// Returned when a password violates a rule of the password policy.
+type ErrPasswordPolicyViolated struct {
+	Rule PasswordRule
+}
+
+func (e *ErrPasswordPolicyViolated) Error() string {
+	return fmt.Sprintf("password policy violated: %s", e.Rule)
+}
+
+// PasswordPolicy defines the password policy structure. The history size is the amount of most recent passwords,
+// including the current one, that cannot be reused, and breached passwords are only rejected when checked.
+type PasswordPolicy struct {
+	MinLength       int
+	MaxLength       int
+	MinDigits       int
+	MinLetters      int
+	MinSpecialChars int
+	HistorySize     int
+	CheckBreached   bool
+}
+
+// Check returns an error with the first rule of the policy that the password violates, nil otherwise. The rules that
+// depend on breached and previous passwords are not checked.
+func (p PasswordPolicy) Check(password Password) error {
+	if len(password) < p.MinLength {
+		return &ErrPasswordPolicyViolated{Rule: PasswordRuleMinLength}
+	}
+	if len(password) > p.MaxLength {
+		return &ErrPasswordPolicyViolated{Rule: PasswordRuleMaxLength}
+	}
+
+	var digits int
+	var letters int
+	var specialChars int
+	for _, r := range string(password) {
+		switch {
+		case unicode.IsDigit(r):
+			digits++
+		case unicode.IsLetter(r):
+			letters++
+		default:
+			specialChars++
+		}
+	}
+
+	if digits < p.MinDigits {
+		return &ErrPasswordPolicyViolated{Rule: PasswordRuleMinDigits}
+	}
+	if letters < p.MinLetters {
+		return &ErrPasswordPolicyViolated{Rule: PasswordRuleMinLetters}
+	}
+	if specialChars < p.MinSpecialChars {
+		return &ErrPasswordPolicyViolated{Rule: PasswordRuleMinSpecialChars}
+	}
+
+	return nil
+}
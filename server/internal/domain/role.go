@@ -0,0 +1,178 @@
+package domain
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Role errors.
+var (
+	ErrRoleNotFound      = errors.New("role not found")                  // Returned when a role is not found.
+	ErrRoleAlreadyExists = errors.New("role already exists")             // Returned when a role already exists with the same name.
+	ErrRoleBuiltIn       = errors.New("built-in role cannot be deleted") // Returned when deleting a built-in role.
+	ErrRoleAssigned      = errors.New("role assigned")                   // Returned when deleting a role that is granted to an API key or employee.
+	ErrRoleNotGrantable  = errors.New("role not grantable")              // Returned when granting a role with permissions that the grantor does not hold.
+)
+
+// Field constraints.
+const (
+	roleNameMinLength = 1
+	roleNameMaxLength = 50
+)
+
+// Permission defines a permission granted by a role.
+type Permission string
+
+const (
+	PermissionUsersRead           Permission = "users:read"
+	PermissionUsersReadOwn        Permission = "users:read:own"
+	PermissionUsersWrite          Permission = "users:write"
+	PermissionUsersWriteOwn       Permission = "users:write:own"
+	PermissionBookmarksReadOwn    Permission = "bookmarks:read:own"
+	PermissionBookmarksWriteOwn   Permission = "bookmarks:write:own"
+	PermissionEmployeesRead       Permission = "employees:read"
+	PermissionEmployeesReadOwn    Permission = "employees:read:own"
+	PermissionEmployeesWrite      Permission = "employees:write"
+	PermissionEmployeesWriteOwn   Permission = "employees:write:own"
+	PermissionContainersRead      Permission = "containers:read"
+	PermissionContainersWrite     Permission = "containers:write"
+	PermissionTrucksRead          Permission = "trucks:read"
+	PermissionTrucksWrite         Permission = "trucks:write"
+	PermissionTrucksOperate       Permission = "trucks:operate"
+	PermissionMaintenancesRead    Permission = "maintenances:read"
+	PermissionMaintenancesWrite   Permission = "maintenances:write"
+	PermissionWarehousesRead      Permission = "warehouses:read"
+	PermissionWarehousesWrite     Permission = "warehouses:write"
+	PermissionLandfillsRead       Permission = "landfills:read"
+	PermissionLandfillsWrite      Permission = "landfills:write"
+	PermissionRoutesRead          Permission = "routes:read"
+	PermissionRoutesPlan          Permission = "routes:plan"
+	PermissionRoutesOperate       Permission = "routes:operate"
+	PermissionRoutesMonitor       Permission = "routes:monitor"
+	PermissionGeocodingRead       Permission = "geocoding:read"
+	PermissionEmissionsRead       Permission = "emissions:read"
+	PermissionSignInLockoutsRead  Permission = "signInLockouts:read"
+	PermissionSignInLockoutsWrite Permission = "signInLockouts:write"
+	PermissionAPIKeysRead         Permission = "apiKeys:read"
+	PermissionAPIKeysWrite        Permission = "apiKeys:write"
+	PermissionRolesRead           Permission = "roles:read"
+	PermissionRolesWrite          Permission = "roles:write"
+)
+
+// PermissionOwnSuffix defines the suffix of the permissions that only grant access to the identifiers of the subject.
+const PermissionOwnSuffix = ":own"
+
+// Valid returns true if the permission is valid, false otherwise.
+func (p Permission) Valid() bool {
+	switch p {
+	case PermissionUsersRead,
+		PermissionUsersReadOwn,
+		PermissionUsersWrite,
+		PermissionUsersWriteOwn,
+		PermissionBookmarksReadOwn,
+		PermissionBookmarksWriteOwn,
+		PermissionEmployeesRead,
+		PermissionEmployeesReadOwn,
+		PermissionEmployeesWrite,
+		PermissionEmployeesWriteOwn,
+		PermissionContainersRead,
+		PermissionContainersWrite,
+		PermissionTrucksRead,
+		PermissionTrucksWrite,
+		PermissionTrucksOperate,
+		PermissionMaintenancesRead,
+		PermissionMaintenancesWrite,
+		PermissionWarehousesRead,
+		PermissionWarehousesWrite,
+		PermissionLandfillsRead,
+		PermissionLandfillsWrite,
+		PermissionRoutesRead,
+		PermissionRoutesPlan,
+		PermissionRoutesOperate,
+		PermissionRoutesMonitor,
+		PermissionGeocodingRead,
+		PermissionEmissionsRead,
+		PermissionSignInLockoutsRead,
+		PermissionSignInLockoutsWrite,
+		PermissionAPIKeysRead,
+		PermissionAPIKeysWrite,
+		PermissionRolesRead,
+		PermissionRolesWrite:
+		return true
+	default:
+		return false
+	}
+}
+
+// RoleName defines the name of a role, which is the name granted to the subjects with the role.
+type RoleName string
+
+// Valid returns true if the role name is valid, false otherwise. Role names start with a letter and only contain
+// letters and digits, like the names of the built-in roles.
+func (n RoleName) Valid() bool {
+	if len(n) < roleNameMinLength || len(n) > roleNameMaxLength {
+		return false
+	}
+
+	for i, r := range n {
+		isLetter := (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+		isDigit := r >= '0' && r <= '9'
+		if !isLetter && (i == 0 || !isDigit) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// RolePermissions defines the permissions granted by a role.
+type RolePermissions []Permission
+
+// Valid returns true if the permissions are valid, false otherwise. Permissions cannot be repeated.
+func (p RolePermissions) Valid() bool {
+	for i, permission := range p {
+		if !permission.Valid() || slices.Contains(p[:i], permission) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// HeldBy returns true if every permission is held by a subject with the specified permissions, false otherwise. A
+// permission restricted to the subject is also held through the permission that is not restricted.
+func (p RolePermissions) HeldBy(permissions []Permission) bool {
+	for _, permission := range p {
+		unrestricted, _ := strings.CutSuffix(string(permission), PermissionOwnSuffix)
+		if !slices.Contains(permissions, permission) && !slices.Contains(permissions, Permission(unrestricted)) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// EditableRole defines the editable role structure.
+type EditableRole struct {
+	Name        RoleName
+	Permissions RolePermissions
+}
+
+// EditableRolePatch defines the patchable role structure. The name of a role cannot be changed, since it is granted to
+// the subjects with the role.
+type EditableRolePatch struct {
+	Permissions *RolePermissions
+}
+
+// Role defines the role structure. Built-in roles are granted to users and employees and cannot be deleted.
+type Role struct {
+	EditableRole
+	ID         uuid.UUID
+	BuiltIn    bool
+	CreatedAt  time.Time
+	ModifiedAt time.Time
+}
@@ -14,11 +14,19 @@ const (
 
 // Route errors.
 var (
-	ErrRouteNotFound                    = errors.New("route not found")                                 // Returned when a route is not found.
-	ErrRouteDepartureWarehouseNotFound  = errors.New("route departure warehouse not found")             // Returned when a route departure warehouse is not found.
-	ErrRouteArrivalWarehouseNotFound    = errors.New("route arrival warehouse not found")               // Returned when a route arrival warehouse is not found.
-	ErrRouteTruckPersonCapacityMinLimit = errors.New("route truck person capacity below minimum limit") // Returned when a route truck person capacity is below the minimum limit.
-	ErrRouteTruckPersonCapacityMaxLimit = errors.New("route truck person capacity above maximum limit") // Returned when a route truck person capacity is above the maximum limit.
+	ErrRouteNotFound                               = errors.New("route not found")                                  // Returned when a route is not found.
+	ErrRouteDepartureWarehouseNotFound             = errors.New("route departure warehouse not found")              // Returned when a route departure warehouse is not found.
+	ErrRouteArrivalWarehouseNotFound               = errors.New("route arrival warehouse not found")                // Returned when a route arrival warehouse is not found.
+	ErrRouteTruckPersonCapacityMinLimit            = errors.New("route truck person capacity below minimum limit")  // Returned when a route truck person capacity is below the minimum limit.
+	ErrRouteTruckPersonCapacityMaxLimit            = errors.New("route truck person capacity above maximum limit")  // Returned when a route truck person capacity is above the maximum limit.
+	ErrRouteLandfillNotFound                       = errors.New("no landfill accepts the route categories")         // Returned when there are landfills but none accepts every container category of the route.
+	ErrRouteTruckCompartmentsIncompatible          = errors.New("route truck does not accept the route categories") // Returned when the compartments of the route truck do not accept every container category of the route.
+	ErrRouteStopUnreachable                        = errors.New("route stop unreachable")                           // Returned when a stop of the route cannot be reached by road from the previous stop.
+	ErrRoutePayloadCapacityExceeded                = errors.New("route truck payload capacity exceeded")            // Returned when the load of the route truck exceeds its payload capacity, since there is no landfill to unload it or a single container exceeds it.
+	ErrRouteInProgress                             = errors.New("route in progress")                                // Returned when a route is in progress.
+	ErrRouteNotInProgress                          = errors.New("route not in progress")                            // Returned when a route is not in progress.
+	ErrRouteTruckUnavailable                       = errors.New("route truck unavailable")                          // Returned when the route truck is in maintenance or retired.
+	ErrRouteHazardousMaterialsCertificationMissing = errors.New("route hazardous materials certification missing")  // Returned when a route that collects hazardous containers has no crew member with a valid hazardous materials certification.
 )
 
 // RouteName defines the route name type.
@@ -35,6 +43,7 @@ type EditableRoute struct {
 	TruckID              uuid.UUID
 	DepartureWarehouseID uuid.UUID
 	ArrivalWarehouseID   uuid.UUID
+	DepartureTime        *time.Time
 }
 
 // EditableRoutePatch defines the patchable route structure.
@@ -43,6 +52,7 @@ type EditableRoutePatch struct {
 	TruckID              *uuid.UUID
 	DepartureWarehouseID *uuid.UUID
 	ArrivalWarehouseID   *uuid.UUID
+	DepartureTime        *time.Time
 }
 
 // Route defines the route structure.
@@ -52,10 +62,35 @@ type Route struct {
 	Truck              Truck
 	DepartureWarehouse Warehouse
 	ArrivalWarehouse   Warehouse
+	DepartureTime      *time.Time
+	StartedAt          *time.Time
+	FinishedAt         *time.Time
 	CreatedAt          time.Time
 	ModifiedAt         time.Time
 }
 
+// InProgress returns true if the route has started and has not finished yet, false otherwise.
+func (r Route) InProgress() bool {
+	return r.StartedAt != nil && r.FinishedAt == nil
+}
+
+// NextDeparture returns the next departure of the route after the given time. Routes depart daily at their departure
+// time, so the next departure is on the day of the given time, or on the following day when the departure time has
+// already passed. Returns nil when the route has no departure time.
+func (r Route) NextDeparture(t time.Time) *time.Time {
+	if r.DepartureTime == nil {
+		return nil
+	}
+
+	t = t.UTC()
+	departure := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC).Add(TimeOfDay(*r.DepartureTime))
+	if departure.Before(t) {
+		departure = departure.AddDate(0, 0, 1)
+	}
+
+	return &departure
+}
+
 // RoutePaginatedSort defines the field of the route to sort.
 type RoutePaginatedSort string
 
@@ -96,3 +131,49 @@ type RoutesPaginatedFilter struct {
 	DepartureWarehouseID *uuid.UUID
 	ArrivalWarehouseID   *uuid.UUID
 }
+
+// RouteCategory defines the route category structure, which summarizes the containers of a category collected by the
+// route. The compartment index identifies the truck compartment that collects the category, and is not defined when
+// the truck has no compartments. The volume and weight are estimated in cubic meters and kilograms respectively.
+type RouteCategory struct {
+	Category         ContainerCategory
+	CompartmentIndex *int
+	Containers       int
+	Volume           float64
+	Weight           float64
+}
+
+// RouteEmissions defines the route emissions structure, which estimates the fuel consumed and the carbon dioxide
+// emitted by the route truck over the road network distance of the route, including the visits to the landfill. The
+// distance, fuel and carbon dioxide are in kilometers, liters and kilograms respectively. The estimates are only defined
+// when the fuel consumption of the truck is defined.
+type RouteEmissions struct {
+	FuelType        TruckFuelType
+	FuelConsumption *TruckFuelConsumption
+	Distance        float64
+	Fuel            *float64
+	CO2             *float64
+}
+
+// RouteStopType defines the type of the route stop.
+type RouteStopType string
+
+const (
+	RouteStopTypeDepartureWarehouse RouteStopType = "departureWarehouse"
+	RouteStopTypeContainer          RouteStopType = "container"
+	RouteStopTypeLandfill           RouteStopType = "landfill"
+	RouteStopTypeArrivalWarehouse   RouteStopType = "arrivalWarehouse"
+)
+
+// RouteStop defines the route stop structure. The load volume and weight represent the estimated truck load after the
+// stop, in cubic meters and kilograms respectively. The arrival time is only defined when the route has a departure
+// time, in which case the collection window violation flags a container reached after its collection window closes.
+type RouteStop struct {
+	Type                     RouteStopType
+	ID                       uuid.UUID
+	GeoJSON                  GeoJSON
+	LoadVolume               float64
+	LoadWeight               float64
+	ArrivalTime              *time.Time
+	CollectionWindowViolated bool
+}
@@ -4,8 +4,9 @@ import "errors"
 
 // Route container errors.
 var (
-	ErrRouteContainerAlreadyExists = errors.New("route container already exists") // Returned when a route container association already exists.
-	ErrRouteContainerNotFound      = errors.New("route container not found")      // Returned when a route container association is not found.
+	ErrRouteContainerAlreadyExists        = errors.New("route container already exists")                     // Returned when a route container association already exists.
+	ErrRouteContainerNotFound             = errors.New("route container not found")                          // Returned when a route container association is not found.
+	ErrRouteContainerCategoryIncompatible = errors.New("route truck does not accept the container category") // Returned when no compartment of the route truck accepts the container category.
 )
 
 // RouteContainerPaginatedSort defines the field of the route container to sort.
@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Route container collection errors.
+var (
+	ErrRouteContainerCollectionNotFound = errors.New("route container collection not found") // Returned when a route container collection is not found.
+)
+
+// RouteContainerCollectionSource defines the source of the route container collection.
+type RouteContainerCollectionSource string
+
+const (
+	RouteContainerCollectionSourceAutomatic RouteContainerCollectionSource = "automatic"
+	RouteContainerCollectionSourceManual    RouteContainerCollectionSource = "manual"
+)
+
+// Valid returns true if the source is valid, false otherwise.
+func (s RouteContainerCollectionSource) Valid() bool {
+	switch s {
+	case RouteContainerCollectionSourceAutomatic,
+		RouteContainerCollectionSourceManual:
+		return true
+	default:
+		return false
+	}
+}
+
+// EditableRouteContainerCollection defines the editable route container collection structure.
+type EditableRouteContainerCollection struct {
+	Collected bool
+}
+
+// RouteContainerCollection defines the route container collection structure. Collections are recorded for each run
+// of the route, identified by the time the route started.
+type RouteContainerCollection struct {
+	EditableRouteContainerCollection
+	RouteID        uuid.UUID
+	ContainerID    uuid.UUID
+	RouteStartedAt time.Time
+	Confidence     float64 // Confidence of the collection, between 0 and 1. Manual collections have full confidence.
+	Source         RouteContainerCollectionSource
+	CollectedAt    time.Time
+	CreatedAt      time.Time
+	ModifiedAt     time.Time
+}
+
+// RouteContainerDwell defines the route container dwell structure, which summarizes the recorded positions of the
+// route truck close to a route container.
+type RouteContainerDwell struct {
+	ContainerID uuid.UUID
+	ArrivedAt   time.Time
+	DepartedAt  time.Time
+	MinDistance float64 // Minimum distance, in meters, between the truck and the container.
+}
+
+// Duration returns the time the truck dwelled close to the container.
+func (d RouteContainerDwell) Duration() time.Duration {
+	return d.DepartedAt.Sub(d.ArrivedAt)
+}
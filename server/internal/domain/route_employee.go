@@ -4,8 +4,12 @@ import "errors"
 
 // Route employee errors.
 var (
-	ErrRouteEmployeeAlreadyExists = errors.New("route employee already exists") // Returned when a route employee association already exists.
-	ErrRouteEmployeeNotFound      = errors.New("route employee not found")      // Returned when a route employee association is not found.
+	ErrRouteEmployeeAlreadyExists  = errors.New("route employee already exists")  // Returned when a route employee association already exists.
+	ErrRouteEmployeeNotFound       = errors.New("route employee not found")       // Returned when a route employee association is not found.
+	ErrRouteEmployeeAbsent         = errors.New("route employee absent")          // Returned when the employee is absent on the date of the next route departure.
+	ErrRouteEmployeeOffShift       = errors.New("route employee off shift")       // Returned when the employee is off shift at the next route departure.
+	ErrRouteEmployeeLicenseMissing = errors.New("route employee license missing") // Returned when the driver does not hold a valid license of the category required by the route truck.
+	ErrRouteCrewDriverNotFound     = errors.New("route crew driver not found")    // Returned when no available employee holds a valid license of the category required by the route truck.
 )
 
 // RouteEmployeeRole defines the role of the route employee.
@@ -38,6 +42,13 @@ type RouteEmployee struct {
 	EditableRouteEmployee
 }
 
+// RouteCrewMember defines the route crew member structure, which includes the distance, in meters, between the location
+// of the employee and the route departure warehouse.
+type RouteCrewMember struct {
+	RouteEmployee
+	Distance float64
+}
+
 // RouteEmployeePaginatedSort defines the field of the route employee to sort.
 type RouteEmployeePaginatedSort string
 
@@ -0,0 +1,126 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Route track errors.
+var (
+	ErrRouteTrackNotFound = errors.New("route track not found") // Returned when a route track is not found.
+)
+
+// Route track constraints.
+const (
+	routeTrackCoordinatesMinLength = 2
+	routeTrackCoordinatesMaxLength = 10000
+)
+
+// EditableRouteTrack defines the editable route track structure, which contains the track driven by the route truck
+// and the time each coordinate of the track was recorded.
+type EditableRouteTrack struct {
+	Geometry   GeoJSONGeometryLineString
+	Timestamps []time.Time
+}
+
+// GeometryValid returns true if the geometry of the track is valid, false otherwise.
+func (t EditableRouteTrack) GeometryValid() bool {
+	return len(t.Geometry.Coordinates) >= routeTrackCoordinatesMinLength && len(t.Geometry.Coordinates) <= routeTrackCoordinatesMaxLength
+}
+
+// TimestampsValid returns true if there is a timestamp for each coordinate of the track, sorted in chronological order,
+// false otherwise.
+func (t EditableRouteTrack) TimestampsValid() bool {
+	if len(t.Timestamps) != len(t.Geometry.Coordinates) {
+		return false
+	}
+
+	for i, timestamp := range t.Timestamps {
+		if timestamp.IsZero() || (i > 0 && timestamp.Before(t.Timestamps[i-1])) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// RouteDeviation defines the route deviation structure, which compares the track driven by the route truck with the
+// planned route. Distances are in kilometers.
+type RouteDeviation struct {
+	PlannedDistance    float64
+	ActualDistance     float64
+	OffRouteDistance   float64 // Distance driven away from the planned route.
+	PlannedDuration    time.Duration
+	ActualDuration     time.Duration
+	OffRouteSegments   []GeoJSONGeometryLineString // Segments of the track driven away from the planned route.
+	MissedContainerIDs []uuid.UUID                 // Containers of the route the track never passed close to.
+}
+
+// ExtraDistance returns the distance driven beyond the planned route distance. It is negative when the track is
+// shorter than the planned route.
+func (d RouteDeviation) ExtraDistance() float64 {
+	return d.ActualDistance - d.PlannedDistance
+}
+
+// ExtraDuration returns the time spent beyond the planned route duration. It is negative when the track is faster than
+// the planned route.
+func (d RouteDeviation) ExtraDuration() time.Duration {
+	return d.ActualDuration - d.PlannedDuration
+}
+
+// Ratio returns the share of the track driven away from the planned route, between 0 and 1.
+func (d RouteDeviation) Ratio() float64 {
+	if d.ActualDistance <= 0 {
+		return 0
+	}
+
+	return d.OffRouteDistance / d.ActualDistance
+}
+
+// RouteTrack defines the route track structure, which contains the track driven on a date and its deviation from the
+// planned route.
+type RouteTrack struct {
+	EditableRouteTrack
+	RouteID    uuid.UUID
+	Date       time.Time
+	Deviation  RouteDeviation
+	CreatedAt  time.Time
+	ModifiedAt time.Time
+}
+
+// RouteTrackPaginatedSort defines the field of the route track to sort.
+type RouteTrackPaginatedSort string
+
+const (
+	RouteTrackPaginatedSortDeviation     RouteTrackPaginatedSort = "deviation"
+	RouteTrackPaginatedSortExtraDistance RouteTrackPaginatedSort = "extraDistance"
+	RouteTrackPaginatedSortExtraDuration RouteTrackPaginatedSort = "extraDuration"
+	RouteTrackPaginatedSortDate          RouteTrackPaginatedSort = "date"
+)
+
+// Field returns the name of the field to sort by.
+func (s RouteTrackPaginatedSort) Field() RouteTrackPaginatedSort {
+	return s
+}
+
+// Valid returns true if the field is valid, false otherwise.
+func (s RouteTrackPaginatedSort) Valid() bool {
+	switch s {
+	case RouteTrackPaginatedSortDeviation,
+		RouteTrackPaginatedSortExtraDistance,
+		RouteTrackPaginatedSortExtraDuration,
+		RouteTrackPaginatedSortDate:
+		return true
+	default:
+		return false
+	}
+}
+
+// RouteTracksPaginatedFilter defines the route tracks filter structure.
+type RouteTracksPaginatedFilter struct {
+	PaginatedRequest[RouteTrackPaginatedSort]
+	RouteID *uuid.UUID
+	Date    *time.Time
+}
@@ -0,0 +1,88 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Field constraints.
+const (
+	routingMatrixLocationsMinLength = 1
+	routingMatrixLocationsMaxLength = 25
+)
+
+// RoutingLocation defines the routing location structure. Exactly one of the fields must be defined.
+type RoutingLocation struct {
+	Geometry    *GeoJSONGeometryPoint
+	ContainerID *uuid.UUID
+	WarehouseID *uuid.UUID
+	LandfillID  *uuid.UUID
+}
+
+// Valid returns true if the routing location is valid, false otherwise.
+func (l RoutingLocation) Valid() bool {
+	var count int
+	if l.Geometry != nil {
+		count++
+	}
+	if l.ContainerID != nil {
+		count++
+	}
+	if l.WarehouseID != nil {
+		count++
+	}
+	if l.LandfillID != nil {
+		count++
+	}
+
+	return count == 1
+}
+
+// RoutingLocations defines the routing locations type.
+type RoutingLocations []RoutingLocation
+
+// Valid returns true if the routing locations are valid, false otherwise.
+func (l RoutingLocations) Valid() bool {
+	if len(l) < routingMatrixLocationsMinLength || len(l) > routingMatrixLocationsMaxLength {
+		return false
+	}
+
+	for _, location := range l {
+		if !location.Valid() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// RoutingMatrixRequest defines the routing matrix request structure.
+type RoutingMatrixRequest struct {
+	Sources RoutingLocations
+	Targets RoutingLocations
+}
+
+// RoutingMatrixEntry defines the routing matrix entry structure. The distance and duration are not defined when the
+// target is unreachable from the source.
+type RoutingMatrixEntry struct {
+	SourceIndex int
+	TargetIndex int
+	Distance    *float64 // Distance in kilometers.
+	Duration    *time.Duration
+}
+
+// RoutingMatrix defines the routing matrix structure.
+type RoutingMatrix struct {
+	Sources []GeoJSONGeometryPoint
+	Targets []GeoJSONGeometryPoint
+	Entries []RoutingMatrixEntry
+}
+
+// RoadVerticesCost defines the cost of traveling between two road vertices.
+type RoadVerticesCost struct {
+	SourceVertexID int
+	TargetVertexID int
+	Distance       float64 // Distance in kilometers.
+	Duration       time.Duration
+}
@@ -0,0 +1,81 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Token errors.
+var (
+	ErrRefreshTokenInvalid = errors.New("invalid refresh token") // Returned when a refresh token is not found, has expired, was revoked or was already used.
+)
+
+// SubjectType defines the type of the subject of a token.
+type SubjectType string
+
+const (
+	SubjectTypeUser     SubjectType = "user"
+	SubjectTypeEmployee SubjectType = "employee"
+)
+
+// Valid returns true if the subject type is valid, false otherwise.
+func (t SubjectType) Valid() bool {
+	switch t {
+	case SubjectTypeUser,
+		SubjectTypeEmployee:
+		return true
+	default:
+		return false
+	}
+}
+
+// Tokens defines the tokens structure, which contains a short-lived access token and the refresh token used to obtain
+// a new pair of tokens.
+type Tokens struct {
+	AccessToken          string
+	AccessTokenExpiresAt time.Time
+	RefreshToken         string
+}
+
+// EditableRefreshToken defines the editable refresh token structure. Refresh tokens issued by rotating another token
+// share its family, and the access token is the one issued along with the refresh token.
+type EditableRefreshToken struct {
+	FamilyID             uuid.UUID
+	SubjectType          SubjectType
+	SubjectID            uuid.UUID
+	Hash                 []byte
+	AccessTokenID        uuid.UUID
+	AccessTokenExpiresAt time.Time
+	ExpiresAt            time.Time
+}
+
+// RefreshToken defines the refresh token structure.
+type RefreshToken struct {
+	EditableRefreshToken
+	ID        uuid.UUID
+	UsedAt    *time.Time
+	RevokedAt *time.Time
+	CreatedAt time.Time
+}
+
+// Valid returns true if the refresh token was not used or revoked and has not expired at the given time, false
+// otherwise.
+func (t RefreshToken) Valid(at time.Time) bool {
+	return t.UsedAt == nil && t.RevokedAt == nil && at.Before(t.ExpiresAt)
+}
+
+// JSONWebKey defines the JSON Web Key structure, which contains the public part of a key used to sign access tokens.
+// The modulus and exponent are only defined for RSA keys, while the curve and the public key are only defined for
+// Ed25519 keys.
+type JSONWebKey struct {
+	KeyType   string
+	ID        string
+	Use       string
+	Algorithm string
+	Modulus   *string
+	Exponent  *string
+	Curve     *string
+	X         *string
+}
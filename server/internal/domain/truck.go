@@ -2,6 +2,7 @@ package domain
 
 import (
 	"errors"
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
@@ -13,13 +14,29 @@ const (
 	truckModelMaxLength         = 50
 	truckLicensePlateMaxLength  = 30
 	truckPersonCapacityMinValue = 1
+	truckVolumeCapacityMinValue = 0
+	truckWeightCapacityMinValue = 0
+	truckCompartmentsMaxLength  = 10
+
+	truckServiceIntervalDistanceMinValue = 0
+	truckServiceIntervalPeriodMinValue   = 0
+	truckOdometerMinValue                = 0
+	truckFuelConsumptionMinValue         = 0
+)
+
+// Carbon dioxide emission factors, in kilograms per liter of fuel burned.
+const (
+	truckFuelTypeDieselCO2EmissionFactor   = 2.68
+	truckFuelTypeGasolineCO2EmissionFactor = 2.31
+	truckFuelTypeLPGCO2EmissionFactor      = 1.61
 )
 
 // Truck errors.
 var (
-	ErrTruckNotFound                     = errors.New("truck not found")                 // Returned when a truck is not found.
-	ErrTruckAssociatedWithWarehouseTruck = errors.New("truck associated with warehouse") // Returned when a truck is associated with a warehouse.
-	ErrTruckAssociatedWithRoute          = errors.New("truck associated with route")     // Returned when a truck is associated with a route.
+	ErrTruckNotFound                     = errors.New("truck not found")                                               // Returned when a truck is not found.
+	ErrTruckAssociatedWithWarehouseTruck = errors.New("truck associated with warehouse")                               // Returned when a truck is associated with a warehouse.
+	ErrTruckAssociatedWithRoute          = errors.New("truck associated with route")                                   // Returned when a truck is associated with a route.
+	ErrTruckCompartmentsIncompatible     = errors.New("truck compartments do not accept the categories of its routes") // Returned when the truck compartments do not accept the container categories of its routes.
 )
 
 // TruckMake defines the make of the truck.
@@ -54,32 +71,255 @@ func (pc TruckPersonCapacity) Valid() bool {
 	return pc >= truckPersonCapacityMinValue
 }
 
+// TruckVolumeCapacity defines the payload volume capacity of the truck in cubic meters.
+type TruckVolumeCapacity float64
+
+// Valid returns true if the volume capacity is valid, false otherwise.
+func (vc TruckVolumeCapacity) Valid() bool {
+	return vc > truckVolumeCapacityMinValue
+}
+
+// TruckWeightCapacity defines the payload weight capacity of the truck in kilograms.
+type TruckWeightCapacity float64
+
+// Valid returns true if the weight capacity is valid, false otherwise.
+func (wc TruckWeightCapacity) Valid() bool {
+	return wc > truckWeightCapacityMinValue
+}
+
+// TruckCompartment defines the truck compartment structure. Containers of any of the compartment categories can share
+// the compartment.
+type TruckCompartment struct {
+	Categories []ContainerCategory
+}
+
+// Valid returns true if the compartment is valid, false otherwise.
+func (c TruckCompartment) Valid() bool {
+	if len(c.Categories) == 0 {
+		return false
+	}
+
+	seen := make(map[ContainerCategory]struct{}, len(c.Categories))
+	for _, category := range c.Categories {
+		if !category.Valid() {
+			return false
+		}
+
+		if _, ok := seen[category]; ok {
+			return false
+		}
+		seen[category] = struct{}{}
+	}
+
+	return true
+}
+
+// TruckCompartments defines the truck compartments type.
+type TruckCompartments []TruckCompartment
+
+// Valid returns true if the compartments are valid, false otherwise.
+func (c TruckCompartments) Valid() bool {
+	if len(c) > truckCompartmentsMaxLength {
+		return false
+	}
+
+	for _, compartment := range c {
+		if !compartment.Valid() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// TruckStatus defines the availability status of the truck.
+type TruckStatus string
+
+const (
+	TruckStatusActive      TruckStatus = "active"
+	TruckStatusMaintenance TruckStatus = "maintenance"
+	TruckStatusRetired     TruckStatus = "retired"
+)
+
+// Valid returns true if the status is valid, false otherwise.
+func (s TruckStatus) Valid() bool {
+	switch s {
+	case TruckStatusActive,
+		TruckStatusMaintenance,
+		TruckStatusRetired:
+		return true
+	default:
+		return false
+	}
+}
+
+// TruckServiceIntervalDistance defines the distance, in kilometers, the truck can drive between services.
+type TruckServiceIntervalDistance float64
+
+// Valid returns true if the service interval distance is valid, false otherwise.
+func (d TruckServiceIntervalDistance) Valid() bool {
+	return d > truckServiceIntervalDistanceMinValue
+}
+
+// TruckServiceIntervalPeriod defines the number of days the truck can go between services.
+type TruckServiceIntervalPeriod int
+
+// Valid returns true if the service interval period is valid, false otherwise.
+func (p TruckServiceIntervalPeriod) Valid() bool {
+	return p > truckServiceIntervalPeriodMinValue
+}
+
+// TruckOdometer defines the odometer value of the truck in kilometers.
+type TruckOdometer float64
+
+// Valid returns true if the odometer value is valid, false otherwise.
+func (o TruckOdometer) Valid() bool {
+	return o >= truckOdometerMinValue
+}
+
+// TruckFuelType defines the fuel type of the truck.
+type TruckFuelType string
+
+const (
+	TruckFuelTypeDiesel   TruckFuelType = "diesel"
+	TruckFuelTypeGasoline TruckFuelType = "gasoline"
+	TruckFuelTypeLPG      TruckFuelType = "lpg"
+)
+
+// Valid returns true if the fuel type is valid, false otherwise.
+func (t TruckFuelType) Valid() bool {
+	switch t {
+	case TruckFuelTypeDiesel,
+		TruckFuelTypeGasoline,
+		TruckFuelTypeLPG:
+		return true
+	default:
+		return false
+	}
+}
+
+// CO2EmissionFactor returns the carbon dioxide emitted, in kilograms, per liter of fuel burned.
+func (t TruckFuelType) CO2EmissionFactor() float64 {
+	switch t {
+	case TruckFuelTypeGasoline:
+		return truckFuelTypeGasolineCO2EmissionFactor
+	case TruckFuelTypeLPG:
+		return truckFuelTypeLPGCO2EmissionFactor
+	default:
+		return truckFuelTypeDieselCO2EmissionFactor
+	}
+}
+
+// TruckFuelConsumption defines the fuel consumption of the truck in liters per 100 kilometers.
+type TruckFuelConsumption float64
+
+// Valid returns true if the fuel consumption is valid, false otherwise.
+func (c TruckFuelConsumption) Valid() bool {
+	return c > truckFuelConsumptionMinValue
+}
+
 // EditableTruck defines the editable truck structure.
 type EditableTruck struct {
-	Make           TruckMake
-	Model          TruckModel
-	LicensePlate   TruckLicensePlate
-	PersonCapacity TruckPersonCapacity
-	GeoJSON        GeoJSON
+	Make                    TruckMake
+	Model                   TruckModel
+	LicensePlate            TruckLicensePlate
+	PersonCapacity          TruckPersonCapacity
+	VolumeCapacity          *TruckVolumeCapacity
+	WeightCapacity          *TruckWeightCapacity
+	Compartments            TruckCompartments
+	Status                  TruckStatus
+	ServiceIntervalDistance *TruckServiceIntervalDistance
+	ServiceIntervalPeriod   *TruckServiceIntervalPeriod
+	FuelType                TruckFuelType
+	FuelConsumption         *TruckFuelConsumption
+	LicenseCategory         LicenseCategory // License category required to drive the truck.
+	GeoJSON                 GeoJSON
 }
 
 // EditableTruckPatch defines the patchable truck structure.
 type EditableTruckPatch struct {
-	Make           *TruckMake
-	Model          *TruckModel
-	LicensePlate   *TruckLicensePlate
-	PersonCapacity *TruckPersonCapacity
-	GeoJSON        GeoJSON
+	Make                    *TruckMake
+	Model                   *TruckModel
+	LicensePlate            *TruckLicensePlate
+	PersonCapacity          *TruckPersonCapacity
+	VolumeCapacity          *TruckVolumeCapacity
+	WeightCapacity          *TruckWeightCapacity
+	Compartments            *TruckCompartments
+	Status                  *TruckStatus
+	ServiceIntervalDistance *TruckServiceIntervalDistance
+	ServiceIntervalPeriod   *TruckServiceIntervalPeriod
+	FuelType                *TruckFuelType
+	FuelConsumption         *TruckFuelConsumption
+	LicenseCategory         *LicenseCategory
+	GeoJSON                 GeoJSON
 }
 
 // Truck defines the truck structure.
 type Truck struct {
 	EditableTruck
 	ID         uuid.UUID
+	Odometer   *TruckOdometer // Latest odometer reading.
 	CreatedAt  time.Time
 	ModifiedAt time.Time
 }
 
+// Available returns true if the truck can be assigned to routes, false otherwise.
+func (t Truck) Available() bool {
+	return t.Status == TruckStatusActive
+}
+
+// ExceedsPayloadCapacity returns true if the given volume and weight, in cubic meters and kilograms respectively,
+// exceed the payload capacity of the truck, false otherwise. Capacities that are not defined are considered unlimited.
+func (t Truck) ExceedsPayloadCapacity(volume, weight float64) bool {
+	if t.VolumeCapacity != nil && volume > float64(*t.VolumeCapacity) {
+		return true
+	}
+	if t.WeightCapacity != nil && weight > float64(*t.WeightCapacity) {
+		return true
+	}
+
+	return false
+}
+
+// Emissions returns the estimated fuel consumed, in liters, and the carbon dioxide emitted, in kilograms, by the truck
+// when driving the given distance in kilometers, and true if the fuel consumption of the truck is defined.
+func (t Truck) Emissions(distance float64) (float64, float64, bool) {
+	if t.FuelConsumption == nil {
+		return 0, 0, false
+	}
+
+	fuel := distance * float64(*t.FuelConsumption) / 100
+	return fuel, fuel * t.FuelType.CO2EmissionFactor(), true
+}
+
+// CompartmentIndex returns the index of the first truck compartment that accepts the given container category, and
+// true if any compartment accepts it. Trucks without compartments accept every category in a single shared load, in
+// which case a nil index is returned.
+func (t Truck) CompartmentIndex(category ContainerCategory) (*int, bool) {
+	if len(t.Compartments) == 0 {
+		return nil, true
+	}
+
+	for i, compartment := range t.Compartments {
+		if slices.Contains(compartment.Categories, category) {
+			return &i, true
+		}
+	}
+
+	return nil, false
+}
+
+// AcceptsCategories returns true if the truck compartments accept every given container category, false otherwise.
+func (t Truck) AcceptsCategories(categories []ContainerCategory) bool {
+	for _, category := range categories {
+		if _, ok := t.CompartmentIndex(category); !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
 // TruckPaginatedSort defines the field of the truck to sort.
 type TruckPaginatedSort string
 
@@ -88,6 +328,7 @@ const (
 	TruckPaginatedSortModel            TruckPaginatedSort = "model"
 	TruckPaginatedSortLicensePlate     TruckPaginatedSort = "licensePlate"
 	TruckPaginatedSortPersonCapacity   TruckPaginatedSort = "personCapacity"
+	TruckPaginatedSortStatus           TruckPaginatedSort = "status"
 	TruckPaginatedSortWayName          TruckPaginatedSort = "wayName"
 	TruckPaginatedSortMunicipalityName TruckPaginatedSort = "municipalityName"
 	TruckPaginatedSortCreatedAt        TruckPaginatedSort = "createdAt"
@@ -106,6 +347,7 @@ func (s TruckPaginatedSort) Valid() bool {
 		TruckPaginatedSortModel,
 		TruckPaginatedSortLicensePlate,
 		TruckPaginatedSortPersonCapacity,
+		TruckPaginatedSortStatus,
 		TruckPaginatedSortWayName,
 		TruckPaginatedSortMunicipalityName,
 		TruckPaginatedSortCreatedAt,
@@ -122,5 +364,6 @@ type TrucksPaginatedFilter struct {
 	Make         *TruckMake
 	Model        *TruckModel
 	LicensePlate *TruckLicensePlate
+	Status       *TruckStatus
 	LocationName *string
 }
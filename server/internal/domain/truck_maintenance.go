@@ -0,0 +1,151 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Truck maintenance constraints.
+const (
+	truckMaintenanceCostMinValue   = 0
+	truckMaintenanceNotesMaxLength = 500
+)
+
+// Truck maintenance errors.
+var (
+	ErrTruckMaintenanceNotFound = errors.New("truck maintenance not found") // Returned when a truck maintenance is not found.
+)
+
+// TruckMaintenanceType defines the type of the truck maintenance.
+type TruckMaintenanceType string
+
+const (
+	TruckMaintenanceTypeService    TruckMaintenanceType = "service"
+	TruckMaintenanceTypeRepair     TruckMaintenanceType = "repair"
+	TruckMaintenanceTypeInspection TruckMaintenanceType = "inspection"
+	TruckMaintenanceTypeTires      TruckMaintenanceType = "tires"
+	TruckMaintenanceTypeOther      TruckMaintenanceType = "other"
+)
+
+// Valid returns true if the type is valid, false otherwise.
+func (t TruckMaintenanceType) Valid() bool {
+	switch t {
+	case TruckMaintenanceTypeService,
+		TruckMaintenanceTypeRepair,
+		TruckMaintenanceTypeInspection,
+		TruckMaintenanceTypeTires,
+		TruckMaintenanceTypeOther:
+		return true
+	default:
+		return false
+	}
+}
+
+// TruckMaintenanceCost defines the cost of the truck maintenance.
+type TruckMaintenanceCost float64
+
+// Valid returns true if the cost is valid, false otherwise.
+func (c TruckMaintenanceCost) Valid() bool {
+	return c >= truckMaintenanceCostMinValue
+}
+
+// TruckMaintenanceNotes defines the notes of the truck maintenance.
+type TruckMaintenanceNotes string
+
+// Valid returns true if the notes are valid, false otherwise.
+func (n TruckMaintenanceNotes) Valid() bool {
+	return len(n) <= truckMaintenanceNotesMaxLength
+}
+
+// EditableTruckMaintenance defines the editable truck maintenance structure.
+type EditableTruckMaintenance struct {
+	Type     TruckMaintenanceType
+	Date     time.Time
+	Cost     *TruckMaintenanceCost
+	Notes    *TruckMaintenanceNotes
+	Odometer *TruckOdometer
+}
+
+// TruckMaintenance defines the truck maintenance structure.
+type TruckMaintenance struct {
+	EditableTruckMaintenance
+	ID         uuid.UUID
+	TruckID    uuid.UUID
+	CreatedAt  time.Time
+	ModifiedAt time.Time
+}
+
+// TruckMaintenancePaginatedSort defines the field of the truck maintenance to sort.
+type TruckMaintenancePaginatedSort string
+
+const (
+	TruckMaintenancePaginatedSortType      TruckMaintenancePaginatedSort = "type"
+	TruckMaintenancePaginatedSortDate      TruckMaintenancePaginatedSort = "date"
+	TruckMaintenancePaginatedSortCost      TruckMaintenancePaginatedSort = "cost"
+	TruckMaintenancePaginatedSortCreatedAt TruckMaintenancePaginatedSort = "createdAt"
+)
+
+// Field returns the name of the field to sort by.
+func (s TruckMaintenancePaginatedSort) Field() TruckMaintenancePaginatedSort {
+	return s
+}
+
+// Valid returns true if the field is valid, false otherwise.
+func (s TruckMaintenancePaginatedSort) Valid() bool {
+	switch s {
+	case TruckMaintenancePaginatedSortType,
+		TruckMaintenancePaginatedSortDate,
+		TruckMaintenancePaginatedSortCost,
+		TruckMaintenancePaginatedSortCreatedAt:
+		return true
+	default:
+		return false
+	}
+}
+
+// TruckMaintenancesPaginatedFilter defines the truck maintenances filter structure.
+type TruckMaintenancesPaginatedFilter struct {
+	PaginatedRequest[TruckMaintenancePaginatedSort]
+	Type *TruckMaintenanceType
+}
+
+// TruckServiceDue defines the truck service due structure, which contains when the next service of the truck is due,
+// based on its last service and its service intervals.
+type TruckServiceDue struct {
+	Truck               Truck
+	LastServiceDate     *time.Time
+	LastServiceOdometer *float64
+	DueOdometer         *float64 // Odometer value, in kilometers, at which the next service is due.
+	DueDate             *time.Time
+	RemainingDistance   *float64 // Distance, in kilometers, until the next service is due. Negative when overdue.
+	RemainingDays       *int     // Number of days until the next service is due. Negative when overdue.
+}
+
+// TruckServiceDueSort defines the field of the truck service due to sort.
+type TruckServiceDueSort string
+
+const (
+	TruckServiceDueSortRemainingDistance TruckServiceDueSort = "remainingDistance"
+	TruckServiceDueSortRemainingDays     TruckServiceDueSort = "remainingDays"
+)
+
+// Valid returns true if the field is valid, false otherwise.
+func (s TruckServiceDueSort) Valid() bool {
+	switch s {
+	case TruckServiceDueSortRemainingDistance,
+		TruckServiceDueSortRemainingDays:
+		return true
+	default:
+		return false
+	}
+}
+
+// TruckServiceDueFilter defines the truck service due filter structure. Trucks are due when the next service is within
+// the distance, in kilometers, or within the number of days.
+type TruckServiceDueFilter struct {
+	WithinDistance *float64
+	WithinDays     *int
+	Sort           TruckServiceDueSort
+}
@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Truck odometer reading errors.
+var (
+	ErrTruckOdometerReadingInconsistent  = errors.New("truck odometer reading inconsistent with previous readings") // Returned when an odometer reading is lower than an earlier reading or higher than a later reading.
+	ErrTruckOdometerReadingAlreadyExists = errors.New("truck odometer reading already exists")                      // Returned when an odometer reading was already recorded at the same time.
+)
+
+// EditableTruckOdometerReading defines the editable truck odometer reading structure.
+type EditableTruckOdometerReading struct {
+	Value      TruckOdometer
+	RecordedAt time.Time
+}
+
+// TruckOdometerReading defines the truck odometer reading structure.
+type TruckOdometerReading struct {
+	EditableTruckOdometerReading
+	TruckID   uuid.UUID
+	CreatedAt time.Time
+}
+
+// TruckOdometerReadingPaginatedSort defines the field of the truck odometer reading to sort.
+type TruckOdometerReadingPaginatedSort string
+
+const (
+	TruckOdometerReadingPaginatedSortValue      TruckOdometerReadingPaginatedSort = "value"
+	TruckOdometerReadingPaginatedSortRecordedAt TruckOdometerReadingPaginatedSort = "recordedAt"
+)
+
+// Field returns the name of the field to sort by.
+func (s TruckOdometerReadingPaginatedSort) Field() TruckOdometerReadingPaginatedSort {
+	return s
+}
+
+// Valid returns true if the field is valid, false otherwise.
+func (s TruckOdometerReadingPaginatedSort) Valid() bool {
+	switch s {
+	case TruckOdometerReadingPaginatedSortValue,
+		TruckOdometerReadingPaginatedSortRecordedAt:
+		return true
+	default:
+		return false
+	}
+}
+
+// TruckOdometerReadingsPaginatedFilter defines the truck odometer readings filter structure.
+type TruckOdometerReadingsPaginatedFilter struct {
+	PaginatedRequest[TruckOdometerReadingPaginatedSort]
+}
@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Truck position constraints.
+const (
+	truckPositionSpeedMinValue   = 0
+	truckPositionHeadingMinValue = 0
+	truckPositionHeadingMaxValue = 360
+	truckPositionsMinLength      = 1
+	truckPositionsMaxLength      = 1000
+)
+
+// Truck position errors.
+var (
+	ErrTruckPositionNotFound = errors.New("truck position not found") // Returned when a truck position is not found.
+)
+
+// TruckPositionSpeed defines the speed of the truck in kilometers per hour.
+type TruckPositionSpeed float64
+
+// Valid returns true if the speed is valid, false otherwise.
+func (s TruckPositionSpeed) Valid() bool {
+	return s >= truckPositionSpeedMinValue
+}
+
+// TruckPositionHeading defines the heading of the truck in degrees, measured clockwise from the north.
+type TruckPositionHeading float64
+
+// Valid returns true if the heading is valid, false otherwise.
+func (h TruckPositionHeading) Valid() bool {
+	return h >= truckPositionHeadingMinValue && h < truckPositionHeadingMaxValue
+}
+
+// EditableTruckPosition defines the editable truck position structure.
+type EditableTruckPosition struct {
+	Geometry   GeoJSONGeometryPoint
+	Speed      *TruckPositionSpeed
+	Heading    *TruckPositionHeading
+	RecordedAt time.Time
+}
+
+// EditableTruckPositions defines the editable truck positions type.
+type EditableTruckPositions []EditableTruckPosition
+
+// Valid returns true if the number of positions is valid, false otherwise.
+func (p EditableTruckPositions) Valid() bool {
+	return len(p) >= truckPositionsMinLength && len(p) <= truckPositionsMaxLength
+}
+
+// TruckPosition defines the truck position structure.
+type TruckPosition struct {
+	EditableTruckPosition
+	TruckID   uuid.UUID
+	CreatedAt time.Time
+}
@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"time"
+)
+
+// Truck track constraints.
+const (
+	truckTrackMaxPeriod               = 24 * time.Hour
+	truckTrackStopMinDurationMinValue = 0
+	truckTrackToleranceMinValue       = 0
+	truckTrackToleranceMaxValue       = 1000
+)
+
+// TruckTrackFilter defines the truck track filter structure.
+type TruckTrackFilter struct {
+	From            time.Time
+	To              time.Time
+	StopMinDuration time.Duration // Minimum time the truck must dwell in the same place to be considered stopped.
+	Tolerance       float64       // Maximum distance, in meters, between the simplified and the recorded track.
+}
+
+// PeriodValid returns true if the period of the filter is valid, false otherwise.
+func (f TruckTrackFilter) PeriodValid() bool {
+	return f.To.After(f.From) && f.To.Sub(f.From) <= truckTrackMaxPeriod
+}
+
+// StopMinDurationValid returns true if the stop minimum duration is valid, false otherwise.
+func (f TruckTrackFilter) StopMinDurationValid() bool {
+	return f.StopMinDuration > truckTrackStopMinDurationMinValue
+}
+
+// ToleranceValid returns true if the tolerance is valid, false otherwise.
+func (f TruckTrackFilter) ToleranceValid() bool {
+	return f.Tolerance >= truckTrackToleranceMinValue && f.Tolerance <= truckTrackToleranceMaxValue
+}
+
+// TruckTrackStop defines the truck track stop structure.
+type TruckTrackStop struct {
+	Geometry   GeoJSONGeometryPoint
+	ArrivedAt  time.Time
+	DepartedAt time.Time
+}
+
+// Duration returns the time the truck dwelled in the stop.
+func (s TruckTrackStop) Duration() time.Duration {
+	return s.DepartedAt.Sub(s.ArrivedAt)
+}
+
+// TruckTrack defines the truck track structure. The timestamps correspond to each vertex of the geometry.
+type TruckTrack struct {
+	Geometry   GeoJSONGeometryLineString
+	Timestamps []time.Time
+	Distance   float64 // Distance driven in kilometers, based on every recorded position.
+	Stops      []TruckTrackStop
+	Roads      GeoJSON // Track matched onto the road network.
+}
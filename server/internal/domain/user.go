@@ -13,11 +13,12 @@ var (
 	ErrUserNotFound      = errors.New("user not found")          // Returned when a user is not found.
 )
 
-// EditableUser defines the editable user structure.
+// EditableUser defines the editable user structure. The email address is optional.
 type EditableUser struct {
 	Username  Username
 	FirstName Name
 	LastName  Name
+	Email     *Email
 }
 
 // EditableUserWithPassword defines the editable user structure with a password.
@@ -31,14 +32,21 @@ type EditableUserPatch struct {
 	Username  *Username
 	FirstName *Name
 	LastName  *Name
+	Email     *Email
 }
 
-// User defines the user structure.
+// User defines the user structure. The email address is verified once the user confirms it with the token sent to it.
 type User struct {
 	EditableUser
-	ID         uuid.UUID
-	CreatedAt  time.Time
-	ModifiedAt time.Time
+	ID              uuid.UUID
+	EmailVerifiedAt *time.Time
+	CreatedAt       time.Time
+	ModifiedAt      time.Time
+}
+
+// EmailVerified returns true if the user has an email address and it was verified, false otherwise.
+func (u User) EmailVerified() bool {
+	return u.Email != nil && u.EmailVerifiedAt != nil
 }
 
 // UserPaginatedSort defines the field of the user to sort.
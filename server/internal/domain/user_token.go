@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// User token errors.
+var (
+	ErrUserTokenInvalid       = errors.New("invalid or expired token")        // Returned when a user token is not found, has expired or was already used.
+	ErrUserTokenLimitExceeded = errors.New("user token limit exceeded")       // Returned when too many user tokens were requested for the same account.
+	ErrUserEmailNotVerified   = errors.New("user email address not verified") // Returned when a user without a verified email address requests a token.
+)
+
+// UserTokenPurpose defines the purpose of a user token.
+type UserTokenPurpose string
+
+const (
+	UserTokenPurposePasswordReset     UserTokenPurpose = "passwordReset"
+	UserTokenPurposeEmailVerification UserTokenPurpose = "emailVerification"
+)
+
+// EditableUserToken defines the editable user token structure. User tokens are single-use and are sent to the email
+// address of the user, which is stored along with the token.
+type EditableUserToken struct {
+	UserID    uuid.UUID
+	Purpose   UserTokenPurpose
+	Hash      []byte
+	Email     Email
+	ExpiresAt time.Time
+}
+
+// UserToken defines the user token structure.
+type UserToken struct {
+	EditableUserToken
+	ID        uuid.UUID
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
+
+// Valid returns true if the user token was not used and has not expired at the given time, false otherwise.
+func (t UserToken) Valid(at time.Time) bool {
+	return t.UsedAt == nil && at.Before(t.ExpiresAt)
+}
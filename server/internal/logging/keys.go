@@ -8,6 +8,27 @@ const (
 
 	ServiceMethod = "service.method"
 
+	TokenID = "token.id"
+
+	MailTo      = "mail.to"
+	MailSubject = "mail.subject"
+	MailBody    = "mail.body"
+
+	SignInIPAddress = "signIn.ipAddress"
+
+	SignInLockoutID          = "signInLockout.id"
+	SignInLockoutSubjectType = "signInLockout.subjectType"
+	SignInLockoutKeyType     = "signInLockout.keyType"
+	SignInLockoutKey         = "signInLockout.key"
+	SignInLockoutFailures    = "signInLockout.failures"
+
+	APIKeyID   = "apiKey.id"
+	APIKeyName = "apiKey.name"
+
+	RoleID          = "role.id"
+	RoleName        = "role.name"
+	RolePermissions = "role.permissions"
+
 	UserID        = "user.id"
 	UserUsername  = "user.username"
 	UserFirstName = "user.firstName"
@@ -23,6 +44,23 @@ const (
 	EmployeeGeoJSON       = "employee.geoJson"
 	EmployeeScheduleStart = "employee.scheduleStart"
 	EmployeeScheduleEnd   = "employee.scheduleEnd"
+	EmployeeSSOSubject    = "employee.ssoSubject"
+
+	EmployeeAbsenceID        = "employeeAbsence.id"
+	EmployeeAbsenceType      = "employeeAbsence.type"
+	EmployeeAbsenceStartDate = "employeeAbsence.startDate"
+	EmployeeAbsenceEndDate   = "employeeAbsence.endDate"
+
+	EmployeeQualificationID              = "employeeQualification.id"
+	EmployeeQualificationType            = "employeeQualification.type"
+	EmployeeQualificationLicenseCategory = "employeeQualification.licenseCategory"
+	EmployeeQualificationExpiryDate      = "employeeQualification.expiryDate"
+
+	EmployeeAgendaDate = "employeeAgenda.date"
+
+	EmployeesAvailabilityDate      = "employeesAvailability.date"
+	EmployeesAvailabilityStartTime = "employeesAvailability.startTime"
+	EmployeesAvailabilityEndTime   = "employeesAvailability.endTime"
 
 	ContainerID       = "container.id"
 	ContainerCategory = "container.category"
@@ -32,6 +70,17 @@ const (
 	TruckModel          = "truck.model"
 	TruckLicensePlate   = "truck.licensePlate"
 	TruckPersonCapacity = "truck.personCapacity"
+	TruckPositionsCount = "truck.positionsCount"
+	TruckTrackFrom      = "truck.trackFrom"
+	TruckTrackTo        = "truck.trackTo"
+	TruckStatus         = "truck.status"
+
+	TruckMaintenanceID   = "truckMaintenance.id"
+	TruckMaintenanceType = "truckMaintenance.type"
+	TruckMaintenanceDate = "truckMaintenance.date"
+
+	TruckOdometerReadingValue      = "truckOdometerReading.value"
+	TruckOdometerReadingRecordedAt = "truckOdometerReading.recordedAt"
 
 	WarehouseID            = "warehouse.id"
 	WarehouseTruckCapacity = "warehouse.truckCapacity"
@@ -45,4 +94,13 @@ const (
 	RouteArrivalWarehouseID   = "route.arrivalWarehouseID"
 
 	RouteEmployeeEmployeeRole = "routeEmployee.employeeRole"
+
+	RouteCrewPreview = "routeCrew.preview"
+
+	RouteContainerCollectionCollected = "routeContainerCollection.collected"
+
+	RouteTrackDate = "routeTrack.date"
+
+	RoutingMatrixSources = "routingMatrix.sources"
+	RoutingMatrixTargets = "routingMatrix.targets"
 )
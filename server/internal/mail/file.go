@@ -0,0 +1,62 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+
+	"github.com/goncalo-marques/ecomap/server/internal/logging"
+)
+
+const (
+	descriptionFailedWriteFile = "mail: failed to write message to file"
+	descriptionMessageSent     = "mail: message sent"
+
+	fileSeparator = "\r\n--------------------------------------------------------------------------------\r\n\r\n"
+)
+
+// fileSender defines the file sender structure, which is meant for development.
+type fileSender struct {
+	file string
+	from string
+	mu   sync.Mutex
+}
+
+// NewFile returns a new sender that appends the messages to the specified file. When the file is empty, the messages
+// are written to the log instead.
+func NewFile(file, from string) *fileSender {
+	return &fileSender{
+		file: file,
+		from: from,
+	}
+}
+
+// Send sends the given message.
+func (s *fileSender) Send(ctx context.Context, message Message) error {
+	if len(s.file) == 0 {
+		logging.Logger.InfoContext(ctx, descriptionMessageSent,
+			slog.String(logging.MailTo, message.To),
+			slog.String(logging.MailSubject, message.Subject),
+			slog.String(logging.MailBody, message.Body),
+		)
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	file, err := os.OpenFile(s.file, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("%s: %w", descriptionFailedWriteFile, err)
+	}
+	defer file.Close()
+
+	_, err = file.Write(append(message.bytes(s.from), fileSeparator...))
+	if err != nil {
+		return fmt.Errorf("%s: %w", descriptionFailedWriteFile, err)
+	}
+
+	return nil
+}
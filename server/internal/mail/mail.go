@@ -0,0 +1,31 @@
+package mail
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Message defines the email message structure.
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// bytes returns the message encoded according to RFC 5322, with the given sender address.
+func (m Message) bytes(from string) []byte {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "From: %s\r\n", from)
+	fmt.Fprintf(&b, "To: %s\r\n", m.To)
+	fmt.Fprintf(&b, "Subject: %s\r\n", m.Subject)
+	fmt.Fprintf(&b, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(m.Body, "\n", "\r\n"))
+	b.WriteString("\r\n")
+
+	return []byte(b.String())
+}
@@ -0,0 +1,57 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+)
+
+const (
+	descriptionFailedSendSMTP = "mail: failed to send message with smtp"
+)
+
+// SMTPOptions defines the SMTP sender options structure.
+type SMTPOptions struct {
+	Host     string // Host name of the SMTP server.
+	Port     int    // Port of the SMTP server.
+	Username string // Username to authenticate with the SMTP server. Authentication is skipped when empty.
+	Password string // Password to authenticate with the SMTP server.
+	From     string // Address of the sender.
+}
+
+// smtpSender defines the SMTP sender structure. The connection is upgraded with STARTTLS when the server supports it.
+type smtpSender struct {
+	options SMTPOptions
+}
+
+// NewSMTP returns a new sender that delivers the messages through an SMTP server.
+func NewSMTP(options SMTPOptions) *smtpSender {
+	return &smtpSender{
+		options: options,
+	}
+}
+
+// Send sends the given message.
+func (s *smtpSender) Send(ctx context.Context, message Message) error {
+	addr := net.JoinHostPort(s.options.Host, strconv.Itoa(s.options.Port))
+
+	var auth smtp.Auth
+	if len(s.options.Username) != 0 {
+		auth = smtp.PlainAuth("", s.options.Username, s.options.Password, s.options.Host)
+	}
+
+	from, err := mail.ParseAddress(s.options.From)
+	if err != nil {
+		return fmt.Errorf("%s: %w", descriptionFailedSendSMTP, err)
+	}
+
+	err = smtp.SendMail(addr, auth, from.Address, []string{message.To}, message.bytes(s.options.From))
+	if err != nil {
+		return fmt.Errorf("%s: %w", descriptionFailedSendSMTP, err)
+	}
+
+	return nil
+}
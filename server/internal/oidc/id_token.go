@@ -0,0 +1,225 @@
+package oidc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// idTokenValidMethods defines the algorithms accepted to sign id tokens.
+var idTokenValidMethods = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512", "EdDSA"}
+
+// jwk defines the structure of a provider public key in the JSON Web Key format.
+type jwk struct {
+	KeyType string `json:"kty"`
+	ID      string `json:"kid"`
+	Use     string `json:"use"`
+	N       string `json:"n"`
+	E       string `json:"e"`
+	Curve   string `json:"crv"`
+	X       string `json:"x"`
+	Y       string `json:"y"`
+}
+
+// publicKey returns the public key of the JSON Web Key, or false if the key type is not supported.
+func (k jwk) publicKey() (any, bool) {
+	switch k.KeyType {
+	case "RSA":
+		n, errN := base64.RawURLEncoding.DecodeString(k.N)
+		e, errE := base64.RawURLEncoding.DecodeString(k.E)
+		if errN != nil || errE != nil {
+			return nil, false
+		}
+
+		return &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}, true
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Curve {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, false
+		}
+
+		x, errX := base64.RawURLEncoding.DecodeString(k.X)
+		y, errY := base64.RawURLEncoding.DecodeString(k.Y)
+		if errX != nil || errY != nil {
+			return nil, false
+		}
+
+		return &ecdsa.PublicKey{
+			Curve: curve,
+			X:     new(big.Int).SetBytes(x),
+			Y:     new(big.Int).SetBytes(y),
+		}, true
+	case "OKP":
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if k.Curve != "Ed25519" || err != nil || len(x) != ed25519.PublicKeySize {
+			return nil, false
+		}
+
+		return ed25519.PublicKey(x), true
+	default:
+		return nil, false
+	}
+}
+
+// verifyIDToken verifies the signature and the claims of the id token, and returns the identity of the subject.
+func (p *provider) verifyIDToken(ctx context.Context, d *discovery, idToken, nonce string) (Identity, error) {
+	claims := jwt.MapClaims{}
+
+	_, err := jwt.ParseWithClaims(idToken, claims, func(t *jwt.Token) (interface{}, error) {
+		keyID, _ := t.Header["kid"].(string)
+		return p.getKey(ctx, d, keyID)
+	},
+		jwt.WithValidMethods(idTokenValidMethods),
+		jwt.WithIssuer(p.options.Issuer),
+		jwt.WithAudience(p.options.ClientID),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return Identity{}, err
+	}
+
+	if claimString(claims, "nonce") != nonce {
+		return Identity{}, ErrIDTokenInvalid
+	}
+
+	identity := Identity{
+		Subject:           claimString(claims, "sub"),
+		PreferredUsername: claimString(claims, "preferred_username"),
+		Email:             claimString(claims, "email"),
+		GivenName:         claimString(claims, "given_name"),
+		FamilyName:        claimString(claims, "family_name"),
+		Name:              claimString(claims, "name"),
+		Groups:            claimStrings(claims, p.options.GroupsClaim),
+	}
+
+	if len(identity.Subject) == 0 {
+		return Identity{}, ErrIDTokenInvalid
+	}
+
+	return identity, nil
+}
+
+// getKey returns the provider key with the specified identifier. The provider keys are fetched again when the key is
+// not found, which happens after the provider rotates its keys. When the identifier is empty, the provider must have a
+// single key. The keys are fetched at most once per refetch interval, and identifiers not found after a fetch are
+// rejected without fetching until the keys are fetched again.
+func (p *provider) getKey(ctx context.Context, d *discovery, keyID string) (any, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if key, ok := p.lookupKey(keyID); ok {
+		return key, nil
+	}
+
+	if _, ok := p.unknownKeyIDs[keyID]; ok {
+		return nil, ErrKeyNotFound
+	}
+
+	if !p.keysFetchedAt.IsZero() && time.Since(p.keysFetchedAt) < keysRefetchInterval {
+		return nil, ErrKeyNotFound
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, d.JWKSURI, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var jwks struct {
+		Keys []jwk `json:"keys"`
+	}
+
+	p.keysFetchedAt = time.Now()
+
+	err = p.doJSON(request, &jwks)
+	if err != nil {
+		return nil, err
+	}
+
+	p.keys = make(map[string]any, len(jwks.Keys))
+	p.unknownKeyIDs = make(map[string]struct{})
+	for _, k := range jwks.Keys {
+		if len(k.Use) != 0 && k.Use != "sig" {
+			continue
+		}
+
+		if key, ok := k.publicKey(); ok {
+			p.keys[k.ID] = key
+		}
+	}
+
+	if key, ok := p.lookupKey(keyID); ok {
+		return key, nil
+	}
+
+	p.unknownKeyIDs[keyID] = struct{}{}
+
+	return nil, ErrKeyNotFound
+}
+
+// lookupKey returns the cached provider key with the specified identifier.
+func (p *provider) lookupKey(keyID string) (any, bool) {
+	if len(keyID) == 0 && len(p.keys) == 1 {
+		for _, key := range p.keys {
+			return key, true
+		}
+	}
+
+	key, ok := p.keys[keyID]
+	return key, ok
+}
+
+// claimString returns the string value of the specified claim, or an empty string if it is not a string.
+func claimString(claims jwt.MapClaims, name string) string {
+	value, _ := claims[name].(string)
+	return value
+}
+
+// claimStrings returns the string values of the specified claim, which can be either a list or a single string. Nested
+// claims are specified with dots, such as `realm_access.roles`.
+func claimStrings(claims jwt.MapClaims, name string) []string {
+	var value any = map[string]any(claims)
+
+	for _, part := range strings.Split(name, ".") {
+		object, ok := value.(map[string]any)
+		if !ok {
+			return nil
+		}
+
+		value = object[part]
+	}
+
+	switch v := value.(type) {
+	case string:
+		return []string{v}
+	case []any:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+
+		return values
+	default:
+		return nil
+	}
+}
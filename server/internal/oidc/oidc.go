@@ -0,0 +1,232 @@
+package oidc
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	pathDiscovery = "/.well-known/openid-configuration"
+
+	defaultGroupsClaim = "groups"
+	defaultHTTPTimeout = time.Second * 10
+
+	// keysRefetchInterval defines the minimum interval between fetches of the provider keys, so that tokens signed by
+	// unknown keys cannot flood the provider with requests.
+	keysRefetchInterval = time.Minute
+
+	scopeOpenID = "openid"
+
+	codeChallengeMethodS256 = "S256"
+
+	descriptionFailedDiscovery     = "oidc: failed to discover provider"
+	descriptionFailedGetKeys       = "oidc: failed to get provider keys"
+	descriptionFailedExchangeCode  = "oidc: failed to exchange authorization code"
+	descriptionFailedVerifyIDToken = "oidc: failed to verify id token"
+)
+
+// OpenID Connect errors.
+var (
+	ErrOptionsInvalid  = errors.New("invalid options")                         // Returned when the provider options are missing required values.
+	ErrResponseInvalid = errors.New("invalid identity provider response")      // Returned when the identity provider responds with an error or an unexpected body.
+	ErrIDTokenInvalid  = errors.New("invalid id token")                        // Returned when the id token is not valid for the client.
+	ErrKeyNotFound     = errors.New("identity provider signing key not found") // Returned when the id token is signed by an unknown key.
+)
+
+// Options defines the identity provider options structure.
+type Options struct {
+	Issuer       string   // URL of the issuer, used to discover the provider endpoints.
+	ClientID     string   // Identifier of the client registered in the provider.
+	ClientSecret string   // Secret of the client registered in the provider.
+	RedirectURL  string   // URL to which the provider redirects after the authorization.
+	Scopes       []string // Scopes requested in addition to the openid scope.
+	GroupsClaim  string   // Name of the id token claim that contains the groups of the subject.
+}
+
+// Identity defines the identity structure, which contains the claims of the subject authenticated by the provider.
+type Identity struct {
+	Subject           string
+	PreferredUsername string
+	Email             string
+	GivenName         string
+	FamilyName        string
+	Name              string
+	Groups            []string
+}
+
+// discovery defines the structure of the provider metadata.
+type discovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// provider defines the OpenID Connect identity provider structure. The provider metadata and keys are fetched on
+// first use and cached, so that the server starts even if the provider is unavailable.
+type provider struct {
+	options    Options
+	httpClient *http.Client
+
+	mu            sync.Mutex
+	discovery     *discovery
+	keys          map[string]any
+	keysFetchedAt time.Time
+	unknownKeyIDs map[string]struct{}
+}
+
+// New returns a new OpenID Connect identity provider for the authorization code flow.
+func New(options Options) (*provider, error) {
+	if len(options.Issuer) == 0 || len(options.ClientID) == 0 || len(options.RedirectURL) == 0 {
+		return nil, ErrOptionsInvalid
+	}
+
+	if len(options.GroupsClaim) == 0 {
+		options.GroupsClaim = defaultGroupsClaim
+	}
+
+	return &provider{
+		options: options,
+		httpClient: &http.Client{
+			Timeout: defaultHTTPTimeout,
+		},
+	}, nil
+}
+
+// AuthorizationURL returns the URL of the provider where the subject authenticates. The state is returned in the
+// redirection, the nonce is included in the id token and the code verifier is hashed to a code challenge (PKCE).
+func (p *provider) AuthorizationURL(ctx context.Context, state, nonce, codeVerifier string) (string, error) {
+	d, err := p.getDiscovery(ctx)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", descriptionFailedDiscovery, err)
+	}
+
+	authorizationURL, err := url.Parse(d.AuthorizationEndpoint)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", descriptionFailedDiscovery, err)
+	}
+
+	codeChallenge := sha256.Sum256([]byte(codeVerifier))
+
+	query := authorizationURL.Query()
+	query.Set("response_type", "code")
+	query.Set("client_id", p.options.ClientID)
+	query.Set("redirect_uri", p.options.RedirectURL)
+	query.Set("scope", strings.Join(append([]string{scopeOpenID}, p.options.Scopes...), " "))
+	query.Set("state", state)
+	query.Set("nonce", nonce)
+	query.Set("code_challenge", base64.RawURLEncoding.EncodeToString(codeChallenge[:]))
+	query.Set("code_challenge_method", codeChallengeMethodS256)
+	authorizationURL.RawQuery = query.Encode()
+
+	return authorizationURL.String(), nil
+}
+
+// Exchange exchanges the authorization code for the tokens of the subject, and returns the identity in the id token.
+// The id token must be signed by the provider, issued to the client and contain the given nonce.
+func (p *provider) Exchange(ctx context.Context, code, codeVerifier, nonce string) (Identity, error) {
+	d, err := p.getDiscovery(ctx)
+	if err != nil {
+		return Identity{}, fmt.Errorf("%s: %w", descriptionFailedDiscovery, err)
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", p.options.RedirectURL)
+	form.Set("code_verifier", codeVerifier)
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, d.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return Identity{}, fmt.Errorf("%s: %w", descriptionFailedExchangeCode, err)
+	}
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	request.SetBasicAuth(url.QueryEscape(p.options.ClientID), url.QueryEscape(p.options.ClientSecret))
+
+	var tokenResponse struct {
+		IDToken string `json:"id_token"`
+	}
+
+	err = p.doJSON(request, &tokenResponse)
+	if err != nil {
+		return Identity{}, fmt.Errorf("%s: %w", descriptionFailedExchangeCode, err)
+	}
+
+	if len(tokenResponse.IDToken) == 0 {
+		return Identity{}, fmt.Errorf("%s: %w", descriptionFailedExchangeCode, ErrResponseInvalid)
+	}
+
+	identity, err := p.verifyIDToken(ctx, d, tokenResponse.IDToken, nonce)
+	if err != nil {
+		return Identity{}, fmt.Errorf("%s: %w", descriptionFailedVerifyIDToken, err)
+	}
+
+	return identity, nil
+}
+
+// getDiscovery returns the provider metadata, fetching it if it was not fetched yet.
+func (p *provider) getDiscovery(ctx context.Context) (*discovery, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.discovery != nil {
+		return p.discovery, nil
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(p.options.Issuer, "/")+pathDiscovery, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var d discovery
+
+	err = p.doJSON(request, &d)
+	if err != nil {
+		return nil, err
+	}
+
+	if d.Issuer != p.options.Issuer || len(d.AuthorizationEndpoint) == 0 || len(d.TokenEndpoint) == 0 || len(d.JWKSURI) == 0 {
+		return nil, ErrResponseInvalid
+	}
+
+	p.discovery = &d
+
+	return p.discovery, nil
+}
+
+// doJSON sends the given request and decodes the JSON response body into v.
+func (p *provider) doJSON(request *http.Request, v any) error {
+	request.Header.Set("Accept", "application/json")
+
+	response, err := p.httpClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return err
+	}
+
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("%w: status %d: %s", ErrResponseInvalid, response.StatusCode, body)
+	}
+
+	err = json.Unmarshal(body, v)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrResponseInvalid, err)
+	}
+
+	return nil
+}
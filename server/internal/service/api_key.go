@@ -0,0 +1,238 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/netip"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/goncalo-marques/ecomap/server/internal/authn"
+	"github.com/goncalo-marques/ecomap/server/internal/domain"
+	"github.com/goncalo-marques/ecomap/server/internal/logging"
+)
+
+const (
+	// apiKeyLastUsedAtPrecision defines the precision of the time at which an API key was last used, which avoids
+	// updating it on every request.
+	apiKeyLastUsedAtPrecision = time.Minute
+
+	descriptionFailedCreateAPIKey         = "service: failed to create api key"
+	descriptionFailedListAPIKeys          = "service: failed to list api keys"
+	descriptionFailedGetAPIKeyByID        = "service: failed to get api key by id"
+	descriptionFailedDeleteAPIKeyByID     = "service: failed to delete api key by id"
+	descriptionFailedAuthenticateAPIKey   = "service: failed to authenticate api key"
+	descriptionFailedUpdateAPIKeyLastUsed = "service: failed to update api key last used"
+)
+
+// CreateAPIKey creates a new API key with the specified data and returns it along with the key, which is not stored
+// and cannot be obtained again. The roles of the key cannot grant permissions that the grantor does not hold.
+func (s *service) CreateAPIKey(ctx context.Context, editableAPIKey domain.EditableAPIKey, grantorPermissions []domain.Permission) (domain.APIKeyWithKey, error) {
+	logAttrs := []any{
+		slog.String(logging.ServiceMethod, "CreateAPIKey"),
+		slog.String(logging.APIKeyName, string(editableAPIKey.Name)),
+	}
+
+	if !editableAPIKey.Name.Valid() {
+		return domain.APIKeyWithKey{}, logInfoAndWrapError(ctx, &domain.ErrFieldValueInvalid{FieldName: domain.FieldName}, descriptionInvalidFieldValue, logAttrs...)
+	}
+	if !editableAPIKey.Roles.Valid() {
+		return domain.APIKeyWithKey{}, logInfoAndWrapError(ctx, &domain.ErrFieldValueInvalid{FieldName: domain.FieldRoles}, descriptionInvalidFieldValue, logAttrs...)
+	}
+	for _, prefix := range editableAPIKey.AllowedIPAddresses {
+		if !prefix.IsValid() {
+			return domain.APIKeyWithKey{}, logInfoAndWrapError(ctx, &domain.ErrFieldValueInvalid{FieldName: domain.FieldAllowedIPAddresses}, descriptionInvalidFieldValue, logAttrs...)
+		}
+	}
+	if editableAPIKey.ExpiresAt != nil && !editableAPIKey.ExpiresAt.After(time.Now()) {
+		return domain.APIKeyWithKey{}, logInfoAndWrapError(ctx, &domain.ErrFieldValueInvalid{FieldName: domain.FieldExpiresAt}, descriptionInvalidFieldValue, logAttrs...)
+	}
+
+	if editableAPIKey.ExpiresAt != nil {
+		expiresAt := editableAPIKey.ExpiresAt.UTC()
+		editableAPIKey.ExpiresAt = &expiresAt
+	}
+
+	key, err := s.authnService.NewAPIKey()
+	if err != nil {
+		return domain.APIKeyWithKey{}, logAndWrapError(ctx, err, descriptionFailedCreateAPIKey, logAttrs...)
+	}
+
+	var apiKey domain.APIKey
+
+	err = s.readWriteTx(ctx, func(tx pgx.Tx) error {
+		roles, err := s.listRolesByName(ctx, tx, editableAPIKey.Roles, domain.FieldRoles)
+		if err != nil {
+			return err
+		}
+
+		err = checkRolesGrantable(roles, grantorPermissions)
+		if err != nil {
+			return err
+		}
+
+		id, err := s.store.CreateAPIKey(ctx, tx, domain.EditableAPIKeyWithHash{
+			EditableAPIKey: editableAPIKey,
+			Hash:           key.Hash,
+		})
+		if err != nil {
+			return err
+		}
+
+		apiKey, err = s.store.GetAPIKeyByID(ctx, tx, id)
+		return err
+	})
+	if err != nil {
+		var domainErrFieldValueInvalid *domain.ErrFieldValueInvalid
+
+		switch {
+		case errors.As(err, &domainErrFieldValueInvalid):
+			return domain.APIKeyWithKey{}, logInfoAndWrapError(ctx, err, descriptionInvalidFieldValue, logAttrs...)
+		case errors.Is(err, domain.ErrRoleNotGrantable):
+			return domain.APIKeyWithKey{}, logInfoAndWrapError(ctx, err, descriptionFailedCreateAPIKey, logAttrs...)
+		default:
+			return domain.APIKeyWithKey{}, logAndWrapError(ctx, err, descriptionFailedCreateAPIKey, logAttrs...)
+		}
+	}
+
+	return domain.APIKeyWithKey{
+		APIKey: apiKey,
+		Key:    key.Key,
+	}, nil
+}
+
+// ListAPIKeys returns every API key.
+func (s *service) ListAPIKeys(ctx context.Context) ([]domain.APIKey, error) {
+	logAttrs := []any{
+		slog.String(logging.ServiceMethod, "ListAPIKeys"),
+	}
+
+	var apiKeys []domain.APIKey
+	var err error
+
+	err = s.readOnlyTx(ctx, func(tx pgx.Tx) error {
+		apiKeys, err = s.store.ListAPIKeys(ctx, tx)
+		return err
+	})
+	if err != nil {
+		return nil, logAndWrapError(ctx, err, descriptionFailedListAPIKeys, logAttrs...)
+	}
+
+	return apiKeys, nil
+}
+
+// GetAPIKeyByID returns the API key with the specified identifier.
+func (s *service) GetAPIKeyByID(ctx context.Context, id uuid.UUID) (domain.APIKey, error) {
+	logAttrs := []any{
+		slog.String(logging.ServiceMethod, "GetAPIKeyByID"),
+		slog.String(logging.APIKeyID, id.String()),
+	}
+
+	var apiKey domain.APIKey
+	var err error
+
+	err = s.readOnlyTx(ctx, func(tx pgx.Tx) error {
+		apiKey, err = s.store.GetAPIKeyByID(ctx, tx, id)
+		return err
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrAPIKeyNotFound):
+			return domain.APIKey{}, logInfoAndWrapError(ctx, err, descriptionFailedGetAPIKeyByID, logAttrs...)
+		default:
+			return domain.APIKey{}, logAndWrapError(ctx, err, descriptionFailedGetAPIKeyByID, logAttrs...)
+		}
+	}
+
+	return apiKey, nil
+}
+
+// DeleteAPIKeyByID deletes the API key with the specified identifier, which can no longer be used.
+func (s *service) DeleteAPIKeyByID(ctx context.Context, id uuid.UUID) (domain.APIKey, error) {
+	logAttrs := []any{
+		slog.String(logging.ServiceMethod, "DeleteAPIKeyByID"),
+		slog.String(logging.APIKeyID, id.String()),
+	}
+
+	var apiKey domain.APIKey
+	var err error
+
+	err = s.readWriteTx(ctx, func(tx pgx.Tx) error {
+		apiKey, err = s.store.GetAPIKeyByID(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		return s.store.DeleteAPIKeyByID(ctx, tx, id)
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrAPIKeyNotFound):
+			return domain.APIKey{}, logInfoAndWrapError(ctx, err, descriptionFailedDeleteAPIKeyByID, logAttrs...)
+		default:
+			return domain.APIKey{}, logAndWrapError(ctx, err, descriptionFailedDeleteAPIKeyByID, logAttrs...)
+		}
+	}
+
+	return apiKey, nil
+}
+
+// AuthenticateAPIKey returns the claims of the specified API key, whose subject is the identifier of the API key, if
+// it has not expired and can be used from the given IP address. The time at which the API key was last used is
+// recorded with a precision of one minute.
+func (s *service) AuthenticateAPIKey(ctx context.Context, key string, ipAddress netip.Addr) (authn.Claims, error) {
+	logAttrs := []any{
+		slog.String(logging.ServiceMethod, "AuthenticateAPIKey"),
+		slog.String(logging.SignInIPAddress, ipAddress.String()),
+	}
+
+	var apiKey domain.APIKey
+	var err error
+
+	err = s.readOnlyTx(ctx, func(tx pgx.Tx) error {
+		apiKey, err = s.store.GetAPIKeyByHash(ctx, tx, s.authnService.HashAPIKey(key))
+		return err
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrAPIKeyNotFound):
+			return authn.Claims{}, logInfoAndWrapError(ctx, domain.ErrAPIKeyInvalid, descriptionFailedAuthenticateAPIKey, logAttrs...)
+		default:
+			return authn.Claims{}, logAndWrapError(ctx, err, descriptionFailedAuthenticateAPIKey, logAttrs...)
+		}
+	}
+
+	logAttrs = append(logAttrs,
+		slog.String(logging.APIKeyID, apiKey.ID.String()),
+		slog.String(logging.APIKeyName, string(apiKey.Name)),
+	)
+
+	now := time.Now().UTC()
+
+	if apiKey.Expired(now) || !apiKey.AllowsIPAddress(ipAddress) {
+		return authn.Claims{}, logInfoAndWrapError(ctx, domain.ErrAPIKeyInvalid, descriptionFailedAuthenticateAPIKey, logAttrs...)
+	}
+
+	// Failing to record the usage must not reject the request.
+	err = s.readCommittedTx(ctx, func(tx pgx.Tx) error {
+		return s.store.UpdateAPIKeyLastUsedAt(ctx, tx, apiKey.ID, now, now.Add(-apiKeyLastUsedAtPrecision))
+	})
+	if err != nil {
+		logging.Logger.ErrorContext(ctx, descriptionFailedUpdateAPIKeyLastUsed, append(logAttrs, logging.Error(err))...)
+	}
+
+	roles := make([]authn.SubjectRole, len(apiKey.Roles))
+	for i, role := range apiKey.Roles {
+		roles[i] = authn.SubjectRole(role)
+	}
+
+	claims := authn.Claims{
+		Roles: roles,
+	}
+	claims.Subject = apiKey.ID.String()
+
+	return claims, nil
+}
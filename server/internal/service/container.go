@@ -30,6 +30,9 @@ func (s *service) CreateContainer(ctx context.Context, editableContainer domain.
 	if !editableContainer.Category.Valid() {
 		return domain.Container{}, logInfoAndWrapError(ctx, &domain.ErrFieldValueInvalid{FieldName: domain.FieldCategory}, descriptionInvalidFieldValue, logAttrs...)
 	}
+	if editableContainer.Volume != nil && !editableContainer.Volume.Valid() {
+		return domain.Container{}, logInfoAndWrapError(ctx, &domain.ErrFieldValueInvalid{FieldName: domain.FieldVolume}, descriptionInvalidFieldValue, logAttrs...)
+	}
 
 	var container domain.Container
 
@@ -134,7 +137,8 @@ func (s *service) GetContainerByID(ctx context.Context, id uuid.UUID) (domain.Co
 	return container, nil
 }
 
-// PatchContainer modifies the container with the specified identifier.
+// PatchContainer modifies the container with the specified identifier. The category of the container must be accepted
+// by the trucks of the routes associated with it.
 func (s *service) PatchContainer(ctx context.Context, id uuid.UUID, editableContainer domain.EditableContainerPatch) (domain.Container, error) {
 	logAttrs := []any{
 		slog.String(logging.ServiceMethod, "PatchContainer"),
@@ -144,6 +148,9 @@ func (s *service) PatchContainer(ctx context.Context, id uuid.UUID, editableCont
 	if editableContainer.Category != nil && !editableContainer.Category.Valid() {
 		return domain.Container{}, logInfoAndWrapError(ctx, &domain.ErrFieldValueInvalid{FieldName: domain.FieldCategory}, descriptionInvalidFieldValue, logAttrs...)
 	}
+	if editableContainer.Volume != nil && !editableContainer.Volume.Valid() {
+		return domain.Container{}, logInfoAndWrapError(ctx, &domain.ErrFieldValueInvalid{FieldName: domain.FieldVolume}, descriptionInvalidFieldValue, logAttrs...)
+	}
 
 	var container domain.Container
 	var err error
@@ -184,11 +191,25 @@ func (s *service) PatchContainer(ctx context.Context, id uuid.UUID, editableCont
 			return err
 		}
 
+		if editableContainer.Category != nil {
+			routes, err := s.store.ListRoutesByContainerID(ctx, tx, id)
+			if err != nil {
+				return err
+			}
+
+			for _, route := range routes {
+				if !route.Truck.AcceptsCategories([]domain.ContainerCategory{container.Category}) {
+					return domain.ErrRouteContainerCategoryIncompatible
+				}
+			}
+		}
+
 		return nil
 	})
 	if err != nil {
 		switch {
-		case errors.Is(err, domain.ErrContainerNotFound):
+		case errors.Is(err, domain.ErrContainerNotFound),
+			errors.Is(err, domain.ErrRouteContainerCategoryIncompatible):
 			return domain.Container{}, logInfoAndWrapError(ctx, err, descriptionFailedPatchContainer, logAttrs...)
 		default:
 			return domain.Container{}, logAndWrapError(ctx, err, descriptionFailedPatchContainer, logAttrs...)
@@ -4,7 +4,10 @@ import (
 	"context"
 	"errors"
 	"log/slog"
+	"net/netip"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -17,16 +20,21 @@ import (
 const (
 	descriptionFailedCreateEmployee         = "service: failed to create employee"
 	descriptionFailedListEmployees          = "service: failed to list employees"
+	descriptionFailedListEmployeesAvailable = "service: failed to list employees available"
 	descriptionFailedGetEmployeeByID        = "service: failed to get employee by id"
 	descriptionFailedGetEmployeeByUsername  = "service: failed to get employee by username"
 	descriptionFailedGetEmployeeSignIn      = "service: failed to get employee sign-in"
 	descriptionFailedPatchEmployee          = "service: failed to patch employee"
 	descriptionFailedUpdateEmployeePassword = "service: failed to update employee password"
+	descriptionFailedSignInEmployee         = "service: failed to sign in employee"
 	descriptionFailedDeleteEmployeeByID     = "service: failed to delete employee by id"
 )
 
-// CreateEmployee creates a new employee with the specified data.
-func (s *service) CreateEmployee(ctx context.Context, editableEmployee domain.EditableEmployeeWithPassword) (domain.Employee, error) {
+const listEmployeesPaginatedLimit = 100
+
+// CreateEmployee creates a new employee with the specified data. The role of the employee cannot grant permissions that
+// the grantor does not hold.
+func (s *service) CreateEmployee(ctx context.Context, editableEmployee domain.EditableEmployeeWithPassword, grantorPermissions []domain.Permission) (domain.Employee, error) {
 	logAttrs := []any{
 		slog.String(logging.ServiceMethod, "CreateEmployee"),
 		slog.String(logging.EmployeeUsername, string(editableEmployee.Username)),
@@ -48,9 +56,6 @@ func (s *service) CreateEmployee(ctx context.Context, editableEmployee domain.Ed
 	if !editableEmployee.Username.Valid() {
 		return domain.Employee{}, logInfoAndWrapError(ctx, &domain.ErrFieldValueInvalid{FieldName: domain.FieldUsername}, descriptionInvalidFieldValue, logAttrs...)
 	}
-	if !s.authnService.ValidPassword([]byte(editableEmployee.Password)) {
-		return domain.Employee{}, logInfoAndWrapError(ctx, &domain.ErrFieldValueInvalid{FieldName: domain.FieldPassword}, descriptionInvalidFieldValue, logAttrs...)
-	}
 	if !editableEmployee.FirstName.Valid() {
 		return domain.Employee{}, logInfoAndWrapError(ctx, &domain.ErrFieldValueInvalid{FieldName: domain.FieldFirstName}, descriptionInvalidFieldValue, logAttrs...)
 	}
@@ -63,6 +68,14 @@ func (s *service) CreateEmployee(ctx context.Context, editableEmployee domain.Ed
 	if !editableEmployee.PhoneNumber.Valid() {
 		return domain.Employee{}, logInfoAndWrapError(ctx, &domain.ErrFieldValueInvalid{FieldName: domain.FieldPhoneNumber}, descriptionInvalidFieldValue, logAttrs...)
 	}
+	if !editableEmployee.Shifts.Valid() {
+		return domain.Employee{}, logInfoAndWrapError(ctx, &domain.ErrFieldValueInvalid{FieldName: domain.FieldShifts}, descriptionInvalidFieldValue, logAttrs...)
+	}
+
+	err := s.checkPasswordPolicy(ctx, s.options.EmployeePasswordPolicy, editableEmployee.Password, logAttrs...)
+	if err != nil {
+		return domain.Employee{}, err
+	}
 
 	hashedPassword, err := s.authnService.HashPassword([]byte(editableEmployee.Password))
 	if err != nil {
@@ -74,6 +87,16 @@ func (s *service) CreateEmployee(ctx context.Context, editableEmployee domain.Ed
 	var employee domain.Employee
 
 	err = s.readWriteTx(ctx, func(tx pgx.Tx) error {
+		roles, err := s.listRolesByName(ctx, tx, []domain.RoleName{domain.RoleName(editableEmployee.Role)}, domain.FieldRole)
+		if err != nil {
+			return err
+		}
+
+		err = checkRolesGrantable(roles, grantorPermissions)
+		if err != nil {
+			return err
+		}
+
 		geometry := geometryPointFromGeoJSON(editableEmployee.GeoJSON)
 
 		var roadID *int
@@ -101,6 +124,11 @@ func (s *service) CreateEmployee(ctx context.Context, editableEmployee domain.Ed
 			return err
 		}
 
+		err = s.store.CreateEmployeeShifts(ctx, tx, id, editableEmployee.Shifts)
+		if err != nil {
+			return err
+		}
+
 		employee, err = s.store.GetEmployeeByID(ctx, tx, id)
 		if err != nil {
 			return err
@@ -109,8 +137,13 @@ func (s *service) CreateEmployee(ctx context.Context, editableEmployee domain.Ed
 		return nil
 	})
 	if err != nil {
+		var domainErrFieldValueInvalid *domain.ErrFieldValueInvalid
+
 		switch {
-		case errors.Is(err, domain.ErrEmployeeAlreadyExists):
+		case errors.As(err, &domainErrFieldValueInvalid):
+			return domain.Employee{}, logInfoAndWrapError(ctx, err, descriptionInvalidFieldValue, logAttrs...)
+		case errors.Is(err, domain.ErrEmployeeAlreadyExists),
+			errors.Is(err, domain.ErrRoleNotGrantable):
 			return domain.Employee{}, logInfoAndWrapError(ctx, err, descriptionFailedCreateEmployee, logAttrs...)
 		default:
 			return domain.Employee{}, logAndWrapError(ctx, err, descriptionFailedCreateEmployee, logAttrs...)
@@ -153,6 +186,67 @@ func (s *service) ListEmployees(ctx context.Context, filter domain.EmployeesPagi
 	return paginatedEmployees, nil
 }
 
+// ListEmployeesAvailable returns the employees that are on shift and not absent during the period of the specified
+// filter.
+func (s *service) ListEmployeesAvailable(ctx context.Context, filter domain.EmployeesAvailabilityFilter) ([]domain.Employee, error) {
+	logAttrs := []any{
+		slog.String(logging.ServiceMethod, "ListEmployeesAvailable"),
+		slog.String(logging.EmployeesAvailabilityDate, filter.Date.Format(time.DateOnly)),
+		slog.String(logging.EmployeesAvailabilityStartTime, filter.StartTime.Format(time.TimeOnly)),
+		slog.String(logging.EmployeesAvailabilityEndTime, filter.EndTime.Format(time.TimeOnly)),
+	}
+
+	if filter.Date.IsZero() {
+		return nil, logInfoAndWrapError(ctx, &domain.ErrFilterValueInvalid{FilterName: domain.FieldDate}, descriptionInvalidFilterValue, logAttrs...)
+	}
+	if domain.TimeOfDay(filter.StartTime) == domain.TimeOfDay(filter.EndTime) {
+		return nil, logInfoAndWrapError(ctx, &domain.ErrFilterValueInvalid{FilterName: domain.FieldFilterEndTime}, descriptionInvalidFilterValue, logAttrs...)
+	}
+	if filter.Role != nil && !filter.Role.Valid() {
+		return nil, logInfoAndWrapError(ctx, &domain.ErrFilterValueInvalid{FilterName: domain.FieldRole}, descriptionInvalidFilterValue, logAttrs...)
+	}
+
+	start, end := filter.Period()
+
+	var employees []domain.Employee
+
+	err := s.readOnlyTx(ctx, func(tx pgx.Tx) error {
+		allEmployees, err := s.listAllEmployees(ctx, tx, filter.Role)
+		if err != nil {
+			return err
+		}
+
+		absentIDs, err := s.store.ListEmployeesAbsentIDs(ctx, tx, start, end)
+		if err != nil {
+			return err
+		}
+
+		absent := make(map[uuid.UUID]struct{}, len(absentIDs))
+		for _, id := range absentIDs {
+			absent[id] = struct{}{}
+		}
+
+		employees = make([]domain.Employee, 0, len(allEmployees))
+		for _, employee := range allEmployees {
+			if _, ok := absent[employee.ID]; ok {
+				continue
+			}
+			if !employee.OnShift(start, end) {
+				continue
+			}
+
+			employees = append(employees, employee)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, logAndWrapError(ctx, err, descriptionFailedListEmployeesAvailable, logAttrs...)
+	}
+
+	return employees, nil
+}
+
 // GetEmployeeByID returns the employee with the specified identifier.
 func (s *service) GetEmployeeByID(ctx context.Context, id uuid.UUID) (domain.Employee, error) {
 	logAttrs := []any{
@@ -179,8 +273,9 @@ func (s *service) GetEmployeeByID(ctx context.Context, id uuid.UUID) (domain.Emp
 	return employee, nil
 }
 
-// PatchEmployee modifies the employee with the specified identifier.
-func (s *service) PatchEmployee(ctx context.Context, id uuid.UUID, editableEmployee domain.EditableEmployeePatch) (domain.Employee, error) {
+// PatchEmployee modifies the employee with the specified identifier. The role of the employee cannot grant permissions
+// that the grantor does not hold.
+func (s *service) PatchEmployee(ctx context.Context, id uuid.UUID, editableEmployee domain.EditableEmployeePatch, grantorPermissions []domain.Permission) (domain.Employee, error) {
 	logAttrs := []any{
 		slog.String(logging.ServiceMethod, "PatchEmployee"),
 		slog.String(logging.EmployeeID, id.String()),
@@ -209,14 +304,32 @@ func (s *service) PatchEmployee(ctx context.Context, id uuid.UUID, editableEmplo
 	if editableEmployee.LastName != nil && !editableEmployee.LastName.Valid() {
 		return domain.Employee{}, logInfoAndWrapError(ctx, &domain.ErrFieldValueInvalid{FieldName: domain.FieldLastName}, descriptionInvalidFieldValue, logAttrs...)
 	}
+	if editableEmployee.Role != nil && !editableEmployee.Role.Valid() {
+		return domain.Employee{}, logInfoAndWrapError(ctx, &domain.ErrFieldValueInvalid{FieldName: domain.FieldRole}, descriptionInvalidFieldValue, logAttrs...)
+	}
 	if editableEmployee.PhoneNumber != nil && !editableEmployee.PhoneNumber.Valid() {
 		return domain.Employee{}, logInfoAndWrapError(ctx, &domain.ErrFieldValueInvalid{FieldName: domain.FieldPhoneNumber}, descriptionInvalidFieldValue, logAttrs...)
 	}
+	if editableEmployee.Shifts != nil && !editableEmployee.Shifts.Valid() {
+		return domain.Employee{}, logInfoAndWrapError(ctx, &domain.ErrFieldValueInvalid{FieldName: domain.FieldShifts}, descriptionInvalidFieldValue, logAttrs...)
+	}
 
 	var employee domain.Employee
 	var err error
 
 	err = s.readWriteTx(ctx, func(tx pgx.Tx) error {
+		if editableEmployee.Role != nil {
+			roles, err := s.listRolesByName(ctx, tx, []domain.RoleName{domain.RoleName(*editableEmployee.Role)}, domain.FieldRole)
+			if err != nil {
+				return err
+			}
+
+			err = checkRolesGrantable(roles, grantorPermissions)
+			if err != nil {
+				return err
+			}
+		}
+
 		geometry := geometryPointFromGeoJSON(editableEmployee.GeoJSON)
 
 		var roadID *int
@@ -247,6 +360,19 @@ func (s *service) PatchEmployee(ctx context.Context, id uuid.UUID, editableEmplo
 			return err
 		}
 
+		// The shifts are replaced as a whole.
+		if editableEmployee.Shifts != nil {
+			err = s.store.DeleteEmployeeShifts(ctx, tx, id)
+			if err != nil {
+				return err
+			}
+
+			err = s.store.CreateEmployeeShifts(ctx, tx, id, *editableEmployee.Shifts)
+			if err != nil {
+				return err
+			}
+		}
+
 		employee, err = s.store.GetEmployeeByID(ctx, tx, id)
 		if err != nil {
 			return err
@@ -255,9 +381,14 @@ func (s *service) PatchEmployee(ctx context.Context, id uuid.UUID, editableEmplo
 		return nil
 	})
 	if err != nil {
+		var domainErrFieldValueInvalid *domain.ErrFieldValueInvalid
+
 		switch {
+		case errors.As(err, &domainErrFieldValueInvalid):
+			return domain.Employee{}, logInfoAndWrapError(ctx, err, descriptionInvalidFieldValue, logAttrs...)
 		case errors.Is(err, domain.ErrEmployeeNotFound),
-			errors.Is(err, domain.ErrEmployeeAlreadyExists):
+			errors.Is(err, domain.ErrEmployeeAlreadyExists),
+			errors.Is(err, domain.ErrRoleNotGrantable):
 			return domain.Employee{}, logInfoAndWrapError(ctx, err, descriptionFailedPatchEmployee, logAttrs...)
 		default:
 			return domain.Employee{}, logAndWrapError(ctx, err, descriptionFailedPatchEmployee, logAttrs...)
@@ -267,21 +398,28 @@ func (s *service) PatchEmployee(ctx context.Context, id uuid.UUID, editableEmplo
 	return employee, nil
 }
 
-// UpdateEmployeePassword updates the password of the employee with the specified username.
-func (s *service) UpdateEmployeePassword(ctx context.Context, username domain.Username, oldPassword, newPassword domain.Password) error {
+// UpdateEmployeePassword updates the password of the employee with the specified username. Since the old password is
+// checked without authentication, failed attempts are limited like sign-in attempts.
+func (s *service) UpdateEmployeePassword(ctx context.Context, username domain.Username, oldPassword, newPassword domain.Password, ipAddress netip.Addr) error {
 	logAttrs := []any{
 		slog.String(logging.ServiceMethod, "UpdateEmployeePassword"),
 		slog.String(logging.EmployeeUsername, string(username)),
+		slog.String(logging.SignInIPAddress, ipAddress.String()),
 	}
 
 	username = domain.Username(strings.ToLower(string(username)))
 
-	if !s.authnService.ValidPassword([]byte(newPassword)) {
-		return logInfoAndWrapError(ctx, &domain.ErrFieldValueInvalid{FieldName: domain.FieldNewPassword}, descriptionInvalidFieldValue, logAttrs...)
+	err := s.checkSignInLockouts(ctx, domain.SubjectTypeEmployee, username, ipAddress, logAttrs...)
+	if err != nil {
+		return err
+	}
+
+	err = s.checkPasswordPolicy(ctx, s.options.EmployeePasswordPolicy, newPassword, logAttrs...)
+	if err != nil {
+		return err
 	}
 
 	var signIn domain.SignIn
-	var err error
 
 	err = s.readOnlyTx(ctx, func(tx pgx.Tx) error {
 		signIn, err = s.store.GetEmployeeSignIn(ctx, tx, username)
@@ -290,6 +428,7 @@ func (s *service) UpdateEmployeePassword(ctx context.Context, username domain.Us
 	if err != nil {
 		switch {
 		case errors.Is(err, domain.ErrEmployeeNotFound):
+			s.recordSignInFailure(ctx, domain.SubjectTypeEmployee, username, ipAddress, logAttrs...)
 			return logInfoAndWrapError(ctx, domain.ErrCredentialsIncorrect, descriptionFailedGetEmployeeSignIn, logAttrs...)
 		default:
 			return logAndWrapError(ctx, err, descriptionFailedGetEmployeeSignIn, logAttrs...)
@@ -302,22 +441,19 @@ func (s *service) UpdateEmployeePassword(ctx context.Context, username domain.Us
 	}
 
 	if !valid {
+		s.recordSignInFailure(ctx, domain.SubjectTypeEmployee, username, ipAddress, logAttrs...)
 		return logInfoAndWrapError(ctx, domain.ErrCredentialsIncorrect, descriptionFailedCheckPasswordHash, logAttrs...)
 	}
 
-	hashedPassword, err := s.authnService.HashPassword([]byte(newPassword))
-	if err != nil {
-		return logAndWrapError(ctx, err, descriptionFailedHashPassword, logAttrs...)
-	}
-
-	newPassword = domain.Password(hashedPassword)
-
 	err = s.readWriteTx(ctx, func(tx pgx.Tx) error {
-		err = s.store.UpdateEmployeePassword(ctx, tx, username, newPassword)
-		return err
+		return s.updateEmployeePassword(ctx, tx, username, newPassword)
 	})
 	if err != nil {
+		var domainErrPasswordPolicyViolated *domain.ErrPasswordPolicyViolated
+
 		switch {
+		case errors.As(err, &domainErrPasswordPolicyViolated):
+			return logInfoAndWrapError(ctx, err, descriptionFailedUpdateEmployeePassword, logAttrs...)
 		case errors.Is(err, domain.ErrEmployeeNotFound):
 			return logInfoAndWrapError(ctx, domain.ErrCredentialsIncorrect, descriptionFailedUpdateEmployeePassword, logAttrs...)
 		default:
@@ -328,7 +464,8 @@ func (s *service) UpdateEmployeePassword(ctx context.Context, username domain.Us
 	return nil
 }
 
-// ResetEmployeePassword resets the password of the employee with the specified username.
+// ResetEmployeePassword resets the password of the employee with the specified username, revoking the tokens of the
+// employee.
 func (s *service) ResetEmployeePassword(ctx context.Context, username domain.Username, newPassword domain.Password) error {
 	logAttrs := []any{
 		slog.String(logging.ServiceMethod, "ResetEmployeePassword"),
@@ -337,23 +474,30 @@ func (s *service) ResetEmployeePassword(ctx context.Context, username domain.Use
 
 	username = domain.Username(strings.ToLower(string(username)))
 
-	if !s.authnService.ValidPassword([]byte(newPassword)) {
-		return logInfoAndWrapError(ctx, &domain.ErrFieldValueInvalid{FieldName: domain.FieldNewPassword}, descriptionInvalidFieldValue, logAttrs...)
-	}
-
-	hashedPassword, err := s.authnService.HashPassword([]byte(newPassword))
+	err := s.checkPasswordPolicy(ctx, s.options.EmployeePasswordPolicy, newPassword, logAttrs...)
 	if err != nil {
-		return logAndWrapError(ctx, err, descriptionFailedHashPassword, logAttrs...)
+		return err
 	}
 
-	newPassword = domain.Password(hashedPassword)
-
 	err = s.readWriteTx(ctx, func(tx pgx.Tx) error {
-		err = s.store.UpdateEmployeePassword(ctx, tx, username, newPassword)
-		return err
+		err := s.updateEmployeePassword(ctx, tx, username, newPassword)
+		if err != nil {
+			return err
+		}
+
+		employee, err := s.store.GetEmployeeByUsername(ctx, tx, username)
+		if err != nil {
+			return err
+		}
+
+		return s.store.RevokeSubjectRefreshTokens(ctx, tx, employee.ID, time.Now().UTC())
 	})
 	if err != nil {
+		var domainErrPasswordPolicyViolated *domain.ErrPasswordPolicyViolated
+
 		switch {
+		case errors.As(err, &domainErrPasswordPolicyViolated):
+			return logInfoAndWrapError(ctx, err, descriptionFailedUpdateEmployeePassword, logAttrs...)
 		case errors.Is(err, domain.ErrEmployeeNotFound):
 			return logInfoAndWrapError(ctx, err, descriptionFailedUpdateEmployeePassword, logAttrs...)
 		default:
@@ -364,7 +508,7 @@ func (s *service) ResetEmployeePassword(ctx context.Context, username domain.Use
 	return nil
 }
 
-// DeleteEmployeeByID deletes the employee with the specified identifier.
+// DeleteEmployeeByID deletes the employee with the specified identifier, revoking the tokens of the employee.
 func (s *service) DeleteEmployeeByID(ctx context.Context, id uuid.UUID) (domain.Employee, error) {
 	logAttrs := []any{
 		slog.String(logging.ServiceMethod, "DeleteEmployeeByID"),
@@ -385,7 +529,7 @@ func (s *service) DeleteEmployeeByID(ctx context.Context, id uuid.UUID) (domain.
 			return err
 		}
 
-		return nil
+		return s.store.RevokeSubjectRefreshTokens(ctx, tx, id, time.Now().UTC())
 	})
 	if err != nil {
 		switch {
@@ -400,17 +544,25 @@ func (s *service) DeleteEmployeeByID(ctx context.Context, id uuid.UUID) (domain.
 	return employee, nil
 }
 
-// SignInEmployee returns a JSON Web Token for the specified username and password.
-func (s *service) SignInEmployee(ctx context.Context, username domain.Username, password domain.Password) (string, error) {
+// SignInEmployee returns an access token and a refresh token for the specified username and password. Failed attempts
+// are tracked by username and by the IP address of the client, if valid, and further attempts are rejected according
+// to the sign-in lockouts. When the employee has two-factor authentication enabled, or its role requires it, a
+// two-factor authentication challenge is returned instead, which must be answered to obtain the tokens.
+func (s *service) SignInEmployee(ctx context.Context, username domain.Username, password domain.Password, ipAddress netip.Addr) (domain.EmployeeSignInResult, error) {
 	logAttrs := []any{
 		slog.String(logging.ServiceMethod, "SignInEmployee"),
 		slog.String(logging.EmployeeUsername, string(username)),
+		slog.String(logging.SignInIPAddress, ipAddress.String()),
 	}
 
 	username = domain.Username(strings.ToLower(string(username)))
 
+	err := s.checkSignInLockouts(ctx, domain.SubjectTypeEmployee, username, ipAddress, logAttrs...)
+	if err != nil {
+		return domain.EmployeeSignInResult{}, err
+	}
+
 	var signIn domain.SignIn
-	var err error
 
 	err = s.readOnlyTx(ctx, func(tx pgx.Tx) error {
 		signIn, err = s.store.GetEmployeeSignIn(ctx, tx, username)
@@ -419,43 +571,110 @@ func (s *service) SignInEmployee(ctx context.Context, username domain.Username,
 	if err != nil {
 		switch {
 		case errors.Is(err, domain.ErrEmployeeNotFound):
-			return "", logInfoAndWrapError(ctx, domain.ErrCredentialsIncorrect, descriptionFailedGetEmployeeSignIn, logAttrs...)
+			s.recordSignInFailure(ctx, domain.SubjectTypeEmployee, username, ipAddress, logAttrs...)
+			return domain.EmployeeSignInResult{}, logInfoAndWrapError(ctx, domain.ErrCredentialsIncorrect, descriptionFailedGetEmployeeSignIn, logAttrs...)
 		default:
-			return "", logAndWrapError(ctx, err, descriptionFailedGetEmployeeSignIn, logAttrs...)
+			return domain.EmployeeSignInResult{}, logAndWrapError(ctx, err, descriptionFailedGetEmployeeSignIn, logAttrs...)
 		}
 	}
 
 	valid, err := s.authnService.CheckPasswordHash([]byte(password), []byte(signIn.Password))
 	if err != nil {
-		return "", logAndWrapError(ctx, err, descriptionFailedCheckPasswordHash, logAttrs...)
+		return domain.EmployeeSignInResult{}, logAndWrapError(ctx, err, descriptionFailedCheckPasswordHash, logAttrs...)
 	}
 
 	if !valid {
-		return "", logInfoAndWrapError(ctx, domain.ErrCredentialsIncorrect, descriptionFailedCheckPasswordHash, logAttrs...)
+		s.recordSignInFailure(ctx, domain.SubjectTypeEmployee, username, ipAddress, logAttrs...)
+		return domain.EmployeeSignInResult{}, logInfoAndWrapError(ctx, domain.ErrCredentialsIncorrect, descriptionFailedCheckPasswordHash, logAttrs...)
+	}
+
+	s.rehashPassword(ctx, password, signIn.Password, func(tx pgx.Tx, oldHash, newHash domain.Password) error {
+		return s.store.UpdateEmployeePasswordHash(ctx, tx, username, oldHash, newHash)
+	}, logAttrs...)
+
+	// The expired password must be changed before signing in, which is possible with the current password.
+	if s.options.EmployeePasswordMaxAge > 0 && signIn.PasswordChangedAt != nil &&
+		time.Now().After(signIn.PasswordChangedAt.Add(s.options.EmployeePasswordMaxAge)) {
+		return domain.EmployeeSignInResult{}, logInfoAndWrapError(ctx, domain.ErrPasswordExpired, descriptionFailedSignInEmployee, logAttrs...)
 	}
 
 	var employee domain.Employee
+	var totp domain.EmployeeTOTP
 
 	err = s.readOnlyTx(ctx, func(tx pgx.Tx) error {
 		employee, err = s.store.GetEmployeeByUsername(ctx, tx, username)
-		return err
+		if err != nil {
+			return err
+		}
+
+		totp, err = s.store.GetEmployeeTOTP(ctx, tx, employee.ID)
+		if err != nil && !errors.Is(err, domain.ErrEmployeeTOTPNotFound) {
+			return err
+		}
+
+		return nil
 	})
 	if err != nil {
-		return "", logAndWrapError(ctx, err, descriptionFailedGetEmployeeByUsername, logAttrs...)
+		return domain.EmployeeSignInResult{}, logAndWrapError(ctx, err, descriptionFailedGetEmployeeByUsername, logAttrs...)
 	}
 
-	var role authn.SubjectRole
-	switch employee.Role {
-	case domain.EmployeeRoleWasteOperator:
-		role = authn.SubjectRoleWasteOperator
-	case domain.EmployeeRoleManager:
-		role = authn.SubjectRoleManager
+	// The sign-in failures are only cleared once the second factor is verified, so that the password alone does not
+	// reset the attempts left to guess the code.
+	enrolmentRequired := !totp.Enabled() && slices.Contains(s.options.TOTPRequiredRoles, employee.Role)
+	if totp.Enabled() || enrolmentRequired {
+		var challenge domain.MFAChallenge
+
+		err = s.readWriteTx(ctx, func(tx pgx.Tx) error {
+			challenge, err = s.createEmployeeMFAChallenge(ctx, tx, employee.ID, enrolmentRequired)
+			return err
+		})
+		if err != nil {
+			return domain.EmployeeSignInResult{}, logAndWrapError(ctx, err, descriptionFailedCreateEmployeeMFAChallenge, logAttrs...)
+		}
+
+		return domain.EmployeeSignInResult{MFAChallenge: &challenge}, nil
 	}
 
-	token, err := s.authnService.NewJWT(employee.ID.String(), []authn.SubjectRole{role})
+	s.clearSignInFailures(ctx, domain.SubjectTypeEmployee, username, logAttrs...)
+
+	role := employeeSubjectRole(employee.Role)
+
+	var tokens domain.Tokens
+
+	err = s.readWriteTx(ctx, func(tx pgx.Tx) error {
+		tokens, err = s.createTokens(ctx, tx, domain.SubjectTypeEmployee, employee.ID, []authn.SubjectRole{role}, uuid.New())
+		return err
+	})
 	if err != nil {
-		return "", logAndWrapError(ctx, err, descriptionFailedCreateJWT, logAttrs...)
+		return domain.EmployeeSignInResult{}, logAndWrapError(ctx, err, descriptionFailedCreateTokens, logAttrs...)
+	}
+
+	return domain.EmployeeSignInResult{Tokens: tokens}, nil
+}
+
+// listAllEmployees returns all the employees with the specified role. If the role is nil, employees of every role are
+// returned.
+func (s *service) listAllEmployees(ctx context.Context, tx pgx.Tx, role *domain.EmployeeRole) ([]domain.Employee, error) {
+	var employees []domain.Employee
+
+	for {
+		paginatedEmployees, err := s.store.ListEmployees(ctx, tx, domain.EmployeesPaginatedFilter{
+			PaginatedRequest: domain.PaginatedRequest[domain.EmployeePaginatedSort]{
+				Limit:  listEmployeesPaginatedLimit,
+				Offset: domain.PaginationOffset(len(employees)),
+			},
+			Role: role,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		employees = append(employees, paginatedEmployees.Results...)
+
+		if len(paginatedEmployees.Results) < listEmployeesPaginatedLimit {
+			break
+		}
 	}
 
-	return token, nil
+	return employees, nil
 }
@@ -0,0 +1,165 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/goncalo-marques/ecomap/server/internal/domain"
+	"github.com/goncalo-marques/ecomap/server/internal/logging"
+)
+
+const (
+	descriptionFailedCreateEmployeeAbsence     = "service: failed to create employee absence"
+	descriptionFailedListEmployeeAbsences      = "service: failed to list employee absences"
+	descriptionFailedDeleteEmployeeAbsenceByID = "service: failed to delete employee absence by id"
+)
+
+// CreateEmployeeAbsence records an absence of the employee with the specified identifier. The absence cannot overlap
+// other absences of the employee.
+func (s *service) CreateEmployeeAbsence(ctx context.Context, employeeID uuid.UUID, editableAbsence domain.EditableEmployeeAbsence) (domain.EmployeeAbsence, error) {
+	logAttrs := []any{
+		slog.String(logging.ServiceMethod, "CreateEmployeeAbsence"),
+		slog.String(logging.EmployeeID, employeeID.String()),
+		slog.String(logging.EmployeeAbsenceType, string(editableAbsence.Type)),
+		slog.String(logging.EmployeeAbsenceStartDate, editableAbsence.StartDate.Format(time.DateOnly)),
+		slog.String(logging.EmployeeAbsenceEndDate, editableAbsence.EndDate.Format(time.DateOnly)),
+	}
+
+	if !editableAbsence.Type.Valid() {
+		return domain.EmployeeAbsence{}, logInfoAndWrapError(ctx, &domain.ErrFieldValueInvalid{FieldName: domain.FieldType}, descriptionInvalidFieldValue, logAttrs...)
+	}
+	if editableAbsence.StartDate.IsZero() {
+		return domain.EmployeeAbsence{}, logInfoAndWrapError(ctx, &domain.ErrFieldValueInvalid{FieldName: domain.FieldStartDate}, descriptionInvalidFieldValue, logAttrs...)
+	}
+	if editableAbsence.EndDate.IsZero() || editableAbsence.EndDate.Before(editableAbsence.StartDate) {
+		return domain.EmployeeAbsence{}, logInfoAndWrapError(ctx, &domain.ErrFieldValueInvalid{FieldName: domain.FieldEndDate}, descriptionInvalidFieldValue, logAttrs...)
+	}
+	if editableAbsence.Notes != nil && !editableAbsence.Notes.Valid() {
+		return domain.EmployeeAbsence{}, logInfoAndWrapError(ctx, &domain.ErrFieldValueInvalid{FieldName: domain.FieldNotes}, descriptionInvalidFieldValue, logAttrs...)
+	}
+
+	var absence domain.EmployeeAbsence
+
+	err := s.readWriteTx(ctx, func(tx pgx.Tx) error {
+		_, err := s.store.GetEmployeeByID(ctx, tx, employeeID)
+		if err != nil {
+			return err
+		}
+
+		overlap, err := s.store.ExistsEmployeeAbsence(ctx, tx, employeeID, editableAbsence.StartDate, editableAbsence.EndDate)
+		if err != nil {
+			return err
+		}
+		if overlap {
+			return domain.ErrEmployeeAbsenceOverlap
+		}
+
+		id, err := s.store.CreateEmployeeAbsence(ctx, tx, employeeID, editableAbsence)
+		if err != nil {
+			return err
+		}
+
+		absence, err = s.store.GetEmployeeAbsenceByID(ctx, tx, employeeID, id)
+		if err != nil {
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrEmployeeNotFound),
+			errors.Is(err, domain.ErrEmployeeAbsenceOverlap):
+			return domain.EmployeeAbsence{}, logInfoAndWrapError(ctx, err, descriptionFailedCreateEmployeeAbsence, logAttrs...)
+		default:
+			return domain.EmployeeAbsence{}, logAndWrapError(ctx, err, descriptionFailedCreateEmployeeAbsence, logAttrs...)
+		}
+	}
+
+	return absence, nil
+}
+
+// ListEmployeeAbsences returns the absences of the employee with the specified identifier and filter.
+func (s *service) ListEmployeeAbsences(ctx context.Context, employeeID uuid.UUID, filter domain.EmployeeAbsencesPaginatedFilter) (domain.PaginatedResponse[domain.EmployeeAbsence], error) {
+	logAttrs := []any{
+		slog.String(logging.ServiceMethod, "ListEmployeeAbsences"),
+		slog.String(logging.EmployeeID, employeeID.String()),
+	}
+
+	if filter.Sort != nil && !filter.Sort.Valid() {
+		return domain.PaginatedResponse[domain.EmployeeAbsence]{}, logInfoAndWrapError(ctx, &domain.ErrFilterValueInvalid{FilterName: domain.FieldFilterSort}, descriptionInvalidFilterValue, logAttrs...)
+	}
+	if !filter.Order.Valid() {
+		return domain.PaginatedResponse[domain.EmployeeAbsence]{}, logInfoAndWrapError(ctx, &domain.ErrFilterValueInvalid{FilterName: domain.FieldFilterOrder}, descriptionInvalidFilterValue, logAttrs...)
+	}
+	if !filter.Limit.Valid() {
+		return domain.PaginatedResponse[domain.EmployeeAbsence]{}, logInfoAndWrapError(ctx, &domain.ErrFilterValueInvalid{FilterName: domain.FieldFilterLimit}, descriptionInvalidFilterValue, logAttrs...)
+	}
+	if !filter.Offset.Valid() {
+		return domain.PaginatedResponse[domain.EmployeeAbsence]{}, logInfoAndWrapError(ctx, &domain.ErrFilterValueInvalid{FilterName: domain.FieldFilterOffset}, descriptionInvalidFilterValue, logAttrs...)
+	}
+
+	var paginatedAbsences domain.PaginatedResponse[domain.EmployeeAbsence]
+
+	err := s.readOnlyTx(ctx, func(tx pgx.Tx) error {
+		_, err := s.store.GetEmployeeByID(ctx, tx, employeeID)
+		if err != nil {
+			return err
+		}
+
+		paginatedAbsences, err = s.store.ListEmployeeAbsences(ctx, tx, employeeID, filter)
+		return err
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrEmployeeNotFound):
+			return domain.PaginatedResponse[domain.EmployeeAbsence]{}, logInfoAndWrapError(ctx, err, descriptionFailedListEmployeeAbsences, logAttrs...)
+		default:
+			return domain.PaginatedResponse[domain.EmployeeAbsence]{}, logAndWrapError(ctx, err, descriptionFailedListEmployeeAbsences, logAttrs...)
+		}
+	}
+
+	return paginatedAbsences, nil
+}
+
+// DeleteEmployeeAbsenceByID deletes the absence with the specified identifier of the employee with the specified
+// identifier.
+func (s *service) DeleteEmployeeAbsenceByID(ctx context.Context, employeeID, id uuid.UUID) (domain.EmployeeAbsence, error) {
+	logAttrs := []any{
+		slog.String(logging.ServiceMethod, "DeleteEmployeeAbsenceByID"),
+		slog.String(logging.EmployeeID, employeeID.String()),
+		slog.String(logging.EmployeeAbsenceID, id.String()),
+	}
+
+	var absence domain.EmployeeAbsence
+	var err error
+
+	err = s.readWriteTx(ctx, func(tx pgx.Tx) error {
+		absence, err = s.store.GetEmployeeAbsenceByID(ctx, tx, employeeID, id)
+		if err != nil {
+			return err
+		}
+
+		err = s.store.DeleteEmployeeAbsenceByID(ctx, tx, employeeID, id)
+		if err != nil {
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrEmployeeAbsenceNotFound):
+			return domain.EmployeeAbsence{}, logInfoAndWrapError(ctx, err, descriptionFailedDeleteEmployeeAbsenceByID, logAttrs...)
+		default:
+			return domain.EmployeeAbsence{}, logAndWrapError(ctx, err, descriptionFailedDeleteEmployeeAbsenceByID, logAttrs...)
+		}
+	}
+
+	return absence, nil
+}
@@ -0,0 +1,119 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/goncalo-marques/ecomap/server/internal/domain"
+	"github.com/goncalo-marques/ecomap/server/internal/logging"
+)
+
+const (
+	descriptionFailedGetEmployeeAgenda = "service: failed to get employee agenda"
+)
+
+// GetEmployeeAgenda returns the agenda of the employee with the specified identifier on the date of the given time,
+// containing the routes the employee works on, ordered by departure. Each route includes the role of the employee, the
+// sequential stops of the route plan and the roads that connect them. When the route has a departure time, the
+// departure and the arrival times of the stops are on the agenda date.
+func (s *service) GetEmployeeAgenda(ctx context.Context, employeeID uuid.UUID, date time.Time) (domain.EmployeeAgenda, error) {
+	logAttrs := []any{
+		slog.String(logging.ServiceMethod, "GetEmployeeAgenda"),
+		slog.String(logging.EmployeeID, employeeID.String()),
+		slog.String(logging.EmployeeAgendaDate, date.Format(time.DateOnly)),
+	}
+
+	date = time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	agenda := domain.EmployeeAgenda{
+		Date: date,
+	}
+
+	err := s.readWriteTx(ctx, func(tx pgx.Tx) error {
+		_, err := s.store.GetEmployeeByID(ctx, tx, employeeID)
+		if err != nil {
+			return err
+		}
+
+		agenda.Absent, err = s.store.ExistsEmployeeAbsence(ctx, tx, employeeID, date, date)
+		if err != nil {
+			return err
+		}
+		if agenda.Absent {
+			return nil
+		}
+
+		routesRoles, err := s.store.ListEmployeeRoutesRoles(ctx, tx, employeeID)
+		if err != nil {
+			return err
+		}
+
+		for routeID, routeRole := range routesRoles {
+			route, err := s.store.GetRouteByID(ctx, tx, routeID)
+			if err != nil {
+				return err
+			}
+
+			// Anchor the departure time on the agenda date, so that the arrival times of the stops are on that date.
+			if route.DepartureTime != nil {
+				departureAt := date.Add(domain.TimeOfDay(*route.DepartureTime))
+				route.DepartureTime = &departureAt
+			}
+
+			plan, err := s.planRoute(ctx, tx, route)
+			if err != nil {
+				return err
+			}
+
+			roadsGeometry, err := s.store.GetRoadsGeometryAStar(ctx, tx, plan.roadNetworkTableName, plan.stopsVertexIDs, true)
+			if err != nil {
+				return err
+			}
+
+			agenda.Routes = append(agenda.Routes, domain.EmployeeAgendaRoute{
+				Route:       route,
+				RouteRole:   routeRole,
+				DepartureAt: route.DepartureTime,
+				Stops:       plan.stops,
+				Roads:       roadsGeoJSON(roadsGeometry),
+			})
+		}
+
+		return nil
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrEmployeeNotFound),
+			errors.Is(err, domain.ErrRouteLandfillNotFound),
+			errors.Is(err, domain.ErrRouteStopUnreachable),
+			errors.Is(err, domain.ErrRoutePayloadCapacityExceeded):
+			return domain.EmployeeAgenda{}, logInfoAndWrapError(ctx, err, descriptionFailedGetEmployeeAgenda, logAttrs...)
+		default:
+			return domain.EmployeeAgenda{}, logAndWrapError(ctx, err, descriptionFailedGetEmployeeAgenda, logAttrs...)
+		}
+	}
+
+	// Routes without a departure time are placed last.
+	slices.SortFunc(agenda.Routes, func(a, b domain.EmployeeAgendaRoute) int {
+		switch {
+		case a.DepartureAt != nil && b.DepartureAt != nil:
+			if c := a.DepartureAt.Compare(*b.DepartureAt); c != 0 {
+				return c
+			}
+		case a.DepartureAt != nil:
+			return -1
+		case b.DepartureAt != nil:
+			return 1
+		}
+
+		return cmp.Compare(a.Name, b.Name)
+	})
+
+	return agenda, nil
+}
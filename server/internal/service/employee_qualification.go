@@ -0,0 +1,198 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/goncalo-marques/ecomap/server/internal/domain"
+	"github.com/goncalo-marques/ecomap/server/internal/logging"
+)
+
+const (
+	descriptionFailedCreateEmployeeQualification         = "service: failed to create employee qualification"
+	descriptionFailedListEmployeeQualifications          = "service: failed to list employee qualifications"
+	descriptionFailedListEmployeesQualificationsExpiring = "service: failed to list employees qualifications expiring"
+	descriptionFailedDeleteEmployeeQualificationByID     = "service: failed to delete employee qualification by id"
+)
+
+// CreateEmployeeQualification records a qualification of the employee with the specified identifier. License
+// qualifications must define the license category, while other qualifications must not.
+func (s *service) CreateEmployeeQualification(ctx context.Context, employeeID uuid.UUID, editableQualification domain.EditableEmployeeQualification) (domain.EmployeeQualification, error) {
+	logAttrs := []any{
+		slog.String(logging.ServiceMethod, "CreateEmployeeQualification"),
+		slog.String(logging.EmployeeID, employeeID.String()),
+		slog.String(logging.EmployeeQualificationType, string(editableQualification.Type)),
+		slog.String(logging.EmployeeQualificationExpiryDate, editableQualification.ExpiryDate.Format(time.DateOnly)),
+	}
+
+	if editableQualification.LicenseCategory != nil {
+		logAttrs = append(logAttrs, slog.String(logging.EmployeeQualificationLicenseCategory, string(*editableQualification.LicenseCategory)))
+	}
+
+	if !editableQualification.Type.Valid() {
+		return domain.EmployeeQualification{}, logInfoAndWrapError(ctx, &domain.ErrFieldValueInvalid{FieldName: domain.FieldType}, descriptionInvalidFieldValue, logAttrs...)
+	}
+	isLicense := editableQualification.Type == domain.EmployeeQualificationTypeLicense
+	if isLicense != (editableQualification.LicenseCategory != nil) ||
+		editableQualification.LicenseCategory != nil && !editableQualification.LicenseCategory.Valid() {
+		return domain.EmployeeQualification{}, logInfoAndWrapError(ctx, &domain.ErrFieldValueInvalid{FieldName: domain.FieldLicenseCategory}, descriptionInvalidFieldValue, logAttrs...)
+	}
+	if editableQualification.ExpiryDate.IsZero() {
+		return domain.EmployeeQualification{}, logInfoAndWrapError(ctx, &domain.ErrFieldValueInvalid{FieldName: domain.FieldExpiryDate}, descriptionInvalidFieldValue, logAttrs...)
+	}
+
+	var qualification domain.EmployeeQualification
+
+	err := s.readWriteTx(ctx, func(tx pgx.Tx) error {
+		id, err := s.store.CreateEmployeeQualification(ctx, tx, employeeID, editableQualification)
+		if err != nil {
+			return err
+		}
+
+		qualification, err = s.store.GetEmployeeQualificationByID(ctx, tx, employeeID, id)
+		if err != nil {
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrEmployeeNotFound),
+			errors.Is(err, domain.ErrEmployeeQualificationAlreadyExists):
+			return domain.EmployeeQualification{}, logInfoAndWrapError(ctx, err, descriptionFailedCreateEmployeeQualification, logAttrs...)
+		default:
+			return domain.EmployeeQualification{}, logAndWrapError(ctx, err, descriptionFailedCreateEmployeeQualification, logAttrs...)
+		}
+	}
+
+	return qualification, nil
+}
+
+// ListEmployeeQualifications returns the qualifications of the employee with the specified identifier, sorted by their
+// expiry date.
+func (s *service) ListEmployeeQualifications(ctx context.Context, employeeID uuid.UUID) (domain.EmployeeQualifications, error) {
+	logAttrs := []any{
+		slog.String(logging.ServiceMethod, "ListEmployeeQualifications"),
+		slog.String(logging.EmployeeID, employeeID.String()),
+	}
+
+	var qualifications domain.EmployeeQualifications
+
+	err := s.readOnlyTx(ctx, func(tx pgx.Tx) error {
+		_, err := s.store.GetEmployeeByID(ctx, tx, employeeID)
+		if err != nil {
+			return err
+		}
+
+		qualifications, err = s.store.ListEmployeeQualifications(ctx, tx, employeeID)
+		return err
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrEmployeeNotFound):
+			return nil, logInfoAndWrapError(ctx, err, descriptionFailedListEmployeeQualifications, logAttrs...)
+		default:
+			return nil, logAndWrapError(ctx, err, descriptionFailedListEmployeeQualifications, logAttrs...)
+		}
+	}
+
+	return qualifications, nil
+}
+
+// ListEmployeesQualificationsExpiring returns the qualifications of every employee that expire within the number of
+// days of the specified filter, including the ones that have already expired, sorted by their expiry date.
+func (s *service) ListEmployeesQualificationsExpiring(ctx context.Context, filter domain.EmployeeQualificationsExpiringFilter) ([]domain.EmployeeQualificationExpiring, error) {
+	logAttrs := []any{
+		slog.String(logging.ServiceMethod, "ListEmployeesQualificationsExpiring"),
+	}
+
+	if filter.WithinDays < 0 {
+		return nil, logInfoAndWrapError(ctx, &domain.ErrFilterValueInvalid{FilterName: domain.FieldFilterWithinDays}, descriptionInvalidFilterValue, logAttrs...)
+	}
+	if filter.Type != nil && !filter.Type.Valid() {
+		return nil, logInfoAndWrapError(ctx, &domain.ErrFilterValueInvalid{FilterName: domain.FieldType}, descriptionInvalidFilterValue, logAttrs...)
+	}
+
+	now := time.Now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	var qualificationsExpiring []domain.EmployeeQualificationExpiring
+
+	err := s.readOnlyTx(ctx, func(tx pgx.Tx) error {
+		qualifications, err := s.store.ListEmployeesQualificationsExpiring(ctx, tx, today.AddDate(0, 0, filter.WithinDays), filter.Type)
+		if err != nil {
+			return err
+		}
+
+		employeesByID := make(map[uuid.UUID]domain.Employee)
+
+		qualificationsExpiring = make([]domain.EmployeeQualificationExpiring, len(qualifications))
+		for i, qualification := range qualifications {
+			employee, ok := employeesByID[qualification.EmployeeID]
+			if !ok {
+				employee, err = s.store.GetEmployeeByID(ctx, tx, qualification.EmployeeID)
+				if err != nil {
+					return err
+				}
+
+				employeesByID[qualification.EmployeeID] = employee
+			}
+
+			qualificationsExpiring[i] = domain.EmployeeQualificationExpiring{
+				Qualification: qualification,
+				Employee:      employee,
+				RemainingDays: int(qualification.ExpiryDate.Sub(today).Hours() / 24),
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, logAndWrapError(ctx, err, descriptionFailedListEmployeesQualificationsExpiring, logAttrs...)
+	}
+
+	return qualificationsExpiring, nil
+}
+
+// DeleteEmployeeQualificationByID deletes the qualification with the specified identifier of the employee with the
+// specified identifier.
+func (s *service) DeleteEmployeeQualificationByID(ctx context.Context, employeeID, id uuid.UUID) (domain.EmployeeQualification, error) {
+	logAttrs := []any{
+		slog.String(logging.ServiceMethod, "DeleteEmployeeQualificationByID"),
+		slog.String(logging.EmployeeID, employeeID.String()),
+		slog.String(logging.EmployeeQualificationID, id.String()),
+	}
+
+	var qualification domain.EmployeeQualification
+	var err error
+
+	err = s.readWriteTx(ctx, func(tx pgx.Tx) error {
+		qualification, err = s.store.GetEmployeeQualificationByID(ctx, tx, employeeID, id)
+		if err != nil {
+			return err
+		}
+
+		err = s.store.DeleteEmployeeQualificationByID(ctx, tx, employeeID, id)
+		if err != nil {
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrEmployeeQualificationNotFound):
+			return domain.EmployeeQualification{}, logInfoAndWrapError(ctx, err, descriptionFailedDeleteEmployeeQualificationByID, logAttrs...)
+		default:
+			return domain.EmployeeQualification{}, logAndWrapError(ctx, err, descriptionFailedDeleteEmployeeQualificationByID, logAttrs...)
+		}
+	}
+
+	return qualification, nil
+}
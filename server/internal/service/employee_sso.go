@@ -0,0 +1,331 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/netip"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/goncalo-marques/ecomap/server/internal/authn"
+	"github.com/goncalo-marques/ecomap/server/internal/domain"
+	"github.com/goncalo-marques/ecomap/server/internal/logging"
+	"github.com/goncalo-marques/ecomap/server/internal/oidc"
+)
+
+const (
+	ssoAuthorizationExpirationTime = time.Minute * 10
+	ssoRandomValueLength           = 32
+
+	descriptionFailedCreateSSOAuthorization = "service: failed to create single sign-on authorization"
+	descriptionFailedSignInEmployeeSSO      = "service: failed to sign in employee with single sign-on"
+	descriptionFailedExchangeSSOCode        = "service: failed to exchange single sign-on authorization code"
+)
+
+// ssoDefaultEmployee defines the values of the employee fields that are not provided by the identity provider when an
+// employee is provisioned, which can be edited afterwards. The schedule is a regular working day, so that provisioned
+// employees are available for routes.
+var ssoDefaultEmployee = domain.EditableEmployee{
+	DateOfBirth: time.Date(1970, time.January, 1, 0, 0, 0, 0, time.UTC),
+	GeoJSON: domain.GeoJSONFeature{
+		Geometry: domain.GeoJSONGeometryPoint{
+			Coordinates: [2]float64{0, 0},
+		},
+	},
+	ScheduleStart: time.Date(0, time.January, 1, 9, 0, 0, 0, time.UTC),
+	ScheduleEnd:   time.Date(0, time.January, 1, 17, 0, 0, 0, time.UTC),
+}
+
+// CreateEmployeeSSOAuthorization returns the URL of the identity provider where the employee authenticates. The
+// authorization expires after 10 minutes.
+func (s *service) CreateEmployeeSSOAuthorization(ctx context.Context) (string, error) {
+	logAttrs := []any{
+		slog.String(logging.ServiceMethod, "CreateEmployeeSSOAuthorization"),
+	}
+
+	if s.idpService == nil {
+		return "", logInfoAndWrapError(ctx, domain.ErrSSONotConfigured, descriptionFailedCreateSSOAuthorization, logAttrs...)
+	}
+
+	authorization := domain.SSOAuthorization{
+		ExpiresAt: time.Now().Add(ssoAuthorizationExpirationTime).UTC(),
+	}
+
+	var err error
+	for _, value := range []*string{&authorization.State, &authorization.Nonce, &authorization.CodeVerifier} {
+		*value, err = randomString(ssoRandomValueLength)
+		if err != nil {
+			return "", logAndWrapError(ctx, err, descriptionFailedCreateSSOAuthorization, logAttrs...)
+		}
+	}
+
+	authorizationURL, err := s.idpService.AuthorizationURL(ctx, authorization.State, authorization.Nonce, authorization.CodeVerifier)
+	if err != nil {
+		return "", logAndWrapError(ctx, err, descriptionFailedCreateSSOAuthorization, logAttrs...)
+	}
+
+	err = s.readWriteTx(ctx, func(tx pgx.Tx) error {
+		err := s.store.DeleteExpiredSSOAuthorizations(ctx, tx, time.Now().UTC())
+		if err != nil {
+			return err
+		}
+
+		return s.store.CreateSSOAuthorization(ctx, tx, authorization)
+	})
+	if err != nil {
+		return "", logAndWrapError(ctx, err, descriptionFailedCreateSSOAuthorization, logAttrs...)
+	}
+
+	return authorizationURL, nil
+}
+
+// SignInEmployeeSSO returns an access token and a refresh token for the employee authenticated by the identity
+// provider, or a two-factor authentication challenge like SignInEmployee. The authorization code and state are the ones
+// returned by the identity provider. The role of the employee is obtained from the groups of the identity, and
+// employees that sign in for the first time are provisioned. Sign-in lockouts apply as well.
+func (s *service) SignInEmployeeSSO(ctx context.Context, code, state string, ipAddress netip.Addr) (domain.EmployeeSignInResult, error) {
+	logAttrs := []any{
+		slog.String(logging.ServiceMethod, "SignInEmployeeSSO"),
+		slog.String(logging.SignInIPAddress, ipAddress.String()),
+	}
+
+	if s.idpService == nil {
+		return domain.EmployeeSignInResult{}, logInfoAndWrapError(ctx, domain.ErrSSONotConfigured, descriptionFailedSignInEmployeeSSO, logAttrs...)
+	}
+
+	var authorization domain.SSOAuthorization
+	var err error
+
+	err = s.readWriteTx(ctx, func(tx pgx.Tx) error {
+		authorization, err = s.store.DeleteSSOAuthorization(ctx, tx, state)
+		return err
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrSSOAuthorizationInvalid):
+			return domain.EmployeeSignInResult{}, logInfoAndWrapError(ctx, err, descriptionFailedSignInEmployeeSSO, logAttrs...)
+		default:
+			return domain.EmployeeSignInResult{}, logAndWrapError(ctx, err, descriptionFailedSignInEmployeeSSO, logAttrs...)
+		}
+	}
+
+	if !time.Now().Before(authorization.ExpiresAt) {
+		return domain.EmployeeSignInResult{}, logInfoAndWrapError(ctx, domain.ErrSSOAuthorizationInvalid, descriptionFailedSignInEmployeeSSO, logAttrs...)
+	}
+
+	oidcIdentity, err := s.idpService.Exchange(ctx, code, authorization.CodeVerifier, authorization.Nonce)
+	if err != nil {
+		return domain.EmployeeSignInResult{}, logInfoAndWrapError(ctx, fmt.Errorf("%w: %w", domain.ErrSSOAuthorizationInvalid, err), descriptionFailedExchangeSSOCode, logAttrs...)
+	}
+
+	identity := ssoIdentityFromOIDC(oidcIdentity)
+	logAttrs = append(logAttrs,
+		slog.String(logging.EmployeeSSOSubject, identity.Subject),
+		slog.String(logging.EmployeeUsername, string(identity.Username)),
+	)
+
+	role, ok := s.ssoRole(identity.Groups)
+	if !ok {
+		return domain.EmployeeSignInResult{}, logInfoAndWrapError(ctx, domain.ErrSSORoleNotMapped, descriptionFailedSignInEmployeeSSO, logAttrs...)
+	}
+
+	var employee domain.Employee
+	var totp domain.EmployeeTOTP
+
+	err = s.readWriteTx(ctx, func(tx pgx.Tx) error {
+		employee, err = s.store.GetEmployeeBySSOSubject(ctx, tx, identity.Subject)
+		if err != nil {
+			if !errors.Is(err, domain.ErrEmployeeNotFound) {
+				return err
+			}
+
+			employee, err = s.provisionSSOEmployee(ctx, tx, identity, role)
+			if err != nil {
+				return err
+			}
+		}
+
+		if employee.Role != role {
+			err = s.store.UpdateEmployeeRole(ctx, tx, employee.ID, role)
+			if err != nil {
+				return err
+			}
+
+			employee.Role = role
+		}
+
+		totp, err = s.store.GetEmployeeTOTP(ctx, tx, employee.ID)
+		if err != nil && !errors.Is(err, domain.ErrEmployeeTOTPNotFound) {
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		var domainErrFieldValueInvalid *domain.ErrFieldValueInvalid
+
+		switch {
+		case errors.Is(err, domain.ErrEmployeeAlreadyExists),
+			errors.As(err, &domainErrFieldValueInvalid):
+			return domain.EmployeeSignInResult{}, logInfoAndWrapError(ctx, err, descriptionFailedSignInEmployeeSSO, logAttrs...)
+		default:
+			return domain.EmployeeSignInResult{}, logAndWrapError(ctx, err, descriptionFailedSignInEmployeeSSO, logAttrs...)
+		}
+	}
+
+	err = s.checkSignInLockouts(ctx, domain.SubjectTypeEmployee, employee.Username, ipAddress, logAttrs...)
+	if err != nil {
+		return domain.EmployeeSignInResult{}, err
+	}
+
+	// The identity provider only replaces the password, so the second factor is still required, as in SignInEmployee.
+	enrolmentRequired := !totp.Enabled() && slices.Contains(s.options.TOTPRequiredRoles, employee.Role)
+	if totp.Enabled() || enrolmentRequired {
+		var challenge domain.MFAChallenge
+
+		err = s.readWriteTx(ctx, func(tx pgx.Tx) error {
+			challenge, err = s.createEmployeeMFAChallenge(ctx, tx, employee.ID, enrolmentRequired)
+			return err
+		})
+		if err != nil {
+			return domain.EmployeeSignInResult{}, logAndWrapError(ctx, err, descriptionFailedCreateEmployeeMFAChallenge, logAttrs...)
+		}
+
+		return domain.EmployeeSignInResult{MFAChallenge: &challenge}, nil
+	}
+
+	s.clearSignInFailures(ctx, domain.SubjectTypeEmployee, employee.Username, logAttrs...)
+
+	var tokens domain.Tokens
+
+	err = s.readWriteTx(ctx, func(tx pgx.Tx) error {
+		tokens, err = s.createTokens(ctx, tx, domain.SubjectTypeEmployee, employee.ID, []authn.SubjectRole{employeeSubjectRole(employee.Role)}, uuid.New())
+		return err
+	})
+	if err != nil {
+		return domain.EmployeeSignInResult{}, logAndWrapError(ctx, err, descriptionFailedCreateTokens, logAttrs...)
+	}
+
+	return domain.EmployeeSignInResult{Tokens: tokens}, nil
+}
+
+// provisionSSOEmployee creates an employee for the given identity, linked to its subject. The employee is created
+// with an unknown random password, so that it can only sign in with the identity provider.
+func (s *service) provisionSSOEmployee(ctx context.Context, tx pgx.Tx, identity domain.SSOIdentity, role domain.EmployeeRole) (domain.Employee, error) {
+	if !identity.Username.Valid() {
+		return domain.Employee{}, &domain.ErrFieldValueInvalid{FieldName: domain.FieldUsername}
+	}
+	if !identity.FirstName.Valid() {
+		return domain.Employee{}, &domain.ErrFieldValueInvalid{FieldName: domain.FieldFirstName}
+	}
+	if !identity.LastName.Valid() {
+		return domain.Employee{}, &domain.ErrFieldValueInvalid{FieldName: domain.FieldLastName}
+	}
+
+	password, err := randomString(ssoRandomValueLength)
+	if err != nil {
+		return domain.Employee{}, err
+	}
+
+	hashedPassword, err := s.authnService.HashPassword([]byte(password))
+	if err != nil {
+		return domain.Employee{}, err
+	}
+
+	editableEmployee := ssoDefaultEmployee
+	editableEmployee.Username = identity.Username
+	editableEmployee.FirstName = identity.FirstName
+	editableEmployee.LastName = identity.LastName
+	editableEmployee.Role = role
+
+	id, err := s.store.CreateEmployee(ctx, tx, domain.EditableEmployeeWithPassword{
+		EditableEmployee: editableEmployee,
+		Password:         domain.Password(hashedPassword),
+	}, nil, nil)
+	if err != nil {
+		return domain.Employee{}, err
+	}
+
+	err = s.store.UpdateEmployeeSSOSubject(ctx, tx, id, identity.Subject)
+	if err != nil {
+		return domain.Employee{}, err
+	}
+
+	return s.store.GetEmployeeByID(ctx, tx, id)
+}
+
+// ssoRole returns the employee role mapped to the given identity provider groups. When the groups are mapped to
+// multiple roles, the manager role takes precedence.
+func (s *service) ssoRole(groups []string) (domain.EmployeeRole, bool) {
+	var role domain.EmployeeRole
+
+	for _, group := range groups {
+		groupRole, ok := s.options.SSOGroupsRoles[group]
+		if !ok {
+			continue
+		}
+
+		if groupRole == domain.EmployeeRoleManager {
+			return groupRole, true
+		}
+
+		role = groupRole
+	}
+
+	return role, role.Valid()
+}
+
+// ssoIdentityFromOIDC returns a single sign-on identity based on the OpenID Connect identity. The username is the
+// preferred username of the identity, falling back to its email and subject, and the names fall back to the username.
+func ssoIdentityFromOIDC(identity oidc.Identity) domain.SSOIdentity {
+	username := identity.PreferredUsername
+	if len(username) == 0 {
+		username = identity.Email
+	}
+	if len(username) == 0 {
+		username = identity.Subject
+	}
+	username = strings.ToLower(replaceSpacesWithHyphen(username))
+
+	firstName, lastName := identity.GivenName, identity.FamilyName
+	if len(firstName) == 0 || len(lastName) == 0 {
+		names := strings.Fields(identity.Name)
+		if len(names) != 0 {
+			firstName, lastName = names[0], names[len(names)-1]
+		}
+	}
+	if len(firstName) == 0 {
+		firstName = username
+	}
+	if len(lastName) == 0 {
+		lastName = firstName
+	}
+
+	return domain.SSOIdentity{
+		Subject:   identity.Subject,
+		Username:  domain.Username(username),
+		FirstName: domain.Name(removeExtraSpaces(firstName)),
+		LastName:  domain.Name(removeExtraSpaces(lastName)),
+		Groups:    identity.Groups,
+	}
+}
+
+// randomString returns a random URL-safe string encoding the specified amount of random bytes.
+func randomString(length int) (string, error) {
+	b := make([]byte, length)
+
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,431 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/netip"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/goncalo-marques/ecomap/server/internal/authn"
+	"github.com/goncalo-marques/ecomap/server/internal/domain"
+	"github.com/goncalo-marques/ecomap/server/internal/logging"
+)
+
+const (
+	// defaultTOTPIssuer defines the default issuer shown by authenticator applications.
+	defaultTOTPIssuer = "EcoMap"
+
+	employeeRecoveryCodesAmount        = 10
+	employeeMFAChallengeExpirationTime = 5 * time.Minute
+	employeeMFAChallengeMaxAttempts    = 5
+
+	descriptionFailedCreateEmployeeTOTP                   = "service: failed to create employee totp"
+	descriptionFailedConfirmEmployeeTOTP                  = "service: failed to confirm employee totp"
+	descriptionFailedDeleteEmployeeTOTP                   = "service: failed to delete employee totp"
+	descriptionFailedGetEmployeeTOTP                      = "service: failed to get employee totp"
+	descriptionFailedCreateEmployeeRecoveryCodes          = "service: failed to create employee recovery codes"
+	descriptionFailedCreateEmployeeMFAChallenge           = "service: failed to create employee mfa challenge"
+	descriptionFailedGetEmployeeMFAChallenge              = "service: failed to get employee mfa challenge"
+	descriptionFailedIncrementEmployeeMFAChallengeAttempt = "service: failed to increment employee mfa challenge attempts"
+	descriptionFailedCreateEmployeeSignInTOTP             = "service: failed to create employee sign-in totp"
+	descriptionFailedSignInEmployeeMFA                    = "service: failed to sign in employee with mfa"
+)
+
+// CreateEmployeeTOTP creates a pending time-based one-time password enrolment for the employee with the specified
+// identifier, replacing any previous pending enrolment. The enrolment must be confirmed with a valid code.
+func (s *service) CreateEmployeeTOTP(ctx context.Context, employeeID uuid.UUID) (domain.TOTPEnrolment, error) {
+	logAttrs := []any{
+		slog.String(logging.ServiceMethod, "CreateEmployeeTOTP"),
+		slog.String(logging.EmployeeID, employeeID.String()),
+	}
+
+	var enrolment domain.TOTPEnrolment
+
+	err := s.readWriteTx(ctx, func(tx pgx.Tx) error {
+		employee, err := s.store.GetEmployeeByID(ctx, tx, employeeID)
+		if err != nil {
+			return err
+		}
+
+		enrolment, err = s.enrolEmployeeTOTP(ctx, tx, employee)
+		return err
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrEmployeeNotFound),
+			errors.Is(err, domain.ErrEmployeeTOTPAlreadyEnabled):
+			return domain.TOTPEnrolment{}, logInfoAndWrapError(ctx, err, descriptionFailedCreateEmployeeTOTP, logAttrs...)
+		default:
+			return domain.TOTPEnrolment{}, logAndWrapError(ctx, err, descriptionFailedCreateEmployeeTOTP, logAttrs...)
+		}
+	}
+
+	return enrolment, nil
+}
+
+// ConfirmEmployeeTOTP confirms the pending time-based one-time password enrolment of the employee with the specified
+// identifier with a valid code, which enables two-factor authentication, and returns new recovery codes.
+func (s *service) ConfirmEmployeeTOTP(ctx context.Context, employeeID uuid.UUID, code string) ([]string, error) {
+	logAttrs := []any{
+		slog.String(logging.ServiceMethod, "ConfirmEmployeeTOTP"),
+		slog.String(logging.EmployeeID, employeeID.String()),
+	}
+
+	var recoveryCodes []string
+
+	err := s.readWriteTx(ctx, func(tx pgx.Tx) error {
+		totp, err := s.store.GetEmployeeTOTP(ctx, tx, employeeID)
+		if err != nil {
+			return err
+		}
+
+		if totp.Enabled() {
+			return domain.ErrEmployeeTOTPAlreadyEnabled
+		}
+
+		step, ok := s.authnService.CheckTOTP(totp.Secret, code, time.Now())
+		if !ok {
+			return domain.ErrEmployeeTOTPCodeInvalid
+		}
+
+		err = s.store.ConfirmEmployeeTOTP(ctx, tx, employeeID, step, time.Now().UTC())
+		if err != nil {
+			return err
+		}
+
+		recoveryCodes, err = s.replaceEmployeeRecoveryCodes(ctx, tx, employeeID)
+		return err
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrEmployeeTOTPNotFound),
+			errors.Is(err, domain.ErrEmployeeTOTPAlreadyEnabled),
+			errors.Is(err, domain.ErrEmployeeTOTPCodeInvalid):
+			return nil, logInfoAndWrapError(ctx, err, descriptionFailedConfirmEmployeeTOTP, logAttrs...)
+		default:
+			return nil, logAndWrapError(ctx, err, descriptionFailedConfirmEmployeeTOTP, logAttrs...)
+		}
+	}
+
+	return recoveryCodes, nil
+}
+
+// DeleteEmployeeTOTP deletes the time-based one-time password enrolment and the recovery codes of the employee with
+// the specified identifier, which disables two-factor authentication.
+func (s *service) DeleteEmployeeTOTP(ctx context.Context, employeeID uuid.UUID) error {
+	logAttrs := []any{
+		slog.String(logging.ServiceMethod, "DeleteEmployeeTOTP"),
+		slog.String(logging.EmployeeID, employeeID.String()),
+	}
+
+	err := s.readWriteTx(ctx, func(tx pgx.Tx) error {
+		err := s.store.DeleteEmployeeTOTP(ctx, tx, employeeID)
+		if err != nil {
+			return err
+		}
+
+		return s.store.DeleteEmployeeRecoveryCodes(ctx, tx, employeeID)
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrEmployeeTOTPNotFound):
+			return logInfoAndWrapError(ctx, err, descriptionFailedDeleteEmployeeTOTP, logAttrs...)
+		default:
+			return logAndWrapError(ctx, err, descriptionFailedDeleteEmployeeTOTP, logAttrs...)
+		}
+	}
+
+	return nil
+}
+
+// CreateEmployeeRecoveryCodes returns new recovery codes for the employee with the specified identifier, which must
+// have two-factor authentication enabled. The previous recovery codes can no longer be used.
+func (s *service) CreateEmployeeRecoveryCodes(ctx context.Context, employeeID uuid.UUID) ([]string, error) {
+	logAttrs := []any{
+		slog.String(logging.ServiceMethod, "CreateEmployeeRecoveryCodes"),
+		slog.String(logging.EmployeeID, employeeID.String()),
+	}
+
+	var recoveryCodes []string
+
+	err := s.readWriteTx(ctx, func(tx pgx.Tx) error {
+		totp, err := s.store.GetEmployeeTOTP(ctx, tx, employeeID)
+		if err != nil {
+			return err
+		}
+
+		if !totp.Enabled() {
+			return domain.ErrEmployeeTOTPNotFound
+		}
+
+		recoveryCodes, err = s.replaceEmployeeRecoveryCodes(ctx, tx, employeeID)
+		return err
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrEmployeeTOTPNotFound):
+			return nil, logInfoAndWrapError(ctx, err, descriptionFailedCreateEmployeeRecoveryCodes, logAttrs...)
+		default:
+			return nil, logAndWrapError(ctx, err, descriptionFailedCreateEmployeeRecoveryCodes, logAttrs...)
+		}
+	}
+
+	return recoveryCodes, nil
+}
+
+// CreateEmployeeSignInTOTP creates a pending time-based one-time password enrolment for the employee of the specified
+// two-factor authentication challenge, which must require the enrolment. The enrolment is confirmed by answering the
+// challenge with a valid code.
+func (s *service) CreateEmployeeSignInTOTP(ctx context.Context, challengeToken string) (domain.TOTPEnrolment, error) {
+	logAttrs := []any{
+		slog.String(logging.ServiceMethod, "CreateEmployeeSignInTOTP"),
+	}
+
+	var enrolment domain.TOTPEnrolment
+
+	err := s.readWriteTx(ctx, func(tx pgx.Tx) error {
+		challenge, err := s.store.GetEmployeeMFAChallengeByHash(ctx, tx, s.authnService.HashOneTimeToken(challengeToken))
+		if err != nil {
+			return err
+		}
+
+		if !challenge.Valid(time.Now(), employeeMFAChallengeMaxAttempts) || !challenge.EnrolmentRequired {
+			return domain.ErrMFAChallengeInvalid
+		}
+
+		employee, err := s.store.GetEmployeeByID(ctx, tx, challenge.EmployeeID)
+		if err != nil {
+			return err
+		}
+
+		enrolment, err = s.enrolEmployeeTOTP(ctx, tx, employee)
+		return err
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrMFAChallengeInvalid),
+			errors.Is(err, domain.ErrEmployeeTOTPAlreadyEnabled):
+			return domain.TOTPEnrolment{}, logInfoAndWrapError(ctx, err, descriptionFailedCreateEmployeeSignInTOTP, logAttrs...)
+		default:
+			return domain.TOTPEnrolment{}, logAndWrapError(ctx, err, descriptionFailedCreateEmployeeSignInTOTP, logAttrs...)
+		}
+	}
+
+	return enrolment, nil
+}
+
+// SignInEmployeeMFA answers the two-factor authentication challenge obtained by signing in an employee with the
+// specified code, which is either a time-based one-time password or an unused recovery code, and returns an access
+// token and a refresh token, along with new recovery codes when the code confirms the enrolment required to sign in.
+// Failed attempts count towards the sign-in lockouts of the employee, and the challenge is invalidated after too many
+// of them.
+func (s *service) SignInEmployeeMFA(ctx context.Context, challengeToken, code string, ipAddress netip.Addr) (domain.EmployeeMFASignInResult, error) {
+	logAttrs := []any{
+		slog.String(logging.ServiceMethod, "SignInEmployeeMFA"),
+		slog.String(logging.SignInIPAddress, ipAddress.String()),
+	}
+
+	now := time.Now()
+
+	var challenge domain.EmployeeMFAChallenge
+	var employee domain.Employee
+	var totp domain.EmployeeTOTP
+	var err error
+
+	err = s.readOnlyTx(ctx, func(tx pgx.Tx) error {
+		challenge, err = s.store.GetEmployeeMFAChallengeByHash(ctx, tx, s.authnService.HashOneTimeToken(challengeToken))
+		if err != nil {
+			return err
+		}
+
+		employee, err = s.store.GetEmployeeByID(ctx, tx, challenge.EmployeeID)
+		if err != nil {
+			return err
+		}
+
+		totp, err = s.store.GetEmployeeTOTP(ctx, tx, challenge.EmployeeID)
+		if err != nil && !errors.Is(err, domain.ErrEmployeeTOTPNotFound) {
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrMFAChallengeInvalid):
+			return domain.EmployeeMFASignInResult{}, logInfoAndWrapError(ctx, err, descriptionFailedGetEmployeeMFAChallenge, logAttrs...)
+		default:
+			return domain.EmployeeMFASignInResult{}, logAndWrapError(ctx, err, descriptionFailedGetEmployeeMFAChallenge, logAttrs...)
+		}
+	}
+
+	logAttrs = append(logAttrs,
+		slog.String(logging.EmployeeID, employee.ID.String()),
+		slog.String(logging.EmployeeUsername, string(employee.Username)),
+	)
+
+	if !challenge.Valid(now, employeeMFAChallengeMaxAttempts) {
+		return domain.EmployeeMFASignInResult{}, logInfoAndWrapError(ctx, domain.ErrMFAChallengeInvalid, descriptionFailedSignInEmployeeMFA, logAttrs...)
+	}
+
+	err = s.checkSignInLockouts(ctx, domain.SubjectTypeEmployee, employee.Username, ipAddress, logAttrs...)
+	if err != nil {
+		return domain.EmployeeMFASignInResult{}, err
+	}
+
+	var result domain.EmployeeMFASignInResult
+
+	err = s.readWriteTx(ctx, func(tx pgx.Tx) error {
+		err := s.store.DeleteEmployeeMFAChallenge(ctx, tx, challenge.ID)
+		if err != nil {
+			return err
+		}
+
+		result.RecoveryCodes, err = s.useEmployeeMFACode(ctx, tx, challenge, totp, code, now)
+		if err != nil {
+			return err
+		}
+
+		result.Tokens, err = s.createTokens(ctx, tx, domain.SubjectTypeEmployee, employee.ID, []authn.SubjectRole{employeeSubjectRole(employee.Role)}, uuid.New())
+		return err
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrEmployeeTOTPCodeInvalid):
+			err := s.readCommittedTx(ctx, func(tx pgx.Tx) error {
+				return s.store.IncrementEmployeeMFAChallengeAttempts(ctx, tx, challenge.ID)
+			})
+			if err != nil {
+				logging.Logger.ErrorContext(ctx, descriptionFailedIncrementEmployeeMFAChallengeAttempt, append(logAttrs, logging.Error(err))...)
+			}
+
+			s.recordSignInFailure(ctx, domain.SubjectTypeEmployee, employee.Username, ipAddress, logAttrs...)
+			return domain.EmployeeMFASignInResult{}, logInfoAndWrapError(ctx, domain.ErrEmployeeTOTPCodeInvalid, descriptionFailedSignInEmployeeMFA, logAttrs...)
+		case errors.Is(err, domain.ErrMFAChallengeInvalid):
+			return domain.EmployeeMFASignInResult{}, logInfoAndWrapError(ctx, err, descriptionFailedSignInEmployeeMFA, logAttrs...)
+		default:
+			return domain.EmployeeMFASignInResult{}, logAndWrapError(ctx, err, descriptionFailedSignInEmployeeMFA, logAttrs...)
+		}
+	}
+
+	s.clearSignInFailures(ctx, domain.SubjectTypeEmployee, employee.Username, logAttrs...)
+
+	return result, nil
+}
+
+// createEmployeeMFAChallenge creates a two-factor authentication challenge for the employee with the specified
+// identifier, which expires after 5 minutes. Expired challenges are deleted whenever a new challenge is created.
+func (s *service) createEmployeeMFAChallenge(ctx context.Context, tx pgx.Tx, employeeID uuid.UUID, enrolmentRequired bool) (domain.MFAChallenge, error) {
+	err := s.store.DeleteExpiredEmployeeMFAChallenges(ctx, tx, time.Now().UTC())
+	if err != nil {
+		return domain.MFAChallenge{}, err
+	}
+
+	challengeToken, err := s.authnService.NewOneTimeToken(employeeMFAChallengeExpirationTime)
+	if err != nil {
+		return domain.MFAChallenge{}, err
+	}
+
+	_, err = s.store.CreateEmployeeMFAChallenge(ctx, tx, domain.EditableEmployeeMFAChallenge{
+		EmployeeID:        employeeID,
+		Hash:              challengeToken.Hash,
+		EnrolmentRequired: enrolmentRequired,
+		ExpiresAt:         challengeToken.ExpiresAt,
+	})
+	if err != nil {
+		return domain.MFAChallenge{}, err
+	}
+
+	return domain.MFAChallenge{
+		Token:             challengeToken.Token,
+		ExpiresAt:         challengeToken.ExpiresAt,
+		EnrolmentRequired: enrolmentRequired,
+	}, nil
+}
+
+// useEmployeeMFACode uses the code that answers the specified challenge. A time-based one-time password confirms the
+// pending enrolment when the challenge requires it, returning the new recovery codes, and otherwise must be newer than
+// the last used one. Any other code is used as a recovery code.
+func (s *service) useEmployeeMFACode(ctx context.Context, tx pgx.Tx, challenge domain.EmployeeMFAChallenge, totp domain.EmployeeTOTP, code string, at time.Time) ([]string, error) {
+	if len(totp.Secret) != 0 {
+		if step, ok := s.authnService.CheckTOTP(totp.Secret, code, at); ok {
+			if totp.Enabled() {
+				return nil, s.store.UseEmployeeTOTPStep(ctx, tx, totp.EmployeeID, step)
+			}
+			if !challenge.EnrolmentRequired {
+				return nil, domain.ErrEmployeeTOTPCodeInvalid
+			}
+
+			err := s.store.ConfirmEmployeeTOTP(ctx, tx, totp.EmployeeID, step, at.UTC())
+			if err != nil {
+				return nil, err
+			}
+
+			return s.replaceEmployeeRecoveryCodes(ctx, tx, totp.EmployeeID)
+		}
+	}
+
+	if !totp.Enabled() {
+		return nil, domain.ErrEmployeeTOTPCodeInvalid
+	}
+
+	return nil, s.store.UseEmployeeRecoveryCode(ctx, tx, totp.EmployeeID, s.authnService.HashRecoveryCode(code), at.UTC())
+}
+
+// enrolEmployeeTOTP creates a pending time-based one-time password enrolment for the given employee, unless two-factor
+// authentication is already enabled.
+func (s *service) enrolEmployeeTOTP(ctx context.Context, tx pgx.Tx, employee domain.Employee) (domain.TOTPEnrolment, error) {
+	totp, err := s.store.GetEmployeeTOTP(ctx, tx, employee.ID)
+	if err != nil && !errors.Is(err, domain.ErrEmployeeTOTPNotFound) {
+		return domain.TOTPEnrolment{}, err
+	}
+
+	if totp.Enabled() {
+		return domain.TOTPEnrolment{}, domain.ErrEmployeeTOTPAlreadyEnabled
+	}
+
+	secret, err := s.authnService.NewTOTPSecret()
+	if err != nil {
+		return domain.TOTPEnrolment{}, err
+	}
+
+	err = s.store.PutEmployeeTOTP(ctx, tx, employee.ID, secret)
+	if err != nil {
+		return domain.TOTPEnrolment{}, err
+	}
+
+	return domain.TOTPEnrolment{
+		Secret: secret,
+		URI:    s.authnService.TOTPURI(s.options.TOTPIssuer, string(employee.Username), secret),
+	}, nil
+}
+
+// replaceEmployeeRecoveryCodes replaces the recovery codes of the employee with the specified identifier and returns
+// the new ones, of which only the hashes are stored.
+func (s *service) replaceEmployeeRecoveryCodes(ctx context.Context, tx pgx.Tx, employeeID uuid.UUID) ([]string, error) {
+	recoveryCodes, err := s.authnService.NewRecoveryCodes(employeeRecoveryCodesAmount)
+	if err != nil {
+		return nil, err
+	}
+
+	hashes := make([][]byte, len(recoveryCodes))
+	for i, recoveryCode := range recoveryCodes {
+		hashes[i] = s.authnService.HashRecoveryCode(recoveryCode)
+	}
+
+	err = s.store.DeleteEmployeeRecoveryCodes(ctx, tx, employeeID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.store.CreateEmployeeRecoveryCodes(ctx, tx, employeeID, hashes)
+	if err != nil {
+		return nil, err
+	}
+
+	return recoveryCodes, nil
+}
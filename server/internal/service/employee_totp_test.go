@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+	"crypto/ed25519"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goncalo-marques/ecomap/server/internal/authn"
+	"github.com/goncalo-marques/ecomap/server/internal/domain"
+)
+
+// fakeTOTPStore defines a store that only keeps the last used time step of a confirmed enrolment in memory.
+type fakeTOTPStore struct {
+	Store
+	lastUsedStep *int64
+}
+
+func (s *fakeTOTPStore) UseEmployeeTOTPStep(ctx context.Context, tx pgx.Tx, employeeID uuid.UUID, step int64) error {
+	if s.lastUsedStep != nil && *s.lastUsedStep >= step {
+		return domain.ErrEmployeeTOTPCodeInvalid
+	}
+
+	s.lastUsedStep = &step
+	return nil
+}
+
+func (s *fakeTOTPStore) UseEmployeeRecoveryCode(ctx context.Context, tx pgx.Tx, employeeID uuid.UUID, hash []byte, usedAt time.Time) error {
+	return domain.ErrEmployeeTOTPCodeInvalid
+}
+
+// newTestAuthnService returns a new authentication service with a single signing key.
+func newTestAuthnService(t *testing.T) AuthenticationService {
+	t.Helper()
+
+	_, privateKey, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+
+	authnService, err := authn.New([]authn.Key{{
+		ID:         "test",
+		Algorithm:  authn.KeyAlgorithmEdDSA,
+		PrivateKey: privateKey,
+	}}, 0)
+	require.NoError(t, err)
+
+	return authnService
+}
+
+func TestUseEmployeeMFACodeReplay(t *testing.T) {
+	// Codes of the RFC 6238 test vectors, truncated to 6 digits, at the time steps 37037036 and 37037037.
+	const (
+		secret           = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+		codeStep37037036 = "081804"
+		codeStep37037037 = "050471"
+	)
+
+	type use struct {
+		code    string
+		time    int64
+		wantErr error
+	}
+
+	tests := []struct {
+		name string
+		uses []use
+	}{
+		{
+			name: "code used once",
+			uses: []use{
+				{code: codeStep37037037, time: 1111111111},
+			},
+		},
+		{
+			name: "code used twice in the same step",
+			uses: []use{
+				{code: codeStep37037037, time: 1111111111},
+				{code: codeStep37037037, time: 1111111111, wantErr: domain.ErrEmployeeTOTPCodeInvalid},
+			},
+		},
+		{
+			name: "code used again in the next step",
+			uses: []use{
+				{code: codeStep37037037, time: 1111111111},
+				{code: codeStep37037037, time: 1111111141, wantErr: domain.ErrEmployeeTOTPCodeInvalid},
+			},
+		},
+		{
+			name: "code of the previous step after the code of a later step",
+			uses: []use{
+				{code: codeStep37037037, time: 1111111111},
+				{code: codeStep37037036, time: 1111111111, wantErr: domain.ErrEmployeeTOTPCodeInvalid},
+			},
+		},
+		{
+			name: "code of the next step after the code of an earlier step",
+			uses: []use{
+				{code: codeStep37037036, time: 1111111109},
+				{code: codeStep37037037, time: 1111111109},
+			},
+		},
+	}
+
+	authnService := newTestAuthnService(t)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &fakeTOTPStore{}
+			s := New(authnService, nil, nil, nil, store, Options{})
+
+			confirmedAt := time.Unix(0, 0)
+			totp := domain.EmployeeTOTP{
+				EmployeeID:  uuid.New(),
+				Secret:      secret,
+				ConfirmedAt: &confirmedAt,
+			}
+
+			for _, u := range tt.uses {
+				recoveryCodes, err := s.useEmployeeMFACode(context.Background(), fakeTx{}, domain.EmployeeMFAChallenge{}, totp, u.code, time.Unix(u.time, 0))
+				require.ErrorIs(t, err, u.wantErr)
+				require.Empty(t, recoveryCodes)
+			}
+		})
+	}
+}
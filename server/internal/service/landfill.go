@@ -26,6 +26,21 @@ func (s *service) CreateLandfill(ctx context.Context, editableLandfill domain.Ed
 		slog.String(logging.ServiceMethod, "CreateLandfill"),
 	}
 
+	if editableLandfill.Name != nil && !editableLandfill.Name.Valid() {
+		return domain.Landfill{}, logInfoAndWrapError(ctx, &domain.ErrFieldValueInvalid{FieldName: domain.FieldName}, descriptionInvalidFieldValue, logAttrs...)
+	}
+	if !editableLandfill.AcceptedCategories.Valid() {
+		return domain.Landfill{}, logInfoAndWrapError(ctx, &domain.ErrFieldValueInvalid{FieldName: domain.FieldAcceptedCategories}, descriptionInvalidFieldValue, logAttrs...)
+	}
+	if editableLandfill.DailyCapacity != nil && !editableLandfill.DailyCapacity.Valid() {
+		return domain.Landfill{}, logInfoAndWrapError(ctx, &domain.ErrFieldValueInvalid{FieldName: domain.FieldDailyCapacity}, descriptionInvalidFieldValue, logAttrs...)
+	}
+
+	// By default, the landfill accepts all the container categories.
+	if editableLandfill.AcceptedCategories == nil {
+		editableLandfill.AcceptedCategories = domain.ContainerCategories()
+	}
+
 	var landfill domain.Landfill
 
 	err := s.readWriteTx(ctx, func(tx pgx.Tx) error {
@@ -136,6 +151,16 @@ func (s *service) PatchLandfill(ctx context.Context, id uuid.UUID, editableLandf
 		slog.String(logging.LandfillID, id.String()),
 	}
 
+	if editableLandfill.Name != nil && !editableLandfill.Name.Valid() {
+		return domain.Landfill{}, logInfoAndWrapError(ctx, &domain.ErrFieldValueInvalid{FieldName: domain.FieldName}, descriptionInvalidFieldValue, logAttrs...)
+	}
+	if !editableLandfill.AcceptedCategories.Valid() {
+		return domain.Landfill{}, logInfoAndWrapError(ctx, &domain.ErrFieldValueInvalid{FieldName: domain.FieldAcceptedCategories}, descriptionInvalidFieldValue, logAttrs...)
+	}
+	if editableLandfill.DailyCapacity != nil && !editableLandfill.DailyCapacity.Valid() {
+		return domain.Landfill{}, logInfoAndWrapError(ctx, &domain.ErrFieldValueInvalid{FieldName: domain.FieldDailyCapacity}, descriptionInvalidFieldValue, logAttrs...)
+	}
+
 	var landfill domain.Landfill
 
 	err := s.readWriteTx(ctx, func(tx pgx.Tx) error {
@@ -1,10 +1,13 @@
 package service
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"log/slog"
+	"slices"
 
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 
 	"github.com/goncalo-marques/ecomap/server/internal/domain"
@@ -12,7 +15,8 @@ import (
 )
 
 const (
-	descriptionFailedGetMunicipalityByGeometry = "service: failed to get municipality by geometry"
+	descriptionFailedGetMunicipalityByGeometry   = "service: failed to get municipality by geometry"
+	descriptionFailedListMunicipalitiesEmissions = "service: failed to list municipalities emissions"
 )
 
 // GetMunicipalityByGeometry returns the municipality that contains the given geometry point.
@@ -39,3 +43,82 @@ func (s *service) GetMunicipalityByGeometry(ctx context.Context, geometry domain
 
 	return municipality, nil
 }
+
+// ListMunicipalitiesEmissions returns the emissions of every route aggregated by the municipality of the departure
+// warehouse, sorted by the name of the municipality. Routes departing from warehouses outside any municipality, or for
+// which no landfill accepts every container category, are not accounted for.
+func (s *service) ListMunicipalitiesEmissions(ctx context.Context) ([]domain.MunicipalityEmissions, error) {
+	logAttrs := []any{
+		slog.String(logging.ServiceMethod, "ListMunicipalitiesEmissions"),
+	}
+
+	var municipalitiesEmissions []domain.MunicipalityEmissions
+
+	err := s.readWriteTx(ctx, func(tx pgx.Tx) error {
+		routes, err := s.listAllRoutes(ctx, tx)
+		if err != nil {
+			return err
+		}
+
+		// Warehouses usually depart multiple routes, so the municipality of each one is only computed once.
+		municipalitiesByWarehouseID := make(map[uuid.UUID]*domain.Municipality)
+		emissionsIndexesByMunicipalityID := make(map[int]int)
+
+		for _, route := range routes {
+			municipality, ok := municipalitiesByWarehouseID[route.DepartureWarehouse.ID]
+			if !ok {
+				m, err := s.store.GetMunicipalityByGeometry(ctx, tx, geometryPointFromGeoJSON(route.DepartureWarehouse.GeoJSON))
+				if err != nil && !errors.Is(err, domain.ErrMunicipalityNotFound) {
+					return err
+				}
+				if err == nil {
+					municipality = &m
+				}
+
+				municipalitiesByWarehouseID[route.DepartureWarehouse.ID] = municipality
+			}
+
+			if municipality == nil {
+				continue
+			}
+
+			routeEmissions, err := s.routeEmissions(ctx, tx, route)
+			if err != nil {
+				if errors.Is(err, domain.ErrRouteLandfillNotFound) || errors.Is(err, domain.ErrRouteStopUnreachable) || errors.Is(err, domain.ErrRoutePayloadCapacityExceeded) {
+					continue
+				}
+
+				return err
+			}
+
+			i, ok := emissionsIndexesByMunicipalityID[municipality.ID]
+			if !ok {
+				i = len(municipalitiesEmissions)
+				emissionsIndexesByMunicipalityID[municipality.ID] = i
+				municipalitiesEmissions = append(municipalitiesEmissions, domain.MunicipalityEmissions{
+					Municipality: *municipality,
+				})
+			}
+
+			municipalitiesEmissions[i].Routes++
+			municipalitiesEmissions[i].Distance += routeEmissions.Distance
+
+			if routeEmissions.Fuel != nil && routeEmissions.CO2 != nil {
+				municipalitiesEmissions[i].EstimatedRoutes++
+				municipalitiesEmissions[i].Fuel += *routeEmissions.Fuel
+				municipalitiesEmissions[i].CO2 += *routeEmissions.CO2
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, logAndWrapError(ctx, err, descriptionFailedListMunicipalitiesEmissions, logAttrs...)
+	}
+
+	slices.SortFunc(municipalitiesEmissions, func(a, b domain.MunicipalityEmissions) int {
+		return cmp.Compare(a.Municipality.Name, b.Municipality.Name)
+	})
+
+	return municipalitiesEmissions, nil
+}
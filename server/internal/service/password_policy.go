@@ -0,0 +1,166 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/goncalo-marques/ecomap/server/internal/domain"
+)
+
+const descriptionFailedCheckBreachedPassword = "service: failed to check breached password"
+
+// Default password policy, used when the policy of a principal type is not defined.
+const (
+	defaultPasswordMinLength       = 14
+	defaultPasswordMaxLength       = 256
+	defaultPasswordMinDigits       = 1
+	defaultPasswordMinLetters      = 1
+	defaultPasswordMinSpecialChars = 1
+)
+
+// defaultPasswordPolicy returns the given password policy, or the default one if it is not defined. The maximum length
+// is always limited to the default, which is the maximum length accepted by the password hashing.
+func defaultPasswordPolicy(policy domain.PasswordPolicy) domain.PasswordPolicy {
+	if policy == (domain.PasswordPolicy{}) {
+		policy = domain.PasswordPolicy{
+			MinLength:       defaultPasswordMinLength,
+			MinDigits:       defaultPasswordMinDigits,
+			MinLetters:      defaultPasswordMinLetters,
+			MinSpecialChars: defaultPasswordMinSpecialChars,
+		}
+	}
+	if policy.MaxLength <= 0 || policy.MaxLength > defaultPasswordMaxLength {
+		policy.MaxLength = defaultPasswordMaxLength
+	}
+
+	return policy
+}
+
+// checkPasswordPolicy returns an error with the rule of the password policy that the password violates, nil
+// otherwise. The password history is checked separately, since it requires a transaction.
+func (s *service) checkPasswordPolicy(ctx context.Context, policy domain.PasswordPolicy, password domain.Password, logAttrs ...any) error {
+	err := policy.Check(password)
+	if err != nil {
+		return logInfoAndWrapError(ctx, err, descriptionInvalidFieldValue, logAttrs...)
+	}
+
+	if !policy.CheckBreached || s.breachedPasswords == nil {
+		return nil
+	}
+
+	breached, err := s.breachedPasswords.Breached(ctx, []byte(password))
+	if err != nil {
+		return logAndWrapError(ctx, err, descriptionFailedCheckBreachedPassword, logAttrs...)
+	}
+
+	if breached {
+		return logInfoAndWrapError(ctx, &domain.ErrPasswordPolicyViolated{Rule: domain.PasswordRuleNotBreached}, descriptionInvalidFieldValue, logAttrs...)
+	}
+
+	return nil
+}
+
+// checkPasswordReused returns an error if the password matches any of the given hashed passwords.
+func (s *service) checkPasswordReused(password domain.Password, hashes []domain.Password) error {
+	for _, hash := range hashes {
+		reused, err := s.authnService.CheckPasswordHash([]byte(password), []byte(hash))
+		if err != nil {
+			return err
+		}
+
+		if reused {
+			return &domain.ErrPasswordPolicyViolated{Rule: domain.PasswordRuleNotReused}
+		}
+	}
+
+	return nil
+}
+
+// updateUserPassword hashes and updates the password of the user with the specified username, as long as it is not
+// one of the most recent passwords of the user, as defined by the password history of the user password policy.
+func (s *service) updateUserPassword(ctx context.Context, tx pgx.Tx, username domain.Username, password domain.Password) error {
+	historySize := s.options.UserPasswordPolicy.HistorySize
+
+	var signIn domain.SignIn
+	var history []domain.Password
+	var err error
+
+	if historySize > 0 {
+		signIn, err = s.store.GetUserSignIn(ctx, tx, username)
+		if err != nil {
+			return err
+		}
+
+		history, err = s.store.ListUserPasswordHistory(ctx, tx, username, historySize-1)
+		if err != nil {
+			return err
+		}
+
+		err = s.checkPasswordReused(password, append([]domain.Password{signIn.Password}, history...))
+		if err != nil {
+			return err
+		}
+	}
+
+	hashedPassword, err := s.authnService.HashPassword([]byte(password))
+	if err != nil {
+		return fmt.Errorf("%s: %w", descriptionFailedHashPassword, err)
+	}
+
+	err = s.store.UpdateUserPassword(ctx, tx, username, domain.Password(hashedPassword))
+	if err != nil {
+		return err
+	}
+
+	if historySize > 1 {
+		return s.store.AddUserPasswordHistory(ctx, tx, username, signIn.Password, historySize-1)
+	}
+
+	return nil
+}
+
+// updateEmployeePassword hashes and updates the password of the employee with the specified username, as long as it
+// is not one of the most recent passwords of the employee, as defined by the password history of the employee password
+// policy.
+func (s *service) updateEmployeePassword(ctx context.Context, tx pgx.Tx, username domain.Username, password domain.Password) error {
+	historySize := s.options.EmployeePasswordPolicy.HistorySize
+
+	var signIn domain.SignIn
+	var history []domain.Password
+	var err error
+
+	if historySize > 0 {
+		signIn, err = s.store.GetEmployeeSignIn(ctx, tx, username)
+		if err != nil {
+			return err
+		}
+
+		history, err = s.store.ListEmployeePasswordHistory(ctx, tx, username, historySize-1)
+		if err != nil {
+			return err
+		}
+
+		err = s.checkPasswordReused(password, append([]domain.Password{signIn.Password}, history...))
+		if err != nil {
+			return err
+		}
+	}
+
+	hashedPassword, err := s.authnService.HashPassword([]byte(password))
+	if err != nil {
+		return fmt.Errorf("%s: %w", descriptionFailedHashPassword, err)
+	}
+
+	err = s.store.UpdateEmployeePassword(ctx, tx, username, domain.Password(hashedPassword))
+	if err != nil {
+		return err
+	}
+
+	if historySize > 1 {
+		return s.store.AddEmployeePasswordHistory(ctx, tx, username, signIn.Password, historySize-1)
+	}
+
+	return nil
+}
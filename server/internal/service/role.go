@@ -0,0 +1,285 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/goncalo-marques/ecomap/server/internal/authn"
+	"github.com/goncalo-marques/ecomap/server/internal/domain"
+	"github.com/goncalo-marques/ecomap/server/internal/logging"
+)
+
+const (
+	descriptionFailedCreateRole             = "service: failed to create role"
+	descriptionFailedListRoles              = "service: failed to list roles"
+	descriptionFailedGetRoleByID            = "service: failed to get role by id"
+	descriptionFailedPatchRole              = "service: failed to patch role"
+	descriptionFailedDeleteRoleByID         = "service: failed to delete role by id"
+	descriptionFailedListSubjectPermissions = "service: failed to list subject permissions"
+)
+
+// CreateRole creates a new role with the specified data. The role cannot grant permissions that the grantor does not
+// hold.
+func (s *service) CreateRole(ctx context.Context, editableRole domain.EditableRole, grantorPermissions []domain.Permission) (domain.Role, error) {
+	logAttrs := []any{
+		slog.String(logging.ServiceMethod, "CreateRole"),
+		slog.String(logging.RoleName, string(editableRole.Name)),
+		slog.Any(logging.RolePermissions, editableRole.Permissions),
+	}
+
+	if !editableRole.Name.Valid() {
+		return domain.Role{}, logInfoAndWrapError(ctx, &domain.ErrFieldValueInvalid{FieldName: domain.FieldName}, descriptionInvalidFieldValue, logAttrs...)
+	}
+	if !editableRole.Permissions.Valid() {
+		return domain.Role{}, logInfoAndWrapError(ctx, &domain.ErrFieldValueInvalid{FieldName: domain.FieldPermissions}, descriptionInvalidFieldValue, logAttrs...)
+	}
+	if !editableRole.Permissions.HeldBy(grantorPermissions) {
+		return domain.Role{}, logInfoAndWrapError(ctx, domain.ErrRoleNotGrantable, descriptionFailedCreateRole, logAttrs...)
+	}
+
+	var role domain.Role
+
+	err := s.readWriteTx(ctx, func(tx pgx.Tx) error {
+		id, err := s.store.CreateRole(ctx, tx, editableRole)
+		if err != nil {
+			return err
+		}
+
+		role, err = s.store.GetRoleByID(ctx, tx, id)
+		return err
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrRoleAlreadyExists):
+			return domain.Role{}, logInfoAndWrapError(ctx, err, descriptionFailedCreateRole, logAttrs...)
+		default:
+			return domain.Role{}, logAndWrapError(ctx, err, descriptionFailedCreateRole, logAttrs...)
+		}
+	}
+
+	return role, nil
+}
+
+// ListRoles returns every role.
+func (s *service) ListRoles(ctx context.Context) ([]domain.Role, error) {
+	logAttrs := []any{
+		slog.String(logging.ServiceMethod, "ListRoles"),
+	}
+
+	var roles []domain.Role
+	var err error
+
+	err = s.readOnlyTx(ctx, func(tx pgx.Tx) error {
+		roles, err = s.store.ListRoles(ctx, tx)
+		return err
+	})
+	if err != nil {
+		return nil, logAndWrapError(ctx, err, descriptionFailedListRoles, logAttrs...)
+	}
+
+	return roles, nil
+}
+
+// GetRoleByID returns the role with the specified identifier.
+func (s *service) GetRoleByID(ctx context.Context, id uuid.UUID) (domain.Role, error) {
+	logAttrs := []any{
+		slog.String(logging.ServiceMethod, "GetRoleByID"),
+		slog.String(logging.RoleID, id.String()),
+	}
+
+	var role domain.Role
+	var err error
+
+	err = s.readOnlyTx(ctx, func(tx pgx.Tx) error {
+		role, err = s.store.GetRoleByID(ctx, tx, id)
+		return err
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrRoleNotFound):
+			return domain.Role{}, logInfoAndWrapError(ctx, err, descriptionFailedGetRoleByID, logAttrs...)
+		default:
+			return domain.Role{}, logAndWrapError(ctx, err, descriptionFailedGetRoleByID, logAttrs...)
+		}
+	}
+
+	return role, nil
+}
+
+// PatchRole modifies the role with the specified identifier. The permissions to manage roles cannot be removed from
+// built-in roles, so that roles can always be managed, and the modified role cannot grant permissions that the grantor
+// does not hold.
+func (s *service) PatchRole(ctx context.Context, id uuid.UUID, editableRole domain.EditableRolePatch, grantorPermissions []domain.Permission) (domain.Role, error) {
+	logAttrs := []any{
+		slog.String(logging.ServiceMethod, "PatchRole"),
+		slog.String(logging.RoleID, id.String()),
+	}
+
+	if editableRole.Permissions != nil {
+		logAttrs = append(logAttrs, slog.Any(logging.RolePermissions, *editableRole.Permissions))
+
+		if !editableRole.Permissions.Valid() {
+			return domain.Role{}, logInfoAndWrapError(ctx, &domain.ErrFieldValueInvalid{FieldName: domain.FieldPermissions}, descriptionInvalidFieldValue, logAttrs...)
+		}
+	}
+
+	var role domain.Role
+
+	err := s.readWriteTx(ctx, func(tx pgx.Tx) error {
+		currentRole, err := s.store.GetRoleByID(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		if currentRole.BuiltIn && editableRole.Permissions != nil &&
+			slices.Contains(currentRole.Permissions, domain.PermissionRolesWrite) &&
+			!slices.Contains(*editableRole.Permissions, domain.PermissionRolesWrite) {
+			return &domain.ErrFieldValueInvalid{FieldName: domain.FieldPermissions}
+		}
+
+		permissions := currentRole.Permissions
+		if editableRole.Permissions != nil {
+			permissions = *editableRole.Permissions
+		}
+
+		if !permissions.HeldBy(grantorPermissions) {
+			return domain.ErrRoleNotGrantable
+		}
+
+		err = s.store.PatchRole(ctx, tx, id, editableRole)
+		if err != nil {
+			return err
+		}
+
+		role, err = s.store.GetRoleByID(ctx, tx, id)
+		return err
+	})
+	if err != nil {
+		var domainErrFieldValueInvalid *domain.ErrFieldValueInvalid
+
+		switch {
+		case errors.As(err, &domainErrFieldValueInvalid):
+			return domain.Role{}, logInfoAndWrapError(ctx, err, descriptionInvalidFieldValue, logAttrs...)
+		case errors.Is(err, domain.ErrRoleNotFound),
+			errors.Is(err, domain.ErrRoleNotGrantable):
+			return domain.Role{}, logInfoAndWrapError(ctx, err, descriptionFailedPatchRole, logAttrs...)
+		default:
+			return domain.Role{}, logAndWrapError(ctx, err, descriptionFailedPatchRole, logAttrs...)
+		}
+	}
+
+	return role, nil
+}
+
+// DeleteRoleByID deletes the role with the specified identifier. Built-in roles and roles granted to API keys or
+// employees cannot be deleted.
+func (s *service) DeleteRoleByID(ctx context.Context, id uuid.UUID) (domain.Role, error) {
+	logAttrs := []any{
+		slog.String(logging.ServiceMethod, "DeleteRoleByID"),
+		slog.String(logging.RoleID, id.String()),
+	}
+
+	var role domain.Role
+	var err error
+
+	err = s.readWriteTx(ctx, func(tx pgx.Tx) error {
+		role, err = s.store.GetRoleByID(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		if role.BuiltIn {
+			return domain.ErrRoleBuiltIn
+		}
+
+		assigned, err := s.store.ExistsAPIKeyWithRole(ctx, tx, role.Name)
+		if err != nil {
+			return err
+		}
+
+		if assigned {
+			return domain.ErrRoleAssigned
+		}
+
+		assigned, err = s.store.ExistsEmployeeWithRole(ctx, tx, role.Name)
+		if err != nil {
+			return err
+		}
+
+		if assigned {
+			return domain.ErrRoleAssigned
+		}
+
+		return s.store.DeleteRoleByID(ctx, tx, id)
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrRoleNotFound),
+			errors.Is(err, domain.ErrRoleBuiltIn),
+			errors.Is(err, domain.ErrRoleAssigned):
+			return domain.Role{}, logInfoAndWrapError(ctx, err, descriptionFailedDeleteRoleByID, logAttrs...)
+		default:
+			return domain.Role{}, logAndWrapError(ctx, err, descriptionFailedDeleteRoleByID, logAttrs...)
+		}
+	}
+
+	return role, nil
+}
+
+// listRolesByName returns the roles with the specified names, or an invalid value error of the given field when any of
+// them does not exist.
+func (s *service) listRolesByName(ctx context.Context, tx pgx.Tx, names []domain.RoleName, fieldName string) ([]domain.Role, error) {
+	roles, err := s.store.ListRolesByName(ctx, tx, names)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(roles) != len(names) {
+		return nil, &domain.ErrFieldValueInvalid{FieldName: fieldName}
+	}
+
+	return roles, nil
+}
+
+// checkRolesGrantable returns an error if any of the roles grants a permission that the grantor does not hold, so that
+// subjects cannot grant more than they are granted.
+func checkRolesGrantable(roles []domain.Role, grantorPermissions []domain.Permission) error {
+	for _, role := range roles {
+		if !role.Permissions.HeldBy(grantorPermissions) {
+			return domain.ErrRoleNotGrantable
+		}
+	}
+
+	return nil
+}
+
+// SubjectPermissions returns the permissions granted by the specified subject roles. Roles that do not exist grant no
+// permissions.
+func (s *service) SubjectPermissions(ctx context.Context, roles []authn.SubjectRole) ([]domain.Permission, error) {
+	logAttrs := []any{
+		slog.String(logging.ServiceMethod, "SubjectPermissions"),
+	}
+
+	names := make([]domain.RoleName, len(roles))
+	for i, role := range roles {
+		names[i] = domain.RoleName(role)
+	}
+
+	var permissions []domain.Permission
+	var err error
+
+	err = s.readOnlyTx(ctx, func(tx pgx.Tx) error {
+		permissions, err = s.store.ListRolesPermissions(ctx, tx, names)
+		return err
+	})
+	if err != nil {
+		return nil, logAndWrapError(ctx, err, descriptionFailedListSubjectPermissions, logAttrs...)
+	}
+
+	return permissions, nil
+}
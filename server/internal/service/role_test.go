@@ -0,0 +1,169 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goncalo-marques/ecomap/server/internal/domain"
+)
+
+// fakeTx defines a transaction that commits and rolls back without a database.
+type fakeTx struct {
+	pgx.Tx
+}
+
+func (fakeTx) Commit(ctx context.Context) error   { return nil }
+func (fakeTx) Rollback(ctx context.Context) error { return nil }
+
+// fakeRoleStore defines a store that only keeps roles in memory.
+type fakeRoleStore struct {
+	Store
+	roles map[uuid.UUID]domain.Role
+}
+
+func (s *fakeRoleStore) NewTx(ctx context.Context, isoLevel pgx.TxIsoLevel, accessMode pgx.TxAccessMode) (pgx.Tx, error) {
+	return fakeTx{}, nil
+}
+
+func (s *fakeRoleStore) CreateRole(ctx context.Context, tx pgx.Tx, editableRole domain.EditableRole) (uuid.UUID, error) {
+	id := uuid.New()
+	s.roles[id] = domain.Role{EditableRole: editableRole, ID: id}
+	return id, nil
+}
+
+func (s *fakeRoleStore) GetRoleByID(ctx context.Context, tx pgx.Tx, id uuid.UUID) (domain.Role, error) {
+	role, ok := s.roles[id]
+	if !ok {
+		return domain.Role{}, domain.ErrRoleNotFound
+	}
+
+	return role, nil
+}
+
+func (s *fakeRoleStore) PatchRole(ctx context.Context, tx pgx.Tx, id uuid.UUID, editableRole domain.EditableRolePatch) error {
+	role := s.roles[id]
+	if editableRole.Permissions != nil {
+		role.Permissions = *editableRole.Permissions
+	}
+
+	s.roles[id] = role
+	return nil
+}
+
+// routePlannerPermissions defines the permissions of a subject that manages roles without being a manager.
+var routePlannerPermissions = []domain.Permission{
+	domain.PermissionRolesRead,
+	domain.PermissionRolesWrite,
+	domain.PermissionRoutesRead,
+	domain.PermissionRoutesPlan,
+	domain.PermissionEmployeesReadOwn,
+}
+
+func TestCreateRole(t *testing.T) {
+	tests := []struct {
+		name        string
+		permissions domain.RolePermissions
+		wantErr     error
+	}{
+		{
+			name:        "held permissions",
+			permissions: domain.RolePermissions{domain.PermissionRoutesRead, domain.PermissionRoutesPlan},
+		},
+		{
+			name:        "permission restricted to the subject",
+			permissions: domain.RolePermissions{domain.PermissionRoutesRead, domain.PermissionEmployeesReadOwn},
+		},
+		{
+			name:        "api key permission",
+			permissions: domain.RolePermissions{domain.PermissionRoutesRead, domain.PermissionAPIKeysWrite},
+			wantErr:     domain.ErrRoleNotGrantable,
+		},
+		{
+			name:        "unrestricted permission of a permission restricted to the subject",
+			permissions: domain.RolePermissions{domain.PermissionEmployeesRead},
+			wantErr:     domain.ErrRoleNotGrantable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &fakeRoleStore{roles: make(map[uuid.UUID]domain.Role)}
+			s := New(nil, nil, nil, nil, store, Options{})
+
+			role, err := s.CreateRole(context.Background(), domain.EditableRole{
+				Name:        "custom",
+				Permissions: tt.permissions,
+			}, routePlannerPermissions)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				require.Empty(t, store.roles)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.permissions, role.Permissions)
+		})
+	}
+}
+
+func TestPatchRole(t *testing.T) {
+	heldPermissions := domain.RolePermissions{domain.PermissionRolesRead, domain.PermissionRolesWrite, domain.PermissionRoutesRead}
+	managerPermissions := domain.RolePermissions{domain.PermissionRolesRead, domain.PermissionRolesWrite, domain.PermissionAPIKeysWrite}
+
+	tests := []struct {
+		name               string
+		currentPermissions domain.RolePermissions
+		permissions        *domain.RolePermissions
+		wantErr            error
+	}{
+		{
+			name:               "held permissions",
+			currentPermissions: heldPermissions,
+			permissions:        &domain.RolePermissions{domain.PermissionRolesRead, domain.PermissionRolesWrite, domain.PermissionRoutesPlan},
+		},
+		{
+			name:               "api key permission added",
+			currentPermissions: heldPermissions,
+			permissions:        &domain.RolePermissions{domain.PermissionRolesRead, domain.PermissionRolesWrite, domain.PermissionAPIKeysWrite},
+			wantErr:            domain.ErrRoleNotGrantable,
+		},
+		{
+			name:               "role with permissions not held left unchanged",
+			currentPermissions: managerPermissions,
+			wantErr:            domain.ErrRoleNotGrantable,
+		},
+		{
+			name:               "permissions not held removed",
+			currentPermissions: managerPermissions,
+			permissions:        &heldPermissions,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id := uuid.New()
+			store := &fakeRoleStore{roles: map[uuid.UUID]domain.Role{
+				id: {
+					EditableRole: domain.EditableRole{Name: domain.RoleName(domain.EmployeeRoleWasteOperator), Permissions: tt.currentPermissions},
+					ID:           id,
+					BuiltIn:      true,
+				},
+			}}
+			s := New(nil, nil, nil, nil, store, Options{})
+
+			role, err := s.PatchRole(context.Background(), id, domain.EditableRolePatch{Permissions: tt.permissions}, routePlannerPermissions)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				require.Equal(t, tt.currentPermissions, store.roles[id].Permissions)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, *tt.permissions, role.Permissions)
+		})
+	}
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"errors"
 	"log/slog"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -14,16 +16,23 @@ import (
 )
 
 const (
-	descriptionFailedCreateRoute     = "service: failed to create route"
-	descriptionFailedListRoutes      = "service: failed to list routes"
-	descriptionFailedGetRouteByID    = "service: failed to get route by id"
-	descriptionFailedPatchRoute      = "service: failed to patch route"
-	descriptionFailedDeleteRouteByID = "service: failed to delete route by id"
-	descriptionFailedGetRouteRoads   = "service: failed to get route roads"
+	descriptionFailedCreateRoute        = "service: failed to create route"
+	descriptionFailedListRoutes         = "service: failed to list routes"
+	descriptionFailedGetRouteByID       = "service: failed to get route by id"
+	descriptionFailedPatchRoute         = "service: failed to patch route"
+	descriptionFailedDeleteRouteByID    = "service: failed to delete route by id"
+	descriptionFailedGetRouteRoads      = "service: failed to get route roads"
+	descriptionFailedGetRouteStops      = "service: failed to get route stops"
+	descriptionFailedGetRouteCategories = "service: failed to get route categories"
+	descriptionFailedGetRouteEmissions  = "service: failed to get route emissions"
+	descriptionFailedStartRoute         = "service: failed to start route"
+	descriptionFailedFinishRoute        = "service: failed to finish route"
 )
 
 const (
+	listRoutesPaginatedLimit          = 100
 	listRouteContainersPaginatedLimit = 100
+	routePlanImprovementMaxPasses     = 3
 )
 
 // CreateRoute creates a new route with the specified data.
@@ -43,6 +52,15 @@ func (s *service) CreateRoute(ctx context.Context, editableRoute domain.Editable
 	var route domain.Route
 
 	err := s.readWriteTx(ctx, func(tx pgx.Tx) error {
+		truck, err := s.store.GetTruckByID(ctx, tx, editableRoute.TruckID)
+		if err != nil {
+			return err
+		}
+
+		if !truck.Available() {
+			return domain.ErrRouteTruckUnavailable
+		}
+
 		id, err := s.store.CreateRoute(ctx, tx, editableRoute)
 		if err != nil {
 			return err
@@ -59,7 +77,8 @@ func (s *service) CreateRoute(ctx context.Context, editableRoute domain.Editable
 		switch {
 		case errors.Is(err, domain.ErrTruckNotFound),
 			errors.Is(err, domain.ErrRouteDepartureWarehouseNotFound),
-			errors.Is(err, domain.ErrRouteArrivalWarehouseNotFound):
+			errors.Is(err, domain.ErrRouteArrivalWarehouseNotFound),
+			errors.Is(err, domain.ErrRouteTruckUnavailable):
 			return domain.Route{}, logInfoAndWrapError(ctx, err, descriptionFailedGetRouteByID, logAttrs...)
 		default:
 			return domain.Route{}, logAndWrapError(ctx, err, descriptionFailedCreateRoute, logAttrs...)
@@ -149,6 +168,10 @@ func (s *service) PatchRoute(ctx context.Context, id uuid.UUID, editableRoute do
 				return err
 			}
 
+			if !truck.Available() {
+				return domain.ErrRouteTruckUnavailable
+			}
+
 			routeEmployees, err := s.store.ListRouteEmployees(ctx, tx, id, domain.RouteEmployeesPaginatedFilter{})
 			if err != nil {
 				return err
@@ -157,6 +180,15 @@ func (s *service) PatchRoute(ctx context.Context, id uuid.UUID, editableRoute do
 			if int(truck.PersonCapacity) < routeEmployees.Total {
 				return domain.ErrRouteTruckPersonCapacityMinLimit
 			}
+
+			routeContainers, err := s.listAllRouteContainers(ctx, tx, id)
+			if err != nil {
+				return err
+			}
+
+			if !truck.AcceptsCategories(containersCategories(routeContainers)) {
+				return domain.ErrRouteTruckCompartmentsIncompatible
+			}
 		}
 
 		err = s.store.PatchRoute(ctx, tx, id, editableRoute)
@@ -177,7 +209,9 @@ func (s *service) PatchRoute(ctx context.Context, id uuid.UUID, editableRoute do
 			errors.Is(err, domain.ErrTruckNotFound),
 			errors.Is(err, domain.ErrRouteDepartureWarehouseNotFound),
 			errors.Is(err, domain.ErrRouteArrivalWarehouseNotFound),
-			errors.Is(err, domain.ErrRouteTruckPersonCapacityMinLimit):
+			errors.Is(err, domain.ErrRouteTruckPersonCapacityMinLimit),
+			errors.Is(err, domain.ErrRouteTruckCompartmentsIncompatible),
+			errors.Is(err, domain.ErrRouteTruckUnavailable):
 			return domain.Route{}, logInfoAndWrapError(ctx, err, descriptionFailedPatchRoute, logAttrs...)
 		default:
 			return domain.Route{}, logAndWrapError(ctx, err, descriptionFailedPatchRoute, logAttrs...)
@@ -222,146 +256,361 @@ func (s *service) DeleteRouteByID(ctx context.Context, id uuid.UUID) (domain.Rou
 	return route, nil
 }
 
-// GetRouteRoads returns the route roads using the TSP and A* algorithms. The route starts at the departure warehouse,
-// passes through all the route containers, and before terminating at the arrival warehouse, passes through the nearest
-// landfill to the arrival warehouse.
-func (s *service) GetRouteRoads(ctx context.Context, id uuid.UUID) (domain.GeoJSON, error) {
+// StartRoute starts the route with the specified identifier. While the route is in progress, the positions of its truck
+// are used to detect the collection of its containers.
+func (s *service) StartRoute(ctx context.Context, id uuid.UUID) (domain.Route, error) {
 	logAttrs := []any{
-		slog.String(logging.ServiceMethod, "GetRouteRoads"),
+		slog.String(logging.ServiceMethod, "StartRoute"),
 		slog.String(logging.RouteID, id.String()),
 	}
 
-	var roadsGeometry []domain.GeoJSONGeometryLineString
+	var route domain.Route
+	var err error
 
-	err := s.readWriteTx(ctx, func(tx pgx.Tx) error {
-		route, err := s.store.GetRouteByID(ctx, tx, id)
+	err = s.readWriteTx(ctx, func(tx pgx.Tx) error {
+		route, err = s.store.GetRouteByID(ctx, tx, id)
 		if err != nil {
 			return err
 		}
 
-		var containersGeometry []domain.GeoJSONGeometryPoint
-
-		for {
-			routeContainers, err := s.store.ListRouteContainers(ctx, tx, route.ID, domain.RouteContainersPaginatedFilter{
-				PaginatedRequest: domain.PaginatedRequest[domain.RouteContainerPaginatedSort]{
-					Limit:  listRouteContainersPaginatedLimit,
-					Offset: domain.PaginationOffset(len(containersGeometry)),
-				},
-			})
-			if err != nil {
-				return err
-			}
+		if route.InProgress() {
+			return domain.ErrRouteInProgress
+		}
 
-			for _, result := range routeContainers.Results {
-				containerGeometry := geometryPointFromGeoJSON(result.GeoJSON)
-				containersGeometry = append(containersGeometry, containerGeometry)
-			}
+		if !route.Truck.Available() {
+			return domain.ErrRouteTruckUnavailable
+		}
 
-			if len(routeContainers.Results) < listRouteContainersPaginatedLimit {
-				break
-			}
+		err = s.validateRouteCrewQualifications(ctx, tx, route, time.Now().UTC())
+		if err != nil {
+			return err
 		}
 
-		// Early return when the route does not contain any containers associated.
-		if len(containersGeometry) == 0 {
-			return nil
+		// Route times are stored in UTC, as the truck positions.
+		err = s.store.StartRoute(ctx, tx, id, time.Now().UTC())
+		if err != nil {
+			return err
 		}
 
-		departureWarehouse, err := s.store.GetWarehouseByID(ctx, tx, route.DepartureWarehouse.ID)
+		route, err = s.store.GetRouteByID(ctx, tx, id)
 		if err != nil {
 			return err
 		}
 
-		departureWarehouseGeometry := geometryPointFromGeoJSON(departureWarehouse.GeoJSON)
+		return nil
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrRouteNotFound),
+			errors.Is(err, domain.ErrRouteInProgress),
+			errors.Is(err, domain.ErrRouteTruckUnavailable),
+			errors.Is(err, domain.ErrRouteEmployeeLicenseMissing),
+			errors.Is(err, domain.ErrRouteHazardousMaterialsCertificationMissing):
+			return domain.Route{}, logInfoAndWrapError(ctx, err, descriptionFailedStartRoute, logAttrs...)
+		default:
+			return domain.Route{}, logAndWrapError(ctx, err, descriptionFailedStartRoute, logAttrs...)
+		}
+	}
+
+	return route, nil
+}
+
+// FinishRoute finishes the route with the specified identifier.
+func (s *service) FinishRoute(ctx context.Context, id uuid.UUID) (domain.Route, error) {
+	logAttrs := []any{
+		slog.String(logging.ServiceMethod, "FinishRoute"),
+		slog.String(logging.RouteID, id.String()),
+	}
+
+	var route domain.Route
+	var err error
 
-		arrivalWarehouse, err := s.store.GetWarehouseByID(ctx, tx, route.ArrivalWarehouse.ID)
+	err = s.readWriteTx(ctx, func(tx pgx.Tx) error {
+		route, err = s.store.GetRouteByID(ctx, tx, id)
 		if err != nil {
 			return err
 		}
 
-		arrivalWarehouseGeometry := geometryPointFromGeoJSON(arrivalWarehouse.GeoJSON)
+		if !route.InProgress() {
+			return domain.ErrRouteNotInProgress
+		}
 
-		var landfillGeometry *domain.GeoJSONGeometryPoint
-		landfill, err := s.store.GetLandfillClosestGeometry(ctx, tx, arrivalWarehouseGeometry)
+		err = s.store.FinishRoute(ctx, tx, id, time.Now().UTC())
 		if err != nil {
-			if !errors.Is(err, domain.ErrLandfillNotFound) {
-				return err
-			}
-		} else {
-			geometry := geometryPointFromGeoJSON(landfill.GeoJSON)
-			landfillGeometry = &geometry
+			return err
 		}
 
-		verticesGeometry := make([]domain.GeoJSONGeometryPoint, 0, len(containersGeometry)+3)
+		route, err = s.store.GetRouteByID(ctx, tx, id)
+		if err != nil {
+			return err
+		}
 
-		verticesGeometry = append(verticesGeometry, containersGeometry...)
-		verticesGeometry = append(verticesGeometry, departureWarehouseGeometry)
-		if landfillGeometry != nil {
-			verticesGeometry = append(verticesGeometry, *landfillGeometry)
+		return nil
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrRouteNotFound),
+			errors.Is(err, domain.ErrRouteNotInProgress):
+			return domain.Route{}, logInfoAndWrapError(ctx, err, descriptionFailedFinishRoute, logAttrs...)
+		default:
+			return domain.Route{}, logAndWrapError(ctx, err, descriptionFailedFinishRoute, logAttrs...)
 		}
-		verticesGeometry = append(verticesGeometry, arrivalWarehouseGeometry)
+	}
 
-		// tempTableNameRoadNetwork defines the name of the road network temporary table.
-		// It contains a random suffix to avoid conflicts in the same database session.
-		tempTableNameRoadNetwork := "road_network_temp_" + strings.ReplaceAll(uuid.New().String(), "-", "")
+	return route, nil
+}
 
-		err = s.store.CreateTemporaryTableRoadNetworkWithBuffer(ctx, tx, tempTableNameRoadNetwork, verticesGeometry)
+// GetRouteRoads returns the route roads using the TSP and A* algorithms, following the stops of the route plan.
+func (s *service) GetRouteRoads(ctx context.Context, id uuid.UUID) (domain.GeoJSON, error) {
+	logAttrs := []any{
+		slog.String(logging.ServiceMethod, "GetRouteRoads"),
+		slog.String(logging.RouteID, id.String()),
+	}
+
+	var roadsGeometry []domain.GeoJSONGeometryLineString
+
+	err := s.readWriteTx(ctx, func(tx pgx.Tx) error {
+		route, err := s.store.GetRouteByID(ctx, tx, id)
 		if err != nil {
 			return err
 		}
 
-		vertexIDs, err := s.store.CreateVerticesCloseToRoadNetwork(ctx, tx, tempTableNameRoadNetwork, verticesGeometry)
+		plan, err := s.planRoute(ctx, tx, route)
 		if err != nil {
 			return err
 		}
 
-		var tspStartVertexID int
-		var tspEndVertexID int
-		var tspVertexIDs []int
+		// Compute the roads based on the sequential vertices.
+		roadsGeometry, err = s.store.GetRoadsGeometryAStar(ctx, tx, plan.roadNetworkTableName, plan.stopsVertexIDs, true)
+		if err != nil {
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrRouteNotFound),
+			errors.Is(err, domain.ErrRouteLandfillNotFound),
+			errors.Is(err, domain.ErrRouteStopUnreachable),
+			errors.Is(err, domain.ErrRoutePayloadCapacityExceeded):
+			return nil, logInfoAndWrapError(ctx, err, descriptionFailedGetRouteRoads, logAttrs...)
+		default:
+			return nil, logAndWrapError(ctx, err, descriptionFailedGetRouteRoads, logAttrs...)
+		}
+	}
+
+	return roadsGeoJSON(roadsGeometry), nil
+}
 
-		if landfillGeometry == nil {
-			// If there is no landfill, end at the arrival warehouse.
-			tspStartVertexID = vertexIDs[len(vertexIDs)-2]
-			tspEndVertexID = vertexIDs[len(vertexIDs)-1]
-			tspVertexIDs = vertexIDs
-		} else {
-			// If there is a landfill, end at the closest one to the arrival warehouse.
-			tspStartVertexID = vertexIDs[len(vertexIDs)-3]
-			tspEndVertexID = vertexIDs[len(vertexIDs)-2]
-			tspVertexIDs = vertexIDs[:len(vertexIDs)-1]
+// GetRouteStops returns the sequential stops of the route plan, including the estimated load of the truck after each
+// stop.
+func (s *service) GetRouteStops(ctx context.Context, id uuid.UUID) ([]domain.RouteStop, error) {
+	logAttrs := []any{
+		slog.String(logging.ServiceMethod, "GetRouteStops"),
+		slog.String(logging.RouteID, id.String()),
+	}
+
+	var stops []domain.RouteStop
+
+	err := s.readWriteTx(ctx, func(tx pgx.Tx) error {
+		route, err := s.store.GetRouteByID(ctx, tx, id)
+		if err != nil {
+			return err
 		}
 
-		// Compute the TSP for the route container vertices, starting at the departure warehouse and ending at the
-		// closest landfill to the arrival warehouse. Ignore the last vertex (arrival warehouse) because it is always
-		// the last vertex visited after the landfill.
-		seqVertexIDs, err := s.store.GetRoadVerticesTSP(ctx, tx, tempTableNameRoadNetwork, tspVertexIDs, tspStartVertexID, tspEndVertexID, true)
+		plan, err := s.planRoute(ctx, tx, route)
 		if err != nil {
 			return err
 		}
 
-		// Replace the last vertex with the last actual point of the route.
-		// This is a blind replacement because the last vertex is always the same as the first.
-		if len(seqVertexIDs) != 0 {
-			seqVertexIDs[len(seqVertexIDs)-1] = vertexIDs[len(vertexIDs)-1]
+		stops = plan.stops
+
+		return nil
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrRouteNotFound),
+			errors.Is(err, domain.ErrRouteLandfillNotFound),
+			errors.Is(err, domain.ErrRouteStopUnreachable),
+			errors.Is(err, domain.ErrRoutePayloadCapacityExceeded):
+			return nil, logInfoAndWrapError(ctx, err, descriptionFailedGetRouteStops, logAttrs...)
+		default:
+			return nil, logAndWrapError(ctx, err, descriptionFailedGetRouteStops, logAttrs...)
+		}
+	}
+
+	return stops, nil
+}
+
+// GetRouteCategories returns the breakdown of the container categories collected by the route, including the truck
+// compartment that collects each category.
+func (s *service) GetRouteCategories(ctx context.Context, id uuid.UUID) ([]domain.RouteCategory, error) {
+	logAttrs := []any{
+		slog.String(logging.ServiceMethod, "GetRouteCategories"),
+		slog.String(logging.RouteID, id.String()),
+	}
+
+	var routeCategories []domain.RouteCategory
+
+	err := s.readOnlyTx(ctx, func(tx pgx.Tx) error {
+		route, err := s.store.GetRouteByID(ctx, tx, id)
+		if err != nil {
+			return err
 		}
 
-		// Compute the roads based on the sequential vertices.
-		roadsGeometry, err = s.store.GetRoadsGeometryAStar(ctx, tx, tempTableNameRoadNetwork, seqVertexIDs, true)
+		containers, err := s.listAllRouteContainers(ctx, tx, route.ID)
 		if err != nil {
 			return err
 		}
 
+		routeCategoriesIndexes := make(map[domain.ContainerCategory]int)
+
+		for _, container := range containers {
+			i, ok := routeCategoriesIndexes[container.Category]
+			if !ok {
+				compartmentIndex, _ := route.Truck.CompartmentIndex(container.Category)
+
+				i = len(routeCategories)
+				routeCategoriesIndexes[container.Category] = i
+				routeCategories = append(routeCategories, domain.RouteCategory{
+					Category:         container.Category,
+					CompartmentIndex: compartmentIndex,
+				})
+			}
+
+			volume, weight := container.Load()
+
+			routeCategories[i].Containers++
+			routeCategories[i].Volume += volume
+			routeCategories[i].Weight += weight
+		}
+
 		return nil
 	})
 	if err != nil {
 		switch {
 		case errors.Is(err, domain.ErrRouteNotFound):
-			return nil, logInfoAndWrapError(ctx, err, descriptionFailedGetRouteRoads, logAttrs...)
+			return nil, logInfoAndWrapError(ctx, err, descriptionFailedGetRouteCategories, logAttrs...)
 		default:
-			return nil, logAndWrapError(ctx, err, descriptionFailedGetRouteRoads, logAttrs...)
+			return nil, logAndWrapError(ctx, err, descriptionFailedGetRouteCategories, logAttrs...)
+		}
+	}
+
+	return routeCategories, nil
+}
+
+// GetRouteEmissions returns the estimated fuel consumed and carbon dioxide emitted by the route with the specified
+// identifier, based on the road network distance of the route plan.
+func (s *service) GetRouteEmissions(ctx context.Context, id uuid.UUID) (domain.RouteEmissions, error) {
+	logAttrs := []any{
+		slog.String(logging.ServiceMethod, "GetRouteEmissions"),
+		slog.String(logging.RouteID, id.String()),
+	}
+
+	var emissions domain.RouteEmissions
+
+	err := s.readWriteTx(ctx, func(tx pgx.Tx) error {
+		route, err := s.store.GetRouteByID(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		emissions, err = s.routeEmissions(ctx, tx, route)
+		if err != nil {
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrRouteNotFound),
+			errors.Is(err, domain.ErrRouteLandfillNotFound),
+			errors.Is(err, domain.ErrRouteStopUnreachable),
+			errors.Is(err, domain.ErrRoutePayloadCapacityExceeded):
+			return domain.RouteEmissions{}, logInfoAndWrapError(ctx, err, descriptionFailedGetRouteEmissions, logAttrs...)
+		default:
+			return domain.RouteEmissions{}, logAndWrapError(ctx, err, descriptionFailedGetRouteEmissions, logAttrs...)
+		}
+	}
+
+	return emissions, nil
+}
+
+// routeEmissions returns the emissions of the given route, estimated from the length of the roads computed with the A*
+// algorithm between the stops of the route plan. The intermediate and final visits to the landfill are part of the
+// plan, so the landfill legs are accounted for.
+func (s *service) routeEmissions(ctx context.Context, tx pgx.Tx, route domain.Route) (domain.RouteEmissions, error) {
+	plan, err := s.planRoute(ctx, tx, route)
+	if err != nil {
+		return domain.RouteEmissions{}, err
+	}
+
+	roadsGeometry, err := s.store.GetRoadsGeometryAStar(ctx, tx, plan.roadNetworkTableName, plan.stopsVertexIDs, true)
+	if err != nil {
+		return domain.RouteEmissions{}, err
+	}
+
+	emissions := domain.RouteEmissions{
+		FuelType:        route.Truck.FuelType,
+		FuelConsumption: route.Truck.FuelConsumption,
+		Distance:        lineStringsDistance(roadsGeometry),
+	}
+
+	if fuel, co2, ok := route.Truck.Emissions(emissions.Distance); ok {
+		emissions.Fuel = &fuel
+		emissions.CO2 = &co2
+	}
+
+	return emissions, nil
+}
+
+// lineStringsDistance returns the total length, in kilometers, of the given line strings.
+func lineStringsDistance(lineStrings []domain.GeoJSONGeometryLineString) float64 {
+	var distance float64
+
+	for _, lineString := range lineStrings {
+		for i := 1; i < len(lineString.Coordinates); i++ {
+			distance += haversineDistance(lineString.Coordinates[i-1], lineString.Coordinates[i])
 		}
 	}
 
+	return distance / 1000
+}
+
+// routePlan defines the route plan structure.
+type routePlan struct {
+	roadNetworkTableName       string // Name of the temporary road network table that contains the vertices of the stops.
+	stops                      []domain.RouteStop
+	stopsVertexIDs             []int
+	collectionWindowViolations int
+	duration                   time.Duration // Travel duration of the route, including the waits for the collection windows with a departure time.
+	unreachable                bool          // Whether a stop cannot be reached from the previous one, which makes the plan infeasible.
+	overloaded                 bool          // Whether the load of the truck exceeds its payload capacity, which does not depend on the order of the containers.
+}
+
+// addStop appends the given stop and the respective vertex identifier to the route plan.
+func (p *routePlan) addStop(stop domain.RouteStop, vertexID int) {
+	p.stops = append(p.stops, stop)
+	p.stopsVertexIDs = append(p.stopsVertexIDs, vertexID)
+}
+
+// betterThan returns true if the route plan is feasible while the other plan is not, or violates fewer collection
+// windows than the other plan or, with the same number of violations, has a shorter duration, false otherwise.
+func (p routePlan) betterThan(other routePlan) bool {
+	if p.unreachable != other.unreachable {
+		return !p.unreachable
+	}
+	if p.collectionWindowViolations != other.collectionWindowViolations {
+		return p.collectionWindowViolations < other.collectionWindowViolations
+	}
+
+	return p.duration < other.duration
+}
+
+// roadsGeoJSON returns a feature collection with a feature for each of the given roads geometries.
+func roadsGeoJSON(roadsGeometry []domain.GeoJSONGeometryLineString) domain.GeoJSON {
 	geoJSONFeature := make([]domain.GeoJSONFeature, len(roadsGeometry))
 	for i, geometry := range roadsGeometry {
 		geoJSONFeature[i] = domain.GeoJSONFeature{
@@ -371,5 +620,496 @@ func (s *service) GetRouteRoads(ctx context.Context, id uuid.UUID) (domain.GeoJS
 
 	return domain.GeoJSONFeatureCollection{
 		Features: geoJSONFeature,
-	}, nil
+	}
+}
+
+// listAllRoutes returns every route.
+func (s *service) listAllRoutes(ctx context.Context, tx pgx.Tx) ([]domain.Route, error) {
+	var routes []domain.Route
+
+	for {
+		paginatedRoutes, err := s.store.ListRoutes(ctx, tx, domain.RoutesPaginatedFilter{
+			PaginatedRequest: domain.PaginatedRequest[domain.RoutePaginatedSort]{
+				Limit:  listRoutesPaginatedLimit,
+				Offset: domain.PaginationOffset(len(routes)),
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		routes = append(routes, paginatedRoutes.Results...)
+
+		if len(paginatedRoutes.Results) < listRoutesPaginatedLimit {
+			break
+		}
+	}
+
+	return routes, nil
+}
+
+// listAllRouteContainers returns every container associated with the route with the specified identifier.
+func (s *service) listAllRouteContainers(ctx context.Context, tx pgx.Tx, routeID uuid.UUID) ([]domain.Container, error) {
+	var containers []domain.Container
+
+	for {
+		routeContainers, err := s.store.ListRouteContainers(ctx, tx, routeID, domain.RouteContainersPaginatedFilter{
+			PaginatedRequest: domain.PaginatedRequest[domain.RouteContainerPaginatedSort]{
+				Limit:  listRouteContainersPaginatedLimit,
+				Offset: domain.PaginationOffset(len(containers)),
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		containers = append(containers, routeContainers.Results...)
+
+		if len(routeContainers.Results) < listRouteContainersPaginatedLimit {
+			break
+		}
+	}
+
+	return containers, nil
+}
+
+// routeHazardous returns true if the route with the specified identifier collects any hazardous container, false
+// otherwise.
+func (s *service) routeHazardous(ctx context.Context, tx pgx.Tx, routeID uuid.UUID) (bool, error) {
+	containers, err := s.listAllRouteContainers(ctx, tx, routeID)
+	if err != nil {
+		return false, err
+	}
+
+	return slices.Contains(containersCategories(containers), domain.ContainerCategoryHazardous), nil
+}
+
+// containersCategories returns the distinct categories of the given containers, in order of appearance.
+func containersCategories(containers []domain.Container) []domain.ContainerCategory {
+	var categories []domain.ContainerCategory
+	categoriesSeen := make(map[domain.ContainerCategory]struct{})
+
+	for _, container := range containers {
+		if _, ok := categoriesSeen[container.Category]; !ok {
+			categoriesSeen[container.Category] = struct{}{}
+			categories = append(categories, container.Category)
+		}
+	}
+
+	return categories
+}
+
+// planRoute returns the plan of the given route using the TSP algorithm. The route starts at the departure warehouse,
+// passes through all the route containers, and before terminating at the arrival warehouse, passes through the nearest
+// landfill to the arrival warehouse that accepts every container category of the route, unless there are no landfills.
+// Whenever collecting the next container would exceed the payload capacity of the truck, an intermediate visit to the
+// landfill is inserted to unload the truck, and an error is returned when there is no landfill to do so. When the route
+// has a departure time, the collection windows of the containers are taken into account using the travel times of the
+// road network. An empty plan is returned when the route does not contain any containers associated.
+func (s *service) planRoute(ctx context.Context, tx pgx.Tx, route domain.Route) (routePlan, error) {
+	containers, err := s.listAllRouteContainers(ctx, tx, route.ID)
+	if err != nil {
+		return routePlan{}, err
+	}
+
+	// Early return when the route does not contain any containers associated.
+	if len(containers) == 0 {
+		return routePlan{}, nil
+	}
+
+	departureWarehouse, err := s.store.GetWarehouseByID(ctx, tx, route.DepartureWarehouse.ID)
+	if err != nil {
+		return routePlan{}, err
+	}
+
+	arrivalWarehouse, err := s.store.GetWarehouseByID(ctx, tx, route.ArrivalWarehouse.ID)
+	if err != nil {
+		return routePlan{}, err
+	}
+
+	arrivalWarehouseGeometry := geometryPointFromGeoJSON(arrivalWarehouse.GeoJSON)
+
+	// The landfill must accept every container category of the route. When there are no landfills at all, the route
+	// ends at the arrival warehouse without unloading.
+	var landfill *domain.Landfill
+	closestLandfill, err := s.store.GetLandfillClosestGeometry(ctx, tx, arrivalWarehouseGeometry, containersCategories(containers))
+	if err != nil {
+		if !errors.Is(err, domain.ErrLandfillNotFound) {
+			return routePlan{}, err
+		}
+
+		exists, err := s.store.ExistsLandfill(ctx, tx)
+		if err != nil {
+			return routePlan{}, err
+		}
+		if exists {
+			return routePlan{}, domain.ErrRouteLandfillNotFound
+		}
+	} else {
+		landfill = &closestLandfill
+	}
+
+	verticesGeometry := make([]domain.GeoJSONGeometryPoint, 0, len(containers)+3)
+
+	for _, container := range containers {
+		verticesGeometry = append(verticesGeometry, geometryPointFromGeoJSON(container.GeoJSON))
+	}
+	verticesGeometry = append(verticesGeometry, geometryPointFromGeoJSON(departureWarehouse.GeoJSON))
+	if landfill != nil {
+		verticesGeometry = append(verticesGeometry, geometryPointFromGeoJSON(landfill.GeoJSON))
+	}
+	verticesGeometry = append(verticesGeometry, arrivalWarehouseGeometry)
+
+	// tempTableNameRoadNetwork defines the name of the road network temporary table.
+	// It contains a random suffix to avoid conflicts in the same database session.
+	tempTableNameRoadNetwork := "road_network_temp_" + strings.ReplaceAll(uuid.New().String(), "-", "")
+
+	err = s.store.CreateTemporaryTableRoadNetworkWithBuffer(ctx, tx, tempTableNameRoadNetwork, verticesGeometry)
+	if err != nil {
+		return routePlan{}, err
+	}
+
+	vertexIDs, err := s.store.CreateVerticesCloseToRoadNetwork(ctx, tx, tempTableNameRoadNetwork, verticesGeometry)
+	if err != nil {
+		return routePlan{}, err
+	}
+
+	departureWarehouseVertexID := vertexIDs[len(containers)]
+	arrivalWarehouseVertexID := vertexIDs[len(vertexIDs)-1]
+
+	// Compute the TSP for the route container vertices, starting at the departure warehouse and ending at the
+	// closest landfill to the arrival warehouse. Ignore the last vertex (arrival warehouse) because it is always
+	// the last vertex visited after the landfill. If there is no landfill, end at the arrival warehouse.
+	tspVertexIDs := vertexIDs
+	tspEndVertexID := arrivalWarehouseVertexID
+	var landfillVertexID int
+	if landfill != nil {
+		landfillVertexID = vertexIDs[len(vertexIDs)-2]
+		tspVertexIDs = vertexIDs[:len(vertexIDs)-1]
+		tspEndVertexID = landfillVertexID
+	}
+
+	seqVertexIDs, err := s.store.GetRoadVerticesTSP(ctx, tx, tempTableNameRoadNetwork, tspVertexIDs, departureWarehouseVertexID, tspEndVertexID, true)
+	if err != nil {
+		return routePlan{}, err
+	}
+
+	// Multiple containers can share the same vertex.
+	containersIndexesByVertexID := make(map[int][]int, len(containers))
+	for i := range containers {
+		containersIndexesByVertexID[vertexIDs[i]] = append(containersIndexesByVertexID[vertexIDs[i]], i)
+	}
+
+	tspOrder := make([]int, 0, len(containers))
+	containersVisited := make(map[int]struct{}, len(containers))
+
+	for _, vertexID := range seqVertexIDs {
+		for _, i := range containersIndexesByVertexID[vertexID] {
+			if _, ok := containersVisited[i]; ok {
+				continue
+			}
+			containersVisited[i] = struct{}{}
+
+			tspOrder = append(tspOrder, i)
+		}
+	}
+
+	planner := routePlanner{
+		roadNetworkTableName: tempTableNameRoadNetwork,
+		truck:                route.Truck,
+		departureTime:        route.DepartureTime,
+		containers:           containers,
+		containersVertexIDs:  vertexIDs[:len(containers)],
+		departureWarehouseStop: domain.RouteStop{
+			Type:    domain.RouteStopTypeDepartureWarehouse,
+			ID:      departureWarehouse.ID,
+			GeoJSON: departureWarehouse.GeoJSON,
+		},
+		departureWarehouseVertexID: departureWarehouseVertexID,
+		arrivalWarehouseStop: domain.RouteStop{
+			Type:    domain.RouteStopTypeArrivalWarehouse,
+			ID:      arrivalWarehouse.ID,
+			GeoJSON: arrivalWarehouse.GeoJSON,
+		},
+		arrivalWarehouseVertexID: arrivalWarehouseVertexID,
+	}
+
+	if landfill != nil {
+		planner.landfillStop = &domain.RouteStop{
+			Type:    domain.RouteStopTypeLandfill,
+			ID:      landfill.ID,
+			GeoJSON: landfill.GeoJSON,
+		}
+		planner.landfillVertexID = landfillVertexID
+	}
+
+	costs, err := s.store.GetRoadVerticesDurationMatrix(ctx, tx, tempTableNameRoadNetwork, vertexIDs, true)
+	if err != nil {
+		return routePlan{}, err
+	}
+
+	planner.durations = make(map[[2]int]time.Duration, len(costs))
+	for _, cost := range costs {
+		planner.durations[[2]int{cost.SourceVertexID, cost.TargetVertexID}] = cost.Duration
+	}
+
+	// Without a departure time, the arrival times are unknown and the collection windows cannot be enforced, so the
+	// TSP order is only changed when a stop cannot be reached.
+	var plan routePlan
+	if route.DepartureTime == nil {
+		plan = planner.plan(tspOrder)
+		if plan.unreachable {
+			plan = planner.improve(tspOrder, plan)
+		}
+	} else {
+		plan = planner.bestPlan(tspOrder)
+	}
+
+	if plan.unreachable {
+		return routePlan{}, domain.ErrRouteStopUnreachable
+	}
+	if plan.overloaded {
+		return routePlan{}, domain.ErrRoutePayloadCapacityExceeded
+	}
+
+	return plan, nil
+}
+
+// routePlanner defines the route planner structure, used to build route plans from a sequence of containers.
+type routePlanner struct {
+	roadNetworkTableName string
+	truck                domain.Truck
+	departureTime        *time.Time
+	durations            map[[2]int]time.Duration // Travel duration between vertices.
+
+	containers          []domain.Container
+	containersVertexIDs []int
+
+	departureWarehouseStop     domain.RouteStop
+	departureWarehouseVertexID int
+	landfillStop               *domain.RouteStop // Landfill stop, only defined when there are landfills.
+	landfillVertexID           int
+	arrivalWarehouseStop       domain.RouteStop
+	arrivalWarehouseVertexID   int
+}
+
+// plan returns the route plan that visits the containers in the given order. Whenever collecting the next container
+// would exceed the payload capacity of the truck, an intermediate visit to the landfill, if any, is inserted to unload
+// the truck.
+// When the route has a departure time, the arrival time at each stop is estimated, waiting for the collection windows
+// to open and flagging the containers reached after their collection window closes.
+func (p routePlanner) plan(order []int) routePlan {
+	plan := routePlan{
+		roadNetworkTableName: p.roadNetworkTableName,
+	}
+
+	var midnight time.Time
+	var elapsed time.Duration // Time elapsed since midnight of the departure day.
+	if p.departureTime != nil {
+		elapsed = domain.TimeOfDay(*p.departureTime)
+		midnight = p.departureTime.Add(-elapsed)
+	}
+
+	departureElapsed := elapsed
+	currentVertexID := p.departureWarehouseVertexID
+
+	// travel moves the truck to the given vertex, returning the arrival time when the route has a departure time.
+	travel := func(vertexID int) *time.Time {
+		duration, ok := p.duration(currentVertexID, vertexID)
+		if !ok {
+			plan.unreachable = true
+		}
+
+		elapsed += duration
+		currentVertexID = vertexID
+
+		if p.departureTime == nil {
+			return nil
+		}
+
+		arrivalTime := midnight.Add(elapsed)
+		return &arrivalTime
+	}
+
+	departureWarehouseStop := p.departureWarehouseStop
+	departureWarehouseStop.ArrivalTime = travel(p.departureWarehouseVertexID)
+	plan.addStop(departureWarehouseStop, p.departureWarehouseVertexID)
+
+	var loadVolume, loadWeight float64
+
+	for _, i := range order {
+		container := p.containers[i]
+		volume, weight := container.Load()
+
+		// Unload the truck at the landfill before collecting the container that would exceed the payload capacity.
+		if p.landfillStop != nil && (loadVolume > 0 || loadWeight > 0) && p.truck.ExceedsPayloadCapacity(loadVolume+volume, loadWeight+weight) {
+			landfillStop := *p.landfillStop
+			landfillStop.ArrivalTime = travel(p.landfillVertexID)
+			plan.addStop(landfillStop, p.landfillVertexID)
+
+			loadVolume, loadWeight = 0, 0
+		}
+
+		loadVolume += volume
+		loadWeight += weight
+
+		// Without a landfill, or when the container alone exceeds it, the payload capacity cannot be respected.
+		if p.truck.ExceedsPayloadCapacity(loadVolume, loadWeight) {
+			plan.overloaded = true
+		}
+
+		containerStop := domain.RouteStop{
+			Type:        domain.RouteStopTypeContainer,
+			ID:          container.ID,
+			GeoJSON:     container.GeoJSON,
+			LoadVolume:  loadVolume,
+			LoadWeight:  loadWeight,
+			ArrivalTime: travel(p.containersVertexIDs[i]),
+		}
+
+		if containerStop.ArrivalTime != nil {
+			start, end := container.CollectionWindowAt(elapsed)
+
+			switch {
+			case elapsed < start:
+				elapsed = start
+			case elapsed > end:
+				containerStop.CollectionWindowViolated = true
+				plan.collectionWindowViolations++
+			}
+		}
+
+		plan.addStop(containerStop, p.containersVertexIDs[i])
+	}
+
+	if p.landfillStop != nil {
+		landfillStop := *p.landfillStop
+		landfillStop.ArrivalTime = travel(p.landfillVertexID)
+		plan.addStop(landfillStop, p.landfillVertexID)
+	}
+
+	arrivalWarehouseStop := p.arrivalWarehouseStop
+	arrivalWarehouseStop.ArrivalTime = travel(p.arrivalWarehouseVertexID)
+	plan.addStop(arrivalWarehouseStop, p.arrivalWarehouseVertexID)
+
+	plan.duration = elapsed - departureElapsed
+
+	return plan
+}
+
+// duration returns the travel duration between the given vertices, and whether the target vertex is reachable from the
+// source vertex. Pairs of vertices that are unreachable are missing from the durations.
+func (p routePlanner) duration(sourceVertexID, targetVertexID int) (time.Duration, bool) {
+	if sourceVertexID == targetVertexID {
+		return 0, true
+	}
+
+	duration, ok := p.durations[[2]int{sourceVertexID, targetVertexID}]
+	return duration, ok
+}
+
+// bestPlan returns the route plan that best respects the collection windows of the containers, starting from the
+// given TSP order. When the TSP order violates any collection window, an earliest deadline order is also considered
+// and the best of both is improved by relocating containers. The violated collection windows are reported when no
+// feasible plan is found.
+func (p routePlanner) bestPlan(tspOrder []int) routePlan {
+	bestOrder := tspOrder
+	best := p.plan(tspOrder)
+	if best.collectionWindowViolations == 0 && !best.unreachable {
+		return best
+	}
+
+	deadlineOrder := p.earliestDeadlineOrder()
+	if plan := p.plan(deadlineOrder); plan.betterThan(best) {
+		bestOrder = deadlineOrder
+		best = plan
+	}
+
+	return p.improve(bestOrder, best)
+}
+
+// earliestDeadlineOrder returns the sequence of containers obtained by repeatedly visiting the container whose
+// collection window closes first among the ones that can still be collected on time. Unreachable containers are only
+// visited when no other container is reachable. The intermediate visits to the landfill are not considered.
+func (p routePlanner) earliestDeadlineOrder() []int {
+	order := make([]int, 0, len(p.containers))
+	visited := make([]bool, len(p.containers))
+
+	elapsed := domain.TimeOfDay(*p.departureTime)
+	currentVertexID := p.departureWarehouseVertexID
+
+	for len(order) < len(p.containers) {
+		next := -1
+		var nextElapsed, nextEnd time.Duration
+		var nextOnTime, nextReachable bool
+
+		for i, container := range p.containers {
+			if visited[i] {
+				continue
+			}
+
+			duration, reachable := p.duration(currentVertexID, p.containersVertexIDs[i])
+			arrivalElapsed := elapsed + duration
+			start, end := container.CollectionWindowAt(arrivalElapsed)
+			collectionElapsed := max(arrivalElapsed, start)
+			onTime := reachable && collectionElapsed <= end
+
+			// Prefer the reachable containers, then the ones collected on time, then the earliest closing window, then
+			// the earliest collection.
+			switch {
+			case next == -1,
+				reachable && !nextReachable,
+				onTime && !nextOnTime,
+				onTime && nextOnTime && (end < nextEnd || end == nextEnd && collectionElapsed < nextElapsed),
+				reachable == nextReachable && !onTime && !nextOnTime && collectionElapsed < nextElapsed:
+				next = i
+				nextElapsed = collectionElapsed
+				nextEnd = end
+				nextOnTime = onTime
+				nextReachable = reachable
+			}
+		}
+
+		visited[next] = true
+		order = append(order, next)
+		elapsed = nextElapsed
+		currentVertexID = p.containersVertexIDs[next]
+	}
+
+	return order
+}
+
+// improve returns the given plan improved by relocating single containers within the order, as long as it reduces the
+// number of violated collection windows or, with the same number of violations, the duration of the route.
+func (p routePlanner) improve(order []int, plan routePlan) routePlan {
+	order = slices.Clone(order)
+
+	for pass := 0; pass < routePlanImprovementMaxPasses && (plan.collectionWindowViolations > 0 || plan.unreachable); pass++ {
+		improved := false
+
+		for from := range order {
+			for to := range order {
+				if from == to {
+					continue
+				}
+
+				candidateOrder := slices.Clone(order)
+				container := candidateOrder[from]
+				candidateOrder = slices.Delete(candidateOrder, from, from+1)
+				candidateOrder = slices.Insert(candidateOrder, to, container)
+
+				if candidate := p.plan(candidateOrder); candidate.betterThan(plan) {
+					order = candidateOrder
+					plan = candidate
+					improved = true
+				}
+			}
+		}
+
+		if !improved {
+			break
+		}
+	}
+
+	return plan
 }
@@ -27,13 +27,28 @@ func (s *service) CreateRouteContainer(ctx context.Context, routeID, containerID
 	}
 
 	err := s.readWriteTx(ctx, func(tx pgx.Tx) error {
+		route, err := s.store.GetRouteByID(ctx, tx, routeID)
+		if err != nil {
+			return err
+		}
+
+		container, err := s.store.GetContainerByID(ctx, tx, containerID)
+		if err != nil {
+			return err
+		}
+
+		if !route.Truck.AcceptsCategories([]domain.ContainerCategory{container.Category}) {
+			return domain.ErrRouteContainerCategoryIncompatible
+		}
+
 		return s.store.CreateRouteContainer(ctx, tx, routeID, containerID)
 	})
 	if err != nil {
 		switch {
 		case errors.Is(err, domain.ErrRouteContainerAlreadyExists),
 			errors.Is(err, domain.ErrRouteNotFound),
-			errors.Is(err, domain.ErrContainerNotFound):
+			errors.Is(err, domain.ErrContainerNotFound),
+			errors.Is(err, domain.ErrRouteContainerCategoryIncompatible):
 			return logInfoAndWrapError(ctx, err, descriptionFailedCreateRouteContainer, logAttrs...)
 		default:
 			return logAndWrapError(ctx, err, descriptionFailedCreateRouteContainer, logAttrs...)
@@ -0,0 +1,190 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/goncalo-marques/ecomap/server/internal/domain"
+	"github.com/goncalo-marques/ecomap/server/internal/logging"
+)
+
+const (
+	descriptionFailedListRouteContainerCollections = "service: failed to list route container collections"
+	descriptionFailedPutRouteContainerCollection   = "service: failed to put route container collection"
+)
+
+const (
+	// defaultCollectionDetectionRadius defines the default radius, in meters, around a container within which the truck
+	// must dwell for the container to be considered collected.
+	defaultCollectionDetectionRadius = 25
+
+	// defaultCollectionDetectionDwellTime defines the default time the truck must dwell close to a container for it to be
+	// considered collected.
+	defaultCollectionDetectionDwellTime = 30 * time.Second
+
+	// routeContainerCollectionManualConfidence defines the confidence of the collections recorded manually.
+	routeContainerCollectionManualConfidence = 1
+)
+
+// ListRouteContainerCollections returns the container collections of the current or last run of the route with the
+// specified identifier.
+func (s *service) ListRouteContainerCollections(ctx context.Context, routeID uuid.UUID) ([]domain.RouteContainerCollection, error) {
+	logAttrs := []any{
+		slog.String(logging.ServiceMethod, "ListRouteContainerCollections"),
+		slog.String(logging.RouteID, routeID.String()),
+	}
+
+	var collections []domain.RouteContainerCollection
+
+	err := s.readOnlyTx(ctx, func(tx pgx.Tx) error {
+		route, err := s.store.GetRouteByID(ctx, tx, routeID)
+		if err != nil {
+			return err
+		}
+
+		// Routes that never started do not have collections.
+		if route.StartedAt == nil {
+			return nil
+		}
+
+		collections, err = s.store.ListRouteContainerCollections(ctx, tx, routeID, *route.StartedAt)
+		if err != nil {
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrRouteNotFound):
+			return nil, logInfoAndWrapError(ctx, err, descriptionFailedListRouteContainerCollections, logAttrs...)
+		default:
+			return nil, logAndWrapError(ctx, err, descriptionFailedListRouteContainerCollections, logAttrs...)
+		}
+	}
+
+	return collections, nil
+}
+
+// PutRouteContainerCollection manually records whether the container was collected in the current run of the route,
+// overriding any collection detected automatically.
+func (s *service) PutRouteContainerCollection(ctx context.Context, routeID, containerID uuid.UUID, editableCollection domain.EditableRouteContainerCollection) (domain.RouteContainerCollection, error) {
+	logAttrs := []any{
+		slog.String(logging.ServiceMethod, "PutRouteContainerCollection"),
+		slog.String(logging.RouteID, routeID.String()),
+		slog.String(logging.ContainerID, containerID.String()),
+		slog.Bool(logging.RouteContainerCollectionCollected, editableCollection.Collected),
+	}
+
+	var collection domain.RouteContainerCollection
+
+	err := s.readWriteTx(ctx, func(tx pgx.Tx) error {
+		route, err := s.store.GetRouteByID(ctx, tx, routeID)
+		if err != nil {
+			return err
+		}
+
+		if !route.InProgress() {
+			return domain.ErrRouteNotInProgress
+		}
+
+		collectedAt := time.Now().UTC()
+
+		// Confirming a detected collection keeps the time it was detected.
+		existingCollection, err := s.store.GetRouteContainerCollection(ctx, tx, routeID, containerID, *route.StartedAt)
+		if err != nil {
+			if !errors.Is(err, domain.ErrRouteContainerCollectionNotFound) {
+				return err
+			}
+		} else if existingCollection.Collected && editableCollection.Collected {
+			collectedAt = existingCollection.CollectedAt
+		}
+
+		err = s.store.PutRouteContainerCollection(ctx, tx, domain.RouteContainerCollection{
+			EditableRouteContainerCollection: editableCollection,
+			RouteID:                          routeID,
+			ContainerID:                      containerID,
+			RouteStartedAt:                   *route.StartedAt,
+			Confidence:                       routeContainerCollectionManualConfidence,
+			Source:                           domain.RouteContainerCollectionSourceManual,
+			CollectedAt:                      collectedAt,
+		})
+		if err != nil {
+			return err
+		}
+
+		collection, err = s.store.GetRouteContainerCollection(ctx, tx, routeID, containerID, *route.StartedAt)
+		if err != nil {
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrRouteNotFound),
+			errors.Is(err, domain.ErrRouteNotInProgress),
+			errors.Is(err, domain.ErrRouteContainerNotFound):
+			return domain.RouteContainerCollection{}, logInfoAndWrapError(ctx, err, descriptionFailedPutRouteContainerCollection, logAttrs...)
+		default:
+			return domain.RouteContainerCollection{}, logAndWrapError(ctx, err, descriptionFailedPutRouteContainerCollection, logAttrs...)
+		}
+	}
+
+	return collection, nil
+}
+
+// detectRouteContainersCollections records the collection of the containers of the routes in progress that use the
+// truck with the specified identifier, when the truck dwells close enough to them. Only the containers the truck has
+// been close to since the given time are considered.
+func (s *service) detectRouteContainersCollections(ctx context.Context, tx pgx.Tx, truckID uuid.UUID, since time.Time) error {
+	routes, err := s.store.ListRoutesInProgressByTruckID(ctx, tx, truckID)
+	if err != nil {
+		return err
+	}
+
+	for _, route := range routes {
+		dwells, err := s.store.ListRouteContainersDwells(ctx, tx, route.ID, truckID, *route.StartedAt, since, s.options.CollectionDetectionRadius)
+		if err != nil {
+			return err
+		}
+
+		for _, dwell := range dwells {
+			if dwell.Duration() < s.options.CollectionDetectionDwellTime {
+				continue
+			}
+
+			err = s.store.PutRouteContainerCollection(ctx, tx, domain.RouteContainerCollection{
+				EditableRouteContainerCollection: domain.EditableRouteContainerCollection{
+					Collected: true,
+				},
+				RouteID:        route.ID,
+				ContainerID:    dwell.ContainerID,
+				RouteStartedAt: *route.StartedAt,
+				Confidence:     s.collectionConfidence(dwell),
+				Source:         domain.RouteContainerCollectionSourceAutomatic,
+				CollectedAt:    dwell.DepartedAt,
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// collectionConfidence returns the confidence of the collection detected from the given dwell, between 0 and 1. The
+// confidence increases as the truck gets closer to the container and dwells longer close to it, reaching the maximum
+// after twice the detection dwell time.
+func (s *service) collectionConfidence(dwell domain.RouteContainerDwell) float64 {
+	proximity := 1 - dwell.MinDistance/s.options.CollectionDetectionRadius
+	duration := dwell.Duration().Seconds() / (2 * s.options.CollectionDetectionDwellTime.Seconds())
+
+	return max(0, min(1, (max(0, proximity)+min(1, duration))/2))
+}
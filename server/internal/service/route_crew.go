@@ -0,0 +1,254 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/goncalo-marques/ecomap/server/internal/domain"
+	"github.com/goncalo-marques/ecomap/server/internal/logging"
+)
+
+const (
+	descriptionFailedAutoAssignRouteCrew = "service: failed to auto assign route crew"
+)
+
+// AutoAssignRouteCrew assigns employees to the free seats of the truck of the route with the specified identifier. The
+// employees are waste operators that are not absent and, when the route has a departure time, are on shift at the next
+// departure of the route, preferring the ones whose location is nearest to the route departure warehouse. When the
+// route has no driver, the nearest employee with a valid license of the category required by the truck is assigned as
+// the driver, and the remaining seats are filled with collectors. The crew of a hazardous route includes an employee
+// with a hazardous materials certification. Seats are left free when there are not enough employees available. In
+// preview mode, the proposed crew members are returned without being assigned.
+func (s *service) AutoAssignRouteCrew(ctx context.Context, routeID uuid.UUID, preview bool) ([]domain.RouteCrewMember, error) {
+	logAttrs := []any{
+		slog.String(logging.ServiceMethod, "AutoAssignRouteCrew"),
+		slog.String(logging.RouteID, routeID.String()),
+		slog.Bool(logging.RouteCrewPreview, preview),
+	}
+
+	var members []domain.RouteCrewMember
+
+	err := s.readWriteTx(ctx, func(tx pgx.Tx) error {
+		route, err := s.store.GetRouteByID(ctx, tx, routeID)
+		if err != nil {
+			return err
+		}
+
+		crew, err := s.listAllRouteEmployees(ctx, tx, routeID)
+		if err != nil {
+			return err
+		}
+
+		seats := int(route.Truck.PersonCapacity) - len(crew)
+		if seats <= 0 {
+			return nil
+		}
+
+		// Availability and qualifications are checked on the next departure of the route, or today when the route has
+		// no departure time.
+		now := time.Now().UTC()
+		departure := route.NextDeparture(now)
+
+		qualifiedAt := now
+		if departure != nil {
+			qualifiedAt = *departure
+		}
+
+		crewIDs := make([]uuid.UUID, len(crew))
+		hasDriver := false
+		for i, crewMember := range crew {
+			crewIDs[i] = crewMember.ID
+			if crewMember.RouteRole == domain.RouteEmployeeRoleDriver {
+				hasDriver = true
+			}
+		}
+
+		hazardous, err := s.routeHazardous(ctx, tx, routeID)
+		if err != nil {
+			return err
+		}
+
+		certified := !hazardous
+		if hazardous {
+			certified, err = s.routeCrewHazardousMaterialsCertified(ctx, tx, crewIDs, qualifiedAt)
+			if err != nil {
+				return err
+			}
+		}
+
+		candidates, err := s.routeCrewCandidates(ctx, tx, route, crewIDs, qualifiedAt, departure != nil)
+		if err != nil {
+			return err
+		}
+
+		qualificationsByID := make(map[uuid.UUID]domain.EmployeeQualifications)
+		qualifications := func(employeeID uuid.UUID) (domain.EmployeeQualifications, error) {
+			if q, ok := qualificationsByID[employeeID]; ok {
+				return q, nil
+			}
+
+			q, err := s.store.ListEmployeeQualifications(ctx, tx, employeeID)
+			if err != nil {
+				return nil, err
+			}
+
+			qualificationsByID[employeeID] = q
+			return q, nil
+		}
+
+		// pick assigns the nearest candidate not yet assigned whose qualifications are accepted, returning false if
+		// there is no such candidate.
+		selected := make(map[uuid.UUID]struct{})
+		pick := func(role domain.RouteEmployeeRole, accept func(domain.EmployeeQualifications) bool) (bool, error) {
+			for _, candidate := range candidates {
+				if _, ok := selected[candidate.ID]; ok {
+					continue
+				}
+
+				q, err := qualifications(candidate.ID)
+				if err != nil {
+					return false, err
+				}
+				if accept != nil && !accept(q) {
+					continue
+				}
+
+				if q.HasHazardousMaterialsCertification(qualifiedAt) {
+					certified = true
+				}
+
+				candidate.RouteRole = role
+				members = append(members, candidate)
+				selected[candidate.ID] = struct{}{}
+				seats--
+
+				return true, nil
+			}
+
+			return false, nil
+		}
+
+		if !hasDriver {
+			// When the driver takes the last seat, the driver must also complete the crew certification.
+			requireCertification := !certified && seats == 1
+
+			ok, err := pick(domain.RouteEmployeeRoleDriver, func(q domain.EmployeeQualifications) bool {
+				if requireCertification && !q.HasHazardousMaterialsCertification(qualifiedAt) {
+					return false
+				}
+
+				return q.HasLicense(route.Truck.LicenseCategory, qualifiedAt)
+			})
+			if err != nil {
+				return err
+			}
+			if !ok {
+				return domain.ErrRouteCrewDriverNotFound
+			}
+		}
+
+		if !certified && seats > 0 {
+			_, err := pick(domain.RouteEmployeeRoleCollector, func(q domain.EmployeeQualifications) bool {
+				return q.HasHazardousMaterialsCertification(qualifiedAt)
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		if !certified {
+			return domain.ErrRouteHazardousMaterialsCertificationMissing
+		}
+
+		for seats > 0 {
+			ok, err := pick(domain.RouteEmployeeRoleCollector, nil)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				break
+			}
+		}
+
+		if preview {
+			return nil
+		}
+
+		for _, member := range members {
+			err = s.store.CreateRouteEmployee(ctx, tx, routeID, member.ID, member.EditableRouteEmployee)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrRouteNotFound),
+			errors.Is(err, domain.ErrRouteCrewDriverNotFound),
+			errors.Is(err, domain.ErrRouteHazardousMaterialsCertificationMissing):
+			return nil, logInfoAndWrapError(ctx, err, descriptionFailedAutoAssignRouteCrew, logAttrs...)
+		default:
+			return nil, logAndWrapError(ctx, err, descriptionFailedAutoAssignRouteCrew, logAttrs...)
+		}
+	}
+
+	return members, nil
+}
+
+// routeCrewCandidates returns the waste operators that can join the crew of the given route, sorted by the distance
+// between their location and the route departure warehouse. Employees in the given crew, absent on the date of the
+// given time or, when onShift is true, off shift at that time are excluded.
+func (s *service) routeCrewCandidates(ctx context.Context, tx pgx.Tx, route domain.Route, crewIDs []uuid.UUID, t time.Time, onShift bool) ([]domain.RouteCrewMember, error) {
+	role := domain.EmployeeRoleWasteOperator
+	employees, err := s.listAllEmployees(ctx, tx, &role)
+	if err != nil {
+		return nil, err
+	}
+
+	absentIDs, err := s.store.ListEmployeesAbsentIDs(ctx, tx, t, t)
+	if err != nil {
+		return nil, err
+	}
+
+	excluded := make(map[uuid.UUID]struct{}, len(crewIDs)+len(absentIDs))
+	for _, id := range crewIDs {
+		excluded[id] = struct{}{}
+	}
+	for _, id := range absentIDs {
+		excluded[id] = struct{}{}
+	}
+
+	warehouseCoordinates := geometryPointFromGeoJSON(route.DepartureWarehouse.GeoJSON).Coordinates
+
+	var candidates []domain.RouteCrewMember
+	for _, employee := range employees {
+		if _, ok := excluded[employee.ID]; ok {
+			continue
+		}
+		if onShift && !employee.OnShift(t, t) {
+			continue
+		}
+
+		candidates = append(candidates, domain.RouteCrewMember{
+			RouteEmployee: domain.RouteEmployee{
+				Employee: employee,
+			},
+			Distance: haversineDistance(geometryPointFromGeoJSON(employee.GeoJSON).Coordinates, warehouseCoordinates),
+		})
+	}
+
+	slices.SortStableFunc(candidates, func(a, b domain.RouteCrewMember) int {
+		return cmp.Compare(a.Distance, b.Distance)
+	})
+
+	return candidates, nil
+}
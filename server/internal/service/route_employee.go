@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"log/slog"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -18,6 +19,8 @@ const (
 	descriptionFailedDeleteRouteEmployee = "service: failed to delete route employee association"
 )
 
+const listRouteEmployeesPaginatedLimit = 100
+
 // CreateRouteEmployee creates a route employee association.
 func (s *service) CreateRouteEmployee(ctx context.Context, routeID, employeeID uuid.UUID, editableRouteEmployee domain.EditableRouteEmployee) error {
 	logAttrs := []any{
@@ -51,12 +54,80 @@ func (s *service) CreateRouteEmployee(ctx context.Context, routeID, employeeID u
 			return domain.ErrRouteTruckPersonCapacityMaxLimit
 		}
 
+		employee, err := s.store.GetEmployeeByID(ctx, tx, employeeID)
+		if err != nil {
+			return err
+		}
+
+		// Availability and qualifications are checked on the next departure of the route, or today when the route has
+		// no departure time.
+		qualifiedAt := time.Now().UTC()
+		if departure := route.NextDeparture(qualifiedAt); departure != nil {
+			absent, err := s.store.ExistsEmployeeAbsence(ctx, tx, employeeID, *departure, *departure)
+			if err != nil {
+				return err
+			}
+			if absent {
+				return domain.ErrRouteEmployeeAbsent
+			}
+
+			if !employee.OnShift(*departure, *departure) {
+				return domain.ErrRouteEmployeeOffShift
+			}
+
+			qualifiedAt = *departure
+		}
+
+		if editableRouteEmployee.RouteRole == domain.RouteEmployeeRoleDriver {
+			qualifications, err := s.store.ListEmployeeQualifications(ctx, tx, employeeID)
+			if err != nil {
+				return err
+			}
+
+			if !qualifications.HasLicense(truck.LicenseCategory, qualifiedAt) {
+				return domain.ErrRouteEmployeeLicenseMissing
+			}
+		}
+
+		// The last crew member of a hazardous route must complete a crew with a hazardous materials certification.
+		if int(truck.PersonCapacity) == routeEmployees.Total+1 {
+			hazardous, err := s.routeHazardous(ctx, tx, routeID)
+			if err != nil {
+				return err
+			}
+
+			if hazardous {
+				crew, err := s.listAllRouteEmployees(ctx, tx, routeID)
+				if err != nil {
+					return err
+				}
+
+				crewIDs := []uuid.UUID{employee.ID}
+				for _, crewMember := range crew {
+					crewIDs = append(crewIDs, crewMember.ID)
+				}
+
+				certified, err := s.routeCrewHazardousMaterialsCertified(ctx, tx, crewIDs, qualifiedAt)
+				if err != nil {
+					return err
+				}
+
+				if !certified {
+					return domain.ErrRouteHazardousMaterialsCertificationMissing
+				}
+			}
+		}
+
 		return s.store.CreateRouteEmployee(ctx, tx, routeID, employeeID, editableRouteEmployee)
 	})
 	if err != nil {
 		switch {
 		case errors.Is(err, domain.ErrRouteTruckPersonCapacityMaxLimit),
 			errors.Is(err, domain.ErrRouteEmployeeAlreadyExists),
+			errors.Is(err, domain.ErrRouteEmployeeAbsent),
+			errors.Is(err, domain.ErrRouteEmployeeOffShift),
+			errors.Is(err, domain.ErrRouteEmployeeLicenseMissing),
+			errors.Is(err, domain.ErrRouteHazardousMaterialsCertificationMissing),
 			errors.Is(err, domain.ErrRouteNotFound),
 			errors.Is(err, domain.ErrEmployeeNotFound):
 			return logInfoAndWrapError(ctx, err, descriptionFailedCreateRouteEmployee, logAttrs...)
@@ -123,3 +194,91 @@ func (s *service) DeleteRouteEmployee(ctx context.Context, routeID, employeeID u
 
 	return nil
 }
+
+// listAllRouteEmployees returns every employee associated with the route with the specified identifier.
+func (s *service) listAllRouteEmployees(ctx context.Context, tx pgx.Tx, routeID uuid.UUID) ([]domain.RouteEmployee, error) {
+	var routeEmployees []domain.RouteEmployee
+
+	for {
+		paginatedRouteEmployees, err := s.store.ListRouteEmployees(ctx, tx, routeID, domain.RouteEmployeesPaginatedFilter{
+			PaginatedRequest: domain.PaginatedRequest[domain.RouteEmployeePaginatedSort]{
+				Limit:  listRouteEmployeesPaginatedLimit,
+				Offset: domain.PaginationOffset(len(routeEmployees)),
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		routeEmployees = append(routeEmployees, paginatedRouteEmployees.Results...)
+
+		if len(paginatedRouteEmployees.Results) < listRouteEmployeesPaginatedLimit {
+			break
+		}
+	}
+
+	return routeEmployees, nil
+}
+
+// routeCrewHazardousMaterialsCertified returns true if any of the given employees holds a hazardous materials
+// certification valid on the date of the given time, false otherwise.
+func (s *service) routeCrewHazardousMaterialsCertified(ctx context.Context, tx pgx.Tx, employeeIDs []uuid.UUID, t time.Time) (bool, error) {
+	for _, employeeID := range employeeIDs {
+		qualifications, err := s.store.ListEmployeeQualifications(ctx, tx, employeeID)
+		if err != nil {
+			return false, err
+		}
+
+		if qualifications.HasHazardousMaterialsCertification(t) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// validateRouteCrewQualifications validates that every driver of the given route holds a license of the category
+// required by the route truck, and that the crew of a hazardous route includes a member with a hazardous materials
+// certification. Qualifications must be valid on the date of the given time.
+func (s *service) validateRouteCrewQualifications(ctx context.Context, tx pgx.Tx, route domain.Route, t time.Time) error {
+	crew, err := s.listAllRouteEmployees(ctx, tx, route.ID)
+	if err != nil {
+		return err
+	}
+
+	crewIDs := make([]uuid.UUID, len(crew))
+	for i, crewMember := range crew {
+		crewIDs[i] = crewMember.ID
+
+		if crewMember.RouteRole != domain.RouteEmployeeRoleDriver {
+			continue
+		}
+
+		qualifications, err := s.store.ListEmployeeQualifications(ctx, tx, crewMember.ID)
+		if err != nil {
+			return err
+		}
+
+		if !qualifications.HasLicense(route.Truck.LicenseCategory, t) {
+			return domain.ErrRouteEmployeeLicenseMissing
+		}
+	}
+
+	hazardous, err := s.routeHazardous(ctx, tx, route.ID)
+	if err != nil {
+		return err
+	}
+
+	if hazardous {
+		certified, err := s.routeCrewHazardousMaterialsCertified(ctx, tx, crewIDs, t)
+		if err != nil {
+			return err
+		}
+
+		if !certified {
+			return domain.ErrRouteHazardousMaterialsCertificationMissing
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,303 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/goncalo-marques/ecomap/server/internal/domain"
+	"github.com/goncalo-marques/ecomap/server/internal/logging"
+)
+
+const (
+	descriptionFailedPutRouteTrack   = "service: failed to put route track"
+	descriptionFailedGetRouteTrack   = "service: failed to get route track"
+	descriptionFailedListRouteTracks = "service: failed to list route tracks"
+)
+
+const (
+	// defaultDeviationRadius defines the default distance, in meters, the track can be from the planned route before it
+	// is considered off route.
+	defaultDeviationRadius = 50
+)
+
+// PutRouteTrack records the track driven by the truck of the route with the specified identifier on the given date,
+// replacing any track previously recorded on that date. The track is compared with the current plan of the route, and
+// the resulting deviation is stored with the track.
+func (s *service) PutRouteTrack(ctx context.Context, routeID uuid.UUID, date time.Time, editableTrack domain.EditableRouteTrack) (domain.RouteTrack, error) {
+	date = time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+
+	logAttrs := []any{
+		slog.String(logging.ServiceMethod, "PutRouteTrack"),
+		slog.String(logging.RouteID, routeID.String()),
+		slog.String(logging.RouteTrackDate, date.Format(time.DateOnly)),
+	}
+
+	if !editableTrack.GeometryValid() {
+		return domain.RouteTrack{}, logInfoAndWrapError(ctx, &domain.ErrFieldValueInvalid{FieldName: domain.FieldGeometry}, descriptionInvalidFieldValue, logAttrs...)
+	}
+	if !editableTrack.TimestampsValid() {
+		return domain.RouteTrack{}, logInfoAndWrapError(ctx, &domain.ErrFieldValueInvalid{FieldName: domain.FieldTimestamps}, descriptionInvalidFieldValue, logAttrs...)
+	}
+
+	// Timestamps are stored in UTC, regardless of the time zone of the device.
+	timestamps := make([]time.Time, len(editableTrack.Timestamps))
+	for i, timestamp := range editableTrack.Timestamps {
+		timestamps[i] = timestamp.UTC()
+	}
+	editableTrack.Timestamps = timestamps
+
+	var track domain.RouteTrack
+
+	err := s.readWriteTx(ctx, func(tx pgx.Tx) error {
+		route, err := s.store.GetRouteByID(ctx, tx, routeID)
+		if err != nil {
+			return err
+		}
+
+		plan, err := s.planRoute(ctx, tx, route)
+		if err != nil {
+			return err
+		}
+
+		plannedGeometry, err := s.store.GetRoadsGeometryAStar(ctx, tx, plan.roadNetworkTableName, plan.stopsVertexIDs, true)
+		if err != nil {
+			return err
+		}
+
+		deviation := routeDeviation(editableTrack, plannedGeometry, plan.stops, s.options.DeviationRadius)
+
+		deviation.PlannedDistance, deviation.PlannedDuration, err = s.routePlanCost(ctx, tx, plan)
+		if err != nil {
+			return err
+		}
+
+		err = s.store.PutRouteTrack(ctx, tx, domain.RouteTrack{
+			EditableRouteTrack: editableTrack,
+			RouteID:            routeID,
+			Date:               date,
+			Deviation:          deviation,
+		})
+		if err != nil {
+			return err
+		}
+
+		track, err = s.store.GetRouteTrack(ctx, tx, routeID, date)
+		if err != nil {
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrRouteNotFound),
+			errors.Is(err, domain.ErrRouteLandfillNotFound),
+			errors.Is(err, domain.ErrRouteStopUnreachable),
+			errors.Is(err, domain.ErrRoutePayloadCapacityExceeded):
+			return domain.RouteTrack{}, logInfoAndWrapError(ctx, err, descriptionFailedPutRouteTrack, logAttrs...)
+		default:
+			return domain.RouteTrack{}, logAndWrapError(ctx, err, descriptionFailedPutRouteTrack, logAttrs...)
+		}
+	}
+
+	return track, nil
+}
+
+// GetRouteTrack returns the track driven by the truck of the route with the specified identifier on the given date,
+// along with its deviation from the planned route.
+func (s *service) GetRouteTrack(ctx context.Context, routeID uuid.UUID, date time.Time) (domain.RouteTrack, error) {
+	date = time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+
+	logAttrs := []any{
+		slog.String(logging.ServiceMethod, "GetRouteTrack"),
+		slog.String(logging.RouteID, routeID.String()),
+		slog.String(logging.RouteTrackDate, date.Format(time.DateOnly)),
+	}
+
+	var track domain.RouteTrack
+	var err error
+
+	err = s.readOnlyTx(ctx, func(tx pgx.Tx) error {
+		track, err = s.store.GetRouteTrack(ctx, tx, routeID, date)
+		return err
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrRouteTrackNotFound):
+			return domain.RouteTrack{}, logInfoAndWrapError(ctx, err, descriptionFailedGetRouteTrack, logAttrs...)
+		default:
+			return domain.RouteTrack{}, logAndWrapError(ctx, err, descriptionFailedGetRouteTrack, logAttrs...)
+		}
+	}
+
+	return track, nil
+}
+
+// ListRouteTracks returns the route tracks of the fleet with the specified filter, without their geometries.
+func (s *service) ListRouteTracks(ctx context.Context, filter domain.RouteTracksPaginatedFilter) (domain.PaginatedResponse[domain.RouteTrack], error) {
+	logAttrs := []any{
+		slog.String(logging.ServiceMethod, "ListRouteTracks"),
+	}
+
+	if filter.Sort != nil && !filter.Sort.Valid() {
+		return domain.PaginatedResponse[domain.RouteTrack]{}, logInfoAndWrapError(ctx, &domain.ErrFilterValueInvalid{FilterName: domain.FieldFilterSort}, descriptionInvalidFilterValue, logAttrs...)
+	}
+	if !filter.Order.Valid() {
+		return domain.PaginatedResponse[domain.RouteTrack]{}, logInfoAndWrapError(ctx, &domain.ErrFilterValueInvalid{FilterName: domain.FieldFilterOrder}, descriptionInvalidFilterValue, logAttrs...)
+	}
+	if !filter.Limit.Valid() {
+		return domain.PaginatedResponse[domain.RouteTrack]{}, logInfoAndWrapError(ctx, &domain.ErrFilterValueInvalid{FilterName: domain.FieldFilterLimit}, descriptionInvalidFilterValue, logAttrs...)
+	}
+	if !filter.Offset.Valid() {
+		return domain.PaginatedResponse[domain.RouteTrack]{}, logInfoAndWrapError(ctx, &domain.ErrFilterValueInvalid{FilterName: domain.FieldFilterOffset}, descriptionInvalidFilterValue, logAttrs...)
+	}
+
+	var paginatedTracks domain.PaginatedResponse[domain.RouteTrack]
+	var err error
+
+	err = s.readOnlyTx(ctx, func(tx pgx.Tx) error {
+		paginatedTracks, err = s.store.ListRouteTracks(ctx, tx, filter)
+		return err
+	})
+	if err != nil {
+		return domain.PaginatedResponse[domain.RouteTrack]{}, logAndWrapError(ctx, err, descriptionFailedListRouteTracks, logAttrs...)
+	}
+
+	return paginatedTracks, nil
+}
+
+// routePlanCost returns the distance, in kilometers, and the travel duration of the given route plan, using the costs
+// of the road network between consecutive stops.
+func (s *service) routePlanCost(ctx context.Context, tx pgx.Tx, plan routePlan) (float64, time.Duration, error) {
+	costs, err := s.store.GetRoadVerticesCostMatrix(ctx, tx, plan.roadNetworkTableName, plan.stopsVertexIDs, true)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	costsByVertexIDs := make(map[[2]int]domain.RoadVerticesCost, len(costs))
+	for _, cost := range costs {
+		costsByVertexIDs[[2]int{cost.SourceVertexID, cost.TargetVertexID}] = cost
+	}
+
+	var distance float64
+	var duration time.Duration
+
+	for i := 1; i < len(plan.stopsVertexIDs); i++ {
+		cost, ok := costsByVertexIDs[[2]int{plan.stopsVertexIDs[i-1], plan.stopsVertexIDs[i]}]
+		if !ok {
+			continue
+		}
+
+		distance += cost.Distance
+		duration += cost.Duration
+	}
+
+	return distance, duration, nil
+}
+
+// routeDeviation returns the deviation of the given track from the planned route geometry. The track is off route
+// wherever it is farther than the radius, in meters, from the planned geometry, and the containers of the stops are
+// missed when the track never passes within the radius of them. The planned distance and duration are not computed.
+func routeDeviation(track domain.EditableRouteTrack, plannedGeometry []domain.GeoJSONGeometryLineString, stops []domain.RouteStop, radius float64) domain.RouteDeviation {
+	var deviation domain.RouteDeviation
+
+	coordinates := track.Geometry.Coordinates
+	plannedSegments := lineSegments(plannedGeometry...)
+
+	offRoute := make([]bool, len(coordinates))
+	for i, c := range coordinates {
+		offRoute[i] = !withinDistanceOfSegments(c, plannedSegments, radius)
+	}
+
+	// Every edge of the track with an extremity off route belongs to an off route segment.
+	var offRouteSegment [][2]float64
+	for i := 1; i < len(coordinates); i++ {
+		distance := haversineDistance(coordinates[i-1], coordinates[i]) / 1000
+		deviation.ActualDistance += distance
+
+		if !offRoute[i-1] && !offRoute[i] {
+			if len(offRouteSegment) != 0 {
+				deviation.OffRouteSegments = append(deviation.OffRouteSegments, domain.GeoJSONGeometryLineString{Coordinates: offRouteSegment})
+				offRouteSegment = nil
+			}
+
+			continue
+		}
+
+		deviation.OffRouteDistance += distance
+
+		if len(offRouteSegment) == 0 {
+			offRouteSegment = append(offRouteSegment, coordinates[i-1])
+		}
+		offRouteSegment = append(offRouteSegment, coordinates[i])
+	}
+	if len(offRouteSegment) != 0 {
+		deviation.OffRouteSegments = append(deviation.OffRouteSegments, domain.GeoJSONGeometryLineString{Coordinates: offRouteSegment})
+	}
+
+	if len(track.Timestamps) != 0 {
+		deviation.ActualDuration = track.Timestamps[len(track.Timestamps)-1].Sub(track.Timestamps[0])
+	}
+
+	trackSegments := lineSegments(track.Geometry)
+	for _, stop := range stops {
+		if stop.Type != domain.RouteStopTypeContainer {
+			continue
+		}
+
+		if !withinDistanceOfSegments(geometryPointFromGeoJSON(stop.GeoJSON).Coordinates, trackSegments, radius) {
+			deviation.MissedContainerIDs = append(deviation.MissedContainerIDs, stop.ID)
+		}
+	}
+
+	return deviation
+}
+
+// lineSegments returns the segments between the consecutive coordinates of the given line strings. Line strings with a
+// single coordinate result in a segment with the same start and end.
+func lineSegments(lineStrings ...domain.GeoJSONGeometryLineString) [][2][2]float64 {
+	var segments [][2][2]float64
+
+	for _, lineString := range lineStrings {
+		coordinates := lineString.Coordinates
+		if len(coordinates) == 1 {
+			segments = append(segments, [2][2]float64{coordinates[0], coordinates[0]})
+			continue
+		}
+
+		for i := 1; i < len(coordinates); i++ {
+			segments = append(segments, [2][2]float64{coordinates[i-1], coordinates[i]})
+		}
+	}
+
+	return segments
+}
+
+// withinDistanceOfSegments returns true if the point is within the distance, in meters, of any of the segments, false
+// otherwise.
+func withinDistanceOfSegments(point [2]float64, segments [][2][2]float64, distance float64) bool {
+	// Segments whose bounding box, expanded by the distance, does not contain the point are too far.
+	latitudeMargin := distance / earthRadius * 180 / math.Pi
+	longitudeMargin := latitudeMargin / math.Max(math.Cos(point[1]*math.Pi/180), 0.01)
+
+	for _, segment := range segments {
+		start, end := segment[0], segment[1]
+
+		if point[0] < min(start[0], end[0])-longitudeMargin || point[0] > max(start[0], end[0])+longitudeMargin ||
+			point[1] < min(start[1], end[1])-latitudeMargin || point[1] > max(start[1], end[1])+latitudeMargin {
+			continue
+		}
+
+		if segmentDistance(point, start, end) <= distance {
+			return true
+		}
+	}
+
+	return false
+}
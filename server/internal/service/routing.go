@@ -0,0 +1,158 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/goncalo-marques/ecomap/server/internal/domain"
+	"github.com/goncalo-marques/ecomap/server/internal/logging"
+)
+
+const (
+	descriptionFailedGetRoutingMatrix = "service: failed to get routing matrix"
+)
+
+// GetRoutingMatrix returns the network distance and duration between every source and target using the A* cost
+// matrix.
+func (s *service) GetRoutingMatrix(ctx context.Context, request domain.RoutingMatrixRequest) (domain.RoutingMatrix, error) {
+	logAttrs := []any{
+		slog.String(logging.ServiceMethod, "GetRoutingMatrix"),
+		slog.Int(logging.RoutingMatrixSources, len(request.Sources)),
+		slog.Int(logging.RoutingMatrixTargets, len(request.Targets)),
+	}
+
+	if !request.Sources.Valid() {
+		return domain.RoutingMatrix{}, logInfoAndWrapError(ctx, &domain.ErrFieldValueInvalid{FieldName: domain.FieldSources}, descriptionInvalidFieldValue, logAttrs...)
+	}
+	if !request.Targets.Valid() {
+		return domain.RoutingMatrix{}, logInfoAndWrapError(ctx, &domain.ErrFieldValueInvalid{FieldName: domain.FieldTargets}, descriptionInvalidFieldValue, logAttrs...)
+	}
+
+	routingMatrix := domain.RoutingMatrix{
+		Sources: make([]domain.GeoJSONGeometryPoint, len(request.Sources)),
+		Targets: make([]domain.GeoJSONGeometryPoint, len(request.Targets)),
+		Entries: make([]domain.RoutingMatrixEntry, 0, len(request.Sources)*len(request.Targets)),
+	}
+
+	err := s.readWriteTx(ctx, func(tx pgx.Tx) error {
+		for i, location := range request.Sources {
+			geometry, err := s.routingLocationGeometry(ctx, tx, location)
+			if err != nil {
+				return err
+			}
+
+			routingMatrix.Sources[i] = geometry
+		}
+
+		for i, location := range request.Targets {
+			geometry, err := s.routingLocationGeometry(ctx, tx, location)
+			if err != nil {
+				return err
+			}
+
+			routingMatrix.Targets[i] = geometry
+		}
+
+		verticesGeometry := make([]domain.GeoJSONGeometryPoint, 0, len(routingMatrix.Sources)+len(routingMatrix.Targets))
+		verticesGeometry = append(verticesGeometry, routingMatrix.Sources...)
+		verticesGeometry = append(verticesGeometry, routingMatrix.Targets...)
+
+		// tempTableNameRoadNetwork defines the name of the road network temporary table.
+		// It contains a random suffix to avoid conflicts in the same database session.
+		tempTableNameRoadNetwork := "road_network_temp_" + strings.ReplaceAll(uuid.New().String(), "-", "")
+
+		err := s.store.CreateTemporaryTableRoadNetworkWithBuffer(ctx, tx, tempTableNameRoadNetwork, verticesGeometry)
+		if err != nil {
+			return err
+		}
+
+		vertexIDs, err := s.store.CreateVerticesCloseToRoadNetwork(ctx, tx, tempTableNameRoadNetwork, verticesGeometry)
+		if err != nil {
+			return err
+		}
+
+		costs, err := s.store.GetRoadVerticesCostMatrix(ctx, tx, tempTableNameRoadNetwork, vertexIDs, true)
+		if err != nil {
+			return err
+		}
+
+		costsByVertices := make(map[[2]int]domain.RoadVerticesCost, len(costs))
+		for _, cost := range costs {
+			costsByVertices[[2]int{cost.SourceVertexID, cost.TargetVertexID}] = cost
+		}
+
+		sourceVertexIDs := vertexIDs[:len(routingMatrix.Sources)]
+		targetVertexIDs := vertexIDs[len(routingMatrix.Sources):]
+
+		for i, sourceVertexID := range sourceVertexIDs {
+			for j, targetVertexID := range targetVertexIDs {
+				entry := domain.RoutingMatrixEntry{
+					SourceIndex: i,
+					TargetIndex: j,
+				}
+
+				// The cost matrix does not include the cost between a vertex and itself.
+				if sourceVertexID == targetVertexID {
+					var zero domain.RoadVerticesCost
+					entry.Distance = &zero.Distance
+					entry.Duration = &zero.Duration
+				} else if cost, ok := costsByVertices[[2]int{sourceVertexID, targetVertexID}]; ok {
+					entry.Distance = &cost.Distance
+					entry.Duration = &cost.Duration
+				}
+
+				routingMatrix.Entries = append(routingMatrix.Entries, entry)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrContainerNotFound),
+			errors.Is(err, domain.ErrWarehouseNotFound),
+			errors.Is(err, domain.ErrLandfillNotFound):
+			return domain.RoutingMatrix{}, logInfoAndWrapError(ctx, err, descriptionFailedGetRoutingMatrix, logAttrs...)
+		default:
+			return domain.RoutingMatrix{}, logAndWrapError(ctx, err, descriptionFailedGetRoutingMatrix, logAttrs...)
+		}
+	}
+
+	return routingMatrix, nil
+}
+
+// routingLocationGeometry returns the geometry point of the given routing location.
+func (s *service) routingLocationGeometry(ctx context.Context, tx pgx.Tx, location domain.RoutingLocation) (domain.GeoJSONGeometryPoint, error) {
+	switch {
+	case location.ContainerID != nil:
+		container, err := s.store.GetContainerByID(ctx, tx, *location.ContainerID)
+		if err != nil {
+			return domain.GeoJSONGeometryPoint{}, err
+		}
+
+		return geometryPointFromGeoJSON(container.GeoJSON), nil
+	case location.WarehouseID != nil:
+		warehouse, err := s.store.GetWarehouseByID(ctx, tx, *location.WarehouseID)
+		if err != nil {
+			return domain.GeoJSONGeometryPoint{}, err
+		}
+
+		return geometryPointFromGeoJSON(warehouse.GeoJSON), nil
+	case location.LandfillID != nil:
+		landfill, err := s.store.GetLandfillByID(ctx, tx, *location.LandfillID)
+		if err != nil {
+			return domain.GeoJSONGeometryPoint{}, err
+		}
+
+		return geometryPointFromGeoJSON(landfill.GeoJSON), nil
+	case location.Geometry != nil:
+		return *location.Geometry, nil
+	default:
+		return domain.GeoJSONGeometryPoint{}, nil
+	}
+}
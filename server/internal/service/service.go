@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -11,6 +12,8 @@ import (
 	"github.com/goncalo-marques/ecomap/server/internal/authn"
 	"github.com/goncalo-marques/ecomap/server/internal/domain"
 	"github.com/goncalo-marques/ecomap/server/internal/logging"
+	"github.com/goncalo-marques/ecomap/server/internal/mail"
+	"github.com/goncalo-marques/ecomap/server/internal/oidc"
 )
 
 const (
@@ -18,16 +21,47 @@ const (
 	descriptionInvalidFilterValue      = "service: invalid filter value"
 	descriptionFailedHashPassword      = "service: failed to hash password"
 	descriptionFailedCheckPasswordHash = "service: failed to check password hash"
-	descriptionFailedCreateJWT         = "service: failed to create jwt"
+	descriptionFailedRehashPassword    = "service: failed to rehash password"
+	descriptionFailedCreateTokens      = "service: failed to create tokens"
 )
 
 // AuthenticationService defines the authentication service interface.
 type AuthenticationService interface {
-	ValidPassword(password []byte) bool
 	HashPassword(password []byte) ([]byte, error)
 	CheckPasswordHash(password, hash []byte) (bool, error)
+	PasswordHashOutdated(hash []byte) bool
+
+	NewJWT(subject string, subjectRoles []authn.SubjectRole) (string, authn.Claims, error)
+	JWKS(t time.Time) []authn.JWK
+	NewRefreshToken() (authn.RefreshToken, error)
+	HashRefreshToken(token string) []byte
+	NewOneTimeToken(expirationTime time.Duration) (authn.OneTimeToken, error)
+	HashOneTimeToken(token string) []byte
+
+	NewTOTPSecret() (string, error)
+	TOTPURI(issuer, accountName, secret string) string
+	CheckTOTP(secret, code string, t time.Time) (int64, bool)
+	NewRecoveryCodes(amount int) ([]string, error)
+	HashRecoveryCode(code string) []byte
+
+	NewAPIKey() (authn.APIKey, error)
+	HashAPIKey(key string) []byte
+}
+
+// IdentityProvider defines the single sign-on identity provider interface.
+type IdentityProvider interface {
+	AuthorizationURL(ctx context.Context, state, nonce, codeVerifier string) (string, error)
+	Exchange(ctx context.Context, code, codeVerifier, nonce string) (oidc.Identity, error)
+}
 
-	NewJWT(subject string, subjectRoles []authn.SubjectRole) (string, error)
+// MailSender defines the mail sender interface.
+type MailSender interface {
+	Send(ctx context.Context, message mail.Message) error
+}
+
+// BreachedPasswordChecker defines the breached password checker interface.
+type BreachedPasswordChecker interface {
+	Breached(ctx context.Context, password []byte) (bool, error)
 }
 
 // Store defines the store interface.
@@ -39,8 +73,18 @@ type Store interface {
 	GetUserSignIn(ctx context.Context, tx pgx.Tx, username domain.Username) (domain.SignIn, error)
 	PatchUser(ctx context.Context, tx pgx.Tx, id uuid.UUID, editableUser domain.EditableUserPatch) error
 	UpdateUserPassword(ctx context.Context, tx pgx.Tx, username domain.Username, password domain.Password) error
+	UpdateUserPasswordHash(ctx context.Context, tx pgx.Tx, username domain.Username, oldHash, newHash domain.Password) error
+	AddUserPasswordHistory(ctx context.Context, tx pgx.Tx, username domain.Username, password domain.Password, keep int) error
+	ListUserPasswordHistory(ctx context.Context, tx pgx.Tx, username domain.Username, limit int) ([]domain.Password, error)
+	VerifyUserEmail(ctx context.Context, tx pgx.Tx, id uuid.UUID, email domain.Email, verifiedAt time.Time) error
 	DeleteUserByID(ctx context.Context, tx pgx.Tx, id uuid.UUID) error
 
+	CreateUserToken(ctx context.Context, tx pgx.Tx, editableUserToken domain.EditableUserToken) (uuid.UUID, error)
+	CountUserTokens(ctx context.Context, tx pgx.Tx, userID uuid.UUID, purpose domain.UserTokenPurpose, period time.Duration) (int, error)
+	GetUserTokenByHash(ctx context.Context, tx pgx.Tx, purpose domain.UserTokenPurpose, hash []byte) (domain.UserToken, error)
+	UseUserTokens(ctx context.Context, tx pgx.Tx, userID uuid.UUID, purpose domain.UserTokenPurpose, usedAt time.Time) error
+	DeleteExpiredUserTokens(ctx context.Context, tx pgx.Tx, before time.Time) error
+
 	CreateUserContainerBookmark(ctx context.Context, tx pgx.Tx, userID, containerID uuid.UUID) error
 	ListUserContainerBookmarks(ctx context.Context, tx pgx.Tx, userID uuid.UUID, filter domain.UserContainerBookmarksPaginatedFilter) (domain.PaginatedResponse[domain.Container], error)
 	DeleteUserContainerBookmark(ctx context.Context, tx pgx.Tx, userID, containerID uuid.UUID) error
@@ -52,7 +96,33 @@ type Store interface {
 	GetEmployeeSignIn(ctx context.Context, tx pgx.Tx, username domain.Username) (domain.SignIn, error)
 	PatchEmployee(ctx context.Context, tx pgx.Tx, id uuid.UUID, editableEmployee domain.EditableEmployeePatch, roadID, municipalityID *int) error
 	UpdateEmployeePassword(ctx context.Context, tx pgx.Tx, username domain.Username, password domain.Password) error
+	UpdateEmployeePasswordHash(ctx context.Context, tx pgx.Tx, username domain.Username, oldHash, newHash domain.Password) error
+	AddEmployeePasswordHistory(ctx context.Context, tx pgx.Tx, username domain.Username, password domain.Password, keep int) error
+	ListEmployeePasswordHistory(ctx context.Context, tx pgx.Tx, username domain.Username, limit int) ([]domain.Password, error)
 	DeleteEmployeeByID(ctx context.Context, tx pgx.Tx, id uuid.UUID) error
+	CreateEmployeeShifts(ctx context.Context, tx pgx.Tx, employeeID uuid.UUID, shifts domain.EmployeeShifts) error
+	GetEmployeeBySSOSubject(ctx context.Context, tx pgx.Tx, subject string) (domain.Employee, error)
+	UpdateEmployeeSSOSubject(ctx context.Context, tx pgx.Tx, id uuid.UUID, subject string) error
+	UpdateEmployeeRole(ctx context.Context, tx pgx.Tx, id uuid.UUID, role domain.EmployeeRole) error
+	ExistsEmployeeWithRole(ctx context.Context, tx pgx.Tx, role domain.RoleName) (bool, error)
+
+	CreateSSOAuthorization(ctx context.Context, tx pgx.Tx, authorization domain.SSOAuthorization) error
+	DeleteSSOAuthorization(ctx context.Context, tx pgx.Tx, state string) (domain.SSOAuthorization, error)
+	DeleteExpiredSSOAuthorizations(ctx context.Context, tx pgx.Tx, before time.Time) error
+	DeleteEmployeeShifts(ctx context.Context, tx pgx.Tx, employeeID uuid.UUID) error
+
+	CreateEmployeeAbsence(ctx context.Context, tx pgx.Tx, employeeID uuid.UUID, editableAbsence domain.EditableEmployeeAbsence) (uuid.UUID, error)
+	ExistsEmployeeAbsence(ctx context.Context, tx pgx.Tx, employeeID uuid.UUID, startDate, endDate time.Time) (bool, error)
+	ListEmployeesAbsentIDs(ctx context.Context, tx pgx.Tx, startDate, endDate time.Time) ([]uuid.UUID, error)
+	ListEmployeeAbsences(ctx context.Context, tx pgx.Tx, employeeID uuid.UUID, filter domain.EmployeeAbsencesPaginatedFilter) (domain.PaginatedResponse[domain.EmployeeAbsence], error)
+	GetEmployeeAbsenceByID(ctx context.Context, tx pgx.Tx, employeeID, id uuid.UUID) (domain.EmployeeAbsence, error)
+	DeleteEmployeeAbsenceByID(ctx context.Context, tx pgx.Tx, employeeID, id uuid.UUID) error
+
+	CreateEmployeeQualification(ctx context.Context, tx pgx.Tx, employeeID uuid.UUID, editableQualification domain.EditableEmployeeQualification) (uuid.UUID, error)
+	ListEmployeeQualifications(ctx context.Context, tx pgx.Tx, employeeID uuid.UUID) (domain.EmployeeQualifications, error)
+	ListEmployeesQualificationsExpiring(ctx context.Context, tx pgx.Tx, until time.Time, qualificationType *domain.EmployeeQualificationType) (domain.EmployeeQualifications, error)
+	GetEmployeeQualificationByID(ctx context.Context, tx pgx.Tx, employeeID, id uuid.UUID) (domain.EmployeeQualification, error)
+	DeleteEmployeeQualificationByID(ctx context.Context, tx pgx.Tx, employeeID, id uuid.UUID) error
 
 	CreateContainer(ctx context.Context, tx pgx.Tx, editableContainer domain.EditableContainer, roadID, municipalityID *int) (uuid.UUID, error)
 	ListContainers(ctx context.Context, tx pgx.Tx, filter domain.ContainersPaginatedFilter) (domain.PaginatedResponse[domain.Container], error)
@@ -65,6 +135,19 @@ type Store interface {
 	GetTruckByID(ctx context.Context, tx pgx.Tx, id uuid.UUID) (domain.Truck, error)
 	PatchTruck(ctx context.Context, tx pgx.Tx, id uuid.UUID, editableTruck domain.EditableTruckPatch, roadID, municipalityID *int) error
 	DeleteTruckByID(ctx context.Context, tx pgx.Tx, id uuid.UUID) error
+	CreateTruckCompartments(ctx context.Context, tx pgx.Tx, truckID uuid.UUID, compartments domain.TruckCompartments) error
+	DeleteTruckCompartments(ctx context.Context, tx pgx.Tx, truckID uuid.UUID) error
+	CreateTruckPositions(ctx context.Context, tx pgx.Tx, truckID uuid.UUID, positions domain.EditableTruckPositions) error
+	GetTruckLatestPosition(ctx context.Context, tx pgx.Tx, truckID uuid.UUID) (domain.TruckPosition, error)
+	ListTruckPositions(ctx context.Context, tx pgx.Tx, truckID uuid.UUID, from, to time.Time) ([]domain.TruckPosition, error)
+	CreateTruckMaintenance(ctx context.Context, tx pgx.Tx, truckID uuid.UUID, editableMaintenance domain.EditableTruckMaintenance) (uuid.UUID, error)
+	ListTruckMaintenances(ctx context.Context, tx pgx.Tx, truckID uuid.UUID, filter domain.TruckMaintenancesPaginatedFilter) (domain.PaginatedResponse[domain.TruckMaintenance], error)
+	GetTruckMaintenanceByID(ctx context.Context, tx pgx.Tx, truckID, id uuid.UUID) (domain.TruckMaintenance, error)
+	ListTrucksLastMaintenances(ctx context.Context, tx pgx.Tx, maintenanceType domain.TruckMaintenanceType) ([]domain.TruckMaintenance, error)
+	DeleteTruckMaintenanceByID(ctx context.Context, tx pgx.Tx, truckID, id uuid.UUID) error
+	CreateTruckOdometerReading(ctx context.Context, tx pgx.Tx, truckID uuid.UUID, editableReading domain.EditableTruckOdometerReading) (domain.TruckOdometerReading, error)
+	ExistsTruckOdometerReadingInconsistent(ctx context.Context, tx pgx.Tx, truckID uuid.UUID, editableReading domain.EditableTruckOdometerReading) (bool, error)
+	ListTruckOdometerReadings(ctx context.Context, tx pgx.Tx, truckID uuid.UUID, filter domain.TruckOdometerReadingsPaginatedFilter) (domain.PaginatedResponse[domain.TruckOdometerReading], error)
 
 	CreateWarehouse(ctx context.Context, tx pgx.Tx, editableWarehouse domain.EditableWarehouse, roadID, municipalityID *int) (uuid.UUID, error)
 	ListWarehouses(ctx context.Context, tx pgx.Tx, filter domain.WarehousesPaginatedFilter) (domain.PaginatedResponse[domain.Warehouse], error)
@@ -77,7 +160,8 @@ type Store interface {
 	CreateLandfill(ctx context.Context, tx pgx.Tx, editableLandfill domain.EditableLandfill, roadID, municipalityID *int) (uuid.UUID, error)
 	ListLandfills(ctx context.Context, tx pgx.Tx, filter domain.LandfillsPaginatedFilter) (domain.PaginatedResponse[domain.Landfill], error)
 	GetLandfillByID(ctx context.Context, tx pgx.Tx, id uuid.UUID) (domain.Landfill, error)
-	GetLandfillClosestGeometry(ctx context.Context, tx pgx.Tx, geometry domain.GeoJSONGeometryPoint) (domain.Landfill, error)
+	GetLandfillClosestGeometry(ctx context.Context, tx pgx.Tx, geometry domain.GeoJSONGeometryPoint, categories []domain.ContainerCategory) (domain.Landfill, error)
+	ExistsLandfill(ctx context.Context, tx pgx.Tx) (bool, error)
 	PatchLandfill(ctx context.Context, tx pgx.Tx, id uuid.UUID, editableLandfill domain.EditableLandfillPatch, roadID, municipalityID *int) error
 	DeleteLandfillByID(ctx context.Context, tx pgx.Tx, id uuid.UUID) error
 
@@ -86,40 +170,187 @@ type Store interface {
 	GetRouteByID(ctx context.Context, tx pgx.Tx, id uuid.UUID) (domain.Route, error)
 	PatchRoute(ctx context.Context, tx pgx.Tx, id uuid.UUID, editableRoute domain.EditableRoutePatch) error
 	DeleteRouteByID(ctx context.Context, tx pgx.Tx, id uuid.UUID) error
+	ListRoutesInProgressByTruckID(ctx context.Context, tx pgx.Tx, truckID uuid.UUID) ([]domain.Route, error)
+	ListRoutesByContainerID(ctx context.Context, tx pgx.Tx, containerID uuid.UUID) ([]domain.Route, error)
+	StartRoute(ctx context.Context, tx pgx.Tx, id uuid.UUID, startedAt time.Time) error
+	FinishRoute(ctx context.Context, tx pgx.Tx, id uuid.UUID, finishedAt time.Time) error
 
 	CreateRouteContainer(ctx context.Context, tx pgx.Tx, routeID, containerID uuid.UUID) error
 	ListRouteContainers(ctx context.Context, tx pgx.Tx, routeID uuid.UUID, filter domain.RouteContainersPaginatedFilter) (domain.PaginatedResponse[domain.Container], error)
 	DeleteRouteContainer(ctx context.Context, tx pgx.Tx, routeID, containerID uuid.UUID) error
+	PutRouteContainerCollection(ctx context.Context, tx pgx.Tx, collection domain.RouteContainerCollection) error
+	GetRouteContainerCollection(ctx context.Context, tx pgx.Tx, routeID, containerID uuid.UUID, routeStartedAt time.Time) (domain.RouteContainerCollection, error)
+	ListRouteContainerCollections(ctx context.Context, tx pgx.Tx, routeID uuid.UUID, routeStartedAt time.Time) ([]domain.RouteContainerCollection, error)
+	ListRouteContainersDwells(ctx context.Context, tx pgx.Tx, routeID, truckID uuid.UUID, routeStartedAt, since time.Time, radius float64) ([]domain.RouteContainerDwell, error)
+
+	PutRouteTrack(ctx context.Context, tx pgx.Tx, track domain.RouteTrack) error
+	GetRouteTrack(ctx context.Context, tx pgx.Tx, routeID uuid.UUID, date time.Time) (domain.RouteTrack, error)
+	ListRouteTracks(ctx context.Context, tx pgx.Tx, filter domain.RouteTracksPaginatedFilter) (domain.PaginatedResponse[domain.RouteTrack], error)
+	ListRoutesContainerCategoriesByTruckID(ctx context.Context, tx pgx.Tx, truckID uuid.UUID) ([]domain.ContainerCategory, error)
 
 	CreateRouteEmployee(ctx context.Context, tx pgx.Tx, routeID, employeeID uuid.UUID, editableRouteEmployee domain.EditableRouteEmployee) error
 	ListRouteEmployees(ctx context.Context, tx pgx.Tx, routeID uuid.UUID, filter domain.RouteEmployeesPaginatedFilter) (domain.PaginatedResponse[domain.RouteEmployee], error)
+	ListEmployeeRoutesRoles(ctx context.Context, tx pgx.Tx, employeeID uuid.UUID) (map[uuid.UUID]domain.RouteEmployeeRole, error)
 	DeleteRouteEmployee(ctx context.Context, tx pgx.Tx, routeID, employeeID uuid.UUID) error
 
 	GetRoadByGeometry(ctx context.Context, tx pgx.Tx, geometry domain.GeoJSONGeometryPoint) (domain.Road, error)
 	CreateTemporaryTableRoadNetworkWithBuffer(ctx context.Context, tx pgx.Tx, tableName string, verticesGeometry []domain.GeoJSONGeometryPoint) error
 	CreateVerticesCloseToRoadNetwork(ctx context.Context, tx pgx.Tx, roadNetworkTableName string, verticesGeometry []domain.GeoJSONGeometryPoint) ([]int, error)
 	GetRoadVerticesTSP(ctx context.Context, tx pgx.Tx, roadNetworkTableName string, vertexIDs []int, startVertexID, endVertexID int, directed bool) ([]int, error)
+	GetRoadVerticesCostMatrix(ctx context.Context, tx pgx.Tx, roadNetworkTableName string, vertexIDs []int, directed bool) ([]domain.RoadVerticesCost, error)
+	GetRoadVerticesDurationMatrix(ctx context.Context, tx pgx.Tx, roadNetworkTableName string, vertexIDs []int, directed bool) ([]domain.RoadVerticesCost, error)
 	GetRoadsGeometryAStar(ctx context.Context, tx pgx.Tx, roadNetworkTableName string, seqVertexIDs []int, directed bool) ([]domain.GeoJSONGeometryLineString, error)
 
 	GetMunicipalityByGeometry(ctx context.Context, tx pgx.Tx, geometry domain.GeoJSONGeometryPoint) (domain.Municipality, error)
 
 	NewTx(ctx context.Context, isoLevel pgx.TxIsoLevel, accessMode pgx.TxAccessMode) (pgx.Tx, error)
+
+	CreateRefreshToken(ctx context.Context, tx pgx.Tx, editableRefreshToken domain.EditableRefreshToken) (uuid.UUID, error)
+	GetRefreshTokenByHash(ctx context.Context, tx pgx.Tx, hash []byte) (domain.RefreshToken, error)
+	UseRefreshToken(ctx context.Context, tx pgx.Tx, id uuid.UUID, usedAt time.Time) error
+	RevokeRefreshTokenFamily(ctx context.Context, tx pgx.Tx, familyID uuid.UUID, revokedAt time.Time) error
+	RevokeSubjectRefreshTokens(ctx context.Context, tx pgx.Tx, subjectID uuid.UUID, revokedAt time.Time) error
+	DeleteExpiredTokens(ctx context.Context, tx pgx.Tx, before time.Time) error
+	ExistsRevokedToken(ctx context.Context, tx pgx.Tx, id uuid.UUID) (bool, error)
+
+	RecordSignInFailure(ctx context.Context, tx pgx.Tx, subjectType domain.SubjectType, keyType domain.SignInLockoutKeyType, key string, failedAt, windowStart time.Time) (domain.SignInLockout, error)
+	UpdateSignInLockoutUntil(ctx context.Context, tx pgx.Tx, id uuid.UUID, blockedUntil, lockedUntil *time.Time) error
+	GetSignInLockout(ctx context.Context, tx pgx.Tx, subjectType domain.SubjectType, keyType domain.SignInLockoutKeyType, key string) (domain.SignInLockout, error)
+	ListSignInLockouts(ctx context.Context, tx pgx.Tx, filter domain.SignInLockoutsFilter, windowStart, at time.Time) ([]domain.SignInLockout, error)
+	GetSignInLockoutByID(ctx context.Context, tx pgx.Tx, id uuid.UUID) (domain.SignInLockout, error)
+	DeleteSignInLockoutByID(ctx context.Context, tx pgx.Tx, id uuid.UUID) error
+	DeleteSignInLockout(ctx context.Context, tx pgx.Tx, subjectType domain.SubjectType, keyType domain.SignInLockoutKeyType, key string) error
+	DeleteExpiredSignInLockouts(ctx context.Context, tx pgx.Tx, windowStart, at time.Time) error
+
+	PutEmployeeTOTP(ctx context.Context, tx pgx.Tx, employeeID uuid.UUID, secret string) error
+	GetEmployeeTOTP(ctx context.Context, tx pgx.Tx, employeeID uuid.UUID) (domain.EmployeeTOTP, error)
+	ConfirmEmployeeTOTP(ctx context.Context, tx pgx.Tx, employeeID uuid.UUID, step int64, confirmedAt time.Time) error
+	UseEmployeeTOTPStep(ctx context.Context, tx pgx.Tx, employeeID uuid.UUID, step int64) error
+	DeleteEmployeeTOTP(ctx context.Context, tx pgx.Tx, employeeID uuid.UUID) error
+	CreateEmployeeRecoveryCodes(ctx context.Context, tx pgx.Tx, employeeID uuid.UUID, hashes [][]byte) error
+	UseEmployeeRecoveryCode(ctx context.Context, tx pgx.Tx, employeeID uuid.UUID, hash []byte, usedAt time.Time) error
+	DeleteEmployeeRecoveryCodes(ctx context.Context, tx pgx.Tx, employeeID uuid.UUID) error
+
+	CreateEmployeeMFAChallenge(ctx context.Context, tx pgx.Tx, editableChallenge domain.EditableEmployeeMFAChallenge) (uuid.UUID, error)
+	GetEmployeeMFAChallengeByHash(ctx context.Context, tx pgx.Tx, hash []byte) (domain.EmployeeMFAChallenge, error)
+	IncrementEmployeeMFAChallengeAttempts(ctx context.Context, tx pgx.Tx, id uuid.UUID) error
+	DeleteEmployeeMFAChallenge(ctx context.Context, tx pgx.Tx, id uuid.UUID) error
+	DeleteExpiredEmployeeMFAChallenges(ctx context.Context, tx pgx.Tx, before time.Time) error
+
+	CreateAPIKey(ctx context.Context, tx pgx.Tx, editableAPIKey domain.EditableAPIKeyWithHash) (uuid.UUID, error)
+	ListAPIKeys(ctx context.Context, tx pgx.Tx) ([]domain.APIKey, error)
+	GetAPIKeyByID(ctx context.Context, tx pgx.Tx, id uuid.UUID) (domain.APIKey, error)
+	GetAPIKeyByHash(ctx context.Context, tx pgx.Tx, hash []byte) (domain.APIKey, error)
+	UpdateAPIKeyLastUsedAt(ctx context.Context, tx pgx.Tx, id uuid.UUID, lastUsedAt, threshold time.Time) error
+	ExistsAPIKeyWithRole(ctx context.Context, tx pgx.Tx, role domain.RoleName) (bool, error)
+	DeleteAPIKeyByID(ctx context.Context, tx pgx.Tx, id uuid.UUID) error
+
+	CreateRole(ctx context.Context, tx pgx.Tx, editableRole domain.EditableRole) (uuid.UUID, error)
+	ListRoles(ctx context.Context, tx pgx.Tx) ([]domain.Role, error)
+	ListRolesByName(ctx context.Context, tx pgx.Tx, names []domain.RoleName) ([]domain.Role, error)
+	GetRoleByID(ctx context.Context, tx pgx.Tx, id uuid.UUID) (domain.Role, error)
+	PatchRole(ctx context.Context, tx pgx.Tx, id uuid.UUID, editableRole domain.EditableRolePatch) error
+	DeleteRoleByID(ctx context.Context, tx pgx.Tx, id uuid.UUID) error
+	ListRolesPermissions(ctx context.Context, tx pgx.Tx, names []domain.RoleName) ([]domain.Permission, error)
+}
+
+// Options defines the service options structure.
+type Options struct {
+	CollectionDetectionRadius    float64                        // Maximum distance, in meters, between the truck and a container to detect its collection.
+	CollectionDetectionDwellTime time.Duration                  // Minimum time the truck must dwell close to a container to detect its collection.
+	DeviationRadius              float64                        // Maximum distance, in meters, between a route track and the planned route to be considered on route.
+	SSOGroupsRoles               map[string]domain.EmployeeRole // Employee roles of the identity provider groups.
+	UserPasswordResetURL         string                         // URL where users reset the password, to which the token is added as a query parameter.
+	UserEmailVerificationURL     string                         // URL where users verify the email address, to which the token is added as a query parameter.
+	UserTokensLimit              int                            // Maximum amount of tokens of each purpose sent to the same user within the limit period.
+	UserTokensLimitPeriod        time.Duration                  // Period within which the tokens sent to the same user are limited.
+	SignInFailureWindow          time.Duration                  // Period within which the failed sign-in attempts are counted.
+	SignInBackoffThreshold       int                            // Amount of failed sign-in attempts with the same username after which the following attempts are delayed exponentially.
+	SignInBackoffBase            time.Duration                  // Delay after the first failed sign-in attempt above the backoff threshold.
+	SignInBackoffMax             time.Duration                  // Maximum delay between failed sign-in attempts.
+	SignInLockoutThreshold       int                            // Amount of failed sign-in attempts with the same username after which the account is locked.
+	SignInLockoutDuration        time.Duration                  // Duration of account and IP address lockouts.
+	SignInIPAddressThreshold     int                            // Amount of failed sign-in attempts from the same IP address after which the IP address is blocked.
+	TOTPIssuer                   string                         // Issuer shown by authenticator applications.
+	TOTPRequiredRoles            []domain.EmployeeRole          // Employee roles that must sign in with two-factor authentication.
+	UserPasswordPolicy           domain.PasswordPolicy          // Password policy of the users.
+	EmployeePasswordPolicy       domain.PasswordPolicy          // Password policy of the employees.
+	EmployeePasswordMaxAge       time.Duration                  // Maximum age of the employee passwords, after which they must be changed. Passwords do not expire when it is not defined.
 }
 
 // service defines the service structure.
 type service struct {
-	authnService AuthenticationService
-	store        Store
+	authnService         AuthenticationService
+	idpService           IdentityProvider
+	mailSender           MailSender
+	breachedPasswords    BreachedPasswordChecker
+	store                Store
+	options              Options
+	truckPositionsBroker *truckPositionsBroker
 }
 
-// New returns a new http handler.
-func New(authnService AuthenticationService, store Store) *service {
+// New returns a new http handler. Options that are not defined are set to their default values. The identity provider
+// is optional, and single sign-on is not available without it. The breached password checker is also optional, and
+// breached passwords are not rejected without it.
+func New(authnService AuthenticationService, idpService IdentityProvider, mailSender MailSender, breachedPasswords BreachedPasswordChecker, store Store, options Options) *service {
+	if options.CollectionDetectionRadius <= 0 {
+		options.CollectionDetectionRadius = defaultCollectionDetectionRadius
+	}
+	if options.CollectionDetectionDwellTime <= 0 {
+		options.CollectionDetectionDwellTime = defaultCollectionDetectionDwellTime
+	}
+	if options.DeviationRadius <= 0 {
+		options.DeviationRadius = defaultDeviationRadius
+	}
+	if options.UserTokensLimit <= 0 {
+		options.UserTokensLimit = defaultUserTokensLimit
+	}
+	if options.UserTokensLimitPeriod <= 0 {
+		options.UserTokensLimitPeriod = defaultUserTokensLimitPeriod
+	}
+	if options.SignInFailureWindow <= 0 {
+		options.SignInFailureWindow = defaultSignInFailureWindow
+	}
+	if options.SignInBackoffThreshold <= 0 {
+		options.SignInBackoffThreshold = defaultSignInBackoffThreshold
+	}
+	if options.SignInBackoffBase <= 0 {
+		options.SignInBackoffBase = defaultSignInBackoffBase
+	}
+	if options.SignInBackoffMax <= 0 {
+		options.SignInBackoffMax = defaultSignInBackoffMax
+	}
+	if options.SignInLockoutThreshold <= 0 {
+		options.SignInLockoutThreshold = defaultSignInLockoutThreshold
+	}
+	if options.SignInLockoutDuration <= 0 {
+		options.SignInLockoutDuration = defaultSignInLockoutDuration
+	}
+	if options.SignInIPAddressThreshold <= 0 {
+		options.SignInIPAddressThreshold = defaultSignInIPAddressThreshold
+	}
+	if len(options.TOTPIssuer) == 0 {
+		options.TOTPIssuer = defaultTOTPIssuer
+	}
+	options.UserPasswordPolicy = defaultPasswordPolicy(options.UserPasswordPolicy)
+	options.EmployeePasswordPolicy = defaultPasswordPolicy(options.EmployeePasswordPolicy)
+
 	return &service{
-		authnService: authnService,
-		store:        store,
+		authnService:         authnService,
+		idpService:           idpService,
+		mailSender:           mailSender,
+		breachedPasswords:    breachedPasswords,
+		store:                store,
+		options:              options,
+		truckPositionsBroker: newTruckPositionsBroker(),
 	}
 }
 
+// Close closes the live subscriptions of the service.
+func (s *service) Close() {
+	s.truckPositionsBroker.close()
+}
+
 // rollbackFunc returns a function to rollback a transaction.
 func rollbackFunc(ctx context.Context, tx pgx.Tx) func() {
 	return func() {
@@ -160,6 +391,44 @@ func (s *service) readWriteTx(ctx context.Context, f func(pgx.Tx) error) error {
 	return tx.Commit(ctx)
 }
 
+// readCommittedTx returns a read and write transaction wrapper with the read committed isolation level, for writes
+// that must not fail to serialize when executed concurrently.
+func (s *service) readCommittedTx(ctx context.Context, f func(pgx.Tx) error) error {
+	tx, err := s.store.NewTx(ctx, pgx.ReadCommitted, pgx.ReadWrite)
+	if err != nil {
+		return err
+	}
+	defer rollbackFunc(ctx, tx)()
+
+	if err := f(tx); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// rehashPassword hashes the password again when the given hash was not computed with the current algorithm and
+// parameters, and replaces the hash with the given function. Failing to do so is only logged, since the outdated hash
+// remains valid.
+func (s *service) rehashPassword(ctx context.Context, password, hash domain.Password, updateHash func(tx pgx.Tx, oldHash, newHash domain.Password) error, logAttrs ...any) {
+	if !s.authnService.PasswordHashOutdated([]byte(hash)) {
+		return
+	}
+
+	newHash, err := s.authnService.HashPassword([]byte(password))
+	if err != nil {
+		logging.Logger.ErrorContext(ctx, descriptionFailedRehashPassword, append(logAttrs, logging.Error(err))...)
+		return
+	}
+
+	err = s.readCommittedTx(ctx, func(tx pgx.Tx) error {
+		return updateHash(tx, hash, domain.Password(newHash))
+	})
+	if err != nil {
+		logging.Logger.ErrorContext(ctx, descriptionFailedRehashPassword, append(logAttrs, logging.Error(err))...)
+	}
+}
+
 // logInfoAndWrapError logs the error at the info level and returns the error wrapped with the provided description.
 func logInfoAndWrapError(ctx context.Context, err error, description string, logAttrs ...any) error {
 	logAttrs = append(logAttrs, logging.Error(err))
@@ -0,0 +1,287 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/netip"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/goncalo-marques/ecomap/server/internal/domain"
+	"github.com/goncalo-marques/ecomap/server/internal/logging"
+)
+
+const (
+	// defaultSignInFailureWindow defines the default period within which the failed sign-in attempts are counted.
+	defaultSignInFailureWindow = time.Hour
+
+	// defaultSignInBackoffThreshold defines the default amount of failed sign-in attempts after which the following
+	// attempts are delayed exponentially.
+	defaultSignInBackoffThreshold = 3
+
+	// defaultSignInBackoffBase defines the default delay after the first failed sign-in attempt above the backoff
+	// threshold, which doubles with each failure.
+	defaultSignInBackoffBase = time.Second
+
+	// defaultSignInBackoffMax defines the default maximum delay between failed sign-in attempts.
+	defaultSignInBackoffMax = 5 * time.Minute
+
+	// defaultSignInLockoutThreshold defines the default amount of failed sign-in attempts with the same username after
+	// which the account is locked.
+	defaultSignInLockoutThreshold = 10
+
+	// defaultSignInLockoutDuration defines the default duration of account and IP address lockouts.
+	defaultSignInLockoutDuration = 15 * time.Minute
+
+	// defaultSignInIPAddressThreshold defines the default amount of failed sign-in attempts from the same IP address
+	// after which the IP address is blocked.
+	defaultSignInIPAddressThreshold = 50
+
+	descriptionSignInRejected                = "service: sign-in attempt rejected"
+	descriptionSignInAccountLocked           = "service: account locked after failed sign-in attempts"
+	descriptionSignInIPAddressBlocked        = "service: ip address blocked after failed sign-in attempts"
+	descriptionFailedCheckSignInLockouts     = "service: failed to check sign-in lockouts"
+	descriptionFailedRecordSignInFailure     = "service: failed to record sign-in failure"
+	descriptionFailedDeleteSignInLockout     = "service: failed to delete sign-in lockout"
+	descriptionFailedListSignInLockouts      = "service: failed to list sign-in lockouts"
+	descriptionFailedGetSignInLockoutByID    = "service: failed to get sign-in lockout by id"
+	descriptionFailedDeleteSignInLockoutByID = "service: failed to delete sign-in lockout by id"
+)
+
+// signInKey defines the key by which the failed sign-in attempts are tracked.
+type signInKey struct {
+	keyType domain.SignInLockoutKeyType
+	key     string
+}
+
+// ListSignInLockouts returns the sign-in lockouts with the specified filter that recorded failures within the failure
+// window or still reject attempts.
+func (s *service) ListSignInLockouts(ctx context.Context, filter domain.SignInLockoutsFilter) ([]domain.SignInLockout, error) {
+	logAttrs := []any{
+		slog.String(logging.ServiceMethod, "ListSignInLockouts"),
+	}
+
+	if filter.SubjectType != nil && !filter.SubjectType.Valid() {
+		return nil, logInfoAndWrapError(ctx, &domain.ErrFilterValueInvalid{FilterName: domain.FieldSubjectType}, descriptionInvalidFilterValue, logAttrs...)
+	}
+	if filter.KeyType != nil && !filter.KeyType.Valid() {
+		return nil, logInfoAndWrapError(ctx, &domain.ErrFilterValueInvalid{FilterName: domain.FieldKeyType}, descriptionInvalidFilterValue, logAttrs...)
+	}
+
+	now := time.Now().UTC()
+
+	var lockouts []domain.SignInLockout
+	var err error
+
+	err = s.readOnlyTx(ctx, func(tx pgx.Tx) error {
+		lockouts, err = s.store.ListSignInLockouts(ctx, tx, filter, now.Add(-s.options.SignInFailureWindow), now)
+		return err
+	})
+	if err != nil {
+		return nil, logAndWrapError(ctx, err, descriptionFailedListSignInLockouts, logAttrs...)
+	}
+
+	return lockouts, nil
+}
+
+// DeleteSignInLockoutByID deletes the sign-in lockout with the specified identifier, which clears the failed sign-in
+// attempts of the username or IP address.
+func (s *service) DeleteSignInLockoutByID(ctx context.Context, id uuid.UUID) (domain.SignInLockout, error) {
+	logAttrs := []any{
+		slog.String(logging.ServiceMethod, "DeleteSignInLockoutByID"),
+		slog.String(logging.SignInLockoutID, id.String()),
+	}
+
+	var lockout domain.SignInLockout
+	var err error
+
+	err = s.readWriteTx(ctx, func(tx pgx.Tx) error {
+		lockout, err = s.store.GetSignInLockoutByID(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		return s.store.DeleteSignInLockoutByID(ctx, tx, id)
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrSignInLockoutNotFound):
+			return domain.SignInLockout{}, logInfoAndWrapError(ctx, err, descriptionFailedDeleteSignInLockoutByID, logAttrs...)
+		default:
+			return domain.SignInLockout{}, logAndWrapError(ctx, err, descriptionFailedDeleteSignInLockoutByID, logAttrs...)
+		}
+	}
+
+	logging.Logger.InfoContext(ctx, "service: sign-in lockout cleared", append(logAttrs,
+		slog.String(logging.SignInLockoutSubjectType, string(lockout.SubjectType)),
+		slog.String(logging.SignInLockoutKeyType, string(lockout.KeyType)),
+		slog.String(logging.SignInLockoutKey, lockout.Key),
+	)...)
+
+	return lockout, nil
+}
+
+// checkSignInLockouts returns an error if the sign-in attempt of the subject type with the specified username and IP
+// address must be rejected, either because the account is locked or the attempts are blocked due to previous failures.
+func (s *service) checkSignInLockouts(ctx context.Context, subjectType domain.SubjectType, username domain.Username, ipAddress netip.Addr, logAttrs ...any) error {
+	now := time.Now().UTC()
+
+	var retryAt time.Time
+
+	err := s.readOnlyTx(ctx, func(tx pgx.Tx) error {
+		for _, key := range signInKeys(username, ipAddress) {
+			lockout, err := s.store.GetSignInLockout(ctx, tx, subjectType, key.keyType, key.key)
+			if err != nil {
+				if errors.Is(err, domain.ErrSignInLockoutNotFound) {
+					continue
+				}
+
+				return err
+			}
+
+			if lockout.Locked(now) {
+				return &domain.ErrSignInLocked{LockedUntil: *lockout.LockedUntil}
+			}
+			if lockout.Blocked(now) && lockout.BlockedUntil.After(retryAt) {
+				retryAt = *lockout.BlockedUntil
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		var domainErrSignInLocked *domain.ErrSignInLocked
+
+		switch {
+		case errors.As(err, &domainErrSignInLocked):
+			return logInfoAndWrapError(ctx, err, descriptionSignInRejected, logAttrs...)
+		default:
+			return logAndWrapError(ctx, err, descriptionFailedCheckSignInLockouts, logAttrs...)
+		}
+	}
+
+	if !retryAt.IsZero() {
+		return logInfoAndWrapError(ctx, &domain.ErrSignInThrottled{RetryAt: retryAt}, descriptionSignInRejected, logAttrs...)
+	}
+
+	return nil
+}
+
+// recordSignInFailure records a failed sign-in attempt of the subject type with the specified username and IP address.
+// The following attempts with the username are delayed once the backoff threshold is reached and the account is locked
+// once the lockout threshold is reached. The IP address is only blocked once the IP address threshold is reached, so
+// that clients sharing it are not delayed by the failures of others. Errors are logged, since the attempt has already
+// failed.
+func (s *service) recordSignInFailure(ctx context.Context, subjectType domain.SubjectType, username domain.Username, ipAddress netip.Addr, logAttrs ...any) {
+	now := time.Now().UTC()
+	windowStart := now.Add(-s.options.SignInFailureWindow)
+
+	var lockouts []domain.SignInLockout
+
+	// Failures are recorded with the read committed isolation level, so that concurrent attempts are all counted
+	// instead of failing to serialize.
+	err := s.readCommittedTx(ctx, func(tx pgx.Tx) error {
+		err := s.store.DeleteExpiredSignInLockouts(ctx, tx, windowStart, now)
+		if err != nil {
+			return err
+		}
+
+		for _, key := range signInKeys(username, ipAddress) {
+			lockout, err := s.store.RecordSignInFailure(ctx, tx, subjectType, key.keyType, key.key, now, windowStart)
+			if err != nil {
+				return err
+			}
+
+			switch key.keyType {
+			case domain.SignInLockoutKeyTypeUsername:
+				if backoff := s.signInBackoff(lockout.Failures); backoff > 0 {
+					blockedUntil := now.Add(backoff)
+					lockout.BlockedUntil = &blockedUntil
+				}
+				if lockout.Failures >= s.options.SignInLockoutThreshold {
+					lockedUntil := now.Add(s.options.SignInLockoutDuration)
+					lockout.LockedUntil = &lockedUntil
+					lockouts = append(lockouts, lockout)
+				}
+			case domain.SignInLockoutKeyTypeIPAddress:
+				if lockout.Failures >= s.options.SignInIPAddressThreshold {
+					blockedUntil := now.Add(s.options.SignInLockoutDuration)
+					lockout.BlockedUntil = &blockedUntil
+					lockouts = append(lockouts, lockout)
+				}
+			}
+
+			err = s.store.UpdateSignInLockoutUntil(ctx, tx, lockout.ID, lockout.BlockedUntil, lockout.LockedUntil)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		logging.Logger.ErrorContext(ctx, descriptionFailedRecordSignInFailure, append(logAttrs, logging.Error(err))...)
+		return
+	}
+
+	// Log the lockouts at the warn level, since they indicate brute-force or credential stuffing attacks.
+	for _, lockout := range lockouts {
+		description := descriptionSignInAccountLocked
+		if lockout.KeyType == domain.SignInLockoutKeyTypeIPAddress {
+			description = descriptionSignInIPAddressBlocked
+		}
+
+		logging.Logger.WarnContext(ctx, description, append(logAttrs,
+			slog.String(logging.SignInLockoutID, lockout.ID.String()),
+			slog.String(logging.SignInLockoutSubjectType, string(lockout.SubjectType)),
+			slog.String(logging.SignInLockoutKeyType, string(lockout.KeyType)),
+			slog.String(logging.SignInLockoutKey, lockout.Key),
+			slog.Int(logging.SignInLockoutFailures, lockout.Failures),
+		)...)
+	}
+}
+
+// clearSignInFailures clears the failed sign-in attempts of the subject type with the specified username after a
+// successful sign-in. The failures of the IP address are kept, so that an attacker cannot reset them by signing in
+// with an account of its own. Errors are logged, since the attempt has already succeeded.
+func (s *service) clearSignInFailures(ctx context.Context, subjectType domain.SubjectType, username domain.Username, logAttrs ...any) {
+	err := s.readWriteTx(ctx, func(tx pgx.Tx) error {
+		return s.store.DeleteSignInLockout(ctx, tx, subjectType, domain.SignInLockoutKeyTypeUsername, string(username))
+	})
+	if err != nil {
+		logging.Logger.ErrorContext(ctx, descriptionFailedDeleteSignInLockout, append(logAttrs, logging.Error(err))...)
+	}
+}
+
+// signInBackoff returns the delay until the next sign-in attempt after the specified amount of failures, which doubles
+// with each failure above the backoff threshold, up to the maximum backoff.
+func (s *service) signInBackoff(failures int) time.Duration {
+	if failures < s.options.SignInBackoffThreshold {
+		return 0
+	}
+
+	backoff := s.options.SignInBackoffBase
+	for i := s.options.SignInBackoffThreshold; i < failures && backoff < s.options.SignInBackoffMax; i++ {
+		backoff *= 2
+	}
+
+	return min(backoff, s.options.SignInBackoffMax)
+}
+
+// signInKeys returns the keys by which the sign-in attempts with the specified username and IP address are tracked.
+// Invalid usernames, which cannot belong to any account, and invalid IP addresses are not tracked.
+func signInKeys(username domain.Username, ipAddress netip.Addr) []signInKey {
+	var keys []signInKey
+
+	if username.Valid() {
+		keys = append(keys, signInKey{keyType: domain.SignInLockoutKeyTypeUsername, key: string(username)})
+	}
+	if ipAddress.IsValid() {
+		keys = append(keys, signInKey{keyType: domain.SignInLockoutKeyTypeIPAddress, key: ipAddress.Unmap().String()})
+	}
+
+	return keys
+}
@@ -0,0 +1,249 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/goncalo-marques/ecomap/server/internal/authn"
+	"github.com/goncalo-marques/ecomap/server/internal/domain"
+	"github.com/goncalo-marques/ecomap/server/internal/logging"
+)
+
+const (
+	descriptionFailedRefreshTokens     = "service: failed to refresh tokens"
+	descriptionFailedSignOut           = "service: failed to sign out"
+	descriptionFailedCheckTokenRevoked = "service: failed to check token revoked"
+)
+
+// RefreshTokens returns a new pair of tokens for the subject of the specified refresh token, which is rotated and
+// cannot be used again. Using a refresh token that was already rotated revokes every token of its family, since it
+// indicates that the token was stolen.
+func (s *service) RefreshTokens(ctx context.Context, refreshToken string) (domain.Tokens, error) {
+	logAttrs := []any{
+		slog.String(logging.ServiceMethod, "RefreshTokens"),
+	}
+
+	hash := s.authnService.HashRefreshToken(refreshToken)
+
+	var tokens domain.Tokens
+	var reused bool
+
+	err := s.readWriteTx(ctx, func(tx pgx.Tx) error {
+		storedRefreshToken, err := s.store.GetRefreshTokenByHash(ctx, tx, hash)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now().UTC()
+
+		// The revocation must be committed, so the error is only returned after the transaction.
+		if storedRefreshToken.UsedAt != nil && storedRefreshToken.RevokedAt == nil {
+			reused = true
+			return s.store.RevokeRefreshTokenFamily(ctx, tx, storedRefreshToken.FamilyID, now)
+		}
+
+		if !storedRefreshToken.Valid(now) {
+			return domain.ErrRefreshTokenInvalid
+		}
+
+		// The roles are obtained again, so that the new access token reflects the current role of the subject.
+		subjectRoles, err := s.subjectRoles(ctx, tx, storedRefreshToken.SubjectType, storedRefreshToken.SubjectID)
+		if err != nil {
+			if errors.Is(err, domain.ErrUserNotFound) || errors.Is(err, domain.ErrEmployeeNotFound) {
+				return domain.ErrRefreshTokenInvalid
+			}
+
+			return err
+		}
+
+		err = s.store.UseRefreshToken(ctx, tx, storedRefreshToken.ID, now)
+		if err != nil {
+			return err
+		}
+
+		tokens, err = s.createTokens(ctx, tx, storedRefreshToken.SubjectType, storedRefreshToken.SubjectID, subjectRoles, storedRefreshToken.FamilyID)
+		return err
+	})
+	if err == nil && reused {
+		err = domain.ErrRefreshTokenInvalid
+	}
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrRefreshTokenInvalid):
+			return domain.Tokens{}, logInfoAndWrapError(ctx, err, descriptionFailedRefreshTokens, logAttrs...)
+		default:
+			return domain.Tokens{}, logAndWrapError(ctx, err, descriptionFailedRefreshTokens, logAttrs...)
+		}
+	}
+
+	return tokens, nil
+}
+
+// SignOutUser revokes the specified refresh token of a user, along with every token of its family.
+func (s *service) SignOutUser(ctx context.Context, refreshToken string) error {
+	logAttrs := []any{
+		slog.String(logging.ServiceMethod, "SignOutUser"),
+	}
+
+	return s.signOut(ctx, domain.SubjectTypeUser, refreshToken, logAttrs)
+}
+
+// SignOutEmployee revokes the specified refresh token of an employee, along with every token of its family.
+func (s *service) SignOutEmployee(ctx context.Context, refreshToken string) error {
+	logAttrs := []any{
+		slog.String(logging.ServiceMethod, "SignOutEmployee"),
+	}
+
+	return s.signOut(ctx, domain.SubjectTypeEmployee, refreshToken, logAttrs)
+}
+
+// TokenRevoked returns true if the access token with the specified identifier was revoked, false otherwise. Tokens
+// without a valid identifier cannot be revoked individually, so they are considered revoked.
+func (s *service) TokenRevoked(ctx context.Context, id string) (bool, error) {
+	logAttrs := []any{
+		slog.String(logging.ServiceMethod, "TokenRevoked"),
+		slog.String(logging.TokenID, id),
+	}
+
+	tokenID, err := uuid.Parse(id)
+	if err != nil {
+		return true, nil
+	}
+
+	var revoked bool
+
+	err = s.readOnlyTx(ctx, func(tx pgx.Tx) error {
+		revoked, err = s.store.ExistsRevokedToken(ctx, tx, tokenID)
+		return err
+	})
+	if err != nil {
+		return false, logAndWrapError(ctx, err, descriptionFailedCheckTokenRevoked, logAttrs...)
+	}
+
+	return revoked, nil
+}
+
+// signOut revokes the specified refresh token of a subject of the given type, along with every token of its family.
+func (s *service) signOut(ctx context.Context, subjectType domain.SubjectType, refreshToken string, logAttrs []any) error {
+	hash := s.authnService.HashRefreshToken(refreshToken)
+
+	err := s.readWriteTx(ctx, func(tx pgx.Tx) error {
+		storedRefreshToken, err := s.store.GetRefreshTokenByHash(ctx, tx, hash)
+		if err != nil {
+			return err
+		}
+
+		if storedRefreshToken.SubjectType != subjectType {
+			return domain.ErrRefreshTokenInvalid
+		}
+
+		return s.store.RevokeRefreshTokenFamily(ctx, tx, storedRefreshToken.FamilyID, time.Now().UTC())
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrRefreshTokenInvalid):
+			return logInfoAndWrapError(ctx, err, descriptionFailedSignOut, logAttrs...)
+		default:
+			return logAndWrapError(ctx, err, descriptionFailedSignOut, logAttrs...)
+		}
+	}
+
+	return nil
+}
+
+// createTokens returns an access token with the given roles and a refresh token of the given family for the subject
+// with the specified type and identifier. Expired tokens are deleted whenever new tokens are created.
+func (s *service) createTokens(ctx context.Context, tx pgx.Tx, subjectType domain.SubjectType, subjectID uuid.UUID, subjectRoles []authn.SubjectRole, familyID uuid.UUID) (domain.Tokens, error) {
+	accessToken, claims, err := s.authnService.NewJWT(subjectID.String(), subjectRoles)
+	if err != nil {
+		return domain.Tokens{}, err
+	}
+
+	accessTokenID, err := uuid.Parse(claims.ID)
+	if err != nil {
+		return domain.Tokens{}, err
+	}
+
+	refreshToken, err := s.authnService.NewRefreshToken()
+	if err != nil {
+		return domain.Tokens{}, err
+	}
+
+	err = s.store.DeleteExpiredTokens(ctx, tx, time.Now().UTC())
+	if err != nil {
+		return domain.Tokens{}, err
+	}
+
+	_, err = s.store.CreateRefreshToken(ctx, tx, domain.EditableRefreshToken{
+		FamilyID:             familyID,
+		SubjectType:          subjectType,
+		SubjectID:            subjectID,
+		Hash:                 refreshToken.Hash,
+		AccessTokenID:        accessTokenID,
+		AccessTokenExpiresAt: claims.ExpiresAt.Time,
+		ExpiresAt:            refreshToken.ExpiresAt,
+	})
+	if err != nil {
+		return domain.Tokens{}, err
+	}
+
+	return domain.Tokens{
+		AccessToken:          accessToken,
+		AccessTokenExpiresAt: claims.ExpiresAt.Time,
+		RefreshToken:         refreshToken.Token,
+	}, nil
+}
+
+// subjectRoles returns the roles of the subject with the specified type and identifier.
+func (s *service) subjectRoles(ctx context.Context, tx pgx.Tx, subjectType domain.SubjectType, subjectID uuid.UUID) ([]authn.SubjectRole, error) {
+	switch subjectType {
+	case domain.SubjectTypeUser:
+		_, err := s.store.GetUserByID(ctx, tx, subjectID)
+		if err != nil {
+			return nil, err
+		}
+
+		return []authn.SubjectRole{authn.SubjectRoleUser}, nil
+	case domain.SubjectTypeEmployee:
+		employee, err := s.store.GetEmployeeByID(ctx, tx, subjectID)
+		if err != nil {
+			return nil, err
+		}
+
+		return []authn.SubjectRole{employeeSubjectRole(employee.Role)}, nil
+	default:
+		return nil, domain.ErrRefreshTokenInvalid
+	}
+}
+
+// employeeSubjectRole returns the subject role of an employee with the given role, which is the name of the stored
+// role, so that roles created by administrators are also granted to employees.
+func employeeSubjectRole(role domain.EmployeeRole) authn.SubjectRole {
+	return authn.SubjectRole(role)
+}
+
+// ListJSONWebKeys returns the public keys used to verify access tokens.
+func (s *service) ListJSONWebKeys(ctx context.Context) []domain.JSONWebKey {
+	jwks := s.authnService.JWKS(time.Now())
+
+	keys := make([]domain.JSONWebKey, len(jwks))
+	for i, jwk := range jwks {
+		keys[i] = domain.JSONWebKey{
+			KeyType:   jwk.KeyType,
+			ID:        jwk.ID,
+			Use:       jwk.Use,
+			Algorithm: string(jwk.Algorithm),
+			Modulus:   jwk.Modulus,
+			Exponent:  jwk.Exponent,
+			Curve:     jwk.Curve,
+			X:         jwk.X,
+		}
+	}
+
+	return keys
+}
@@ -42,6 +42,33 @@ func (s *service) CreateTruck(ctx context.Context, editableTruck domain.Editable
 	if !editableTruck.PersonCapacity.Valid() {
 		return domain.Truck{}, logInfoAndWrapError(ctx, &domain.ErrFieldValueInvalid{FieldName: domain.FieldPersonCapacity}, descriptionInvalidFieldValue, logAttrs...)
 	}
+	if editableTruck.VolumeCapacity != nil && !editableTruck.VolumeCapacity.Valid() {
+		return domain.Truck{}, logInfoAndWrapError(ctx, &domain.ErrFieldValueInvalid{FieldName: domain.FieldVolumeCapacity}, descriptionInvalidFieldValue, logAttrs...)
+	}
+	if editableTruck.WeightCapacity != nil && !editableTruck.WeightCapacity.Valid() {
+		return domain.Truck{}, logInfoAndWrapError(ctx, &domain.ErrFieldValueInvalid{FieldName: domain.FieldWeightCapacity}, descriptionInvalidFieldValue, logAttrs...)
+	}
+	if !editableTruck.Compartments.Valid() {
+		return domain.Truck{}, logInfoAndWrapError(ctx, &domain.ErrFieldValueInvalid{FieldName: domain.FieldCompartments}, descriptionInvalidFieldValue, logAttrs...)
+	}
+	if !editableTruck.Status.Valid() {
+		return domain.Truck{}, logInfoAndWrapError(ctx, &domain.ErrFieldValueInvalid{FieldName: domain.FieldStatus}, descriptionInvalidFieldValue, logAttrs...)
+	}
+	if editableTruck.ServiceIntervalDistance != nil && !editableTruck.ServiceIntervalDistance.Valid() {
+		return domain.Truck{}, logInfoAndWrapError(ctx, &domain.ErrFieldValueInvalid{FieldName: domain.FieldServiceIntervalDistance}, descriptionInvalidFieldValue, logAttrs...)
+	}
+	if editableTruck.ServiceIntervalPeriod != nil && !editableTruck.ServiceIntervalPeriod.Valid() {
+		return domain.Truck{}, logInfoAndWrapError(ctx, &domain.ErrFieldValueInvalid{FieldName: domain.FieldServiceIntervalPeriod}, descriptionInvalidFieldValue, logAttrs...)
+	}
+	if !editableTruck.FuelType.Valid() {
+		return domain.Truck{}, logInfoAndWrapError(ctx, &domain.ErrFieldValueInvalid{FieldName: domain.FieldFuelType}, descriptionInvalidFieldValue, logAttrs...)
+	}
+	if editableTruck.FuelConsumption != nil && !editableTruck.FuelConsumption.Valid() {
+		return domain.Truck{}, logInfoAndWrapError(ctx, &domain.ErrFieldValueInvalid{FieldName: domain.FieldFuelConsumption}, descriptionInvalidFieldValue, logAttrs...)
+	}
+	if !editableTruck.LicenseCategory.Valid() {
+		return domain.Truck{}, logInfoAndWrapError(ctx, &domain.ErrFieldValueInvalid{FieldName: domain.FieldLicenseCategory}, descriptionInvalidFieldValue, logAttrs...)
+	}
 
 	var truck domain.Truck
 
@@ -73,6 +100,11 @@ func (s *service) CreateTruck(ctx context.Context, editableTruck domain.Editable
 			return err
 		}
 
+		err = s.store.CreateTruckCompartments(ctx, tx, id, editableTruck.Compartments)
+		if err != nil {
+			return err
+		}
+
 		truck, err = s.store.GetTruckByID(ctx, tx, id)
 		if err != nil {
 			return err
@@ -165,6 +197,33 @@ func (s *service) PatchTruck(ctx context.Context, id uuid.UUID, editableTruck do
 	if editableTruck.PersonCapacity != nil && !editableTruck.PersonCapacity.Valid() {
 		return domain.Truck{}, logInfoAndWrapError(ctx, &domain.ErrFieldValueInvalid{FieldName: domain.FieldPersonCapacity}, descriptionInvalidFieldValue, logAttrs...)
 	}
+	if editableTruck.VolumeCapacity != nil && !editableTruck.VolumeCapacity.Valid() {
+		return domain.Truck{}, logInfoAndWrapError(ctx, &domain.ErrFieldValueInvalid{FieldName: domain.FieldVolumeCapacity}, descriptionInvalidFieldValue, logAttrs...)
+	}
+	if editableTruck.WeightCapacity != nil && !editableTruck.WeightCapacity.Valid() {
+		return domain.Truck{}, logInfoAndWrapError(ctx, &domain.ErrFieldValueInvalid{FieldName: domain.FieldWeightCapacity}, descriptionInvalidFieldValue, logAttrs...)
+	}
+	if editableTruck.Compartments != nil && !editableTruck.Compartments.Valid() {
+		return domain.Truck{}, logInfoAndWrapError(ctx, &domain.ErrFieldValueInvalid{FieldName: domain.FieldCompartments}, descriptionInvalidFieldValue, logAttrs...)
+	}
+	if editableTruck.Status != nil && !editableTruck.Status.Valid() {
+		return domain.Truck{}, logInfoAndWrapError(ctx, &domain.ErrFieldValueInvalid{FieldName: domain.FieldStatus}, descriptionInvalidFieldValue, logAttrs...)
+	}
+	if editableTruck.ServiceIntervalDistance != nil && !editableTruck.ServiceIntervalDistance.Valid() {
+		return domain.Truck{}, logInfoAndWrapError(ctx, &domain.ErrFieldValueInvalid{FieldName: domain.FieldServiceIntervalDistance}, descriptionInvalidFieldValue, logAttrs...)
+	}
+	if editableTruck.ServiceIntervalPeriod != nil && !editableTruck.ServiceIntervalPeriod.Valid() {
+		return domain.Truck{}, logInfoAndWrapError(ctx, &domain.ErrFieldValueInvalid{FieldName: domain.FieldServiceIntervalPeriod}, descriptionInvalidFieldValue, logAttrs...)
+	}
+	if editableTruck.FuelType != nil && !editableTruck.FuelType.Valid() {
+		return domain.Truck{}, logInfoAndWrapError(ctx, &domain.ErrFieldValueInvalid{FieldName: domain.FieldFuelType}, descriptionInvalidFieldValue, logAttrs...)
+	}
+	if editableTruck.FuelConsumption != nil && !editableTruck.FuelConsumption.Valid() {
+		return domain.Truck{}, logInfoAndWrapError(ctx, &domain.ErrFieldValueInvalid{FieldName: domain.FieldFuelConsumption}, descriptionInvalidFieldValue, logAttrs...)
+	}
+	if editableTruck.LicenseCategory != nil && !editableTruck.LicenseCategory.Valid() {
+		return domain.Truck{}, logInfoAndWrapError(ctx, &domain.ErrFieldValueInvalid{FieldName: domain.FieldLicenseCategory}, descriptionInvalidFieldValue, logAttrs...)
+	}
 
 	var truck domain.Truck
 	var err error
@@ -200,16 +259,41 @@ func (s *service) PatchTruck(ctx context.Context, id uuid.UUID, editableTruck do
 			return err
 		}
 
+		if editableTruck.Compartments != nil {
+			err = s.store.DeleteTruckCompartments(ctx, tx, id)
+			if err != nil {
+				return err
+			}
+
+			err = s.store.CreateTruckCompartments(ctx, tx, id, *editableTruck.Compartments)
+			if err != nil {
+				return err
+			}
+		}
+
 		truck, err = s.store.GetTruckByID(ctx, tx, id)
 		if err != nil {
 			return err
 		}
 
+		// The compartments must accept every container category of the routes that use the truck.
+		if editableTruck.Compartments != nil {
+			categories, err := s.store.ListRoutesContainerCategoriesByTruckID(ctx, tx, id)
+			if err != nil {
+				return err
+			}
+
+			if !truck.AcceptsCategories(categories) {
+				return domain.ErrTruckCompartmentsIncompatible
+			}
+		}
+
 		return nil
 	})
 	if err != nil {
 		switch {
-		case errors.Is(err, domain.ErrTruckNotFound):
+		case errors.Is(err, domain.ErrTruckNotFound),
+			errors.Is(err, domain.ErrTruckCompartmentsIncompatible):
 			return domain.Truck{}, logInfoAndWrapError(ctx, err, descriptionFailedPatchTruck, logAttrs...)
 		default:
 			return domain.Truck{}, logAndWrapError(ctx, err, descriptionFailedPatchTruck, logAttrs...)
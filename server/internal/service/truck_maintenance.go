@@ -0,0 +1,328 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/goncalo-marques/ecomap/server/internal/domain"
+	"github.com/goncalo-marques/ecomap/server/internal/logging"
+)
+
+const (
+	descriptionFailedCreateTruckMaintenance     = "service: failed to create truck maintenance"
+	descriptionFailedListTruckMaintenances      = "service: failed to list truck maintenances"
+	descriptionFailedDeleteTruckMaintenanceByID = "service: failed to delete truck maintenance by id"
+	descriptionFailedListTrucksServiceDue       = "service: failed to list trucks service due"
+)
+
+const (
+	listTrucksPaginatedLimit = 100
+)
+
+// CreateTruckMaintenance records a maintenance of the truck with the specified identifier.
+func (s *service) CreateTruckMaintenance(ctx context.Context, truckID uuid.UUID, editableMaintenance domain.EditableTruckMaintenance) (domain.TruckMaintenance, error) {
+	logAttrs := []any{
+		slog.String(logging.ServiceMethod, "CreateTruckMaintenance"),
+		slog.String(logging.TruckID, truckID.String()),
+		slog.String(logging.TruckMaintenanceType, string(editableMaintenance.Type)),
+		slog.String(logging.TruckMaintenanceDate, editableMaintenance.Date.Format(time.DateOnly)),
+	}
+
+	if !editableMaintenance.Type.Valid() {
+		return domain.TruckMaintenance{}, logInfoAndWrapError(ctx, &domain.ErrFieldValueInvalid{FieldName: domain.FieldType}, descriptionInvalidFieldValue, logAttrs...)
+	}
+	if editableMaintenance.Date.IsZero() {
+		return domain.TruckMaintenance{}, logInfoAndWrapError(ctx, &domain.ErrFieldValueInvalid{FieldName: domain.FieldDate}, descriptionInvalidFieldValue, logAttrs...)
+	}
+	if editableMaintenance.Cost != nil && !editableMaintenance.Cost.Valid() {
+		return domain.TruckMaintenance{}, logInfoAndWrapError(ctx, &domain.ErrFieldValueInvalid{FieldName: domain.FieldCost}, descriptionInvalidFieldValue, logAttrs...)
+	}
+	if editableMaintenance.Notes != nil && !editableMaintenance.Notes.Valid() {
+		return domain.TruckMaintenance{}, logInfoAndWrapError(ctx, &domain.ErrFieldValueInvalid{FieldName: domain.FieldNotes}, descriptionInvalidFieldValue, logAttrs...)
+	}
+	if editableMaintenance.Odometer != nil && !editableMaintenance.Odometer.Valid() {
+		return domain.TruckMaintenance{}, logInfoAndWrapError(ctx, &domain.ErrFieldValueInvalid{FieldName: domain.FieldOdometer}, descriptionInvalidFieldValue, logAttrs...)
+	}
+
+	var maintenance domain.TruckMaintenance
+
+	err := s.readWriteTx(ctx, func(tx pgx.Tx) error {
+		id, err := s.store.CreateTruckMaintenance(ctx, tx, truckID, editableMaintenance)
+		if err != nil {
+			return err
+		}
+
+		maintenance, err = s.store.GetTruckMaintenanceByID(ctx, tx, truckID, id)
+		if err != nil {
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrTruckNotFound):
+			return domain.TruckMaintenance{}, logInfoAndWrapError(ctx, err, descriptionFailedCreateTruckMaintenance, logAttrs...)
+		default:
+			return domain.TruckMaintenance{}, logAndWrapError(ctx, err, descriptionFailedCreateTruckMaintenance, logAttrs...)
+		}
+	}
+
+	return maintenance, nil
+}
+
+// ListTruckMaintenances returns the maintenances of the truck with the specified identifier and filter.
+func (s *service) ListTruckMaintenances(ctx context.Context, truckID uuid.UUID, filter domain.TruckMaintenancesPaginatedFilter) (domain.PaginatedResponse[domain.TruckMaintenance], error) {
+	logAttrs := []any{
+		slog.String(logging.ServiceMethod, "ListTruckMaintenances"),
+		slog.String(logging.TruckID, truckID.String()),
+	}
+
+	if filter.Sort != nil && !filter.Sort.Valid() {
+		return domain.PaginatedResponse[domain.TruckMaintenance]{}, logInfoAndWrapError(ctx, &domain.ErrFilterValueInvalid{FilterName: domain.FieldFilterSort}, descriptionInvalidFilterValue, logAttrs...)
+	}
+	if !filter.Order.Valid() {
+		return domain.PaginatedResponse[domain.TruckMaintenance]{}, logInfoAndWrapError(ctx, &domain.ErrFilterValueInvalid{FilterName: domain.FieldFilterOrder}, descriptionInvalidFilterValue, logAttrs...)
+	}
+	if !filter.Limit.Valid() {
+		return domain.PaginatedResponse[domain.TruckMaintenance]{}, logInfoAndWrapError(ctx, &domain.ErrFilterValueInvalid{FilterName: domain.FieldFilterLimit}, descriptionInvalidFilterValue, logAttrs...)
+	}
+	if !filter.Offset.Valid() {
+		return domain.PaginatedResponse[domain.TruckMaintenance]{}, logInfoAndWrapError(ctx, &domain.ErrFilterValueInvalid{FilterName: domain.FieldFilterOffset}, descriptionInvalidFilterValue, logAttrs...)
+	}
+
+	var paginatedMaintenances domain.PaginatedResponse[domain.TruckMaintenance]
+
+	err := s.readOnlyTx(ctx, func(tx pgx.Tx) error {
+		_, err := s.store.GetTruckByID(ctx, tx, truckID)
+		if err != nil {
+			return err
+		}
+
+		paginatedMaintenances, err = s.store.ListTruckMaintenances(ctx, tx, truckID, filter)
+		return err
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrTruckNotFound):
+			return domain.PaginatedResponse[domain.TruckMaintenance]{}, logInfoAndWrapError(ctx, err, descriptionFailedListTruckMaintenances, logAttrs...)
+		default:
+			return domain.PaginatedResponse[domain.TruckMaintenance]{}, logAndWrapError(ctx, err, descriptionFailedListTruckMaintenances, logAttrs...)
+		}
+	}
+
+	return paginatedMaintenances, nil
+}
+
+// DeleteTruckMaintenanceByID deletes the maintenance with the specified identifier of the truck with the specified
+// identifier.
+func (s *service) DeleteTruckMaintenanceByID(ctx context.Context, truckID, id uuid.UUID) (domain.TruckMaintenance, error) {
+	logAttrs := []any{
+		slog.String(logging.ServiceMethod, "DeleteTruckMaintenanceByID"),
+		slog.String(logging.TruckID, truckID.String()),
+		slog.String(logging.TruckMaintenanceID, id.String()),
+	}
+
+	var maintenance domain.TruckMaintenance
+	var err error
+
+	err = s.readWriteTx(ctx, func(tx pgx.Tx) error {
+		maintenance, err = s.store.GetTruckMaintenanceByID(ctx, tx, truckID, id)
+		if err != nil {
+			return err
+		}
+
+		err = s.store.DeleteTruckMaintenanceByID(ctx, tx, truckID, id)
+		if err != nil {
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrTruckMaintenanceNotFound):
+			return domain.TruckMaintenance{}, logInfoAndWrapError(ctx, err, descriptionFailedDeleteTruckMaintenanceByID, logAttrs...)
+		default:
+			return domain.TruckMaintenance{}, logAndWrapError(ctx, err, descriptionFailedDeleteTruckMaintenanceByID, logAttrs...)
+		}
+	}
+
+	return maintenance, nil
+}
+
+// ListTrucksServiceDue returns the trucks whose next service is due within the distance or the number of days of the
+// specified filter, including overdue trucks. The next service is due once the truck drives its service interval
+// distance or goes its service interval period since the last service, or since it was created when it was never
+// serviced. Retired trucks are not included.
+func (s *service) ListTrucksServiceDue(ctx context.Context, filter domain.TruckServiceDueFilter) ([]domain.TruckServiceDue, error) {
+	logAttrs := []any{
+		slog.String(logging.ServiceMethod, "ListTrucksServiceDue"),
+	}
+
+	if filter.WithinDistance != nil && *filter.WithinDistance < 0 {
+		return nil, logInfoAndWrapError(ctx, &domain.ErrFilterValueInvalid{FilterName: domain.FieldFilterWithinDistance}, descriptionInvalidFilterValue, logAttrs...)
+	}
+	if filter.WithinDays != nil && *filter.WithinDays < 0 {
+		return nil, logInfoAndWrapError(ctx, &domain.ErrFilterValueInvalid{FilterName: domain.FieldFilterWithinDays}, descriptionInvalidFilterValue, logAttrs...)
+	}
+	if !filter.Sort.Valid() {
+		return nil, logInfoAndWrapError(ctx, &domain.ErrFilterValueInvalid{FilterName: domain.FieldFilterSort}, descriptionInvalidFilterValue, logAttrs...)
+	}
+
+	var trucks []domain.Truck
+	var lastServices []domain.TruckMaintenance
+
+	err := s.readOnlyTx(ctx, func(tx pgx.Tx) error {
+		var err error
+
+		trucks, err = s.listAllTrucks(ctx, tx)
+		if err != nil {
+			return err
+		}
+
+		lastServices, err = s.store.ListTrucksLastMaintenances(ctx, tx, domain.TruckMaintenanceTypeService)
+		if err != nil {
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, logAndWrapError(ctx, err, descriptionFailedListTrucksServiceDue, logAttrs...)
+	}
+
+	lastServicesByTruckID := make(map[uuid.UUID]domain.TruckMaintenance, len(lastServices))
+	for _, lastService := range lastServices {
+		lastServicesByTruckID[lastService.TruckID] = lastService
+	}
+
+	now := time.Now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	var servicesDue []domain.TruckServiceDue
+	for _, truck := range trucks {
+		if truck.Status == domain.TruckStatusRetired {
+			continue
+		}
+
+		var lastService *domain.TruckMaintenance
+		if maintenance, ok := lastServicesByTruckID[truck.ID]; ok {
+			lastService = &maintenance
+		}
+
+		serviceDue := truckServiceDue(truck, lastService, today)
+
+		dueByDistance := filter.WithinDistance != nil && serviceDue.RemainingDistance != nil && *serviceDue.RemainingDistance <= *filter.WithinDistance
+		dueByDays := filter.WithinDays != nil && serviceDue.RemainingDays != nil && *serviceDue.RemainingDays <= *filter.WithinDays
+		if !dueByDistance && !dueByDays {
+			continue
+		}
+
+		servicesDue = append(servicesDue, serviceDue)
+	}
+
+	// Trucks without the remaining value to sort by are placed last.
+	slices.SortStableFunc(servicesDue, func(a, b domain.TruckServiceDue) int {
+		switch filter.Sort {
+		case domain.TruckServiceDueSortRemainingDays:
+			return compareOptional(a.RemainingDays, b.RemainingDays)
+		default:
+			return compareOptional(a.RemainingDistance, b.RemainingDistance)
+		}
+	})
+
+	return servicesDue, nil
+}
+
+// listAllTrucks returns every truck.
+func (s *service) listAllTrucks(ctx context.Context, tx pgx.Tx) ([]domain.Truck, error) {
+	var trucks []domain.Truck
+
+	for {
+		paginatedTrucks, err := s.store.ListTrucks(ctx, tx, domain.TrucksPaginatedFilter{
+			PaginatedRequest: domain.PaginatedRequest[domain.TruckPaginatedSort]{
+				Limit:  listTrucksPaginatedLimit,
+				Offset: domain.PaginationOffset(len(trucks)),
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		trucks = append(trucks, paginatedTrucks.Results...)
+
+		if len(paginatedTrucks.Results) < listTrucksPaginatedLimit {
+			break
+		}
+	}
+
+	return trucks, nil
+}
+
+// truckServiceDue returns when the next service of the truck is due, given its last service, if any, and the current
+// date. The remaining distance is only known when the truck has odometer readings.
+func truckServiceDue(truck domain.Truck, lastService *domain.TruckMaintenance, today time.Time) domain.TruckServiceDue {
+	serviceDue := domain.TruckServiceDue{
+		Truck: truck,
+	}
+
+	if lastService != nil {
+		serviceDue.LastServiceDate = &lastService.Date
+		if lastService.Odometer != nil {
+			lastServiceOdometer := float64(*lastService.Odometer)
+			serviceDue.LastServiceOdometer = &lastServiceOdometer
+		}
+	}
+
+	if truck.ServiceIntervalDistance != nil {
+		var dueOdometer float64
+		if serviceDue.LastServiceOdometer != nil {
+			dueOdometer = *serviceDue.LastServiceOdometer
+		}
+		dueOdometer += float64(*truck.ServiceIntervalDistance)
+		serviceDue.DueOdometer = &dueOdometer
+
+		if truck.Odometer != nil {
+			remainingDistance := dueOdometer - float64(*truck.Odometer)
+			serviceDue.RemainingDistance = &remainingDistance
+		}
+	}
+
+	if truck.ServiceIntervalPeriod != nil {
+		since := truck.CreatedAt
+		if serviceDue.LastServiceDate != nil {
+			since = *serviceDue.LastServiceDate
+		}
+		since = time.Date(since.Year(), since.Month(), since.Day(), 0, 0, 0, 0, time.UTC)
+
+		dueDate := since.AddDate(0, 0, int(*truck.ServiceIntervalPeriod))
+		serviceDue.DueDate = &dueDate
+
+		remainingDays := int(dueDate.Sub(today).Hours() / 24)
+		serviceDue.RemainingDays = &remainingDays
+	}
+
+	return serviceDue
+}
+
+// compareOptional compares the given optional values, placing missing values after every present value.
+func compareOptional[T cmp.Ordered](a, b *T) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return 1
+	case b == nil:
+		return -1
+	default:
+		return cmp.Compare(*a, *b)
+	}
+}
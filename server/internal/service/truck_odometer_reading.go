@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/goncalo-marques/ecomap/server/internal/domain"
+	"github.com/goncalo-marques/ecomap/server/internal/logging"
+)
+
+const (
+	descriptionFailedCreateTruckOdometerReading = "service: failed to create truck odometer reading"
+	descriptionFailedListTruckOdometerReadings  = "service: failed to list truck odometer readings"
+)
+
+// CreateTruckOdometerReading records an odometer reading of the truck with the specified identifier. Odometers only
+// move forward, so the reading must not be lower than any earlier reading nor higher than any later reading.
+func (s *service) CreateTruckOdometerReading(ctx context.Context, truckID uuid.UUID, editableReading domain.EditableTruckOdometerReading) (domain.TruckOdometerReading, error) {
+	logAttrs := []any{
+		slog.String(logging.ServiceMethod, "CreateTruckOdometerReading"),
+		slog.String(logging.TruckID, truckID.String()),
+		slog.Float64(logging.TruckOdometerReadingValue, float64(editableReading.Value)),
+		slog.Time(logging.TruckOdometerReadingRecordedAt, editableReading.RecordedAt),
+	}
+
+	if !editableReading.Value.Valid() {
+		return domain.TruckOdometerReading{}, logInfoAndWrapError(ctx, &domain.ErrFieldValueInvalid{FieldName: domain.FieldValue}, descriptionInvalidFieldValue, logAttrs...)
+	}
+	if editableReading.RecordedAt.IsZero() || editableReading.RecordedAt.After(time.Now().Add(truckPositionRecordedAtMaxSkew)) {
+		return domain.TruckOdometerReading{}, logInfoAndWrapError(ctx, &domain.ErrFieldValueInvalid{FieldName: domain.FieldRecordedAt}, descriptionInvalidFieldValue, logAttrs...)
+	}
+
+	// Readings are stored in UTC, regardless of the time zone of the device.
+	editableReading.RecordedAt = editableReading.RecordedAt.UTC()
+
+	var reading domain.TruckOdometerReading
+
+	err := s.readWriteTx(ctx, func(tx pgx.Tx) error {
+		_, err := s.store.GetTruckByID(ctx, tx, truckID)
+		if err != nil {
+			return err
+		}
+
+		inconsistent, err := s.store.ExistsTruckOdometerReadingInconsistent(ctx, tx, truckID, editableReading)
+		if err != nil {
+			return err
+		}
+
+		if inconsistent {
+			return domain.ErrTruckOdometerReadingInconsistent
+		}
+
+		reading, err = s.store.CreateTruckOdometerReading(ctx, tx, truckID, editableReading)
+		return err
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrTruckNotFound),
+			errors.Is(err, domain.ErrTruckOdometerReadingInconsistent),
+			errors.Is(err, domain.ErrTruckOdometerReadingAlreadyExists):
+			return domain.TruckOdometerReading{}, logInfoAndWrapError(ctx, err, descriptionFailedCreateTruckOdometerReading, logAttrs...)
+		default:
+			return domain.TruckOdometerReading{}, logAndWrapError(ctx, err, descriptionFailedCreateTruckOdometerReading, logAttrs...)
+		}
+	}
+
+	return reading, nil
+}
+
+// ListTruckOdometerReadings returns the odometer readings of the truck with the specified identifier and filter.
+func (s *service) ListTruckOdometerReadings(ctx context.Context, truckID uuid.UUID, filter domain.TruckOdometerReadingsPaginatedFilter) (domain.PaginatedResponse[domain.TruckOdometerReading], error) {
+	logAttrs := []any{
+		slog.String(logging.ServiceMethod, "ListTruckOdometerReadings"),
+		slog.String(logging.TruckID, truckID.String()),
+	}
+
+	if filter.Sort != nil && !filter.Sort.Valid() {
+		return domain.PaginatedResponse[domain.TruckOdometerReading]{}, logInfoAndWrapError(ctx, &domain.ErrFilterValueInvalid{FilterName: domain.FieldFilterSort}, descriptionInvalidFilterValue, logAttrs...)
+	}
+	if !filter.Order.Valid() {
+		return domain.PaginatedResponse[domain.TruckOdometerReading]{}, logInfoAndWrapError(ctx, &domain.ErrFilterValueInvalid{FilterName: domain.FieldFilterOrder}, descriptionInvalidFilterValue, logAttrs...)
+	}
+	if !filter.Limit.Valid() {
+		return domain.PaginatedResponse[domain.TruckOdometerReading]{}, logInfoAndWrapError(ctx, &domain.ErrFilterValueInvalid{FilterName: domain.FieldFilterLimit}, descriptionInvalidFilterValue, logAttrs...)
+	}
+	if !filter.Offset.Valid() {
+		return domain.PaginatedResponse[domain.TruckOdometerReading]{}, logInfoAndWrapError(ctx, &domain.ErrFilterValueInvalid{FilterName: domain.FieldFilterOffset}, descriptionInvalidFilterValue, logAttrs...)
+	}
+
+	var paginatedReadings domain.PaginatedResponse[domain.TruckOdometerReading]
+
+	err := s.readOnlyTx(ctx, func(tx pgx.Tx) error {
+		_, err := s.store.GetTruckByID(ctx, tx, truckID)
+		if err != nil {
+			return err
+		}
+
+		paginatedReadings, err = s.store.ListTruckOdometerReadings(ctx, tx, truckID, filter)
+		return err
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrTruckNotFound):
+			return domain.PaginatedResponse[domain.TruckOdometerReading]{}, logInfoAndWrapError(ctx, err, descriptionFailedListTruckOdometerReadings, logAttrs...)
+		default:
+			return domain.PaginatedResponse[domain.TruckOdometerReading]{}, logAndWrapError(ctx, err, descriptionFailedListTruckOdometerReadings, logAttrs...)
+		}
+	}
+
+	return paginatedReadings, nil
+}
@@ -0,0 +1,215 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/goncalo-marques/ecomap/server/internal/domain"
+	"github.com/goncalo-marques/ecomap/server/internal/logging"
+)
+
+const (
+	descriptionFailedCreateTruckPositions = "service: failed to create truck positions"
+)
+
+const (
+	// truckPositionRecordedAtMaxSkew defines how far in the future a position can be recorded, to tolerate devices with
+	// slightly inaccurate clocks.
+	truckPositionRecordedAtMaxSkew = 5 * time.Minute
+
+	// truckPositionsSubscriptionBufferSize defines the number of positions buffered for each subscriber.
+	truckPositionsSubscriptionBufferSize = 64
+)
+
+// CreateTruckPositions records the positions of the truck with the specified identifier. The truck location is updated
+// to the most recent recorded position, which is then published to the truck positions subscribers, and the collection
+// of the containers of the routes in progress that use the truck is detected.
+func (s *service) CreateTruckPositions(ctx context.Context, truckID uuid.UUID, positions domain.EditableTruckPositions) error {
+	logAttrs := []any{
+		slog.String(logging.ServiceMethod, "CreateTruckPositions"),
+		slog.String(logging.TruckID, truckID.String()),
+		slog.Int(logging.TruckPositionsCount, len(positions)),
+	}
+
+	if !positions.Valid() {
+		return logInfoAndWrapError(ctx, &domain.ErrFieldValueInvalid{FieldName: domain.FieldPositions}, descriptionInvalidFieldValue, logAttrs...)
+	}
+
+	maxRecordedAt := time.Now().Add(truckPositionRecordedAtMaxSkew)
+	normalizedPositions := make(domain.EditableTruckPositions, len(positions))
+
+	for i, position := range positions {
+		if position.Speed != nil && !position.Speed.Valid() {
+			return logInfoAndWrapError(ctx, &domain.ErrFieldValueInvalid{FieldName: domain.FieldSpeed}, descriptionInvalidFieldValue, logAttrs...)
+		}
+		if position.Heading != nil && !position.Heading.Valid() {
+			return logInfoAndWrapError(ctx, &domain.ErrFieldValueInvalid{FieldName: domain.FieldHeading}, descriptionInvalidFieldValue, logAttrs...)
+		}
+		if position.RecordedAt.IsZero() || position.RecordedAt.After(maxRecordedAt) {
+			return logInfoAndWrapError(ctx, &domain.ErrFieldValueInvalid{FieldName: domain.FieldRecordedAt}, descriptionInvalidFieldValue, logAttrs...)
+		}
+
+		// Positions are stored in UTC, regardless of the time zone of the device.
+		position.RecordedAt = position.RecordedAt.UTC()
+		normalizedPositions[i] = position
+	}
+
+	compareRecordedAt := func(a, b domain.EditableTruckPosition) int {
+		return a.RecordedAt.Compare(b.RecordedAt)
+	}
+	earliestRecordedAt := slices.MinFunc(normalizedPositions, compareRecordedAt).RecordedAt
+	latestRecordedAt := slices.MaxFunc(normalizedPositions, compareRecordedAt).RecordedAt
+
+	var latestPosition *domain.TruckPosition
+
+	err := s.readWriteTx(ctx, func(tx pgx.Tx) error {
+		err := s.store.CreateTruckPositions(ctx, tx, truckID, normalizedPositions)
+		if err != nil {
+			return err
+		}
+
+		// The recorded positions may reveal the collection of the containers of the routes in progress.
+		err = s.detectRouteContainersCollections(ctx, tx, truckID, earliestRecordedAt)
+		if err != nil {
+			return err
+		}
+
+		position, err := s.store.GetTruckLatestPosition(ctx, tx, truckID)
+		if err != nil {
+			return err
+		}
+
+		// Batches that only contain past positions do not change the truck location.
+		if !position.RecordedAt.Equal(latestRecordedAt) {
+			return nil
+		}
+
+		var roadID *int
+		road, err := s.store.GetRoadByGeometry(ctx, tx, position.Geometry)
+		if err != nil {
+			if !errors.Is(err, domain.ErrRoadNotFound) {
+				return err
+			}
+		} else {
+			roadID = &road.ID
+		}
+
+		var municipalityID *int
+		municipality, err := s.store.GetMunicipalityByGeometry(ctx, tx, position.Geometry)
+		if err != nil {
+			if !errors.Is(err, domain.ErrMunicipalityNotFound) {
+				return err
+			}
+		} else {
+			municipalityID = &municipality.ID
+		}
+
+		editableTruck := domain.EditableTruckPatch{
+			GeoJSON: domain.GeoJSONFeature{
+				Geometry: position.Geometry,
+			},
+		}
+
+		err = s.store.PatchTruck(ctx, tx, truckID, editableTruck, roadID, municipalityID)
+		if err != nil {
+			return err
+		}
+
+		latestPosition = &position
+
+		return nil
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrTruckNotFound):
+			return logInfoAndWrapError(ctx, err, descriptionFailedCreateTruckPositions, logAttrs...)
+		default:
+			return logAndWrapError(ctx, err, descriptionFailedCreateTruckPositions, logAttrs...)
+		}
+	}
+
+	if latestPosition != nil {
+		s.truckPositionsBroker.publish(*latestPosition)
+	}
+
+	return nil
+}
+
+// SubscribeTruckPositions returns a channel that receives the most recent position of the trucks as they are recorded,
+// and a function to cancel the subscription. The channel is closed when the subscription is cancelled or the service is
+// closed. Positions are dropped for subscribers that do not keep up.
+func (s *service) SubscribeTruckPositions(ctx context.Context) (<-chan domain.TruckPosition, func()) {
+	return s.truckPositionsBroker.subscribe()
+}
+
+// truckPositionsBroker defines the truck positions broker structure, which fans out the published positions to the
+// subscribers of the service instance.
+type truckPositionsBroker struct {
+	mu          sync.Mutex
+	subscribers map[chan domain.TruckPosition]struct{}
+	closed      bool
+}
+
+// newTruckPositionsBroker returns a new truck positions broker.
+func newTruckPositionsBroker() *truckPositionsBroker {
+	return &truckPositionsBroker{
+		subscribers: make(map[chan domain.TruckPosition]struct{}),
+	}
+}
+
+// subscribe registers a new subscriber and returns its channel and a function to unsubscribe.
+func (b *truckPositionsBroker) subscribe() (<-chan domain.TruckPosition, func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	subscriber := make(chan domain.TruckPosition, truckPositionsSubscriptionBufferSize)
+	if b.closed {
+		close(subscriber)
+		return subscriber, func() {}
+	}
+
+	b.subscribers[subscriber] = struct{}{}
+
+	return subscriber, func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+
+		if _, ok := b.subscribers[subscriber]; ok {
+			delete(b.subscribers, subscriber)
+			close(subscriber)
+		}
+	}
+}
+
+// publish sends the position to every subscriber without blocking.
+func (b *truckPositionsBroker) publish(position domain.TruckPosition) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for subscriber := range b.subscribers {
+		select {
+		case subscriber <- position:
+		default:
+		}
+	}
+}
+
+// close unsubscribes every subscriber and rejects new subscriptions.
+func (b *truckPositionsBroker) close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for subscriber := range b.subscribers {
+		delete(b.subscribers, subscriber)
+		close(subscriber)
+	}
+
+	b.closed = true
+}
@@ -0,0 +1,299 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/goncalo-marques/ecomap/server/internal/domain"
+	"github.com/goncalo-marques/ecomap/server/internal/logging"
+)
+
+const (
+	descriptionFailedGetTruckTrack = "service: failed to get truck track"
+)
+
+const (
+	// earthRadius defines the mean radius of the Earth in meters.
+	earthRadius = 6371008.8
+
+	// truckTrackStopRadius defines the maximum distance, in meters, between the positions of the truck while stopped,
+	// to absorb the inaccuracy of the positioning devices.
+	truckTrackStopRadius = 30
+
+	// truckTrackMapMatchingMaxVertices defines the maximum number of track vertices matched onto the road network.
+	truckTrackMapMatchingMaxVertices = 100
+)
+
+// GetTruckTrack returns the track driven by the truck with the specified identifier in the period of the filter. The
+// track geometry is simplified with the filter tolerance and matched onto the road network.
+func (s *service) GetTruckTrack(ctx context.Context, truckID uuid.UUID, filter domain.TruckTrackFilter) (domain.TruckTrack, error) {
+	logAttrs := []any{
+		slog.String(logging.ServiceMethod, "GetTruckTrack"),
+		slog.String(logging.TruckID, truckID.String()),
+		slog.Time(logging.TruckTrackFrom, filter.From),
+		slog.Time(logging.TruckTrackTo, filter.To),
+	}
+
+	if !filter.PeriodValid() {
+		return domain.TruckTrack{}, logInfoAndWrapError(ctx, &domain.ErrFilterValueInvalid{FilterName: domain.FieldFilterTo}, descriptionInvalidFilterValue, logAttrs...)
+	}
+	if !filter.StopMinDurationValid() {
+		return domain.TruckTrack{}, logInfoAndWrapError(ctx, &domain.ErrFilterValueInvalid{FilterName: domain.FieldFilterStopMinDuration}, descriptionInvalidFilterValue, logAttrs...)
+	}
+	if !filter.ToleranceValid() {
+		return domain.TruckTrack{}, logInfoAndWrapError(ctx, &domain.ErrFilterValueInvalid{FilterName: domain.FieldFilterTolerance}, descriptionInvalidFilterValue, logAttrs...)
+	}
+
+	var track domain.TruckTrack
+
+	err := s.readWriteTx(ctx, func(tx pgx.Tx) error {
+		_, err := s.store.GetTruckByID(ctx, tx, truckID)
+		if err != nil {
+			return err
+		}
+
+		// Positions are stored in UTC.
+		positions, err := s.store.ListTruckPositions(ctx, tx, truckID, filter.From.UTC(), filter.To.UTC())
+		if err != nil {
+			return err
+		}
+
+		track = truckTrack(positions, filter)
+
+		roadsGeometry, err := s.matchTrackToRoadNetwork(ctx, tx, track.Geometry)
+		if err != nil {
+			return err
+		}
+
+		geoJSONFeature := make([]domain.GeoJSONFeature, len(roadsGeometry))
+		for i, geometry := range roadsGeometry {
+			geoJSONFeature[i] = domain.GeoJSONFeature{
+				Geometry: geometry,
+			}
+		}
+
+		track.Roads = domain.GeoJSONFeatureCollection{
+			Features: geoJSONFeature,
+		}
+
+		return nil
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrTruckNotFound):
+			return domain.TruckTrack{}, logInfoAndWrapError(ctx, err, descriptionFailedGetTruckTrack, logAttrs...)
+		default:
+			return domain.TruckTrack{}, logAndWrapError(ctx, err, descriptionFailedGetTruckTrack, logAttrs...)
+		}
+	}
+
+	return track, nil
+}
+
+// matchTrackToRoadNetwork returns the roads driven along the given track geometry, by connecting the closest road
+// vertices of consecutive track vertices with the shortest path between them.
+func (s *service) matchTrackToRoadNetwork(ctx context.Context, tx pgx.Tx, geometry domain.GeoJSONGeometryLineString) ([]domain.GeoJSONGeometryLineString, error) {
+	coordinates := sampleCoordinates(geometry.Coordinates, truckTrackMapMatchingMaxVertices)
+	if len(coordinates) < 2 {
+		return nil, nil
+	}
+
+	verticesGeometry := make([]domain.GeoJSONGeometryPoint, len(coordinates))
+	for i, c := range coordinates {
+		verticesGeometry[i] = domain.GeoJSONGeometryPoint{Coordinates: c}
+	}
+
+	// tempTableNameRoadNetwork defines the name of the road network temporary table.
+	// It contains a random suffix to avoid conflicts in the same database session.
+	tempTableNameRoadNetwork := "road_network_temp_" + strings.ReplaceAll(uuid.New().String(), "-", "")
+
+	err := s.store.CreateTemporaryTableRoadNetworkWithBuffer(ctx, tx, tempTableNameRoadNetwork, verticesGeometry)
+	if err != nil {
+		return nil, err
+	}
+
+	vertexIDs, err := s.store.CreateVerticesCloseToRoadNetwork(ctx, tx, tempTableNameRoadNetwork, verticesGeometry)
+	if err != nil {
+		return nil, err
+	}
+
+	// Consecutive track vertices close to the same road vertex do not add any road.
+	seqVertexIDs := make([]int, 0, len(vertexIDs))
+	for _, vertexID := range vertexIDs {
+		if len(seqVertexIDs) == 0 || seqVertexIDs[len(seqVertexIDs)-1] != vertexID {
+			seqVertexIDs = append(seqVertexIDs, vertexID)
+		}
+	}
+
+	return s.store.GetRoadsGeometryAStar(ctx, tx, tempTableNameRoadNetwork, seqVertexIDs, true)
+}
+
+// truckTrack returns the truck track based on the given positions, sorted by the time they were recorded.
+func truckTrack(positions []domain.TruckPosition, filter domain.TruckTrackFilter) domain.TruckTrack {
+	coordinates := make([][2]float64, len(positions))
+	for i, position := range positions {
+		coordinates[i] = position.Geometry.Coordinates
+	}
+
+	var distance float64
+	for i := 1; i < len(coordinates); i++ {
+		distance += haversineDistance(coordinates[i-1], coordinates[i])
+	}
+
+	indexes := simplifyCoordinates(coordinates, filter.Tolerance)
+
+	simplifiedCoordinates := make([][2]float64, len(indexes))
+	timestamps := make([]time.Time, len(indexes))
+	for i, index := range indexes {
+		simplifiedCoordinates[i] = coordinates[index]
+		timestamps[i] = positions[index].RecordedAt
+	}
+
+	return domain.TruckTrack{
+		Geometry: domain.GeoJSONGeometryLineString{
+			Coordinates: simplifiedCoordinates,
+		},
+		Timestamps: timestamps,
+		Distance:   distance / 1000,
+		Stops:      truckTrackStops(positions, filter.StopMinDuration),
+	}
+}
+
+// truckTrackStops returns the stops of the given positions, sorted by the time they were recorded. A stop is a
+// sequence of positions within the stop radius of the first one, in which the truck dwelled at least the given
+// duration.
+func truckTrackStops(positions []domain.TruckPosition, minDuration time.Duration) []domain.TruckTrackStop {
+	var stops []domain.TruckTrackStop
+
+	for start := 0; start < len(positions); {
+		end := start
+		for end+1 < len(positions) && haversineDistance(positions[start].Geometry.Coordinates, positions[end+1].Geometry.Coordinates) <= truckTrackStopRadius {
+			end++
+		}
+
+		if positions[end].RecordedAt.Sub(positions[start].RecordedAt) >= minDuration {
+			// The stop is located at the centroid of its positions.
+			var centroid [2]float64
+			for _, position := range positions[start : end+1] {
+				centroid[0] += position.Geometry.Coordinates[0]
+				centroid[1] += position.Geometry.Coordinates[1]
+			}
+			centroid[0] /= float64(end - start + 1)
+			centroid[1] /= float64(end - start + 1)
+
+			stops = append(stops, domain.TruckTrackStop{
+				Geometry:   domain.GeoJSONGeometryPoint{Coordinates: centroid},
+				ArrivedAt:  positions[start].RecordedAt,
+				DepartedAt: positions[end].RecordedAt,
+			})
+		}
+
+		start = end + 1
+	}
+
+	return stops
+}
+
+// simplifyCoordinates returns the indexes of the coordinates kept by the Douglas-Peucker algorithm, so that no removed
+// coordinate is farther than the given tolerance, in meters, from the simplified line.
+func simplifyCoordinates(coordinates [][2]float64, tolerance float64) []int {
+	if len(coordinates) <= 2 {
+		indexes := make([]int, len(coordinates))
+		for i := range coordinates {
+			indexes[i] = i
+		}
+
+		return indexes
+	}
+
+	keep := make([]bool, len(coordinates))
+	keep[0] = true
+	keep[len(coordinates)-1] = true
+
+	// Use an explicit stack, since long tracks could exceed a reasonable recursion depth.
+	stack := [][2]int{{0, len(coordinates) - 1}}
+	for len(stack) > 0 {
+		segment := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		maxDistance := -1.0
+		maxIndex := 0
+		for i := segment[0] + 1; i < segment[1]; i++ {
+			distance := segmentDistance(coordinates[i], coordinates[segment[0]], coordinates[segment[1]])
+			if distance > maxDistance {
+				maxDistance = distance
+				maxIndex = i
+			}
+		}
+
+		if maxDistance > tolerance {
+			keep[maxIndex] = true
+			stack = append(stack, [2]int{segment[0], maxIndex}, [2]int{maxIndex, segment[1]})
+		}
+	}
+
+	var indexes []int
+	for i, kept := range keep {
+		if kept {
+			indexes = append(indexes, i)
+		}
+	}
+
+	return indexes
+}
+
+// sampleCoordinates returns at most n coordinates evenly sampled from the given ones, always including the first and
+// the last coordinates.
+func sampleCoordinates(coordinates [][2]float64, n int) [][2]float64 {
+	if len(coordinates) <= n {
+		return coordinates
+	}
+
+	sampled := make([][2]float64, n)
+	for i := range sampled {
+		sampled[i] = coordinates[i*(len(coordinates)-1)/(n-1)]
+	}
+
+	return sampled
+}
+
+// haversineDistance returns the great-circle distance, in meters, between the given longitude and latitude
+// coordinates.
+func haversineDistance(a, b [2]float64) float64 {
+	lat1 := a[1] * math.Pi / 180
+	lat2 := b[1] * math.Pi / 180
+	deltaLat := lat2 - lat1
+	deltaLon := (b[0] - a[0]) * math.Pi / 180
+
+	h := math.Pow(math.Sin(deltaLat/2), 2) + math.Cos(lat1)*math.Cos(lat2)*math.Pow(math.Sin(deltaLon/2), 2)
+
+	return 2 * earthRadius * math.Asin(math.Sqrt(h))
+}
+
+// segmentDistance returns the distance, in meters, between the point and the segment defined by the start and end
+// longitude and latitude coordinates. The coordinates are projected onto a local plane, which is accurate for the short
+// distances of a track.
+func segmentDistance(point, start, end [2]float64) float64 {
+	cosLat := math.Cos(start[1] * math.Pi / 180)
+	project := func(c [2]float64) (float64, float64) {
+		return (c[0] - start[0]) * math.Pi / 180 * earthRadius * cosLat, (c[1] - start[1]) * math.Pi / 180 * earthRadius
+	}
+
+	px, py := project(point)
+	ex, ey := project(end)
+
+	// Project the point onto the segment, clamped to its extremities.
+	var t float64
+	if lengthSquared := ex*ex + ey*ey; lengthSquared > 0 {
+		t = math.Max(0, math.Min(1, (px*ex+py*ey)/lengthSquared))
+	}
+
+	return math.Hypot(px-t*ex, py-t*ey)
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"errors"
 	"log/slog"
+	"net/netip"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -38,19 +40,28 @@ func (s *service) CreateUser(ctx context.Context, editableUser domain.EditableUs
 	editableUser.Username = domain.Username(strings.ToLower(string(editableUser.Username)))
 	editableUser.FirstName = domain.Name(removeExtraSpaces(string(editableUser.FirstName)))
 	editableUser.LastName = domain.Name(removeExtraSpaces(string(editableUser.LastName)))
+	if editableUser.Email != nil {
+		email := domain.Email(strings.TrimSpace(string(*editableUser.Email)))
+		editableUser.Email = &email
+	}
 
 	if !editableUser.Username.Valid() {
 		return domain.User{}, logInfoAndWrapError(ctx, &domain.ErrFieldValueInvalid{FieldName: domain.FieldUsername}, descriptionInvalidFieldValue, logAttrs...)
 	}
-	if !s.authnService.ValidPassword([]byte(editableUser.Password)) {
-		return domain.User{}, logInfoAndWrapError(ctx, &domain.ErrFieldValueInvalid{FieldName: domain.FieldPassword}, descriptionInvalidFieldValue, logAttrs...)
-	}
 	if !editableUser.FirstName.Valid() {
 		return domain.User{}, logInfoAndWrapError(ctx, &domain.ErrFieldValueInvalid{FieldName: domain.FieldFirstName}, descriptionInvalidFieldValue, logAttrs...)
 	}
 	if !editableUser.LastName.Valid() {
 		return domain.User{}, logInfoAndWrapError(ctx, &domain.ErrFieldValueInvalid{FieldName: domain.FieldLastName}, descriptionInvalidFieldValue, logAttrs...)
 	}
+	if editableUser.Email != nil && !editableUser.Email.Valid() {
+		return domain.User{}, logInfoAndWrapError(ctx, &domain.ErrFieldValueInvalid{FieldName: domain.FieldEmail}, descriptionInvalidFieldValue, logAttrs...)
+	}
+
+	err := s.checkPasswordPolicy(ctx, s.options.UserPasswordPolicy, editableUser.Password, logAttrs...)
+	if err != nil {
+		return domain.User{}, err
+	}
 
 	hashedPassword, err := s.authnService.HashPassword([]byte(editableUser.Password))
 	if err != nil {
@@ -83,6 +94,8 @@ func (s *service) CreateUser(ctx context.Context, editableUser domain.EditableUs
 		}
 	}
 
+	s.sendUserEmailVerification(ctx, user)
+
 	return user, nil
 }
 
@@ -165,6 +178,10 @@ func (s *service) PatchUser(ctx context.Context, id uuid.UUID, editableUser doma
 		lastName := domain.Name(removeExtraSpaces(string(*editableUser.LastName)))
 		editableUser.LastName = &lastName
 	}
+	if editableUser.Email != nil {
+		email := domain.Email(strings.TrimSpace(string(*editableUser.Email)))
+		editableUser.Email = &email
+	}
 
 	if editableUser.Username != nil && !editableUser.Username.Valid() {
 		return domain.User{}, logInfoAndWrapError(ctx, &domain.ErrFieldValueInvalid{FieldName: domain.FieldUsername}, descriptionInvalidFieldValue, logAttrs...)
@@ -175,6 +192,9 @@ func (s *service) PatchUser(ctx context.Context, id uuid.UUID, editableUser doma
 	if editableUser.LastName != nil && !editableUser.LastName.Valid() {
 		return domain.User{}, logInfoAndWrapError(ctx, &domain.ErrFieldValueInvalid{FieldName: domain.FieldLastName}, descriptionInvalidFieldValue, logAttrs...)
 	}
+	if editableUser.Email != nil && !editableUser.Email.Valid() {
+		return domain.User{}, logInfoAndWrapError(ctx, &domain.ErrFieldValueInvalid{FieldName: domain.FieldEmail}, descriptionInvalidFieldValue, logAttrs...)
+	}
 
 	var user domain.User
 	var err error
@@ -202,24 +222,35 @@ func (s *service) PatchUser(ctx context.Context, id uuid.UUID, editableUser doma
 		}
 	}
 
+	if editableUser.Email != nil {
+		s.sendUserEmailVerification(ctx, user)
+	}
+
 	return user, nil
 }
 
-// UpdateUserPassword updates the password of the user with the specified username.
-func (s *service) UpdateUserPassword(ctx context.Context, username domain.Username, oldPassword, newPassword domain.Password) error {
+// UpdateUserPassword updates the password of the user with the specified username. Since the old password is checked
+// without authentication, failed attempts are limited like sign-in attempts.
+func (s *service) UpdateUserPassword(ctx context.Context, username domain.Username, oldPassword, newPassword domain.Password, ipAddress netip.Addr) error {
 	logAttrs := []any{
 		slog.String(logging.ServiceMethod, "UpdateUserPassword"),
 		slog.String(logging.UserUsername, string(username)),
+		slog.String(logging.SignInIPAddress, ipAddress.String()),
 	}
 
 	username = domain.Username(strings.ToLower(string(username)))
 
-	if !s.authnService.ValidPassword([]byte(newPassword)) {
-		return logInfoAndWrapError(ctx, &domain.ErrFieldValueInvalid{FieldName: domain.FieldNewPassword}, descriptionInvalidFieldValue, logAttrs...)
+	err := s.checkSignInLockouts(ctx, domain.SubjectTypeUser, username, ipAddress, logAttrs...)
+	if err != nil {
+		return err
+	}
+
+	err = s.checkPasswordPolicy(ctx, s.options.UserPasswordPolicy, newPassword, logAttrs...)
+	if err != nil {
+		return err
 	}
 
 	var signIn domain.SignIn
-	var err error
 
 	err = s.readOnlyTx(ctx, func(tx pgx.Tx) error {
 		signIn, err = s.store.GetUserSignIn(ctx, tx, username)
@@ -228,6 +259,7 @@ func (s *service) UpdateUserPassword(ctx context.Context, username domain.Userna
 	if err != nil {
 		switch {
 		case errors.Is(err, domain.ErrUserNotFound):
+			s.recordSignInFailure(ctx, domain.SubjectTypeUser, username, ipAddress, logAttrs...)
 			return logInfoAndWrapError(ctx, domain.ErrCredentialsIncorrect, descriptionFailedGetUserSignIn, logAttrs...)
 		default:
 			return logAndWrapError(ctx, err, descriptionFailedGetUserSignIn, logAttrs...)
@@ -240,22 +272,19 @@ func (s *service) UpdateUserPassword(ctx context.Context, username domain.Userna
 	}
 
 	if !valid {
+		s.recordSignInFailure(ctx, domain.SubjectTypeUser, username, ipAddress, logAttrs...)
 		return logInfoAndWrapError(ctx, domain.ErrCredentialsIncorrect, descriptionFailedCheckPasswordHash, logAttrs...)
 	}
 
-	hashedPassword, err := s.authnService.HashPassword([]byte(newPassword))
-	if err != nil {
-		return logAndWrapError(ctx, err, descriptionFailedHashPassword, logAttrs...)
-	}
-
-	newPassword = domain.Password(hashedPassword)
-
 	err = s.readWriteTx(ctx, func(tx pgx.Tx) error {
-		err = s.store.UpdateUserPassword(ctx, tx, username, newPassword)
-		return err
+		return s.updateUserPassword(ctx, tx, username, newPassword)
 	})
 	if err != nil {
+		var domainErrPasswordPolicyViolated *domain.ErrPasswordPolicyViolated
+
 		switch {
+		case errors.As(err, &domainErrPasswordPolicyViolated):
+			return logInfoAndWrapError(ctx, err, descriptionFailedUpdateUserPassword, logAttrs...)
 		case errors.Is(err, domain.ErrUserNotFound):
 			return logInfoAndWrapError(ctx, domain.ErrCredentialsIncorrect, descriptionFailedUpdateUserPassword, logAttrs...)
 		default:
@@ -266,7 +295,7 @@ func (s *service) UpdateUserPassword(ctx context.Context, username domain.Userna
 	return nil
 }
 
-// ResetUserPassword resets the password of the user with the specified username.
+// ResetUserPassword resets the password of the user with the specified username, revoking the tokens of the user.
 func (s *service) ResetUserPassword(ctx context.Context, username domain.Username, newPassword domain.Password) error {
 	logAttrs := []any{
 		slog.String(logging.ServiceMethod, "ResetUserPassword"),
@@ -275,23 +304,30 @@ func (s *service) ResetUserPassword(ctx context.Context, username domain.Usernam
 
 	username = domain.Username(strings.ToLower(string(username)))
 
-	if !s.authnService.ValidPassword([]byte(newPassword)) {
-		return logInfoAndWrapError(ctx, &domain.ErrFieldValueInvalid{FieldName: domain.FieldNewPassword}, descriptionInvalidFieldValue, logAttrs...)
-	}
-
-	hashedPassword, err := s.authnService.HashPassword([]byte(newPassword))
+	err := s.checkPasswordPolicy(ctx, s.options.UserPasswordPolicy, newPassword, logAttrs...)
 	if err != nil {
-		return logAndWrapError(ctx, err, descriptionFailedHashPassword, logAttrs...)
+		return err
 	}
 
-	newPassword = domain.Password(hashedPassword)
-
 	err = s.readWriteTx(ctx, func(tx pgx.Tx) error {
-		err = s.store.UpdateUserPassword(ctx, tx, username, newPassword)
-		return err
+		err := s.updateUserPassword(ctx, tx, username, newPassword)
+		if err != nil {
+			return err
+		}
+
+		user, err := s.store.GetUserByUsername(ctx, tx, username)
+		if err != nil {
+			return err
+		}
+
+		return s.store.RevokeSubjectRefreshTokens(ctx, tx, user.ID, time.Now().UTC())
 	})
 	if err != nil {
+		var domainErrPasswordPolicyViolated *domain.ErrPasswordPolicyViolated
+
 		switch {
+		case errors.As(err, &domainErrPasswordPolicyViolated):
+			return logInfoAndWrapError(ctx, err, descriptionFailedUpdateUserPassword, logAttrs...)
 		case errors.Is(err, domain.ErrUserNotFound):
 			return logInfoAndWrapError(ctx, err, descriptionFailedUpdateUserPassword, logAttrs...)
 		default:
@@ -302,7 +338,7 @@ func (s *service) ResetUserPassword(ctx context.Context, username domain.Usernam
 	return nil
 }
 
-// DeleteUserByID deletes the user with the specified identifier.
+// DeleteUserByID deletes the user with the specified identifier, revoking the tokens of the user.
 func (s *service) DeleteUserByID(ctx context.Context, id uuid.UUID) (domain.User, error) {
 	logAttrs := []any{
 		slog.String(logging.ServiceMethod, "DeleteUserByID"),
@@ -323,7 +359,7 @@ func (s *service) DeleteUserByID(ctx context.Context, id uuid.UUID) (domain.User
 			return err
 		}
 
-		return nil
+		return s.store.RevokeSubjectRefreshTokens(ctx, tx, id, time.Now().UTC())
 	})
 	if err != nil {
 		switch {
@@ -337,17 +373,24 @@ func (s *service) DeleteUserByID(ctx context.Context, id uuid.UUID) (domain.User
 	return user, nil
 }
 
-// SignInUser returns a JSON Web Token for the specified username and password.
-func (s *service) SignInUser(ctx context.Context, username domain.Username, password domain.Password) (string, error) {
+// SignInUser returns an access token and a refresh token for the specified username and password. Failed attempts
+// are tracked by username and by the IP address of the client, if valid, and further attempts are rejected according
+// to the sign-in lockouts.
+func (s *service) SignInUser(ctx context.Context, username domain.Username, password domain.Password, ipAddress netip.Addr) (domain.Tokens, error) {
 	logAttrs := []any{
 		slog.String(logging.ServiceMethod, "SignInUser"),
 		slog.String(logging.UserUsername, string(username)),
+		slog.String(logging.SignInIPAddress, ipAddress.String()),
 	}
 
 	username = domain.Username(strings.ToLower(string(username)))
 
+	err := s.checkSignInLockouts(ctx, domain.SubjectTypeUser, username, ipAddress, logAttrs...)
+	if err != nil {
+		return domain.Tokens{}, err
+	}
+
 	var signIn domain.SignIn
-	var err error
 
 	err = s.readOnlyTx(ctx, func(tx pgx.Tx) error {
 		signIn, err = s.store.GetUserSignIn(ctx, tx, username)
@@ -356,21 +399,29 @@ func (s *service) SignInUser(ctx context.Context, username domain.Username, pass
 	if err != nil {
 		switch {
 		case errors.Is(err, domain.ErrUserNotFound):
-			return "", logInfoAndWrapError(ctx, domain.ErrCredentialsIncorrect, descriptionFailedGetUserSignIn, logAttrs...)
+			s.recordSignInFailure(ctx, domain.SubjectTypeUser, username, ipAddress, logAttrs...)
+			return domain.Tokens{}, logInfoAndWrapError(ctx, domain.ErrCredentialsIncorrect, descriptionFailedGetUserSignIn, logAttrs...)
 		default:
-			return "", logAndWrapError(ctx, err, descriptionFailedGetUserSignIn, logAttrs...)
+			return domain.Tokens{}, logAndWrapError(ctx, err, descriptionFailedGetUserSignIn, logAttrs...)
 		}
 	}
 
 	valid, err := s.authnService.CheckPasswordHash([]byte(password), []byte(signIn.Password))
 	if err != nil {
-		return "", logAndWrapError(ctx, err, descriptionFailedCheckPasswordHash, logAttrs...)
+		return domain.Tokens{}, logAndWrapError(ctx, err, descriptionFailedCheckPasswordHash, logAttrs...)
 	}
 
 	if !valid {
-		return "", logInfoAndWrapError(ctx, domain.ErrCredentialsIncorrect, descriptionFailedCheckPasswordHash, logAttrs...)
+		s.recordSignInFailure(ctx, domain.SubjectTypeUser, username, ipAddress, logAttrs...)
+		return domain.Tokens{}, logInfoAndWrapError(ctx, domain.ErrCredentialsIncorrect, descriptionFailedCheckPasswordHash, logAttrs...)
 	}
 
+	s.rehashPassword(ctx, password, signIn.Password, func(tx pgx.Tx, oldHash, newHash domain.Password) error {
+		return s.store.UpdateUserPasswordHash(ctx, tx, username, oldHash, newHash)
+	}, logAttrs...)
+
+	s.clearSignInFailures(ctx, domain.SubjectTypeUser, username, logAttrs...)
+
 	var user domain.User
 
 	err = s.readOnlyTx(ctx, func(tx pgx.Tx) error {
@@ -378,14 +429,20 @@ func (s *service) SignInUser(ctx context.Context, username domain.Username, pass
 		return err
 	})
 	if err != nil {
-		return "", logAndWrapError(ctx, err, descriptionFailedGetUserByUsername, logAttrs...)
+		return domain.Tokens{}, logAndWrapError(ctx, err, descriptionFailedGetUserByUsername, logAttrs...)
 	}
 
 	role := authn.SubjectRoleUser
-	token, err := s.authnService.NewJWT(user.ID.String(), []authn.SubjectRole{role})
+
+	var tokens domain.Tokens
+
+	err = s.readWriteTx(ctx, func(tx pgx.Tx) error {
+		tokens, err = s.createTokens(ctx, tx, domain.SubjectTypeUser, user.ID, []authn.SubjectRole{role}, uuid.New())
+		return err
+	})
 	if err != nil {
-		return "", logAndWrapError(ctx, err, descriptionFailedCreateJWT, logAttrs...)
+		return domain.Tokens{}, logAndWrapError(ctx, err, descriptionFailedCreateTokens, logAttrs...)
 	}
 
-	return token, nil
+	return tokens, nil
 }
@@ -0,0 +1,292 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/goncalo-marques/ecomap/server/internal/domain"
+	"github.com/goncalo-marques/ecomap/server/internal/logging"
+	"github.com/goncalo-marques/ecomap/server/internal/mail"
+)
+
+const (
+	// defaultUserTokensLimit defines the default amount of user tokens of each purpose that can be requested for the
+	// same account within the limit period.
+	defaultUserTokensLimit = 3
+
+	// defaultUserTokensLimitPeriod defines the default period within which the user tokens are limited.
+	defaultUserTokensLimitPeriod = time.Hour
+
+	userPasswordResetTokenExpirationTime     = time.Hour
+	userEmailVerificationTokenExpirationTime = time.Hour * 24
+
+	userTokenURLQueryKey = "token"
+
+	descriptionFailedSendUserToken            = "service: failed to send user token"
+	descriptionFailedForgotUserPassword       = "service: failed to request user password reset"
+	descriptionFailedConfirmUserPasswordReset = "service: failed to confirm user password reset"
+	descriptionFailedConfirmUserEmail         = "service: failed to confirm user email"
+)
+
+// ForgotUserPassword sends a single-use password reset token, which expires after 1 hour, to the verified email address
+// of the user with the specified username. To prevent the enumeration of accounts, no error is returned when the user
+// does not exist, has no verified email address or requested too many tokens.
+func (s *service) ForgotUserPassword(ctx context.Context, username domain.Username) error {
+	logAttrs := []any{
+		slog.String(logging.ServiceMethod, "ForgotUserPassword"),
+		slog.String(logging.UserUsername, string(username)),
+	}
+
+	username = domain.Username(strings.ToLower(string(username)))
+
+	var user domain.User
+	var err error
+
+	err = s.readOnlyTx(ctx, func(tx pgx.Tx) error {
+		user, err = s.store.GetUserByUsername(ctx, tx, username)
+		return err
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrUserNotFound):
+			logging.Logger.InfoContext(ctx, descriptionFailedForgotUserPassword, append(logAttrs, logging.Error(err))...)
+			return nil
+		default:
+			return logAndWrapError(ctx, err, descriptionFailedForgotUserPassword, logAttrs...)
+		}
+	}
+
+	if !user.EmailVerified() {
+		logging.Logger.InfoContext(ctx, descriptionFailedForgotUserPassword, append(logAttrs, logging.Error(domain.ErrUserEmailNotVerified))...)
+		return nil
+	}
+
+	err = s.sendUserToken(ctx, user, domain.UserTokenPurposePasswordReset)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrUserTokenLimitExceeded):
+			logging.Logger.InfoContext(ctx, descriptionFailedForgotUserPassword, append(logAttrs, logging.Error(err))...)
+			return nil
+		default:
+			return logAndWrapError(ctx, err, descriptionFailedForgotUserPassword, logAttrs...)
+		}
+	}
+
+	return nil
+}
+
+// ConfirmUserPasswordReset resets the password of the user to which the specified password reset token was sent,
+// revoking the tokens of the user. Every password reset token sent to the user is used.
+func (s *service) ConfirmUserPasswordReset(ctx context.Context, token string, newPassword domain.Password) error {
+	logAttrs := []any{
+		slog.String(logging.ServiceMethod, "ConfirmUserPasswordReset"),
+	}
+
+	err := s.checkPasswordPolicy(ctx, s.options.UserPasswordPolicy, newPassword, logAttrs...)
+	if err != nil {
+		return err
+	}
+
+	err = s.readWriteTx(ctx, func(tx pgx.Tx) error {
+		userToken, err := s.useUserToken(ctx, tx, domain.UserTokenPurposePasswordReset, token)
+		if err != nil {
+			return err
+		}
+
+		user, err := s.store.GetUserByID(ctx, tx, userToken.UserID)
+		if err != nil {
+			return err
+		}
+
+		err = s.updateUserPassword(ctx, tx, user.Username, newPassword)
+		if err != nil {
+			return err
+		}
+
+		return s.store.RevokeSubjectRefreshTokens(ctx, tx, user.ID, time.Now().UTC())
+	})
+	if err != nil {
+		var domainErrPasswordPolicyViolated *domain.ErrPasswordPolicyViolated
+
+		switch {
+		case errors.As(err, &domainErrPasswordPolicyViolated):
+			return logInfoAndWrapError(ctx, err, descriptionFailedConfirmUserPasswordReset, logAttrs...)
+		case errors.Is(err, domain.ErrUserTokenInvalid):
+			return logInfoAndWrapError(ctx, err, descriptionFailedConfirmUserPasswordReset, logAttrs...)
+		default:
+			return logAndWrapError(ctx, err, descriptionFailedConfirmUserPasswordReset, logAttrs...)
+		}
+	}
+
+	return nil
+}
+
+// ConfirmUserEmail verifies the email address to which the specified email verification token was sent, as long as it
+// is still the email address of the user.
+func (s *service) ConfirmUserEmail(ctx context.Context, token string) error {
+	logAttrs := []any{
+		slog.String(logging.ServiceMethod, "ConfirmUserEmail"),
+	}
+
+	err := s.readWriteTx(ctx, func(tx pgx.Tx) error {
+		userToken, err := s.useUserToken(ctx, tx, domain.UserTokenPurposeEmailVerification, token)
+		if err != nil {
+			return err
+		}
+
+		err = s.store.VerifyUserEmail(ctx, tx, userToken.UserID, userToken.Email, time.Now().UTC())
+		if err != nil {
+			if errors.Is(err, domain.ErrUserNotFound) {
+				return domain.ErrUserTokenInvalid
+			}
+
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrUserTokenInvalid):
+			return logInfoAndWrapError(ctx, err, descriptionFailedConfirmUserEmail, logAttrs...)
+		default:
+			return logAndWrapError(ctx, err, descriptionFailedConfirmUserEmail, logAttrs...)
+		}
+	}
+
+	return nil
+}
+
+// sendUserEmailVerification sends an email verification token to the email address of the given user. Errors are only
+// logged, since the user can request the token again by updating the email address.
+func (s *service) sendUserEmailVerification(ctx context.Context, user domain.User) {
+	if user.Email == nil || user.EmailVerified() {
+		return
+	}
+
+	err := s.sendUserToken(ctx, user, domain.UserTokenPurposeEmailVerification)
+	if err != nil {
+		logAttrs := []any{
+			slog.String(logging.UserID, user.ID.String()),
+			logging.Error(err),
+		}
+
+		switch {
+		case errors.Is(err, domain.ErrUserTokenLimitExceeded):
+			logging.Logger.InfoContext(ctx, descriptionFailedSendUserToken, logAttrs...)
+		default:
+			logging.Logger.ErrorContext(ctx, descriptionFailedSendUserToken, logAttrs...)
+		}
+	}
+}
+
+// sendUserToken creates a user token with the specified purpose and sends it to the email address of the given user,
+// unless the user requested too many tokens with the same purpose within the limit period.
+func (s *service) sendUserToken(ctx context.Context, user domain.User, purpose domain.UserTokenPurpose) error {
+	expirationTime := userPasswordResetTokenExpirationTime
+	if purpose == domain.UserTokenPurposeEmailVerification {
+		expirationTime = userEmailVerificationTokenExpirationTime
+	}
+
+	oneTimeToken, err := s.authnService.NewOneTimeToken(expirationTime)
+	if err != nil {
+		return err
+	}
+
+	err = s.readWriteTx(ctx, func(tx pgx.Tx) error {
+		err := s.store.DeleteExpiredUserTokens(ctx, tx, time.Now().UTC())
+		if err != nil {
+			return err
+		}
+
+		count, err := s.store.CountUserTokens(ctx, tx, user.ID, purpose, s.options.UserTokensLimitPeriod)
+		if err != nil {
+			return err
+		}
+
+		if count >= s.options.UserTokensLimit {
+			return domain.ErrUserTokenLimitExceeded
+		}
+
+		_, err = s.store.CreateUserToken(ctx, tx, domain.EditableUserToken{
+			UserID:    user.ID,
+			Purpose:   purpose,
+			Hash:      oneTimeToken.Hash,
+			Email:     *user.Email,
+			ExpiresAt: oneTimeToken.ExpiresAt,
+		})
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.mailSender.Send(ctx, userTokenMessage(user, purpose, s.userTokenURL(purpose, oneTimeToken.Token)))
+}
+
+// useUserToken returns the valid user token with the specified purpose, marking every token with the same purpose of
+// the user as used.
+func (s *service) useUserToken(ctx context.Context, tx pgx.Tx, purpose domain.UserTokenPurpose, token string) (domain.UserToken, error) {
+	userToken, err := s.store.GetUserTokenByHash(ctx, tx, purpose, s.authnService.HashOneTimeToken(token))
+	if err != nil {
+		return domain.UserToken{}, err
+	}
+
+	now := time.Now().UTC()
+
+	if !userToken.Valid(now) {
+		return domain.UserToken{}, domain.ErrUserTokenInvalid
+	}
+
+	err = s.store.UseUserTokens(ctx, tx, userToken.UserID, purpose, now)
+	if err != nil {
+		return domain.UserToken{}, err
+	}
+
+	return userToken, nil
+}
+
+// userTokenURL returns the URL that confirms the user token with the specified purpose, or the token itself if the URL
+// is not configured.
+func (s *service) userTokenURL(purpose domain.UserTokenPurpose, token string) string {
+	rawURL := s.options.UserPasswordResetURL
+	if purpose == domain.UserTokenPurposeEmailVerification {
+		rawURL = s.options.UserEmailVerificationURL
+	}
+
+	tokenURL, err := url.Parse(rawURL)
+	if len(rawURL) == 0 || err != nil {
+		return token
+	}
+
+	query := tokenURL.Query()
+	query.Set(userTokenURLQueryKey, token)
+	tokenURL.RawQuery = query.Encode()
+
+	return tokenURL.String()
+}
+
+// userTokenMessage returns the email message that delivers the user token with the specified purpose.
+func userTokenMessage(user domain.User, purpose domain.UserTokenPurpose, tokenURL string) mail.Message {
+	message := mail.Message{
+		To: string(*user.Email),
+	}
+
+	switch purpose {
+	case domain.UserTokenPurposePasswordReset:
+		message.Subject = "EcoMap password reset"
+		message.Body = fmt.Sprintf("Hello %s,\n\nA password reset was requested for your EcoMap account %s. Use the following link to choose a new password:\n\n%s\n\nThe link expires in 1 hour and can only be used once. If you did not request a password reset, you can ignore this message.\n", user.FirstName, user.Username, tokenURL)
+	case domain.UserTokenPurposeEmailVerification:
+		message.Subject = "EcoMap email verification"
+		message.Body = fmt.Sprintf("Hello %s,\n\nUse the following link to verify the email address of your EcoMap account %s:\n\n%s\n\nThe link expires in 24 hours and can only be used once.\n", user.FirstName, user.Username, tokenURL)
+	}
+
+	return message
+}
@@ -0,0 +1,251 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/goncalo-marques/ecomap/server/internal/domain"
+)
+
+// CreateAPIKey executes a query to create an API key with the specified data.
+func (s *store) CreateAPIKey(ctx context.Context, tx pgx.Tx, editableAPIKey domain.EditableAPIKeyWithHash) (uuid.UUID, error) {
+	row := tx.QueryRow(ctx, `
+		INSERT INTO api_keys (name, hash, roles, allowed_ip_addresses, expires_at)
+		VALUES ($1, $2, $3, $4::cidr[], $5)
+		RETURNING id
+	`,
+		editableAPIKey.Name,
+		editableAPIKey.Hash,
+		apiKeyRolesFromDomain(editableAPIKey.Roles),
+		apiKeyAllowedIPAddressesFromDomain(editableAPIKey.AllowedIPAddresses),
+		editableAPIKey.ExpiresAt,
+	)
+
+	var id uuid.UUID
+
+	err := row.Scan(&id)
+	if err != nil {
+		return uuid.UUID{}, fmt.Errorf("%s: %w", descriptionFailedScanRow, err)
+	}
+
+	return id, nil
+}
+
+// ListAPIKeys executes a query to return every API key.
+func (s *store) ListAPIKeys(ctx context.Context, tx pgx.Tx) ([]domain.APIKey, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT ak.id, ak.name, ak.roles, ak.allowed_ip_addresses::text[], ak.expires_at, ak.last_used_at, ak.created_at
+		FROM api_keys AS ak
+		ORDER BY ak.created_at DESC
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", descriptionFailedQuery, err)
+	}
+	defer rows.Close()
+
+	apiKeys, err := getAPIKeysFromRows(rows)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", descriptionFailedScanRows, err)
+	}
+
+	return apiKeys, nil
+}
+
+// GetAPIKeyByID executes a query to return the API key with the specified identifier.
+func (s *store) GetAPIKeyByID(ctx context.Context, tx pgx.Tx, id uuid.UUID) (domain.APIKey, error) {
+	row := tx.QueryRow(ctx, `
+		SELECT ak.id, ak.name, ak.roles, ak.allowed_ip_addresses::text[], ak.expires_at, ak.last_used_at, ak.created_at
+		FROM api_keys AS ak
+		WHERE ak.id = $1
+	`,
+		id,
+	)
+
+	apiKey, err := getAPIKeyFromRow(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.APIKey{}, fmt.Errorf("%s: %w", descriptionFailedScanRow, domain.ErrAPIKeyNotFound)
+		}
+
+		return domain.APIKey{}, fmt.Errorf("%s: %w", descriptionFailedScanRow, err)
+	}
+
+	return apiKey, nil
+}
+
+// GetAPIKeyByHash executes a query to return the API key with the specified hash.
+func (s *store) GetAPIKeyByHash(ctx context.Context, tx pgx.Tx, hash []byte) (domain.APIKey, error) {
+	row := tx.QueryRow(ctx, `
+		SELECT ak.id, ak.name, ak.roles, ak.allowed_ip_addresses::text[], ak.expires_at, ak.last_used_at, ak.created_at
+		FROM api_keys AS ak
+		WHERE ak.hash = $1
+	`,
+		hash,
+	)
+
+	apiKey, err := getAPIKeyFromRow(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.APIKey{}, fmt.Errorf("%s: %w", descriptionFailedScanRow, domain.ErrAPIKeyNotFound)
+		}
+
+		return domain.APIKey{}, fmt.Errorf("%s: %w", descriptionFailedScanRow, err)
+	}
+
+	return apiKey, nil
+}
+
+// UpdateAPIKeyLastUsedAt executes a query to update the time at which the API key with the specified identifier was
+// last used, unless it was already used after the specified threshold, which avoids a write for every request.
+func (s *store) UpdateAPIKeyLastUsedAt(ctx context.Context, tx pgx.Tx, id uuid.UUID, lastUsedAt, threshold time.Time) error {
+	_, err := tx.Exec(ctx, `
+		UPDATE api_keys SET
+			last_used_at = $2
+		WHERE id = $1 AND (last_used_at IS NULL OR last_used_at < $3)
+	`,
+		id,
+		lastUsedAt,
+		threshold,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", descriptionFailedExec, err)
+	}
+
+	return nil
+}
+
+// ExistsAPIKeyWithRole executes a query to return whether there is an API key granted the role with the specified
+// name.
+func (s *store) ExistsAPIKeyWithRole(ctx context.Context, tx pgx.Tx, role domain.RoleName) (bool, error) {
+	row := tx.QueryRow(ctx, `
+		SELECT EXISTS (
+			SELECT 1
+			FROM api_keys AS ak
+			WHERE $1 = ANY(ak.roles)
+		)
+	`,
+		role,
+	)
+
+	var exists bool
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", descriptionFailedScanRow, err)
+	}
+
+	return exists, nil
+}
+
+// DeleteAPIKeyByID executes a query to delete the API key with the specified identifier.
+func (s *store) DeleteAPIKeyByID(ctx context.Context, tx pgx.Tx, id uuid.UUID) error {
+	commandTag, err := tx.Exec(ctx, `
+		DELETE FROM api_keys
+		WHERE id = $1
+	`,
+		id,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", descriptionFailedExec, err)
+	}
+
+	if commandTag.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", descriptionFailedExec, domain.ErrAPIKeyNotFound)
+	}
+
+	return nil
+}
+
+// getAPIKeyFromRow returns the API key by scanning the given row.
+func getAPIKeyFromRow(row pgx.Row) (domain.APIKey, error) {
+	var apiKey domain.APIKey
+	var roles []string
+	var allowedIPAddresses []string
+
+	err := row.Scan(
+		&apiKey.ID,
+		&apiKey.Name,
+		&roles,
+		&allowedIPAddresses,
+		&apiKey.ExpiresAt,
+		&apiKey.LastUsedAt,
+		&apiKey.CreatedAt,
+	)
+	if err != nil {
+		return domain.APIKey{}, err
+	}
+
+	apiKey.Roles = apiKeyRolesToDomain(roles)
+	apiKey.AllowedIPAddresses, err = apiKeyAllowedIPAddressesToDomain(allowedIPAddresses)
+	if err != nil {
+		return domain.APIKey{}, err
+	}
+
+	return apiKey, nil
+}
+
+// getAPIKeysFromRows returns the API keys by scanning the given rows.
+func getAPIKeysFromRows(rows pgx.Rows) ([]domain.APIKey, error) {
+	var apiKeys []domain.APIKey
+	for rows.Next() {
+		apiKey, err := getAPIKeyFromRow(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		apiKeys = append(apiKeys, apiKey)
+	}
+
+	return apiKeys, nil
+}
+
+// apiKeyRolesFromDomain returns store API key roles based on the domain model.
+func apiKeyRolesFromDomain(roles domain.APIKeyRoles) []string {
+	storeRoles := make([]string, len(roles))
+	for i, role := range roles {
+		storeRoles[i] = string(role)
+	}
+
+	return storeRoles
+}
+
+// apiKeyRolesToDomain returns domain API key roles based on the store model.
+func apiKeyRolesToDomain(roles []string) domain.APIKeyRoles {
+	domainRoles := make(domain.APIKeyRoles, len(roles))
+	for i, role := range roles {
+		domainRoles[i] = domain.RoleName(role)
+	}
+
+	return domainRoles
+}
+
+// apiKeyAllowedIPAddressesFromDomain returns store API key allowed IP addresses based on the domain model. The host
+// bits of the prefixes are cleared, since they are not accepted by the cidr type.
+func apiKeyAllowedIPAddressesFromDomain(prefixes []netip.Prefix) []string {
+	storePrefixes := make([]string, len(prefixes))
+	for i, prefix := range prefixes {
+		storePrefixes[i] = prefix.Masked().String()
+	}
+
+	return storePrefixes
+}
+
+// apiKeyAllowedIPAddressesToDomain returns domain API key allowed IP addresses based on the store model.
+func apiKeyAllowedIPAddressesToDomain(prefixes []string) ([]netip.Prefix, error) {
+	domainPrefixes := make([]netip.Prefix, len(prefixes))
+	for i, prefix := range prefixes {
+		var err error
+		domainPrefixes[i], err = netip.ParsePrefix(prefix)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return domainPrefixes, nil
+}
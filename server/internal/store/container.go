@@ -19,11 +19,14 @@ func (s *store) CreateContainer(ctx context.Context, tx pgx.Tx, editableContaine
 	}
 
 	row := tx.QueryRow(ctx, `
-		INSERT INTO containers (category, geom, road_id, municipality_id)
-		VALUES ($1, ST_GeomFromGeoJSON($2), $3, $4) 
+		INSERT INTO containers (category, volume, collection_start_time, collection_end_time, geom, road_id, municipality_id)
+		VALUES ($1, $2, $3, $4, ST_GeomFromGeoJSON($5), $6, $7) 
 		RETURNING id
 	`,
 		containerCategoryFromDomain(editableContainer.Category),
+		editableContainer.Volume,
+		editableContainer.CollectionStartTime,
+		editableContainer.CollectionEndTime,
 		geoJSON,
 		roadID,
 		municipalityID,
@@ -95,7 +98,7 @@ func (s *store) ListContainers(ctx context.Context, tx pgx.Tx, filter domain.Con
 
 	// Get rows for the given filter.
 	rows, err := tx.Query(ctx, `
-		SELECT c.id, c.category, ST_AsGeoJSON(c.geom)::jsonb, rn.osm_name, m.name, c.created_at, c.modified_at
+		SELECT c.id, c.category, c.volume, c.collection_start_time, c.collection_end_time, ST_AsGeoJSON(c.geom)::jsonb, rn.osm_name, m.name, c.created_at, c.modified_at
 		FROM containers AS c
 		LEFT JOIN road_network AS rn ON c.road_id = rn.id
 		LEFT JOIN municipalities AS m ON c.municipality_id = m.id
@@ -121,7 +124,7 @@ func (s *store) ListContainers(ctx context.Context, tx pgx.Tx, filter domain.Con
 // GetContainerByID executes a query to return the container with the specified identifier.
 func (s *store) GetContainerByID(ctx context.Context, tx pgx.Tx, id uuid.UUID) (domain.Container, error) {
 	row := tx.QueryRow(ctx, `
-		SELECT c.id, c.category, ST_AsGeoJSON(c.geom)::jsonb, rn.osm_name, m.name, c.created_at, c.modified_at 
+		SELECT c.id, c.category, c.volume, c.collection_start_time, c.collection_end_time, ST_AsGeoJSON(c.geom)::jsonb, rn.osm_name, m.name, c.created_at, c.modified_at 
 		FROM containers AS c
 		LEFT JOIN road_network AS rn ON c.road_id = rn.id
 		LEFT JOIN municipalities AS m ON c.municipality_id = m.id
@@ -163,19 +166,25 @@ func (s *store) PatchContainer(ctx context.Context, tx pgx.Tx, id uuid.UUID, edi
 	commandTag, err := tx.Exec(ctx, `
 		UPDATE containers SET
 			category = coalesce($2, category),
-			geom = coalesce(ST_GeomFromGeoJSON($3), geom),
+			volume = coalesce($3, volume),
+			collection_start_time = coalesce($4, collection_start_time),
+			collection_end_time = coalesce($5, collection_end_time),
+			geom = coalesce(ST_GeomFromGeoJSON($6), geom),
 			road_id = CASE 
-					WHEN $3 IS NOT NULL THEN $4 
+					WHEN $6 IS NOT NULL THEN $7 
 					ELSE road_id
 				END,
 			municipality_id = CASE 
-					WHEN $3 IS NOT NULL THEN $5
+					WHEN $6 IS NOT NULL THEN $8
 					ELSE municipality_id
 				END
 		WHERE id = $1
 	`,
 		id,
 		category,
+		editableContainer.Volume,
+		editableContainer.CollectionStartTime,
+		editableContainer.CollectionEndTime,
 		geoJSON,
 		roadID,
 		municipalityID,
@@ -239,6 +248,31 @@ func containerCategoryFromDomain(category domain.ContainerCategory) string {
 	}
 }
 
+// containerCategoriesFromDomain returns store container categories based on the domain model. A nil value is returned
+// when the domain model is nil.
+func containerCategoriesFromDomain(categories []domain.ContainerCategory) []string {
+	if categories == nil {
+		return nil
+	}
+
+	storeCategories := make([]string, len(categories))
+	for i, category := range categories {
+		storeCategories[i] = containerCategoryFromDomain(category)
+	}
+
+	return storeCategories
+}
+
+// containerCategoriesToDomain returns domain container categories based on the store model.
+func containerCategoriesToDomain(categories []string) []domain.ContainerCategory {
+	domainCategories := make([]domain.ContainerCategory, len(categories))
+	for i, category := range categories {
+		domainCategories[i] = containerCategoryToDomain(category)
+	}
+
+	return domainCategories
+}
+
 // containerCategoryToDomain returns a domain container category based on the store model.
 func containerCategoryToDomain(category string) domain.ContainerCategory {
 	switch category {
@@ -272,6 +306,9 @@ func getContainerFromRow(row pgx.Row) (domain.Container, error) {
 	err := row.Scan(
 		&container.ID,
 		&category,
+		&container.Volume,
+		&container.CollectionStartTime,
+		&container.CollectionEndTime,
 		&geoJSONPoint,
 		&wayName,
 		&municipalityName,
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -12,9 +13,18 @@ import (
 )
 
 const (
-	constraintEmployeesUsernameKey = "employees_username_key"
+	constraintEmployeesUsernameKey          = "employees_username_key"
+	constraintEmployeesSSOSubjectKey        = "employees_sso_subject_key"
+	constraintEmployeesShiftsEmployeeIDFkey = "employees_shifts_employee_id_fkey"
 )
 
+// employeeShift defines the structure of the employee shift stored in the database.
+type employeeShift struct {
+	Weekday   int    `json:"weekday"`
+	StartTime string `json:"startTime"`
+	EndTime   string `json:"endTime"`
+}
+
 // CreateEmployee executes a query to create an employee with the specified data.
 func (s *store) CreateEmployee(ctx context.Context, tx pgx.Tx, editableEmployee domain.EditableEmployeeWithPassword, roadID, municipalityID *int) (uuid.UUID, error) {
 	geoJSON, err := jsonMarshalGeoJSONGeometryPoint(editableEmployee.GeoJSON)
@@ -31,7 +41,7 @@ func (s *store) CreateEmployee(ctx context.Context, tx pgx.Tx, editableEmployee
 		editableEmployee.Password,
 		editableEmployee.FirstName,
 		editableEmployee.LastName,
-		employeeRoleFromDomain(editableEmployee.Role),
+		editableEmployee.Role,
 		editableEmployee.DateOfBirth,
 		editableEmployee.PhoneNumber,
 		geoJSON,
@@ -76,7 +86,7 @@ func (s *store) ListEmployees(ctx context.Context, tx pgx.Tx, filter domain.Empl
 	}
 	if filter.Role != nil {
 		filterFields = append(filterFields, "e.role::text")
-		argsWhere = append(argsWhere, employeeRoleFromDomain(*filter.Role))
+		argsWhere = append(argsWhere, *filter.Role)
 	}
 	if filter.DateOfBirth != nil {
 		filterFields = append(filterFields, "e.date_of_birth::text")
@@ -151,7 +161,7 @@ func (s *store) ListEmployees(ctx context.Context, tx pgx.Tx, filter domain.Empl
 
 	// Get rows for the given filter.
 	rows, err := tx.Query(ctx, `
-		SELECT e.id, e.username, e.first_name, e.last_name, e.role, e.date_of_birth, e.phone_number, ST_AsGeoJSON(e.geom)::jsonb, rn.osm_name, m.name, e.schedule_start, e.schedule_end, e.created_at, e.modified_at 
+		SELECT e.id, e.username, e.first_name, e.last_name, e.role, e.date_of_birth, e.phone_number, ST_AsGeoJSON(e.geom)::jsonb, rn.osm_name, m.name, e.schedule_start, e.schedule_end, (SELECT coalesce(jsonb_agg(jsonb_build_object('weekday', es.weekday, 'startTime', es.start_time, 'endTime', es.end_time) ORDER BY es.weekday), '[]') FROM employees_shifts AS es WHERE es.employee_id = e.id), e.created_at, e.modified_at 
 		FROM employees AS e
 		LEFT JOIN road_network AS rn ON e.road_id = rn.id
 		LEFT JOIN municipalities AS m ON e.municipality_id = m.id
@@ -177,7 +187,7 @@ func (s *store) ListEmployees(ctx context.Context, tx pgx.Tx, filter domain.Empl
 // GetEmployeeByID executes a query to return the employee with the specified identifier.
 func (s *store) GetEmployeeByID(ctx context.Context, tx pgx.Tx, id uuid.UUID) (domain.Employee, error) {
 	row := tx.QueryRow(ctx, `
-		SELECT e.id, e.username, e.first_name, e.last_name, e.role, e.date_of_birth, e.phone_number, ST_AsGeoJSON(e.geom)::jsonb, rn.osm_name, m.name, e.schedule_start, e.schedule_end, e.created_at, e.modified_at 
+		SELECT e.id, e.username, e.first_name, e.last_name, e.role, e.date_of_birth, e.phone_number, ST_AsGeoJSON(e.geom)::jsonb, rn.osm_name, m.name, e.schedule_start, e.schedule_end, (SELECT coalesce(jsonb_agg(jsonb_build_object('weekday', es.weekday, 'startTime', es.start_time, 'endTime', es.end_time) ORDER BY es.weekday), '[]') FROM employees_shifts AS es WHERE es.employee_id = e.id), e.created_at, e.modified_at 
 		FROM employees AS e
 		LEFT JOIN road_network AS rn ON e.road_id = rn.id
 		LEFT JOIN municipalities AS m ON e.municipality_id = m.id
@@ -201,7 +211,7 @@ func (s *store) GetEmployeeByID(ctx context.Context, tx pgx.Tx, id uuid.UUID) (d
 // GetEmployeeByUsername executes a query to return the employee with the specified username.
 func (s *store) GetEmployeeByUsername(ctx context.Context, tx pgx.Tx, username domain.Username) (domain.Employee, error) {
 	row := tx.QueryRow(ctx, `
-		SELECT e.id, e.username, e.first_name, e.last_name, e.role, e.date_of_birth, e.phone_number, ST_AsGeoJSON(e.geom)::jsonb, rn.osm_name, m.name, e.schedule_start, e.schedule_end, e.created_at, e.modified_at 
+		SELECT e.id, e.username, e.first_name, e.last_name, e.role, e.date_of_birth, e.phone_number, ST_AsGeoJSON(e.geom)::jsonb, rn.osm_name, m.name, e.schedule_start, e.schedule_end, (SELECT coalesce(jsonb_agg(jsonb_build_object('weekday', es.weekday, 'startTime', es.start_time, 'endTime', es.end_time) ORDER BY es.weekday), '[]') FROM employees_shifts AS es WHERE es.employee_id = e.id), e.created_at, e.modified_at 
 		FROM employees AS e
 		LEFT JOIN road_network AS rn ON e.road_id = rn.id
 		LEFT JOIN municipalities AS m ON e.municipality_id = m.id
@@ -222,10 +232,34 @@ func (s *store) GetEmployeeByUsername(ctx context.Context, tx pgx.Tx, username d
 	return employee, nil
 }
 
+// GetEmployeeBySSOSubject executes a query to return the employee linked to the specified single sign-on subject.
+func (s *store) GetEmployeeBySSOSubject(ctx context.Context, tx pgx.Tx, subject string) (domain.Employee, error) {
+	row := tx.QueryRow(ctx, `
+		SELECT e.id, e.username, e.first_name, e.last_name, e.role, e.date_of_birth, e.phone_number, ST_AsGeoJSON(e.geom)::jsonb, rn.osm_name, m.name, e.schedule_start, e.schedule_end, (SELECT coalesce(jsonb_agg(jsonb_build_object('weekday', es.weekday, 'startTime', es.start_time, 'endTime', es.end_time) ORDER BY es.weekday), '[]') FROM employees_shifts AS es WHERE es.employee_id = e.id), e.created_at, e.modified_at 
+		FROM employees AS e
+		LEFT JOIN road_network AS rn ON e.road_id = rn.id
+		LEFT JOIN municipalities AS m ON e.municipality_id = m.id
+		WHERE e.sso_subject = $1 
+	`,
+		subject,
+	)
+
+	employee, err := getEmployeeFromRow(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.Employee{}, fmt.Errorf("%s: %w", descriptionFailedScanRow, domain.ErrEmployeeNotFound)
+		}
+
+		return domain.Employee{}, fmt.Errorf("%s: %w", descriptionFailedScanRow, err)
+	}
+
+	return employee, nil
+}
+
 // GetEmployeeSignIn executes a query to return the sign-in of the employee with the specified username.
 func (s *store) GetEmployeeSignIn(ctx context.Context, tx pgx.Tx, username domain.Username) (domain.SignIn, error) {
 	row := tx.QueryRow(ctx, `
-		SELECT username, password 
+		SELECT username, password, password_changed_at 
 		FROM employees 
 		WHERE username = $1 
 	`,
@@ -236,6 +270,7 @@ func (s *store) GetEmployeeSignIn(ctx context.Context, tx pgx.Tx, username domai
 	err := row.Scan(
 		&signIn.Username,
 		&signIn.Password,
+		&signIn.PasswordChangedAt,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -265,25 +300,27 @@ func (s *store) PatchEmployee(ctx context.Context, tx pgx.Tx, id uuid.UUID, edit
 			username = coalesce($2, username),
 			first_name = coalesce($3, first_name),
 			last_name = coalesce($4, last_name),
-			date_of_birth = coalesce($5, date_of_birth),
-			phone_number = coalesce($6, phone_number),
-			geom = coalesce(ST_GeomFromGeoJSON($7), geom),
+			role = coalesce($5, role),
+			date_of_birth = coalesce($6, date_of_birth),
+			phone_number = coalesce($7, phone_number),
+			geom = coalesce(ST_GeomFromGeoJSON($8), geom),
 			road_id = CASE 
-					WHEN $7 IS NOT NULL THEN $8 
+					WHEN $8 IS NOT NULL THEN $9 
 					ELSE road_id
 				END,
 			municipality_id = CASE 
-					WHEN $7 IS NOT NULL THEN $9 
+					WHEN $8 IS NOT NULL THEN $10 
 					ELSE municipality_id
 				END,
-			schedule_start = coalesce($10, schedule_start),
-			schedule_end = coalesce($11, schedule_end)
+			schedule_start = coalesce($11, schedule_start),
+			schedule_end = coalesce($12, schedule_end)
 		WHERE id = $1
 	`,
 		id,
 		editableEmployee.Username,
 		editableEmployee.FirstName,
 		editableEmployee.LastName,
+		editableEmployee.Role,
 		editableEmployee.DateOfBirth,
 		editableEmployee.PhoneNumber,
 		geoJSON,
@@ -307,11 +344,53 @@ func (s *store) PatchEmployee(ctx context.Context, tx pgx.Tx, id uuid.UUID, edit
 	return nil
 }
 
-// UpdateEmployeePassword executes a query to update the password of the employee with the specified username.
+// CreateEmployeeShifts executes a query to create the shifts of the employee with the specified identifier.
+func (s *store) CreateEmployeeShifts(ctx context.Context, tx pgx.Tx, employeeID uuid.UUID, shifts domain.EmployeeShifts) error {
+	for _, shift := range shifts {
+		_, err := tx.Exec(ctx, `
+			INSERT INTO employees_shifts (employee_id, weekday, start_time, end_time)
+			VALUES ($1, $2, $3, $4)
+		`,
+			employeeID,
+			int(shift.Weekday),
+			shift.StartTime,
+			shift.EndTime,
+		)
+		if err != nil {
+			switch constraintNameFromError(err) {
+			case constraintEmployeesShiftsEmployeeIDFkey:
+				return fmt.Errorf("%s: %w", descriptionFailedExec, domain.ErrEmployeeNotFound)
+			}
+
+			return fmt.Errorf("%s: %w", descriptionFailedExec, err)
+		}
+	}
+
+	return nil
+}
+
+// DeleteEmployeeShifts executes a query to delete the shifts of the employee with the specified identifier.
+func (s *store) DeleteEmployeeShifts(ctx context.Context, tx pgx.Tx, employeeID uuid.UUID) error {
+	_, err := tx.Exec(ctx, `
+		DELETE FROM employees_shifts
+		WHERE employee_id = $1
+	`,
+		employeeID,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", descriptionFailedExec, err)
+	}
+
+	return nil
+}
+
+// UpdateEmployeePassword executes a query to update the password of the employee with the specified username, as well
+// as the time at which it was changed.
 func (s *store) UpdateEmployeePassword(ctx context.Context, tx pgx.Tx, username domain.Username, password domain.Password) error {
 	commandTag, err := tx.Exec(ctx, `
 		UPDATE employees SET
-			password = $2
+			password = $2,
+			password_changed_at = CURRENT_TIMESTAMP
 		WHERE username = $1 
 	`,
 		username,
@@ -328,6 +407,95 @@ func (s *store) UpdateEmployeePassword(ctx context.Context, tx pgx.Tx, username
 	return nil
 }
 
+// UpdateEmployeePasswordHash executes a query to replace the hashed password of the employee with the specified username,
+// as long as it still matches the specified old hash, so that a password changed in the meantime is not overwritten.
+func (s *store) UpdateEmployeePasswordHash(ctx context.Context, tx pgx.Tx, username domain.Username, oldHash, newHash domain.Password) error {
+	_, err := tx.Exec(ctx, `
+		UPDATE employees SET
+			password = $3
+		WHERE username = $1 AND password = $2
+	`,
+		username,
+		oldHash,
+		newHash,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", descriptionFailedExec, err)
+	}
+
+	return nil
+}
+
+// UpdateEmployeeSSOSubject executes a query to link the employee with the specified identifier to a single sign-on
+// subject.
+func (s *store) UpdateEmployeeSSOSubject(ctx context.Context, tx pgx.Tx, id uuid.UUID, subject string) error {
+	commandTag, err := tx.Exec(ctx, `
+		UPDATE employees SET
+			sso_subject = $2
+		WHERE id = $1 
+	`,
+		id,
+		subject,
+	)
+	if err != nil {
+		if constraintNameFromError(err) == constraintEmployeesSSOSubjectKey {
+			return fmt.Errorf("%s: %w", descriptionFailedExec, domain.ErrEmployeeAlreadyExists)
+		}
+
+		return fmt.Errorf("%s: %w", descriptionFailedExec, err)
+	}
+
+	if commandTag.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", descriptionFailedExec, domain.ErrEmployeeNotFound)
+	}
+
+	return nil
+}
+
+// ExistsEmployeeWithRole executes a query to return whether there is an employee with the role with the specified
+// name.
+func (s *store) ExistsEmployeeWithRole(ctx context.Context, tx pgx.Tx, role domain.RoleName) (bool, error) {
+	row := tx.QueryRow(ctx, `
+		SELECT EXISTS (
+			SELECT 1
+			FROM employees AS e
+			WHERE e.role = $1
+		)
+	`,
+		role,
+	)
+
+	var exists bool
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", descriptionFailedScanRow, err)
+	}
+
+	return exists, nil
+}
+
+// UpdateEmployeeRole executes a query to update the role of the employee with the specified identifier.
+func (s *store) UpdateEmployeeRole(ctx context.Context, tx pgx.Tx, id uuid.UUID, role domain.EmployeeRole) error {
+	commandTag, err := tx.Exec(ctx, `
+		UPDATE employees SET
+			role = $2
+		WHERE id = $1 
+	`,
+		id,
+		role,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", descriptionFailedExec, err)
+	}
+
+	if commandTag.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", descriptionFailedExec, domain.ErrEmployeeNotFound)
+	}
+
+	return nil
+}
+
 // DeleteEmployeeByID executes a query to delete the employee with the specified identifier.
 func (s *store) DeleteEmployeeByID(ctx context.Context, tx pgx.Tx, id uuid.UUID) error {
 	commandTag, err := tx.Exec(ctx, `
@@ -352,44 +520,44 @@ func (s *store) DeleteEmployeeByID(ctx context.Context, tx pgx.Tx, id uuid.UUID)
 	return nil
 }
 
-// employeeRoleFromDomain returns a store employee role based on the domain model.
-func employeeRoleFromDomain(role domain.EmployeeRole) string {
-	switch role {
-	case domain.EmployeeRoleWasteOperator:
-		return "waste_operator"
-	case domain.EmployeeRoleManager:
-		return "manager"
-	default:
-		return string(role)
-	}
-}
+// employeeShiftsToDomain returns domain employee shifts based on the store model.
+func employeeShiftsToDomain(shifts []employeeShift) (domain.EmployeeShifts, error) {
+	domainShifts := make(domain.EmployeeShifts, len(shifts))
+	for i, shift := range shifts {
+		startTime, err := time.Parse(time.TimeOnly, shift.StartTime)
+		if err != nil {
+			return nil, err
+		}
 
-// employeeRoleToDomain returns a domain employee role based on the store model.
-func employeeRoleToDomain(role string) domain.EmployeeRole {
-	switch role {
-	case "waste_operator":
-		return domain.EmployeeRoleWasteOperator
-	case "manager":
-		return domain.EmployeeRoleManager
-	default:
-		return domain.EmployeeRole(role)
+		endTime, err := time.Parse(time.TimeOnly, shift.EndTime)
+		if err != nil {
+			return nil, err
+		}
+
+		domainShifts[i] = domain.EmployeeShift{
+			Weekday:   time.Weekday(shift.Weekday),
+			StartTime: startTime,
+			EndTime:   endTime,
+		}
 	}
+
+	return domainShifts, nil
 }
 
 // getEmployeeFromRow returns the employee by scanning the given row.
 func getEmployeeFromRow(row pgx.Row) (domain.Employee, error) {
 	var employee domain.Employee
-	var role string
 	var geoJSONPoint domain.GeoJSONGeometryPoint
 	var wayName *string
 	var municipalityName *string
+	var shifts []employeeShift
 
 	err := row.Scan(
 		&employee.ID,
 		&employee.Username,
 		&employee.FirstName,
 		&employee.LastName,
-		&role,
+		&employee.Role,
 		&employee.DateOfBirth,
 		&employee.PhoneNumber,
 		&geoJSONPoint,
@@ -397,6 +565,7 @@ func getEmployeeFromRow(row pgx.Row) (domain.Employee, error) {
 		&municipalityName,
 		&employee.ScheduleStart,
 		&employee.ScheduleEnd,
+		&shifts,
 		&employee.CreatedAt,
 		&employee.ModifiedAt,
 	)
@@ -404,7 +573,10 @@ func getEmployeeFromRow(row pgx.Row) (domain.Employee, error) {
 		return domain.Employee{}, err
 	}
 
-	employee.Role = employeeRoleToDomain(role)
+	employee.Shifts, err = employeeShiftsToDomain(shifts)
+	if err != nil {
+		return domain.Employee{}, err
+	}
 
 	geoJSONProperties := make(domain.GeoJSONFeatureProperties)
 	if wayName != nil {
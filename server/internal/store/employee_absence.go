@@ -0,0 +1,288 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/goncalo-marques/ecomap/server/internal/domain"
+)
+
+const (
+	constraintEmployeesAbsencesEmployeeIDFkey = "employees_absences_employee_id_fkey"
+)
+
+// CreateEmployeeAbsence executes a query to create an absence of the employee with the specified identifier.
+func (s *store) CreateEmployeeAbsence(ctx context.Context, tx pgx.Tx, employeeID uuid.UUID, editableAbsence domain.EditableEmployeeAbsence) (uuid.UUID, error) {
+	row := tx.QueryRow(ctx, `
+		INSERT INTO employees_absences (employee_id, type, start_date, end_date, notes)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id
+	`,
+		employeeID,
+		employeeAbsenceTypeFromDomain(editableAbsence.Type),
+		editableAbsence.StartDate,
+		editableAbsence.EndDate,
+		editableAbsence.Notes,
+	)
+
+	var id uuid.UUID
+
+	err := row.Scan(&id)
+	if err != nil {
+		switch constraintNameFromError(err) {
+		case constraintEmployeesAbsencesEmployeeIDFkey:
+			return uuid.UUID{}, fmt.Errorf("%s: %w", descriptionFailedScanRow, domain.ErrEmployeeNotFound)
+		}
+
+		return uuid.UUID{}, fmt.Errorf("%s: %w", descriptionFailedScanRow, err)
+	}
+
+	return id, nil
+}
+
+// ExistsEmployeeAbsence executes a query to return whether the employee with the specified identifier has an absence
+// that overlaps the dates between the given start and end dates, inclusive.
+func (s *store) ExistsEmployeeAbsence(ctx context.Context, tx pgx.Tx, employeeID uuid.UUID, startDate, endDate time.Time) (bool, error) {
+	row := tx.QueryRow(ctx, `
+		SELECT EXISTS (
+			SELECT 1
+			FROM employees_absences AS ea
+			WHERE ea.employee_id = $1 AND ea.start_date <= $3::date AND ea.end_date >= $2::date
+		)
+	`,
+		employeeID,
+		startDate,
+		endDate,
+	)
+
+	var exists bool
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", descriptionFailedScanRow, err)
+	}
+
+	return exists, nil
+}
+
+// ListEmployeesAbsentIDs executes a query to return the identifiers of the employees with an absence that overlaps the
+// dates between the given start and end dates, inclusive.
+func (s *store) ListEmployeesAbsentIDs(ctx context.Context, tx pgx.Tx, startDate, endDate time.Time) ([]uuid.UUID, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT DISTINCT ea.employee_id
+		FROM employees_absences AS ea
+		WHERE ea.start_date <= $2::date AND ea.end_date >= $1::date
+	`,
+		startDate,
+		endDate,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", descriptionFailedQuery, err)
+	}
+	defer rows.Close()
+
+	var employeeIDs []uuid.UUID
+	for rows.Next() {
+		var employeeID uuid.UUID
+		err := rows.Scan(&employeeID)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", descriptionFailedScanRows, err)
+		}
+
+		employeeIDs = append(employeeIDs, employeeID)
+	}
+
+	return employeeIDs, nil
+}
+
+// ListEmployeeAbsences executes a query to return the absences of the employee with the specified identifier for the
+// specified filter.
+func (s *store) ListEmployeeAbsences(ctx context.Context, tx pgx.Tx, employeeID uuid.UUID, filter domain.EmployeeAbsencesPaginatedFilter) (domain.PaginatedResponse[domain.EmployeeAbsence], error) {
+	var filterFields []string
+	var argsWhere []any
+
+	// Append the optional fields to filter.
+	filterFields = append(filterFields, "ea.employee_id::text")
+	argsWhere = append(argsWhere, employeeID)
+	if filter.Type != nil {
+		filterFields = append(filterFields, "ea.type::text")
+		argsWhere = append(argsWhere, employeeAbsenceTypeFromDomain(*filter.Type))
+	}
+
+	sqlWhere := listSQLWhere(filterFields, nil)
+
+	// Get the total number of rows for the given filter.
+	var total int
+	row := tx.QueryRow(ctx, `
+		SELECT count(ea.id) 
+		FROM employees_absences AS ea
+	`+sqlWhere,
+		argsWhere...,
+	)
+
+	err := row.Scan(&total)
+	if err != nil {
+		return domain.PaginatedResponse[domain.EmployeeAbsence]{}, fmt.Errorf("%s: %w", descriptionFailedScanRow, err)
+	}
+
+	// Append the field to sort, if provided.
+	var domainSortField domain.EmployeeAbsencePaginatedSort
+	if filter.Sort != nil {
+		domainSortField = filter.Sort.Field()
+	}
+
+	sortField := "ea.start_date"
+	switch domainSortField {
+	case domain.EmployeeAbsencePaginatedSortType:
+		sortField = "ea.type"
+	case domain.EmployeeAbsencePaginatedSortStartDate:
+		sortField = "ea.start_date"
+	case domain.EmployeeAbsencePaginatedSortEndDate:
+		sortField = "ea.end_date"
+	case domain.EmployeeAbsencePaginatedSortCreatedAt:
+		sortField = "ea.created_at"
+	}
+
+	// Get rows for the given filter.
+	rows, err := tx.Query(ctx, `
+		SELECT ea.id, ea.employee_id, ea.type, ea.start_date, ea.end_date, ea.notes, ea.created_at, ea.modified_at
+		FROM employees_absences AS ea
+	`+sqlWhere+listSQLOrder(sortField, filter.Order)+listSQLLimitOffset(filter.Limit, filter.Offset),
+		argsWhere...,
+	)
+	if err != nil {
+		return domain.PaginatedResponse[domain.EmployeeAbsence]{}, fmt.Errorf("%s: %w", descriptionFailedQuery, err)
+	}
+	defer rows.Close()
+
+	absences, err := getEmployeeAbsencesFromRows(rows)
+	if err != nil {
+		return domain.PaginatedResponse[domain.EmployeeAbsence]{}, fmt.Errorf("%s: %w", descriptionFailedScanRows, err)
+	}
+
+	return domain.PaginatedResponse[domain.EmployeeAbsence]{
+		Total:   total,
+		Results: absences,
+	}, nil
+}
+
+// GetEmployeeAbsenceByID executes a query to return the absence with the specified identifier of the employee with the
+// specified identifier.
+func (s *store) GetEmployeeAbsenceByID(ctx context.Context, tx pgx.Tx, employeeID, id uuid.UUID) (domain.EmployeeAbsence, error) {
+	row := tx.QueryRow(ctx, `
+		SELECT ea.id, ea.employee_id, ea.type, ea.start_date, ea.end_date, ea.notes, ea.created_at, ea.modified_at
+		FROM employees_absences AS ea
+		WHERE ea.employee_id = $1 AND ea.id = $2
+	`,
+		employeeID,
+		id,
+	)
+
+	absence, err := getEmployeeAbsenceFromRow(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.EmployeeAbsence{}, fmt.Errorf("%s: %w", descriptionFailedScanRow, domain.ErrEmployeeAbsenceNotFound)
+		}
+
+		return domain.EmployeeAbsence{}, fmt.Errorf("%s: %w", descriptionFailedScanRow, err)
+	}
+
+	return absence, nil
+}
+
+// DeleteEmployeeAbsenceByID executes a query to delete the absence with the specified identifier of the employee with
+// the specified identifier.
+func (s *store) DeleteEmployeeAbsenceByID(ctx context.Context, tx pgx.Tx, employeeID, id uuid.UUID) error {
+	commandTag, err := tx.Exec(ctx, `
+		DELETE FROM employees_absences
+		WHERE employee_id = $1 AND id = $2
+	`,
+		employeeID,
+		id,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", descriptionFailedExec, err)
+	}
+
+	if commandTag.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", descriptionFailedExec, domain.ErrEmployeeAbsenceNotFound)
+	}
+
+	return nil
+}
+
+// employeeAbsenceTypeFromDomain returns a store employee absence type based on the domain model.
+func employeeAbsenceTypeFromDomain(absenceType domain.EmployeeAbsenceType) string {
+	switch absenceType {
+	case domain.EmployeeAbsenceTypeVacation:
+		return "vacation"
+	case domain.EmployeeAbsenceTypeSickLeave:
+		return "sick_leave"
+	case domain.EmployeeAbsenceTypeTraining:
+		return "training"
+	case domain.EmployeeAbsenceTypeOther:
+		return "other"
+	default:
+		return string(absenceType)
+	}
+}
+
+// employeeAbsenceTypeToDomain returns a domain employee absence type based on the store model.
+func employeeAbsenceTypeToDomain(absenceType string) domain.EmployeeAbsenceType {
+	switch absenceType {
+	case "vacation":
+		return domain.EmployeeAbsenceTypeVacation
+	case "sick_leave":
+		return domain.EmployeeAbsenceTypeSickLeave
+	case "training":
+		return domain.EmployeeAbsenceTypeTraining
+	case "other":
+		return domain.EmployeeAbsenceTypeOther
+	default:
+		return domain.EmployeeAbsenceType(absenceType)
+	}
+}
+
+// getEmployeeAbsenceFromRow returns the employee absence by scanning the given row.
+func getEmployeeAbsenceFromRow(row pgx.Row) (domain.EmployeeAbsence, error) {
+	var absence domain.EmployeeAbsence
+	var absenceType string
+
+	err := row.Scan(
+		&absence.ID,
+		&absence.EmployeeID,
+		&absenceType,
+		&absence.StartDate,
+		&absence.EndDate,
+		&absence.Notes,
+		&absence.CreatedAt,
+		&absence.ModifiedAt,
+	)
+	if err != nil {
+		return domain.EmployeeAbsence{}, err
+	}
+
+	absence.Type = employeeAbsenceTypeToDomain(absenceType)
+
+	return absence, nil
+}
+
+// getEmployeeAbsencesFromRows returns the employee absences by scanning the given rows.
+func getEmployeeAbsencesFromRows(rows pgx.Rows) ([]domain.EmployeeAbsence, error) {
+	var absences []domain.EmployeeAbsence
+	for rows.Next() {
+		absence, err := getEmployeeAbsenceFromRow(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		absences = append(absences, absence)
+	}
+
+	return absences, nil
+}
@@ -0,0 +1,124 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/goncalo-marques/ecomap/server/internal/domain"
+)
+
+// CreateEmployeeMFAChallenge executes a query to create an employee two-factor authentication challenge with the
+// specified data.
+func (s *store) CreateEmployeeMFAChallenge(ctx context.Context, tx pgx.Tx, editableChallenge domain.EditableEmployeeMFAChallenge) (uuid.UUID, error) {
+	row := tx.QueryRow(ctx, `
+		INSERT INTO employees_mfa_challenges (employee_id, hash, enrolment_required, expires_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id
+	`,
+		editableChallenge.EmployeeID,
+		editableChallenge.Hash,
+		editableChallenge.EnrolmentRequired,
+		editableChallenge.ExpiresAt,
+	)
+
+	var id uuid.UUID
+
+	err := row.Scan(&id)
+	if err != nil {
+		return uuid.UUID{}, fmt.Errorf("%s: %w", descriptionFailedScanRow, err)
+	}
+
+	return id, nil
+}
+
+// GetEmployeeMFAChallengeByHash executes a query to return the employee two-factor authentication challenge with the
+// specified hash.
+func (s *store) GetEmployeeMFAChallengeByHash(ctx context.Context, tx pgx.Tx, hash []byte) (domain.EmployeeMFAChallenge, error) {
+	row := tx.QueryRow(ctx, `
+		SELECT id, employee_id, hash, enrolment_required, attempts, expires_at, created_at
+		FROM employees_mfa_challenges
+		WHERE hash = $1
+	`,
+		hash,
+	)
+
+	var challenge domain.EmployeeMFAChallenge
+
+	err := row.Scan(
+		&challenge.ID,
+		&challenge.EmployeeID,
+		&challenge.Hash,
+		&challenge.EnrolmentRequired,
+		&challenge.Attempts,
+		&challenge.ExpiresAt,
+		&challenge.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.EmployeeMFAChallenge{}, fmt.Errorf("%s: %w", descriptionFailedScanRow, domain.ErrMFAChallengeInvalid)
+		}
+
+		return domain.EmployeeMFAChallenge{}, fmt.Errorf("%s: %w", descriptionFailedScanRow, err)
+	}
+
+	return challenge, nil
+}
+
+// IncrementEmployeeMFAChallengeAttempts executes a query to increment the failed attempts of the employee two-factor
+// authentication challenge with the specified identifier.
+func (s *store) IncrementEmployeeMFAChallengeAttempts(ctx context.Context, tx pgx.Tx, id uuid.UUID) error {
+	_, err := tx.Exec(ctx, `
+		UPDATE employees_mfa_challenges SET
+			attempts = attempts + 1
+		WHERE id = $1
+	`,
+		id,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", descriptionFailedExec, err)
+	}
+
+	return nil
+}
+
+// DeleteEmployeeMFAChallenge executes a query to delete the employee two-factor authentication challenge with the
+// specified identifier. An error is returned when it was already deleted, so that a challenge can only be answered
+// once.
+func (s *store) DeleteEmployeeMFAChallenge(ctx context.Context, tx pgx.Tx, id uuid.UUID) error {
+	commandTag, err := tx.Exec(ctx, `
+		DELETE FROM employees_mfa_challenges
+		WHERE id = $1
+	`,
+		id,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", descriptionFailedExec, err)
+	}
+
+	if commandTag.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", descriptionFailedExec, domain.ErrMFAChallengeInvalid)
+	}
+
+	return nil
+}
+
+// DeleteExpiredEmployeeMFAChallenges executes a query to delete the employee two-factor authentication challenges that
+// expired before the specified time.
+func (s *store) DeleteExpiredEmployeeMFAChallenges(ctx context.Context, tx pgx.Tx, before time.Time) error {
+	_, err := tx.Exec(ctx, `
+		DELETE FROM employees_mfa_challenges
+		WHERE expires_at < $1
+	`,
+		before,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", descriptionFailedExec, err)
+	}
+
+	return nil
+}
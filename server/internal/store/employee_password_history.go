@@ -0,0 +1,74 @@
+package store
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/goncalo-marques/ecomap/server/internal/domain"
+)
+
+// AddEmployeePasswordHistory executes a query to add the hashed password to the password history of the employee with
+// the specified username, keeping only the specified amount of most recent passwords.
+func (s *store) AddEmployeePasswordHistory(ctx context.Context, tx pgx.Tx, username domain.Username, password domain.Password, keep int) error {
+	_, err := tx.Exec(ctx, `
+		INSERT INTO employees_password_history (employee_id, password)
+		SELECT id, $2
+		FROM employees
+		WHERE username = $1
+	`,
+		username,
+		password,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", descriptionFailedExec, err)
+	}
+
+	_, err = tx.Exec(ctx, `
+		DELETE FROM employees_password_history
+		WHERE employee_id = (SELECT id FROM employees WHERE username = $1) AND id NOT IN (
+			SELECT eph.id
+			FROM employees_password_history AS eph
+			INNER JOIN employees AS e ON e.id = eph.employee_id
+			WHERE e.username = $1
+			ORDER BY eph.created_at DESC
+			LIMIT $2
+		)
+	`,
+		username,
+		keep,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", descriptionFailedExec, err)
+	}
+
+	return nil
+}
+
+// ListEmployeePasswordHistory executes a query to return the specified amount of most recent hashed passwords of the
+// employee with the specified username, excluding the current one.
+func (s *store) ListEmployeePasswordHistory(ctx context.Context, tx pgx.Tx, username domain.Username, limit int) ([]domain.Password, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT eph.password
+		FROM employees_password_history AS eph
+		INNER JOIN employees AS e ON e.id = eph.employee_id
+		WHERE e.username = $1
+		ORDER BY eph.created_at DESC
+		LIMIT $2
+	`,
+		username,
+		limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", descriptionFailedQuery, err)
+	}
+	defer rows.Close()
+
+	passwords, err := getPasswordsFromRows(rows)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", descriptionFailedScanRows, err)
+	}
+
+	return passwords, nil
+}
@@ -0,0 +1,259 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/goncalo-marques/ecomap/server/internal/domain"
+)
+
+const (
+	constraintEmployeesQualificationsEmployeeIDFkey                   = "employees_qualifications_employee_id_fkey"
+	constraintEmployeesQualificationsEmployeeIDTypeLicenseCategoryKey = "employees_qualifications_employee_id_type_license_category_key"
+)
+
+// CreateEmployeeQualification executes a query to create a qualification of the employee with the specified
+// identifier.
+func (s *store) CreateEmployeeQualification(ctx context.Context, tx pgx.Tx, employeeID uuid.UUID, editableQualification domain.EditableEmployeeQualification) (uuid.UUID, error) {
+	var licenseCategory *string
+	if editableQualification.LicenseCategory != nil {
+		c := licenseCategoryFromDomain(*editableQualification.LicenseCategory)
+		licenseCategory = &c
+	}
+
+	row := tx.QueryRow(ctx, `
+		INSERT INTO employees_qualifications (employee_id, type, license_category, expiry_date)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id
+	`,
+		employeeID,
+		employeeQualificationTypeFromDomain(editableQualification.Type),
+		licenseCategory,
+		editableQualification.ExpiryDate,
+	)
+
+	var id uuid.UUID
+
+	err := row.Scan(&id)
+	if err != nil {
+		switch constraintNameFromError(err) {
+		case constraintEmployeesQualificationsEmployeeIDFkey:
+			return uuid.UUID{}, fmt.Errorf("%s: %w", descriptionFailedScanRow, domain.ErrEmployeeNotFound)
+		case constraintEmployeesQualificationsEmployeeIDTypeLicenseCategoryKey:
+			return uuid.UUID{}, fmt.Errorf("%s: %w", descriptionFailedScanRow, domain.ErrEmployeeQualificationAlreadyExists)
+		}
+
+		return uuid.UUID{}, fmt.Errorf("%s: %w", descriptionFailedScanRow, err)
+	}
+
+	return id, nil
+}
+
+// ListEmployeeQualifications executes a query to return the qualifications of the employee with the specified
+// identifier, sorted by their expiry date.
+func (s *store) ListEmployeeQualifications(ctx context.Context, tx pgx.Tx, employeeID uuid.UUID) (domain.EmployeeQualifications, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT eq.id, eq.employee_id, eq.type, eq.license_category, eq.expiry_date, eq.created_at, eq.modified_at
+		FROM employees_qualifications AS eq
+		WHERE eq.employee_id = $1
+		ORDER BY eq.expiry_date, eq.created_at
+	`,
+		employeeID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", descriptionFailedQuery, err)
+	}
+	defer rows.Close()
+
+	qualifications, err := getEmployeeQualificationsFromRows(rows)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", descriptionFailedScanRows, err)
+	}
+
+	return qualifications, nil
+}
+
+// ListEmployeesQualificationsExpiring executes a query to return the qualifications of every employee that expire on
+// or before the given date, sorted by their expiry date. If the type is defined, only qualifications of that type are
+// returned.
+func (s *store) ListEmployeesQualificationsExpiring(ctx context.Context, tx pgx.Tx, until time.Time, qualificationType *domain.EmployeeQualificationType) (domain.EmployeeQualifications, error) {
+	var storeType *string
+	if qualificationType != nil {
+		t := employeeQualificationTypeFromDomain(*qualificationType)
+		storeType = &t
+	}
+
+	rows, err := tx.Query(ctx, `
+		SELECT eq.id, eq.employee_id, eq.type, eq.license_category, eq.expiry_date, eq.created_at, eq.modified_at
+		FROM employees_qualifications AS eq
+		WHERE eq.expiry_date <= $1::date AND ($2::employees_qualifications_type IS NULL OR eq.type = $2)
+		ORDER BY eq.expiry_date, eq.created_at
+	`,
+		until,
+		storeType,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", descriptionFailedQuery, err)
+	}
+	defer rows.Close()
+
+	qualifications, err := getEmployeeQualificationsFromRows(rows)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", descriptionFailedScanRows, err)
+	}
+
+	return qualifications, nil
+}
+
+// GetEmployeeQualificationByID executes a query to return the qualification with the specified identifier of the
+// employee with the specified identifier.
+func (s *store) GetEmployeeQualificationByID(ctx context.Context, tx pgx.Tx, employeeID, id uuid.UUID) (domain.EmployeeQualification, error) {
+	row := tx.QueryRow(ctx, `
+		SELECT eq.id, eq.employee_id, eq.type, eq.license_category, eq.expiry_date, eq.created_at, eq.modified_at
+		FROM employees_qualifications AS eq
+		WHERE eq.employee_id = $1 AND eq.id = $2
+	`,
+		employeeID,
+		id,
+	)
+
+	qualification, err := getEmployeeQualificationFromRow(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.EmployeeQualification{}, fmt.Errorf("%s: %w", descriptionFailedScanRow, domain.ErrEmployeeQualificationNotFound)
+		}
+
+		return domain.EmployeeQualification{}, fmt.Errorf("%s: %w", descriptionFailedScanRow, err)
+	}
+
+	return qualification, nil
+}
+
+// DeleteEmployeeQualificationByID executes a query to delete the qualification with the specified identifier of the
+// employee with the specified identifier.
+func (s *store) DeleteEmployeeQualificationByID(ctx context.Context, tx pgx.Tx, employeeID, id uuid.UUID) error {
+	commandTag, err := tx.Exec(ctx, `
+		DELETE FROM employees_qualifications
+		WHERE employee_id = $1 AND id = $2
+	`,
+		employeeID,
+		id,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", descriptionFailedExec, err)
+	}
+
+	if commandTag.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", descriptionFailedExec, domain.ErrEmployeeQualificationNotFound)
+	}
+
+	return nil
+}
+
+// licenseCategoryFromDomain returns a store license category based on the domain model.
+func licenseCategoryFromDomain(category domain.LicenseCategory) string {
+	switch category {
+	case domain.LicenseCategoryB:
+		return "B"
+	case domain.LicenseCategoryC1:
+		return "C1"
+	case domain.LicenseCategoryC1E:
+		return "C1E"
+	case domain.LicenseCategoryC:
+		return "C"
+	case domain.LicenseCategoryCE:
+		return "CE"
+	default:
+		return string(category)
+	}
+}
+
+// licenseCategoryToDomain returns a domain license category based on the store model.
+func licenseCategoryToDomain(category string) domain.LicenseCategory {
+	switch category {
+	case "B":
+		return domain.LicenseCategoryB
+	case "C1":
+		return domain.LicenseCategoryC1
+	case "C1E":
+		return domain.LicenseCategoryC1E
+	case "C":
+		return domain.LicenseCategoryC
+	case "CE":
+		return domain.LicenseCategoryCE
+	default:
+		return domain.LicenseCategory(category)
+	}
+}
+
+// employeeQualificationTypeFromDomain returns a store employee qualification type based on the domain model.
+func employeeQualificationTypeFromDomain(qualificationType domain.EmployeeQualificationType) string {
+	switch qualificationType {
+	case domain.EmployeeQualificationTypeLicense:
+		return "license"
+	case domain.EmployeeQualificationTypeHazardousMaterials:
+		return "hazardous_materials"
+	default:
+		return string(qualificationType)
+	}
+}
+
+// employeeQualificationTypeToDomain returns a domain employee qualification type based on the store model.
+func employeeQualificationTypeToDomain(qualificationType string) domain.EmployeeQualificationType {
+	switch qualificationType {
+	case "license":
+		return domain.EmployeeQualificationTypeLicense
+	case "hazardous_materials":
+		return domain.EmployeeQualificationTypeHazardousMaterials
+	default:
+		return domain.EmployeeQualificationType(qualificationType)
+	}
+}
+
+// getEmployeeQualificationFromRow returns the employee qualification by scanning the given row.
+func getEmployeeQualificationFromRow(row pgx.Row) (domain.EmployeeQualification, error) {
+	var qualification domain.EmployeeQualification
+	var qualificationType string
+	var licenseCategory *string
+
+	err := row.Scan(
+		&qualification.ID,
+		&qualification.EmployeeID,
+		&qualificationType,
+		&licenseCategory,
+		&qualification.ExpiryDate,
+		&qualification.CreatedAt,
+		&qualification.ModifiedAt,
+	)
+	if err != nil {
+		return domain.EmployeeQualification{}, err
+	}
+
+	qualification.Type = employeeQualificationTypeToDomain(qualificationType)
+	if licenseCategory != nil {
+		c := licenseCategoryToDomain(*licenseCategory)
+		qualification.LicenseCategory = &c
+	}
+
+	return qualification, nil
+}
+
+// getEmployeeQualificationsFromRows returns the employee qualifications by scanning the given rows.
+func getEmployeeQualificationsFromRows(rows pgx.Rows) (domain.EmployeeQualifications, error) {
+	var qualifications domain.EmployeeQualifications
+	for rows.Next() {
+		qualification, err := getEmployeeQualificationFromRow(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		qualifications = append(qualifications, qualification)
+	}
+
+	return qualifications, nil
+}
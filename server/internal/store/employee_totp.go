@@ -0,0 +1,205 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/goncalo-marques/ecomap/server/internal/domain"
+)
+
+const (
+	constraintEmployeesTOTPEmployeeIDFkey          = "employees_totp_employee_id_fkey"
+	constraintEmployeesRecoveryCodesEmployeeIDFkey = "employees_recovery_codes_employee_id_fkey"
+)
+
+// PutEmployeeTOTP executes a query to create or replace the pending time-based one-time password enrolment of the
+// employee with the specified identifier.
+func (s *store) PutEmployeeTOTP(ctx context.Context, tx pgx.Tx, employeeID uuid.UUID, secret string) error {
+	_, err := tx.Exec(ctx, `
+		INSERT INTO employees_totp (employee_id, secret)
+		VALUES ($1, $2)
+		ON CONFLICT (employee_id) DO UPDATE SET
+			secret = excluded.secret,
+			confirmed_at = NULL,
+			last_used_step = NULL,
+			created_at = CURRENT_TIMESTAMP
+	`,
+		employeeID,
+		secret,
+	)
+	if err != nil {
+		switch constraintNameFromError(err) {
+		case constraintEmployeesTOTPEmployeeIDFkey:
+			return fmt.Errorf("%s: %w", descriptionFailedExec, domain.ErrEmployeeNotFound)
+		}
+
+		return fmt.Errorf("%s: %w", descriptionFailedExec, err)
+	}
+
+	return nil
+}
+
+// GetEmployeeTOTP executes a query to return the time-based one-time password enrolment of the employee with the
+// specified identifier.
+func (s *store) GetEmployeeTOTP(ctx context.Context, tx pgx.Tx, employeeID uuid.UUID) (domain.EmployeeTOTP, error) {
+	row := tx.QueryRow(ctx, `
+		SELECT employee_id, secret, confirmed_at, last_used_step, created_at
+		FROM employees_totp
+		WHERE employee_id = $1
+	`,
+		employeeID,
+	)
+
+	var totp domain.EmployeeTOTP
+
+	err := row.Scan(
+		&totp.EmployeeID,
+		&totp.Secret,
+		&totp.ConfirmedAt,
+		&totp.LastUsedStep,
+		&totp.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.EmployeeTOTP{}, fmt.Errorf("%s: %w", descriptionFailedScanRow, domain.ErrEmployeeTOTPNotFound)
+		}
+
+		return domain.EmployeeTOTP{}, fmt.Errorf("%s: %w", descriptionFailedScanRow, err)
+	}
+
+	return totp, nil
+}
+
+// ConfirmEmployeeTOTP executes a query to confirm the pending time-based one-time password enrolment of the employee
+// with the specified identifier, recording the time step of the code used to confirm it.
+func (s *store) ConfirmEmployeeTOTP(ctx context.Context, tx pgx.Tx, employeeID uuid.UUID, step int64, confirmedAt time.Time) error {
+	commandTag, err := tx.Exec(ctx, `
+		UPDATE employees_totp SET
+			confirmed_at = $3,
+			last_used_step = $2
+		WHERE employee_id = $1 AND confirmed_at IS NULL
+	`,
+		employeeID,
+		step,
+		confirmedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", descriptionFailedExec, err)
+	}
+
+	if commandTag.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", descriptionFailedExec, domain.ErrEmployeeTOTPAlreadyEnabled)
+	}
+
+	return nil
+}
+
+// UseEmployeeTOTPStep executes a query to record the time step of the code used by the employee with the specified
+// identifier. Codes of the same or of previous time steps are rejected, so that each code can only be used once.
+func (s *store) UseEmployeeTOTPStep(ctx context.Context, tx pgx.Tx, employeeID uuid.UUID, step int64) error {
+	commandTag, err := tx.Exec(ctx, `
+		UPDATE employees_totp SET
+			last_used_step = $2
+		WHERE employee_id = $1 AND confirmed_at IS NOT NULL AND (last_used_step IS NULL OR last_used_step < $2)
+	`,
+		employeeID,
+		step,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", descriptionFailedExec, err)
+	}
+
+	if commandTag.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", descriptionFailedExec, domain.ErrEmployeeTOTPCodeInvalid)
+	}
+
+	return nil
+}
+
+// DeleteEmployeeTOTP executes a query to delete the time-based one-time password enrolment of the employee with the
+// specified identifier.
+func (s *store) DeleteEmployeeTOTP(ctx context.Context, tx pgx.Tx, employeeID uuid.UUID) error {
+	commandTag, err := tx.Exec(ctx, `
+		DELETE FROM employees_totp
+		WHERE employee_id = $1
+	`,
+		employeeID,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", descriptionFailedExec, err)
+	}
+
+	if commandTag.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", descriptionFailedExec, domain.ErrEmployeeTOTPNotFound)
+	}
+
+	return nil
+}
+
+// CreateEmployeeRecoveryCodes executes a query to create the recovery codes with the specified hashes for the employee
+// with the specified identifier.
+func (s *store) CreateEmployeeRecoveryCodes(ctx context.Context, tx pgx.Tx, employeeID uuid.UUID, hashes [][]byte) error {
+	for _, hash := range hashes {
+		_, err := tx.Exec(ctx, `
+			INSERT INTO employees_recovery_codes (employee_id, hash)
+			VALUES ($1, $2)
+		`,
+			employeeID,
+			hash,
+		)
+		if err != nil {
+			switch constraintNameFromError(err) {
+			case constraintEmployeesRecoveryCodesEmployeeIDFkey:
+				return fmt.Errorf("%s: %w", descriptionFailedExec, domain.ErrEmployeeNotFound)
+			}
+
+			return fmt.Errorf("%s: %w", descriptionFailedExec, err)
+		}
+	}
+
+	return nil
+}
+
+// UseEmployeeRecoveryCode executes a query to mark the unused recovery code with the specified hash of the employee
+// with the specified identifier as used.
+func (s *store) UseEmployeeRecoveryCode(ctx context.Context, tx pgx.Tx, employeeID uuid.UUID, hash []byte, usedAt time.Time) error {
+	commandTag, err := tx.Exec(ctx, `
+		UPDATE employees_recovery_codes SET
+			used_at = $3
+		WHERE employee_id = $1 AND hash = $2 AND used_at IS NULL
+	`,
+		employeeID,
+		hash,
+		usedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", descriptionFailedExec, err)
+	}
+
+	if commandTag.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", descriptionFailedExec, domain.ErrEmployeeTOTPCodeInvalid)
+	}
+
+	return nil
+}
+
+// DeleteEmployeeRecoveryCodes executes a query to delete every recovery code of the employee with the specified
+// identifier.
+func (s *store) DeleteEmployeeRecoveryCodes(ctx context.Context, tx pgx.Tx, employeeID uuid.UUID) error {
+	_, err := tx.Exec(ctx, `
+		DELETE FROM employees_recovery_codes
+		WHERE employee_id = $1
+	`,
+		employeeID,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", descriptionFailedExec, err)
+	}
+
+	return nil
+}
@@ -20,10 +20,15 @@ func (s *store) CreateLandfill(ctx context.Context, tx pgx.Tx, editableLandfill
 	}
 
 	row := tx.QueryRow(ctx, `
-		INSERT INTO landfills (geom, road_id, municipality_id)
-		VALUES (ST_GeomFromGeoJSON($1), $2, $3) 
+		INSERT INTO landfills (name, accepted_categories, opening_time, closing_time, daily_capacity, geom, road_id, municipality_id)
+		VALUES ($1, $2::text[]::containers_category[], $3, $4, $5, ST_GeomFromGeoJSON($6), $7, $8) 
 		RETURNING id
 	`,
+		editableLandfill.Name,
+		containerCategoriesFromDomain(editableLandfill.AcceptedCategories),
+		editableLandfill.OpeningTime,
+		editableLandfill.ClosingTime,
+		editableLandfill.DailyCapacity,
 		geoJSON,
 		roadID,
 		municipalityID,
@@ -76,6 +81,8 @@ func (s *store) ListLandfills(ctx context.Context, tx pgx.Tx, filter domain.Land
 
 	sortField := "l.created_at"
 	switch domainSortField {
+	case domain.LandfillPaginatedSortName:
+		sortField = "l.name"
 	case domain.LandfillPaginatedSortWayName:
 		sortField = "rn.osm_name"
 	case domain.LandfillPaginatedSortMunicipalityName:
@@ -88,7 +95,7 @@ func (s *store) ListLandfills(ctx context.Context, tx pgx.Tx, filter domain.Land
 
 	// Get rows for the given filter.
 	rows, err := tx.Query(ctx, `
-		SELECT l.id, ST_AsGeoJSON(l.geom)::jsonb, rn.osm_name, m.name, l.created_at, l.modified_at
+		SELECT l.id, l.name, l.accepted_categories::text[], l.opening_time, l.closing_time, l.daily_capacity, ST_AsGeoJSON(l.geom)::jsonb, rn.osm_name, m.name, l.created_at, l.modified_at
 		FROM landfills AS l
 		LEFT JOIN road_network AS rn ON l.road_id = rn.id
 		LEFT JOIN municipalities AS m ON l.municipality_id = m.id
@@ -114,7 +121,7 @@ func (s *store) ListLandfills(ctx context.Context, tx pgx.Tx, filter domain.Land
 // GetLandfillByID executes a query to return the landfill with the specified identifier.
 func (s *store) GetLandfillByID(ctx context.Context, tx pgx.Tx, id uuid.UUID) (domain.Landfill, error) {
 	row := tx.QueryRow(ctx, `
-		SELECT l.id, ST_AsGeoJSON(l.geom)::jsonb, rn.osm_name, m.name, l.created_at, l.modified_at 
+		SELECT l.id, l.name, l.accepted_categories::text[], l.opening_time, l.closing_time, l.daily_capacity, ST_AsGeoJSON(l.geom)::jsonb, rn.osm_name, m.name, l.created_at, l.modified_at 
 		FROM landfills AS l
 		LEFT JOIN road_network AS rn ON l.road_id = rn.id
 		LEFT JOIN municipalities AS m ON l.municipality_id = m.id
@@ -135,22 +142,25 @@ func (s *store) GetLandfillByID(ctx context.Context, tx pgx.Tx, id uuid.UUID) (d
 	return landfill, nil
 }
 
-// GetLandfillClosestGeometry executes a query to return the landfill that is closest to the given geometry.
-func (s *store) GetLandfillClosestGeometry(ctx context.Context, tx pgx.Tx, geometry domain.GeoJSONGeometryPoint) (domain.Landfill, error) {
+// GetLandfillClosestGeometry executes a query to return the landfill that is closest to the given geometry and that
+// accepts all the given container categories.
+func (s *store) GetLandfillClosestGeometry(ctx context.Context, tx pgx.Tx, geometry domain.GeoJSONGeometryPoint, categories []domain.ContainerCategory) (domain.Landfill, error) {
 	geoJSON, err := json.Marshal(geometry)
 	if err != nil {
 		return domain.Landfill{}, fmt.Errorf("%s: %w", descriptionFailedMarshalGeoJSON, err)
 	}
 
 	row := tx.QueryRow(ctx, `
-		SELECT l.id, ST_AsGeoJSON(l.geom)::jsonb, rn.osm_name, m.name, l.created_at, l.modified_at 
+		SELECT l.id, l.name, l.accepted_categories::text[], l.opening_time, l.closing_time, l.daily_capacity, ST_AsGeoJSON(l.geom)::jsonb, rn.osm_name, m.name, l.created_at, l.modified_at 
 		FROM landfills AS l
 		LEFT JOIN road_network AS rn ON l.road_id = rn.id
 		LEFT JOIN municipalities AS m ON l.municipality_id = m.id
+		WHERE l.accepted_categories @> $2::text[]::containers_category[]
 		ORDER BY ST_Distance(l.geom, ST_GeomFromGeoJSON($1))
 		LIMIT 1
 	`,
 		string(geoJSON),
+		containerCategoriesFromDomain(categories),
 	)
 
 	landfill, err := getLandfillFromRow(row)
@@ -165,6 +175,25 @@ func (s *store) GetLandfillClosestGeometry(ctx context.Context, tx pgx.Tx, geome
 	return landfill, nil
 }
 
+// ExistsLandfill executes a query to return whether there is any landfill.
+func (s *store) ExistsLandfill(ctx context.Context, tx pgx.Tx) (bool, error) {
+	row := tx.QueryRow(ctx, `
+		SELECT EXISTS (
+			SELECT 1
+			FROM landfills
+		)
+	`)
+
+	var exists bool
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", descriptionFailedScanRow, err)
+	}
+
+	return exists, nil
+}
+
 // PatchLandfill executes a query to patch an landfill with the specified identifier and data.
 func (s *store) PatchLandfill(ctx context.Context, tx pgx.Tx, id uuid.UUID, editableLandfill domain.EditableLandfillPatch, roadID, municipalityID *int) error {
 	var geoJSON []byte
@@ -179,18 +208,28 @@ func (s *store) PatchLandfill(ctx context.Context, tx pgx.Tx, id uuid.UUID, edit
 
 	commandTag, err := tx.Exec(ctx, `
 		UPDATE landfills SET
-			geom = coalesce(ST_GeomFromGeoJSON($2), geom),
+			name = coalesce($2, name),
+			accepted_categories = coalesce($3::text[]::containers_category[], accepted_categories),
+			opening_time = coalesce($4, opening_time),
+			closing_time = coalesce($5, closing_time),
+			daily_capacity = coalesce($6, daily_capacity),
+			geom = coalesce(ST_GeomFromGeoJSON($7), geom),
 			road_id = CASE 
-					WHEN $2 IS NOT NULL THEN $3
+					WHEN $7 IS NOT NULL THEN $8
 					ELSE road_id
 				END,
 			municipality_id = CASE 
-					WHEN $2 IS NOT NULL THEN $4
+					WHEN $7 IS NOT NULL THEN $9
 					ELSE municipality_id
 				END
 		WHERE id = $1
 	`,
 		id,
+		editableLandfill.Name,
+		containerCategoriesFromDomain(editableLandfill.AcceptedCategories),
+		editableLandfill.OpeningTime,
+		editableLandfill.ClosingTime,
+		editableLandfill.DailyCapacity,
 		geoJSON,
 		roadID,
 		municipalityID,
@@ -228,12 +267,18 @@ func (s *store) DeleteLandfillByID(ctx context.Context, tx pgx.Tx, id uuid.UUID)
 // getLandfillFromRow returns the landfill by scanning the given row.
 func getLandfillFromRow(row pgx.Row) (domain.Landfill, error) {
 	var landfill domain.Landfill
+	var acceptedCategories []string
 	var geoJSONPoint domain.GeoJSONGeometryPoint
 	var wayName *string
 	var municipalityName *string
 
 	err := row.Scan(
 		&landfill.ID,
+		&landfill.Name,
+		&acceptedCategories,
+		&landfill.OpeningTime,
+		&landfill.ClosingTime,
+		&landfill.DailyCapacity,
 		&geoJSONPoint,
 		&wayName,
 		&municipalityName,
@@ -244,6 +289,8 @@ func getLandfillFromRow(row pgx.Row) (domain.Landfill, error) {
 		return domain.Landfill{}, err
 	}
 
+	landfill.AcceptedCategories = containerCategoriesToDomain(acceptedCategories)
+
 	geoJSONProperties := make(domain.GeoJSONFeatureProperties)
 	if wayName != nil {
 		geoJSONProperties.SetWayName(*wayName)
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 
@@ -73,7 +74,8 @@ func (s *store) CreateTemporaryTableRoadNetworkWithBuffer(ctx context.Context, t
 }
 
 // CreateVerticesCloseToRoadNetwork executes a query to create new vertices by dividing the existing road network,
-// taking into account the edge that is closest to each of the given vertices.
+// taking into account the edge that is closest to each of the given vertices. The cost and length of the divided edge
+// are distributed between its parts in proportion to their geometry.
 func (s *store) CreateVerticesCloseToRoadNetwork(ctx context.Context, tx pgx.Tx, roadNetworkTableName string, verticesGeometry []domain.GeoJSONGeometryPoint) ([]int, error) {
 	if len(verticesGeometry) == 0 {
 		return nil, nil
@@ -130,6 +132,7 @@ func (s *store) CreateVerticesCloseToRoadNetwork(ctx context.Context, tx pgx.Tx,
 				SELECT
 					(SELECT cost FROM closest_way) * ST_Length(geom1) / ST_Length((SELECT geom_way FROM closest_way)) AS cost,
 					(SELECT reverse_cost FROM closest_way) * ST_Length(geom1) / ST_Length((SELECT geom_way FROM closest_way)) AS reverse_cost,
+					(SELECT km FROM closest_way) * ST_Length(geom1) / ST_Length((SELECT geom_way FROM closest_way)) AS km,
 					ST_X(ST_StartPoint(geom1)) AS x1,
 					ST_Y(ST_StartPoint(geom1)) AS y1,
 					ST_X(ST_EndPoint(geom1)) AS x2,
@@ -140,6 +143,7 @@ func (s *store) CreateVerticesCloseToRoadNetwork(ctx context.Context, tx pgx.Tx,
 				SELECT
 					(SELECT cost FROM closest_way) * ST_Length(geom2) / ST_Length((SELECT geom_way FROM closest_way)) AS cost,
 					(SELECT reverse_cost FROM closest_way) * ST_Length(geom2) / ST_Length((SELECT geom_way FROM closest_way)) AS reverse_cost,
+					(SELECT km FROM closest_way) * ST_Length(geom2) / ST_Length((SELECT geom_way FROM closest_way)) AS km,
 					ST_X(ST_StartPoint(geom2)) AS x1,
 					ST_Y(ST_StartPoint(geom2)) AS y1,
 					ST_X(ST_EndPoint(geom2)) AS x2,
@@ -163,7 +167,9 @@ func (s *store) CreateVerticesCloseToRoadNetwork(ctx context.Context, tx pgx.Tx,
 					(SELECT x2 FROM new_way1),
 					(SELECT y2 FROM new_way1),
 					(SELECT geom1 FROM split_way_geom) AS geom_way,
-					osm_id, osm_name, osm_meta, osm_source_id, osm_target_id, clazz, flags, km, kmh 
+					osm_id, osm_name, osm_meta, osm_source_id, osm_target_id, clazz, flags,
+					(SELECT km FROM new_way1),
+					kmh 
 				FROM closest_way 
 			),
 			insert2 AS (
@@ -178,7 +184,9 @@ func (s *store) CreateVerticesCloseToRoadNetwork(ctx context.Context, tx pgx.Tx,
 					(SELECT x2 FROM new_way2),
 					(SELECT y2 FROM new_way2),
 					(SELECT geom2 FROM split_way_geom) AS geom_way,
-					osm_id, osm_name, osm_meta, osm_source_id, osm_target_id, clazz, flags, km, kmh 
+					osm_id, osm_name, osm_meta, osm_source_id, osm_target_id, clazz, flags,
+					(SELECT km FROM new_way2),
+					kmh 
 				FROM closest_way
 			),
 			insert_new_ways AS (
@@ -281,6 +289,115 @@ func (s *store) GetRoadVerticesTSP(ctx context.Context, tx pgx.Tx, roadNetworkTa
 	return seqVertexIDs, nil
 }
 
+// GetRoadVerticesCostMatrix executes a query to return the distance and duration between every pair of the given
+// vertices along the fastest path found by the A* algorithm. Pairs of vertices that are unreachable are not returned.
+func (s *store) GetRoadVerticesCostMatrix(ctx context.Context, tx pgx.Tx, roadNetworkTableName string, vertexIDs []int, directed bool) ([]domain.RoadVerticesCost, error) {
+	if len(vertexIDs) == 0 {
+		return nil, nil
+	}
+
+	strVertexIDs := make([]string, len(vertexIDs))
+	for i, id := range vertexIDs {
+		strVertexIDs[i] = strconv.Itoa(id)
+	}
+
+	// The cost of the road network represents the travel time in hours, which results from km / kmh. The distance is
+	// the sum of the length of the edges of the same path, whose last row has no edge and the aggregated cost.
+	rows, err := tx.Query(ctx, fmt.Sprintf(`
+		SELECT a.start_vid, a.end_vid, coalesce(sum(rn.km), 0), max(a.agg_cost)
+		FROM pgr_aStar(
+			'SELECT id, source, target, cost, reverse_cost, x1, y1, x2, y2 FROM %s',
+			'{%s}'::bigint[], '{%s}'::bigint[],
+			directed => %t
+		) AS a
+		LEFT JOIN %s AS rn ON a.edge = rn.id
+		GROUP BY a.start_vid, a.end_vid
+	`,
+		roadNetworkTableName,
+		strings.Join(strVertexIDs, ", "),
+		strings.Join(strVertexIDs, ", "),
+		directed,
+		roadNetworkTableName,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", descriptionFailedQuery, err)
+	}
+	defer rows.Close()
+
+	var costs []domain.RoadVerticesCost
+	for rows.Next() {
+		var cost domain.RoadVerticesCost
+		var hours float64
+
+		err := rows.Scan(
+			&cost.SourceVertexID,
+			&cost.TargetVertexID,
+			&cost.Distance,
+			&hours,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", descriptionFailedScanRows, err)
+		}
+
+		cost.Duration = time.Duration(hours * float64(time.Hour))
+		costs = append(costs, cost)
+	}
+
+	return costs, nil
+}
+
+// GetRoadVerticesDurationMatrix executes a query to return the duration between every pair of the given vertices
+// using the A* cost matrix, which is cheaper than computing the paths. The distance is not computed. Pairs of vertices
+// that are unreachable are not returned.
+func (s *store) GetRoadVerticesDurationMatrix(ctx context.Context, tx pgx.Tx, roadNetworkTableName string, vertexIDs []int, directed bool) ([]domain.RoadVerticesCost, error) {
+	if len(vertexIDs) == 0 {
+		return nil, nil
+	}
+
+	strVertexIDs := make([]string, len(vertexIDs))
+	for i, id := range vertexIDs {
+		strVertexIDs[i] = strconv.Itoa(id)
+	}
+
+	// The cost of the road network represents the travel time in hours, which results from km / kmh.
+	rows, err := tx.Query(ctx, fmt.Sprintf(`
+		SELECT start_vid, end_vid, agg_cost
+		FROM pgr_aStarCostMatrix(
+			'SELECT id, source, target, cost, reverse_cost, x1, y1, x2, y2 FROM %s',
+			'{%s}'::bigint[],
+			directed => %t
+		)
+	`,
+		roadNetworkTableName,
+		strings.Join(strVertexIDs, ", "),
+		directed,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", descriptionFailedQuery, err)
+	}
+	defer rows.Close()
+
+	var costs []domain.RoadVerticesCost
+	for rows.Next() {
+		var cost domain.RoadVerticesCost
+		var hours float64
+
+		err := rows.Scan(
+			&cost.SourceVertexID,
+			&cost.TargetVertexID,
+			&hours,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", descriptionFailedScanRows, err)
+		}
+
+		cost.Duration = time.Duration(hours * float64(time.Hour))
+		costs = append(costs, cost)
+	}
+
+	return costs, nil
+}
+
 // GetRoadsGeometryAStar executes a query to return the geometry of sequential roads using the shortest path A*
 // algorithm.
 func (s *store) GetRoadsGeometryAStar(ctx context.Context, tx pgx.Tx, roadNetworkTableName string, seqVertexIDs []int, directed bool) ([]domain.GeoJSONGeometryLineString, error) {
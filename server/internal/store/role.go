@@ -0,0 +1,240 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/goncalo-marques/ecomap/server/internal/domain"
+)
+
+const (
+	constraintRolesNameKey = "roles_name_key"
+)
+
+// CreateRole executes a query to create a role with the specified data.
+func (s *store) CreateRole(ctx context.Context, tx pgx.Tx, editableRole domain.EditableRole) (uuid.UUID, error) {
+	row := tx.QueryRow(ctx, `
+		INSERT INTO roles (name, permissions)
+		VALUES ($1, $2)
+		RETURNING id
+	`,
+		editableRole.Name,
+		rolePermissionsFromDomain(editableRole.Permissions),
+	)
+
+	var id uuid.UUID
+
+	err := row.Scan(&id)
+	if err != nil {
+		if constraintNameFromError(err) == constraintRolesNameKey {
+			return uuid.UUID{}, fmt.Errorf("%s: %w", descriptionFailedScanRow, domain.ErrRoleAlreadyExists)
+		}
+
+		return uuid.UUID{}, fmt.Errorf("%s: %w", descriptionFailedScanRow, err)
+	}
+
+	return id, nil
+}
+
+// ListRoles executes a query to return every role.
+func (s *store) ListRoles(ctx context.Context, tx pgx.Tx) ([]domain.Role, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT r.id, r.name, r.permissions, r.built_in, r.created_at, r.modified_at
+		FROM roles AS r
+		ORDER BY r.built_in DESC, r.name ASC
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", descriptionFailedQuery, err)
+	}
+	defer rows.Close()
+
+	roles, err := getRolesFromRows(rows)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", descriptionFailedScanRows, err)
+	}
+
+	return roles, nil
+}
+
+// ListRolesByName executes a query to return the roles with the specified names. Names without a role are ignored.
+func (s *store) ListRolesByName(ctx context.Context, tx pgx.Tx, names []domain.RoleName) ([]domain.Role, error) {
+	storeNames := make([]string, len(names))
+	for i, name := range names {
+		storeNames[i] = string(name)
+	}
+
+	rows, err := tx.Query(ctx, `
+		SELECT r.id, r.name, r.permissions, r.built_in, r.created_at, r.modified_at
+		FROM roles AS r
+		WHERE r.name = ANY($1)
+		ORDER BY r.name ASC
+	`,
+		storeNames,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", descriptionFailedQuery, err)
+	}
+	defer rows.Close()
+
+	roles, err := getRolesFromRows(rows)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", descriptionFailedScanRows, err)
+	}
+
+	return roles, nil
+}
+
+// GetRoleByID executes a query to return the role with the specified identifier.
+func (s *store) GetRoleByID(ctx context.Context, tx pgx.Tx, id uuid.UUID) (domain.Role, error) {
+	row := tx.QueryRow(ctx, `
+		SELECT r.id, r.name, r.permissions, r.built_in, r.created_at, r.modified_at
+		FROM roles AS r
+		WHERE r.id = $1
+	`,
+		id,
+	)
+
+	role, err := getRoleFromRow(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.Role{}, fmt.Errorf("%s: %w", descriptionFailedScanRow, domain.ErrRoleNotFound)
+		}
+
+		return domain.Role{}, fmt.Errorf("%s: %w", descriptionFailedScanRow, err)
+	}
+
+	return role, nil
+}
+
+// PatchRole executes a query to patch a role with the specified identifier and data.
+func (s *store) PatchRole(ctx context.Context, tx pgx.Tx, id uuid.UUID, editableRole domain.EditableRolePatch) error {
+	var permissions []string
+	if editableRole.Permissions != nil {
+		permissions = rolePermissionsFromDomain(*editableRole.Permissions)
+	}
+
+	commandTag, err := tx.Exec(ctx, `
+		UPDATE roles SET
+			permissions = coalesce($2, permissions)
+		WHERE id = $1
+	`,
+		id,
+		permissions,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", descriptionFailedExec, err)
+	}
+
+	if commandTag.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", descriptionFailedExec, domain.ErrRoleNotFound)
+	}
+
+	return nil
+}
+
+// DeleteRoleByID executes a query to delete the role with the specified identifier.
+func (s *store) DeleteRoleByID(ctx context.Context, tx pgx.Tx, id uuid.UUID) error {
+	commandTag, err := tx.Exec(ctx, `
+		DELETE FROM roles
+		WHERE id = $1
+	`,
+		id,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", descriptionFailedExec, err)
+	}
+
+	if commandTag.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", descriptionFailedExec, domain.ErrRoleNotFound)
+	}
+
+	return nil
+}
+
+// ListRolesPermissions executes a query to return the distinct permissions granted by the roles with the specified
+// names.
+func (s *store) ListRolesPermissions(ctx context.Context, tx pgx.Tx, names []domain.RoleName) ([]domain.Permission, error) {
+	storeNames := make([]string, len(names))
+	for i, name := range names {
+		storeNames[i] = string(name)
+	}
+
+	row := tx.QueryRow(ctx, `
+		SELECT coalesce(array_agg(DISTINCT p.permission), '{}')
+		FROM roles AS r
+		CROSS JOIN unnest(r.permissions) AS p(permission)
+		WHERE r.name = ANY($1)
+	`,
+		storeNames,
+	)
+
+	var permissions []string
+
+	err := row.Scan(&permissions)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", descriptionFailedScanRow, err)
+	}
+
+	return rolePermissionsToDomain(permissions), nil
+}
+
+// getRoleFromRow returns the role by scanning the given row.
+func getRoleFromRow(row pgx.Row) (domain.Role, error) {
+	var role domain.Role
+	var permissions []string
+
+	err := row.Scan(
+		&role.ID,
+		&role.Name,
+		&permissions,
+		&role.BuiltIn,
+		&role.CreatedAt,
+		&role.ModifiedAt,
+	)
+	if err != nil {
+		return domain.Role{}, err
+	}
+
+	role.Permissions = rolePermissionsToDomain(permissions)
+
+	return role, nil
+}
+
+// getRolesFromRows returns the roles by scanning the given rows.
+func getRolesFromRows(rows pgx.Rows) ([]domain.Role, error) {
+	var roles []domain.Role
+	for rows.Next() {
+		role, err := getRoleFromRow(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		roles = append(roles, role)
+	}
+
+	return roles, nil
+}
+
+// rolePermissionsFromDomain returns store role permissions based on the domain model.
+func rolePermissionsFromDomain(permissions domain.RolePermissions) []string {
+	storePermissions := make([]string, len(permissions))
+	for i, permission := range permissions {
+		storePermissions[i] = string(permission)
+	}
+
+	return storePermissions
+}
+
+// rolePermissionsToDomain returns domain role permissions based on the store model.
+func rolePermissionsToDomain(permissions []string) domain.RolePermissions {
+	domainPermissions := make(domain.RolePermissions, len(permissions))
+	for i, permission := range permissions {
+		domainPermissions[i] = domain.Permission(permission)
+	}
+
+	return domainPermissions
+}
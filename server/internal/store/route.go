@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -20,14 +21,15 @@ const (
 // CreateRoute executes a query to create a route with the specified data.
 func (s *store) CreateRoute(ctx context.Context, tx pgx.Tx, editableRoute domain.EditableRoute) (uuid.UUID, error) {
 	row := tx.QueryRow(ctx, `
-		INSERT INTO routes (name, truck_id, departure_warehouse_id, arrival_warehouse_id)
-		VALUES ($1, $2, $3, $4) 
+		INSERT INTO routes (name, truck_id, departure_warehouse_id, arrival_warehouse_id, departure_time)
+		VALUES ($1, $2, $3, $4, $5) 
 		RETURNING id
 	`,
 		editableRoute.Name,
 		editableRoute.TruckID,
 		editableRoute.DepartureWarehouseID,
 		editableRoute.ArrivalWarehouseID,
+		editableRoute.DepartureTime,
 	)
 
 	var id uuid.UUID
@@ -112,8 +114,8 @@ func (s *store) ListRoutes(ctx context.Context, tx pgx.Tx, filter domain.RoutesP
 
 	// Get rows for the given filter.
 	rows, err := tx.Query(ctx, `
-		SELECT r.id, r.name, r.created_at, r.modified_at,
-			t.id, t.make, t.model, t.license_plate, t.person_capacity, ST_AsGeoJSON(t.geom)::jsonb, rnt.osm_name, mt.name, t.created_at, t.modified_at,
+		SELECT r.id, r.name, r.departure_time, r.started_at, r.finished_at, r.created_at, r.modified_at,
+			t.id, t.make, t.model, t.license_plate, t.person_capacity, t.volume_capacity, t.weight_capacity, (SELECT coalesce(jsonb_agg(tc.categories ORDER BY tc.position), '[]') FROM trucks_compartments AS tc WHERE tc.truck_id = t.id), t.status, t.service_interval_distance, t.service_interval_period, (SELECT tor.value FROM trucks_odometer_readings AS tor WHERE tor.truck_id = t.id ORDER BY tor.recorded_at DESC LIMIT 1), t.fuel_type, t.fuel_consumption, t.license_category, ST_AsGeoJSON(t.geom)::jsonb, rnt.osm_name, mt.name, t.created_at, t.modified_at,
 			wd.id, wd.truck_capacity, ST_AsGeoJSON(wd.geom)::jsonb, rnwd.osm_name, mwd.name, wd.created_at, wd.modified_at, 
 			wa.id, wa.truck_capacity, ST_AsGeoJSON(wa.geom)::jsonb, rnwa.osm_name, mwa.name, wa.created_at, wa.modified_at 
 		FROM routes AS r
@@ -148,8 +150,8 @@ func (s *store) ListRoutes(ctx context.Context, tx pgx.Tx, filter domain.RoutesP
 // GetRouteByID executes a query to return the route with the specified identifier.
 func (s *store) GetRouteByID(ctx context.Context, tx pgx.Tx, id uuid.UUID) (domain.Route, error) {
 	row := tx.QueryRow(ctx, `
-		SELECT r.id, r.name, r.created_at, r.modified_at,
-			t.id, t.make, t.model, t.license_plate, t.person_capacity, ST_AsGeoJSON(t.geom)::jsonb, rnt.osm_name, mt.name, t.created_at, t.modified_at,
+		SELECT r.id, r.name, r.departure_time, r.started_at, r.finished_at, r.created_at, r.modified_at,
+			t.id, t.make, t.model, t.license_plate, t.person_capacity, t.volume_capacity, t.weight_capacity, (SELECT coalesce(jsonb_agg(tc.categories ORDER BY tc.position), '[]') FROM trucks_compartments AS tc WHERE tc.truck_id = t.id), t.status, t.service_interval_distance, t.service_interval_period, (SELECT tor.value FROM trucks_odometer_readings AS tor WHERE tor.truck_id = t.id ORDER BY tor.recorded_at DESC LIMIT 1), t.fuel_type, t.fuel_consumption, t.license_category, ST_AsGeoJSON(t.geom)::jsonb, rnt.osm_name, mt.name, t.created_at, t.modified_at,
 			wd.id, wd.truck_capacity, ST_AsGeoJSON(wd.geom)::jsonb, rnwd.osm_name, mwd.name, wd.created_at, wd.modified_at, 
 			wa.id, wa.truck_capacity, ST_AsGeoJSON(wa.geom)::jsonb, rnwa.osm_name, mwa.name, wa.created_at, wa.modified_at 
 		FROM routes AS r
@@ -179,6 +181,77 @@ func (s *store) GetRouteByID(ctx context.Context, tx pgx.Tx, id uuid.UUID) (doma
 	return route, nil
 }
 
+// ListRoutesInProgressByTruckID executes a query to return the routes in progress that use the truck with the
+// specified identifier.
+func (s *store) ListRoutesInProgressByTruckID(ctx context.Context, tx pgx.Tx, truckID uuid.UUID) ([]domain.Route, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT r.id, r.name, r.departure_time, r.started_at, r.finished_at, r.created_at, r.modified_at,
+			t.id, t.make, t.model, t.license_plate, t.person_capacity, t.volume_capacity, t.weight_capacity, (SELECT coalesce(jsonb_agg(tc.categories ORDER BY tc.position), '[]') FROM trucks_compartments AS tc WHERE tc.truck_id = t.id), t.status, t.service_interval_distance, t.service_interval_period, (SELECT tor.value FROM trucks_odometer_readings AS tor WHERE tor.truck_id = t.id ORDER BY tor.recorded_at DESC LIMIT 1), t.fuel_type, t.fuel_consumption, t.license_category, ST_AsGeoJSON(t.geom)::jsonb, rnt.osm_name, mt.name, t.created_at, t.modified_at,
+			wd.id, wd.truck_capacity, ST_AsGeoJSON(wd.geom)::jsonb, rnwd.osm_name, mwd.name, wd.created_at, wd.modified_at, 
+			wa.id, wa.truck_capacity, ST_AsGeoJSON(wa.geom)::jsonb, rnwa.osm_name, mwa.name, wa.created_at, wa.modified_at 
+		FROM routes AS r
+		INNER JOIN trucks AS t ON r.truck_id = t.id
+			LEFT JOIN road_network AS rnt ON t.road_id = rnt.id
+			LEFT JOIN municipalities AS mt ON t.municipality_id = mt.id
+		INNER JOIN warehouses AS wd ON r.departure_warehouse_id = wd.id
+			LEFT JOIN road_network AS rnwd ON wd.road_id = rnwd.id
+			LEFT JOIN municipalities AS mwd ON wd.municipality_id = mwd.id
+		INNER JOIN warehouses AS wa ON r.arrival_warehouse_id = wa.id
+			LEFT JOIN road_network AS rnwa ON wa.road_id = rnwa.id
+			LEFT JOIN municipalities AS mwa ON wa.municipality_id = mwa.id
+		WHERE r.truck_id = $1 AND r.started_at IS NOT NULL AND r.finished_at IS NULL
+	`,
+		truckID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", descriptionFailedQuery, err)
+	}
+	defer rows.Close()
+
+	routes, err := getRoutesFromRows(rows)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", descriptionFailedScanRows, err)
+	}
+
+	return routes, nil
+}
+
+// ListRoutesByContainerID executes a query to return the routes associated with the container with the specified
+// identifier.
+func (s *store) ListRoutesByContainerID(ctx context.Context, tx pgx.Tx, containerID uuid.UUID) ([]domain.Route, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT r.id, r.name, r.departure_time, r.started_at, r.finished_at, r.created_at, r.modified_at,
+			t.id, t.make, t.model, t.license_plate, t.person_capacity, t.volume_capacity, t.weight_capacity, (SELECT coalesce(jsonb_agg(tc.categories ORDER BY tc.position), '[]') FROM trucks_compartments AS tc WHERE tc.truck_id = t.id), t.status, t.service_interval_distance, t.service_interval_period, (SELECT tor.value FROM trucks_odometer_readings AS tor WHERE tor.truck_id = t.id ORDER BY tor.recorded_at DESC LIMIT 1), t.fuel_type, t.fuel_consumption, t.license_category, ST_AsGeoJSON(t.geom)::jsonb, rnt.osm_name, mt.name, t.created_at, t.modified_at,
+			wd.id, wd.truck_capacity, ST_AsGeoJSON(wd.geom)::jsonb, rnwd.osm_name, mwd.name, wd.created_at, wd.modified_at, 
+			wa.id, wa.truck_capacity, ST_AsGeoJSON(wa.geom)::jsonb, rnwa.osm_name, mwa.name, wa.created_at, wa.modified_at 
+		FROM routes AS r
+		INNER JOIN routes_containers AS rc ON r.id = rc.route_id
+		INNER JOIN trucks AS t ON r.truck_id = t.id
+			LEFT JOIN road_network AS rnt ON t.road_id = rnt.id
+			LEFT JOIN municipalities AS mt ON t.municipality_id = mt.id
+		INNER JOIN warehouses AS wd ON r.departure_warehouse_id = wd.id
+			LEFT JOIN road_network AS rnwd ON wd.road_id = rnwd.id
+			LEFT JOIN municipalities AS mwd ON wd.municipality_id = mwd.id
+		INNER JOIN warehouses AS wa ON r.arrival_warehouse_id = wa.id
+			LEFT JOIN road_network AS rnwa ON wa.road_id = rnwa.id
+			LEFT JOIN municipalities AS mwa ON wa.municipality_id = mwa.id
+		WHERE rc.container_id = $1
+	`,
+		containerID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", descriptionFailedQuery, err)
+	}
+	defer rows.Close()
+
+	routes, err := getRoutesFromRows(rows)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", descriptionFailedScanRows, err)
+	}
+
+	return routes, nil
+}
+
 // PatchRoute executes a query to patch an route with the specified identifier and data.
 func (s *store) PatchRoute(ctx context.Context, tx pgx.Tx, id uuid.UUID, editableRoute domain.EditableRoutePatch) error {
 	commandTag, err := tx.Exec(ctx, `
@@ -186,7 +259,8 @@ func (s *store) PatchRoute(ctx context.Context, tx pgx.Tx, id uuid.UUID, editabl
 			name = coalesce($2, name),
 			truck_id = coalesce($3, truck_id),
 			departure_warehouse_id = coalesce($4, departure_warehouse_id),
-			arrival_warehouse_id = coalesce($5, arrival_warehouse_id)
+			arrival_warehouse_id = coalesce($5, arrival_warehouse_id),
+			departure_time = coalesce($6, departure_time)
 		WHERE id = $1
 	`,
 		id,
@@ -194,6 +268,7 @@ func (s *store) PatchRoute(ctx context.Context, tx pgx.Tx, id uuid.UUID, editabl
 		editableRoute.TruckID,
 		editableRoute.DepartureWarehouseID,
 		editableRoute.ArrivalWarehouseID,
+		editableRoute.DepartureTime,
 	)
 	if err != nil {
 		switch constraintNameFromError(err) {
@@ -215,6 +290,49 @@ func (s *store) PatchRoute(ctx context.Context, tx pgx.Tx, id uuid.UUID, editabl
 	return nil
 }
 
+// StartRoute executes a query to start the route with the specified identifier at the given time.
+func (s *store) StartRoute(ctx context.Context, tx pgx.Tx, id uuid.UUID, startedAt time.Time) error {
+	commandTag, err := tx.Exec(ctx, `
+		UPDATE routes SET
+			started_at = $2,
+			finished_at = NULL
+		WHERE id = $1
+	`,
+		id,
+		startedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", descriptionFailedExec, err)
+	}
+
+	if commandTag.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", descriptionFailedExec, domain.ErrRouteNotFound)
+	}
+
+	return nil
+}
+
+// FinishRoute executes a query to finish the route with the specified identifier at the given time.
+func (s *store) FinishRoute(ctx context.Context, tx pgx.Tx, id uuid.UUID, finishedAt time.Time) error {
+	commandTag, err := tx.Exec(ctx, `
+		UPDATE routes SET
+			finished_at = $2
+		WHERE id = $1
+	`,
+		id,
+		finishedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", descriptionFailedExec, err)
+	}
+
+	if commandTag.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", descriptionFailedExec, domain.ErrRouteNotFound)
+	}
+
+	return nil
+}
+
 // DeleteRouteByID executes a query to delete the route with the specified identifier.
 func (s *store) DeleteRouteByID(ctx context.Context, tx pgx.Tx, id uuid.UUID) error {
 	commandTag, err := tx.Exec(ctx, `
@@ -238,6 +356,10 @@ func (s *store) DeleteRouteByID(ctx context.Context, tx pgx.Tx, id uuid.UUID) er
 func getRouteFromRow(row pgx.Row) (domain.Route, error) {
 	var route domain.Route
 
+	var truckCompartments [][]string
+	var truckStatus string
+	var truckFuelType string
+	var truckLicenseCategory string
 	var truckGeoJSONPoint domain.GeoJSONGeometryPoint
 	var truckWayName *string
 	var truckMunicipalityName *string
@@ -253,6 +375,9 @@ func getRouteFromRow(row pgx.Row) (domain.Route, error) {
 	err := row.Scan(
 		&route.ID,
 		&route.Name,
+		&route.DepartureTime,
+		&route.StartedAt,
+		&route.FinishedAt,
 		&route.CreatedAt,
 		&route.ModifiedAt,
 
@@ -261,6 +386,16 @@ func getRouteFromRow(row pgx.Row) (domain.Route, error) {
 		&route.Truck.Model,
 		&route.Truck.LicensePlate,
 		&route.Truck.PersonCapacity,
+		&route.Truck.VolumeCapacity,
+		&route.Truck.WeightCapacity,
+		&truckCompartments,
+		&truckStatus,
+		&route.Truck.ServiceIntervalDistance,
+		&route.Truck.ServiceIntervalPeriod,
+		&route.Truck.Odometer,
+		&truckFuelType,
+		&route.Truck.FuelConsumption,
+		&truckLicenseCategory,
 		&truckGeoJSONPoint,
 		&truckWayName,
 		&truckMunicipalityName,
@@ -287,6 +422,11 @@ func getRouteFromRow(row pgx.Row) (domain.Route, error) {
 		return domain.Route{}, err
 	}
 
+	route.Truck.Compartments = truckCompartmentsToDomain(truckCompartments)
+	route.Truck.Status = truckStatusToDomain(truckStatus)
+	route.Truck.FuelType = truckFuelTypeToDomain(truckFuelType)
+	route.Truck.LicenseCategory = licenseCategoryToDomain(truckLicenseCategory)
+
 	truckGeoJSONProperties := make(domain.GeoJSONFeatureProperties)
 	if truckWayName != nil {
 		truckGeoJSONProperties.SetWayName(*truckWayName)
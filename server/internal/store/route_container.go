@@ -98,7 +98,7 @@ func (s *store) ListRouteContainers(ctx context.Context, tx pgx.Tx, routeID uuid
 
 	// Get rows for the given filter.
 	rows, err := tx.Query(ctx, `
-		SELECT c.id, c.category, ST_AsGeoJSON(c.geom)::jsonb, rn.osm_name, m.name, c.created_at, c.modified_at
+		SELECT c.id, c.category, c.volume, c.collection_start_time, c.collection_end_time, ST_AsGeoJSON(c.geom)::jsonb, rn.osm_name, m.name, c.created_at, c.modified_at
 		FROM routes_containers AS rc
 		INNER JOIN containers AS c ON rc.container_id = c.id
 		LEFT JOIN road_network AS rn ON c.road_id = rn.id
@@ -122,6 +122,38 @@ func (s *store) ListRouteContainers(ctx context.Context, tx pgx.Tx, routeID uuid
 	}, nil
 }
 
+// ListRoutesContainerCategoriesByTruckID executes a query to return the distinct categories of the containers
+// associated with the routes of the truck with the specified identifier.
+func (s *store) ListRoutesContainerCategoriesByTruckID(ctx context.Context, tx pgx.Tx, truckID uuid.UUID) ([]domain.ContainerCategory, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT DISTINCT c.category::text
+		FROM routes_containers AS rc
+		INNER JOIN routes AS r ON rc.route_id = r.id
+		INNER JOIN containers AS c ON rc.container_id = c.id
+		WHERE r.truck_id = $1
+	`,
+		truckID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", descriptionFailedQuery, err)
+	}
+
+	defer rows.Close()
+
+	var categories []domain.ContainerCategory
+	for rows.Next() {
+		var category string
+		err := rows.Scan(&category)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", descriptionFailedScanRows, err)
+		}
+
+		categories = append(categories, containerCategoryToDomain(category))
+	}
+
+	return categories, nil
+}
+
 // DeleteRouteContainer executes a query to delete the route container association with the specified identifiers.
 func (s *store) DeleteRouteContainer(ctx context.Context, tx pgx.Tx, routeID, containerID uuid.UUID) error {
 	commandTag, err := tx.Exec(ctx, `
@@ -0,0 +1,202 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/goncalo-marques/ecomap/server/internal/domain"
+)
+
+const (
+	constraintRoutesContainersCollectionsRouteIDContainerIDFkey = "routes_containers_collections_route_id_container_id_fkey"
+)
+
+// PutRouteContainerCollection executes a query to create or replace the collection of the route container in the
+// route run with the specified start time.
+func (s *store) PutRouteContainerCollection(ctx context.Context, tx pgx.Tx, collection domain.RouteContainerCollection) error {
+	_, err := tx.Exec(ctx, `
+		INSERT INTO routes_containers_collections (route_id, container_id, route_started_at, collected, confidence, source, collected_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (route_id, container_id, route_started_at) DO UPDATE SET
+			collected = excluded.collected,
+			confidence = excluded.confidence,
+			source = excluded.source,
+			collected_at = excluded.collected_at
+	`,
+		collection.RouteID,
+		collection.ContainerID,
+		collection.RouteStartedAt,
+		collection.Collected,
+		collection.Confidence,
+		routeContainerCollectionSourceFromDomain(collection.Source),
+		collection.CollectedAt,
+	)
+	if err != nil {
+		switch constraintNameFromError(err) {
+		case constraintRoutesContainersCollectionsRouteIDContainerIDFkey:
+			return fmt.Errorf("%s: %w", descriptionFailedExec, domain.ErrRouteContainerNotFound)
+		}
+
+		return fmt.Errorf("%s: %w", descriptionFailedExec, err)
+	}
+
+	return nil
+}
+
+// GetRouteContainerCollection executes a query to return the collection of the route container in the route run with
+// the specified start time.
+func (s *store) GetRouteContainerCollection(ctx context.Context, tx pgx.Tx, routeID, containerID uuid.UUID, routeStartedAt time.Time) (domain.RouteContainerCollection, error) {
+	row := tx.QueryRow(ctx, `
+		SELECT rcc.route_id, rcc.container_id, rcc.route_started_at, rcc.collected, rcc.confidence, rcc.source, rcc.collected_at, rcc.created_at, rcc.modified_at
+		FROM routes_containers_collections AS rcc
+		WHERE rcc.route_id = $1 AND rcc.container_id = $2 AND rcc.route_started_at = $3
+	`,
+		routeID,
+		containerID,
+		routeStartedAt,
+	)
+
+	collection, err := getRouteContainerCollectionFromRow(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.RouteContainerCollection{}, fmt.Errorf("%s: %w", descriptionFailedScanRow, domain.ErrRouteContainerCollectionNotFound)
+		}
+
+		return domain.RouteContainerCollection{}, fmt.Errorf("%s: %w", descriptionFailedScanRow, err)
+	}
+
+	return collection, nil
+}
+
+// ListRouteContainerCollections executes a query to return the collections of the route containers in the route run
+// with the specified start time, sorted by the time they were collected.
+func (s *store) ListRouteContainerCollections(ctx context.Context, tx pgx.Tx, routeID uuid.UUID, routeStartedAt time.Time) ([]domain.RouteContainerCollection, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT rcc.route_id, rcc.container_id, rcc.route_started_at, rcc.collected, rcc.confidence, rcc.source, rcc.collected_at, rcc.created_at, rcc.modified_at
+		FROM routes_containers_collections AS rcc
+		WHERE rcc.route_id = $1 AND rcc.route_started_at = $2
+		ORDER BY rcc.collected_at
+	`,
+		routeID,
+		routeStartedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", descriptionFailedQuery, err)
+	}
+	defer rows.Close()
+
+	var collections []domain.RouteContainerCollection
+	for rows.Next() {
+		collection, err := getRouteContainerCollectionFromRow(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", descriptionFailedScanRows, err)
+		}
+
+		collections = append(collections, collection)
+	}
+
+	return collections, nil
+}
+
+// ListRouteContainersDwells executes a query to return the dwells of the truck with the specified identifier within
+// the given radius, in meters, of the route containers that have not been collected yet in the route run with the
+// specified start time. Only the containers the truck has been close to since the given time are returned.
+func (s *store) ListRouteContainersDwells(ctx context.Context, tx pgx.Tx, routeID, truckID uuid.UUID, routeStartedAt, since time.Time, radius float64) ([]domain.RouteContainerDwell, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT c.id, min(tp.recorded_at), max(tp.recorded_at), min(ST_Distance(c.geom::geography, tp.geom::geography))
+		FROM routes_containers AS rc
+		INNER JOIN containers AS c ON rc.container_id = c.id
+		INNER JOIN trucks_positions AS tp ON tp.truck_id = $2 AND tp.recorded_at >= $3 AND ST_DWithin(c.geom::geography, tp.geom::geography, $5)
+		WHERE rc.route_id = $1 AND NOT EXISTS (
+			SELECT 1
+			FROM routes_containers_collections AS rcc
+			WHERE rcc.route_id = rc.route_id AND rcc.container_id = rc.container_id AND rcc.route_started_at = $3
+		)
+		GROUP BY c.id
+		HAVING max(tp.recorded_at) >= $4
+	`,
+		routeID,
+		truckID,
+		routeStartedAt,
+		since,
+		radius,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", descriptionFailedQuery, err)
+	}
+	defer rows.Close()
+
+	var dwells []domain.RouteContainerDwell
+	for rows.Next() {
+		var dwell domain.RouteContainerDwell
+
+		err := rows.Scan(
+			&dwell.ContainerID,
+			&dwell.ArrivedAt,
+			&dwell.DepartedAt,
+			&dwell.MinDistance,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", descriptionFailedScanRows, err)
+		}
+
+		dwells = append(dwells, dwell)
+	}
+
+	return dwells, nil
+}
+
+// routeContainerCollectionSourceFromDomain returns a store route container collection source based on the domain
+// model.
+func routeContainerCollectionSourceFromDomain(source domain.RouteContainerCollectionSource) string {
+	switch source {
+	case domain.RouteContainerCollectionSourceAutomatic:
+		return "automatic"
+	case domain.RouteContainerCollectionSourceManual:
+		return "manual"
+	default:
+		return string(source)
+	}
+}
+
+// routeContainerCollectionSourceToDomain returns a domain route container collection source based on the store model.
+func routeContainerCollectionSourceToDomain(source string) domain.RouteContainerCollectionSource {
+	switch source {
+	case "automatic":
+		return domain.RouteContainerCollectionSourceAutomatic
+	case "manual":
+		return domain.RouteContainerCollectionSourceManual
+	default:
+		return domain.RouteContainerCollectionSource(source)
+	}
+}
+
+// getRouteContainerCollectionFromRow returns the route container collection by scanning the given row.
+func getRouteContainerCollectionFromRow(row pgx.Row) (domain.RouteContainerCollection, error) {
+	var collection domain.RouteContainerCollection
+	var source string
+
+	err := row.Scan(
+		&collection.RouteID,
+		&collection.ContainerID,
+		&collection.RouteStartedAt,
+		&collection.Collected,
+		&collection.Confidence,
+		&source,
+		&collection.CollectedAt,
+		&collection.CreatedAt,
+		&collection.ModifiedAt,
+	)
+	if err != nil {
+		return domain.RouteContainerCollection{}, err
+	}
+
+	collection.Source = routeContainerCollectionSourceToDomain(source)
+
+	return collection, nil
+}
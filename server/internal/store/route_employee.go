@@ -91,7 +91,7 @@ func (s *store) ListRouteEmployees(ctx context.Context, tx pgx.Tx, routeID uuid.
 	// Get rows for the given filter.
 	rows, err := tx.Query(ctx, `
 		SELECT re.employee_role,
-			e.id, e.username, e.first_name, e.last_name, e.role, e.date_of_birth, e.phone_number, ST_AsGeoJSON(e.geom)::jsonb, rn.osm_name, m.name, e.schedule_start, e.schedule_end, e.created_at, e.modified_at 
+			e.id, e.username, e.first_name, e.last_name, e.role, e.date_of_birth, e.phone_number, ST_AsGeoJSON(e.geom)::jsonb, rn.osm_name, m.name, e.schedule_start, e.schedule_end, (SELECT coalesce(jsonb_agg(jsonb_build_object('weekday', es.weekday, 'startTime', es.start_time, 'endTime', es.end_time) ORDER BY es.weekday), '[]') FROM employees_shifts AS es WHERE es.employee_id = e.id), e.created_at, e.modified_at 
 		FROM routes_employees AS re
 		INNER JOIN employees AS e ON re.employee_id = e.id
 		LEFT JOIN road_network AS rn ON e.road_id = rn.id
@@ -115,6 +115,37 @@ func (s *store) ListRouteEmployees(ctx context.Context, tx pgx.Tx, routeID uuid.
 	}, nil
 }
 
+// ListEmployeeRoutesRoles executes a query to return the roles of the employee with the specified identifier in the
+// routes the employee is associated with, by route identifier.
+func (s *store) ListEmployeeRoutesRoles(ctx context.Context, tx pgx.Tx, employeeID uuid.UUID) (map[uuid.UUID]domain.RouteEmployeeRole, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT re.route_id, re.employee_role
+		FROM routes_employees AS re
+		WHERE re.employee_id = $1
+	`,
+		employeeID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", descriptionFailedQuery, err)
+	}
+	defer rows.Close()
+
+	routesRoles := make(map[uuid.UUID]domain.RouteEmployeeRole)
+	for rows.Next() {
+		var routeID uuid.UUID
+		var role string
+
+		err := rows.Scan(&routeID, &role)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", descriptionFailedScanRows, err)
+		}
+
+		routesRoles[routeID] = routeEmployeeRoleToDomain(role)
+	}
+
+	return routesRoles, nil
+}
+
 // DeleteRouteEmployee executes a query to delete the route employee association with the specified identifiers.
 func (s *store) DeleteRouteEmployee(ctx context.Context, tx pgx.Tx, routeID, employeeID uuid.UUID) error {
 	commandTag, err := tx.Exec(ctx, `
@@ -163,10 +194,10 @@ func routeEmployeeRoleToDomain(role string) domain.RouteEmployeeRole {
 func getRouteEmployeeFromRow(row pgx.Row) (domain.RouteEmployee, error) {
 	var routeEmployee domain.RouteEmployee
 	var routeRole string
-	var role string
 	var geoJSONPoint domain.GeoJSONGeometryPoint
 	var wayName *string
 	var municipalityName *string
+	var shifts []employeeShift
 
 	err := row.Scan(
 		&routeRole,
@@ -174,7 +205,7 @@ func getRouteEmployeeFromRow(row pgx.Row) (domain.RouteEmployee, error) {
 		&routeEmployee.Username,
 		&routeEmployee.FirstName,
 		&routeEmployee.LastName,
-		&role,
+		&routeEmployee.Role,
 		&routeEmployee.DateOfBirth,
 		&routeEmployee.PhoneNumber,
 		&geoJSONPoint,
@@ -182,6 +213,7 @@ func getRouteEmployeeFromRow(row pgx.Row) (domain.RouteEmployee, error) {
 		&municipalityName,
 		&routeEmployee.ScheduleStart,
 		&routeEmployee.ScheduleEnd,
+		&shifts,
 		&routeEmployee.CreatedAt,
 		&routeEmployee.ModifiedAt,
 	)
@@ -189,9 +221,13 @@ func getRouteEmployeeFromRow(row pgx.Row) (domain.RouteEmployee, error) {
 		return domain.RouteEmployee{}, err
 	}
 
-	routeEmployee.Role = employeeRoleToDomain(role)
 	routeEmployee.RouteRole = routeEmployeeRoleToDomain(routeRole)
 
+	routeEmployee.Shifts, err = employeeShiftsToDomain(shifts)
+	if err != nil {
+		return domain.RouteEmployee{}, err
+	}
+
 	geoJSONProperties := make(domain.GeoJSONFeatureProperties)
 	if wayName != nil {
 		geoJSONProperties.SetWayName(*wayName)
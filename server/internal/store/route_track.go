@@ -0,0 +1,227 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/goncalo-marques/ecomap/server/internal/domain"
+)
+
+const (
+	constraintRoutesTracksRouteIDFkey = "routes_tracks_route_id_fkey"
+)
+
+// PutRouteTrack executes a query to create or replace the track of the route on the date of the given track.
+func (s *store) PutRouteTrack(ctx context.Context, tx pgx.Tx, track domain.RouteTrack) error {
+	geoJSON, err := json.Marshal(track.Geometry)
+	if err != nil {
+		return fmt.Errorf("%s: %w", descriptionFailedMarshalGeoJSON, err)
+	}
+
+	offRouteGeoJSON, err := json.Marshal(track.Deviation.OffRouteSegments)
+	if err != nil {
+		return fmt.Errorf("%s: %w", descriptionFailedMarshalGeoJSON, err)
+	}
+
+	missedContainerIDs := track.Deviation.MissedContainerIDs
+	if missedContainerIDs == nil {
+		missedContainerIDs = []uuid.UUID{}
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO routes_tracks (route_id, date, geom, timestamps, planned_distance, actual_distance, off_route_distance, off_route_geom, planned_duration, actual_duration, missed_container_ids)
+		VALUES ($1, $2, ST_GeomFromGeoJSON($3), $4, $5, $6, $7, (
+			SELECT ST_Multi(ST_Collect(ST_GeomFromGeoJSON(s.value::text)))
+			FROM jsonb_array_elements($8::jsonb) AS s
+		), $9, $10, $11)
+		ON CONFLICT (route_id, date) DO UPDATE SET
+			geom = excluded.geom,
+			timestamps = excluded.timestamps,
+			planned_distance = excluded.planned_distance,
+			actual_distance = excluded.actual_distance,
+			off_route_distance = excluded.off_route_distance,
+			off_route_geom = excluded.off_route_geom,
+			planned_duration = excluded.planned_duration,
+			actual_duration = excluded.actual_duration,
+			missed_container_ids = excluded.missed_container_ids
+	`,
+		track.RouteID,
+		track.Date,
+		geoJSON,
+		track.Timestamps,
+		track.Deviation.PlannedDistance,
+		track.Deviation.ActualDistance,
+		track.Deviation.OffRouteDistance,
+		offRouteGeoJSON,
+		int(track.Deviation.PlannedDuration.Seconds()),
+		int(track.Deviation.ActualDuration.Seconds()),
+		missedContainerIDs,
+	)
+	if err != nil {
+		switch constraintNameFromError(err) {
+		case constraintRoutesTracksRouteIDFkey:
+			return fmt.Errorf("%s: %w", descriptionFailedExec, domain.ErrRouteNotFound)
+		}
+
+		return fmt.Errorf("%s: %w", descriptionFailedExec, err)
+	}
+
+	return nil
+}
+
+// GetRouteTrack executes a query to return the track of the route with the specified identifier on the given date.
+func (s *store) GetRouteTrack(ctx context.Context, tx pgx.Tx, routeID uuid.UUID, date time.Time) (domain.RouteTrack, error) {
+	row := tx.QueryRow(ctx, `
+		SELECT rt.route_id, rt.date, ST_AsGeoJSON(rt.geom)::jsonb, rt.timestamps, rt.planned_distance, rt.actual_distance, rt.off_route_distance, (
+			SELECT coalesce(jsonb_agg(ST_AsGeoJSON(d.geom)::jsonb ORDER BY d.path), '[]')
+			FROM ST_Dump(rt.off_route_geom) AS d
+		), rt.planned_duration, rt.actual_duration, rt.missed_container_ids, rt.created_at, rt.modified_at
+		FROM routes_tracks AS rt
+		WHERE rt.route_id = $1 AND rt.date = $2
+	`,
+		routeID,
+		date,
+	)
+
+	track, err := getRouteTrackFromRow(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.RouteTrack{}, fmt.Errorf("%s: %w", descriptionFailedScanRow, domain.ErrRouteTrackNotFound)
+		}
+
+		return domain.RouteTrack{}, fmt.Errorf("%s: %w", descriptionFailedScanRow, err)
+	}
+
+	return track, nil
+}
+
+// ListRouteTracks executes a query to return the route tracks for the specified filter. The geometries of the tracks
+// are not returned.
+func (s *store) ListRouteTracks(ctx context.Context, tx pgx.Tx, filter domain.RouteTracksPaginatedFilter) (domain.PaginatedResponse[domain.RouteTrack], error) {
+	var filterFields []string
+	var argsWhere []any
+
+	// Append the optional fields to filter.
+	if filter.RouteID != nil {
+		filterFields = append(filterFields, "rt.route_id::text")
+		argsWhere = append(argsWhere, *filter.RouteID)
+	}
+	if filter.Date != nil {
+		filterFields = append(filterFields, "rt.date::text")
+		argsWhere = append(argsWhere, filter.Date.Format(time.DateOnly))
+	}
+
+	sqlWhere := listSQLWhere(filterFields, nil)
+
+	// Get the total number of rows for the given filter.
+	var total int
+	row := tx.QueryRow(ctx, `
+		SELECT count(rt.route_id)
+		FROM routes_tracks AS rt
+	`+sqlWhere,
+		argsWhere...,
+	)
+
+	err := row.Scan(&total)
+	if err != nil {
+		return domain.PaginatedResponse[domain.RouteTrack]{}, fmt.Errorf("%s: %w", descriptionFailedScanRow, err)
+	}
+
+	// Append the field to sort, if provided.
+	var domainSortField domain.RouteTrackPaginatedSort
+	if filter.Sort != nil {
+		domainSortField = filter.Sort.Field()
+	}
+
+	sortField := "rt.deviation"
+	switch domainSortField {
+	case domain.RouteTrackPaginatedSortDeviation:
+		sortField = "rt.deviation"
+	case domain.RouteTrackPaginatedSortExtraDistance:
+		sortField = "(rt.actual_distance - rt.planned_distance)"
+	case domain.RouteTrackPaginatedSortExtraDuration:
+		sortField = "(rt.actual_duration - rt.planned_duration)"
+	case domain.RouteTrackPaginatedSortDate:
+		sortField = "rt.date"
+	}
+
+	// Get rows for the given filter.
+	rows, err := tx.Query(ctx, `
+		SELECT rt.route_id, rt.date, rt.planned_distance, rt.actual_distance, rt.off_route_distance, rt.planned_duration, rt.actual_duration, rt.missed_container_ids, rt.created_at, rt.modified_at
+		FROM routes_tracks AS rt
+	`+sqlWhere+listSQLOrder(sortField, filter.Order)+listSQLLimitOffset(filter.Limit, filter.Offset),
+		argsWhere...,
+	)
+	if err != nil {
+		return domain.PaginatedResponse[domain.RouteTrack]{}, fmt.Errorf("%s: %w", descriptionFailedQuery, err)
+	}
+	defer rows.Close()
+
+	var tracks []domain.RouteTrack
+	for rows.Next() {
+		var track domain.RouteTrack
+		var plannedDuration, actualDuration int
+
+		err := rows.Scan(
+			&track.RouteID,
+			&track.Date,
+			&track.Deviation.PlannedDistance,
+			&track.Deviation.ActualDistance,
+			&track.Deviation.OffRouteDistance,
+			&plannedDuration,
+			&actualDuration,
+			&track.Deviation.MissedContainerIDs,
+			&track.CreatedAt,
+			&track.ModifiedAt,
+		)
+		if err != nil {
+			return domain.PaginatedResponse[domain.RouteTrack]{}, fmt.Errorf("%s: %w", descriptionFailedScanRows, err)
+		}
+
+		track.Deviation.PlannedDuration = time.Duration(plannedDuration) * time.Second
+		track.Deviation.ActualDuration = time.Duration(actualDuration) * time.Second
+
+		tracks = append(tracks, track)
+	}
+
+	return domain.PaginatedResponse[domain.RouteTrack]{
+		Total:   total,
+		Results: tracks,
+	}, nil
+}
+
+// getRouteTrackFromRow returns the route track by scanning the given row.
+func getRouteTrackFromRow(row pgx.Row) (domain.RouteTrack, error) {
+	var track domain.RouteTrack
+	var plannedDuration, actualDuration int
+
+	err := row.Scan(
+		&track.RouteID,
+		&track.Date,
+		&track.Geometry,
+		&track.Timestamps,
+		&track.Deviation.PlannedDistance,
+		&track.Deviation.ActualDistance,
+		&track.Deviation.OffRouteDistance,
+		&track.Deviation.OffRouteSegments,
+		&plannedDuration,
+		&actualDuration,
+		&track.Deviation.MissedContainerIDs,
+		&track.CreatedAt,
+		&track.ModifiedAt,
+	)
+	if err != nil {
+		return domain.RouteTrack{}, err
+	}
+
+	track.Deviation.PlannedDuration = time.Duration(plannedDuration) * time.Second
+	track.Deviation.ActualDuration = time.Duration(actualDuration) * time.Second
+
+	return track, nil
+}
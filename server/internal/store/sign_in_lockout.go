@@ -0,0 +1,272 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/goncalo-marques/ecomap/server/internal/domain"
+)
+
+// RecordSignInFailure executes a query to record a failed sign-in attempt of the subject type with the specified key
+// and returns the resulting sign-in lockout. The failures recorded before the window start are discarded.
+func (s *store) RecordSignInFailure(ctx context.Context, tx pgx.Tx, subjectType domain.SubjectType, keyType domain.SignInLockoutKeyType, key string, failedAt, windowStart time.Time) (domain.SignInLockout, error) {
+	row := tx.QueryRow(ctx, `
+		INSERT INTO sign_in_lockouts AS sil (subject_type, key_type, key, failures, last_failure_at)
+		VALUES ($1, $2, $3, 1, $4)
+		ON CONFLICT (subject_type, key_type, key) DO UPDATE SET
+			failures = CASE WHEN sil.last_failure_at < $5 THEN 1 ELSE sil.failures + 1 END,
+			last_failure_at = $4
+		RETURNING sil.id, sil.subject_type, sil.key_type, sil.key, sil.failures, sil.last_failure_at, sil.blocked_until, sil.locked_until, sil.created_at
+	`,
+		subjectTypeFromDomain(subjectType),
+		signInLockoutKeyTypeFromDomain(keyType),
+		key,
+		failedAt,
+		windowStart,
+	)
+
+	lockout, err := getSignInLockoutFromRow(row)
+	if err != nil {
+		return domain.SignInLockout{}, fmt.Errorf("%s: %w", descriptionFailedScanRow, err)
+	}
+
+	return lockout, nil
+}
+
+// UpdateSignInLockoutUntil executes a query to update the times until which the sign-in lockout with the specified
+// identifier rejects attempts.
+func (s *store) UpdateSignInLockoutUntil(ctx context.Context, tx pgx.Tx, id uuid.UUID, blockedUntil, lockedUntil *time.Time) error {
+	commandTag, err := tx.Exec(ctx, `
+		UPDATE sign_in_lockouts SET
+			blocked_until = $2,
+			locked_until = $3
+		WHERE id = $1
+	`,
+		id,
+		blockedUntil,
+		lockedUntil,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", descriptionFailedExec, err)
+	}
+
+	if commandTag.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", descriptionFailedExec, domain.ErrSignInLockoutNotFound)
+	}
+
+	return nil
+}
+
+// GetSignInLockout executes a query to return the sign-in lockout of the subject type with the specified key.
+func (s *store) GetSignInLockout(ctx context.Context, tx pgx.Tx, subjectType domain.SubjectType, keyType domain.SignInLockoutKeyType, key string) (domain.SignInLockout, error) {
+	row := tx.QueryRow(ctx, `
+		SELECT sil.id, sil.subject_type, sil.key_type, sil.key, sil.failures, sil.last_failure_at, sil.blocked_until, sil.locked_until, sil.created_at
+		FROM sign_in_lockouts AS sil
+		WHERE sil.subject_type = $1 AND sil.key_type = $2 AND sil.key = $3
+	`,
+		subjectTypeFromDomain(subjectType),
+		signInLockoutKeyTypeFromDomain(keyType),
+		key,
+	)
+
+	lockout, err := getSignInLockoutFromRow(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.SignInLockout{}, fmt.Errorf("%s: %w", descriptionFailedScanRow, domain.ErrSignInLockoutNotFound)
+		}
+
+		return domain.SignInLockout{}, fmt.Errorf("%s: %w", descriptionFailedScanRow, err)
+	}
+
+	return lockout, nil
+}
+
+// ListSignInLockouts executes a query to return the sign-in lockouts with the specified filter that recorded failures
+// since the window start or still reject attempts at the specified time, ordered by the most recent failure.
+func (s *store) ListSignInLockouts(ctx context.Context, tx pgx.Tx, filter domain.SignInLockoutsFilter, windowStart, at time.Time) ([]domain.SignInLockout, error) {
+	var storeSubjectType *string
+	if filter.SubjectType != nil {
+		t := subjectTypeFromDomain(*filter.SubjectType)
+		storeSubjectType = &t
+	}
+
+	var storeKeyType *string
+	if filter.KeyType != nil {
+		t := signInLockoutKeyTypeFromDomain(*filter.KeyType)
+		storeKeyType = &t
+	}
+
+	rows, err := tx.Query(ctx, `
+		SELECT sil.id, sil.subject_type, sil.key_type, sil.key, sil.failures, sil.last_failure_at, sil.blocked_until, sil.locked_until, sil.created_at
+		FROM sign_in_lockouts AS sil
+		WHERE ($1::sign_in_lockouts_subject_type IS NULL OR sil.subject_type = $1)
+			AND ($2::sign_in_lockouts_key_type IS NULL OR sil.key_type = $2)
+			AND (sil.blocked_until > $5 OR sil.locked_until > $5 OR (NOT $3 AND sil.last_failure_at >= $4))
+		ORDER BY sil.last_failure_at DESC
+	`,
+		storeSubjectType,
+		storeKeyType,
+		filter.ActiveOnly,
+		windowStart,
+		at,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", descriptionFailedQuery, err)
+	}
+	defer rows.Close()
+
+	lockouts, err := getSignInLockoutsFromRows(rows)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", descriptionFailedScanRows, err)
+	}
+
+	return lockouts, nil
+}
+
+// GetSignInLockoutByID executes a query to return the sign-in lockout with the specified identifier.
+func (s *store) GetSignInLockoutByID(ctx context.Context, tx pgx.Tx, id uuid.UUID) (domain.SignInLockout, error) {
+	row := tx.QueryRow(ctx, `
+		SELECT sil.id, sil.subject_type, sil.key_type, sil.key, sil.failures, sil.last_failure_at, sil.blocked_until, sil.locked_until, sil.created_at
+		FROM sign_in_lockouts AS sil
+		WHERE sil.id = $1
+	`,
+		id,
+	)
+
+	lockout, err := getSignInLockoutFromRow(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.SignInLockout{}, fmt.Errorf("%s: %w", descriptionFailedScanRow, domain.ErrSignInLockoutNotFound)
+		}
+
+		return domain.SignInLockout{}, fmt.Errorf("%s: %w", descriptionFailedScanRow, err)
+	}
+
+	return lockout, nil
+}
+
+// DeleteSignInLockoutByID executes a query to delete the sign-in lockout with the specified identifier.
+func (s *store) DeleteSignInLockoutByID(ctx context.Context, tx pgx.Tx, id uuid.UUID) error {
+	commandTag, err := tx.Exec(ctx, `
+		DELETE FROM sign_in_lockouts
+		WHERE id = $1
+	`,
+		id,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", descriptionFailedExec, err)
+	}
+
+	if commandTag.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", descriptionFailedExec, domain.ErrSignInLockoutNotFound)
+	}
+
+	return nil
+}
+
+// DeleteSignInLockout executes a query to delete the sign-in lockout of the subject type with the specified key.
+func (s *store) DeleteSignInLockout(ctx context.Context, tx pgx.Tx, subjectType domain.SubjectType, keyType domain.SignInLockoutKeyType, key string) error {
+	_, err := tx.Exec(ctx, `
+		DELETE FROM sign_in_lockouts
+		WHERE subject_type = $1 AND key_type = $2 AND key = $3
+	`,
+		subjectTypeFromDomain(subjectType),
+		signInLockoutKeyTypeFromDomain(keyType),
+		key,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", descriptionFailedExec, err)
+	}
+
+	return nil
+}
+
+// DeleteExpiredSignInLockouts executes a query to delete the sign-in lockouts without failures since the window start
+// that no longer reject attempts at the specified time.
+func (s *store) DeleteExpiredSignInLockouts(ctx context.Context, tx pgx.Tx, windowStart, at time.Time) error {
+	_, err := tx.Exec(ctx, `
+		DELETE FROM sign_in_lockouts
+		WHERE last_failure_at < $1
+			AND (blocked_until IS NULL OR blocked_until <= $2)
+			AND (locked_until IS NULL OR locked_until <= $2)
+	`,
+		windowStart,
+		at,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", descriptionFailedExec, err)
+	}
+
+	return nil
+}
+
+// getSignInLockoutFromRow returns the sign-in lockout by scanning the given row.
+func getSignInLockoutFromRow(row pgx.Row) (domain.SignInLockout, error) {
+	var lockout domain.SignInLockout
+	var subjectType string
+	var keyType string
+
+	err := row.Scan(
+		&lockout.ID,
+		&subjectType,
+		&keyType,
+		&lockout.Key,
+		&lockout.Failures,
+		&lockout.LastFailureAt,
+		&lockout.BlockedUntil,
+		&lockout.LockedUntil,
+		&lockout.CreatedAt,
+	)
+	if err != nil {
+		return domain.SignInLockout{}, err
+	}
+
+	lockout.SubjectType = subjectTypeToDomain(subjectType)
+	lockout.KeyType = signInLockoutKeyTypeToDomain(keyType)
+
+	return lockout, nil
+}
+
+// getSignInLockoutsFromRows returns the sign-in lockouts by scanning the given rows.
+func getSignInLockoutsFromRows(rows pgx.Rows) ([]domain.SignInLockout, error) {
+	var lockouts []domain.SignInLockout
+	for rows.Next() {
+		lockout, err := getSignInLockoutFromRow(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		lockouts = append(lockouts, lockout)
+	}
+
+	return lockouts, nil
+}
+
+// signInLockoutKeyTypeFromDomain returns a store sign-in lockout key type based on the domain model.
+func signInLockoutKeyTypeFromDomain(keyType domain.SignInLockoutKeyType) string {
+	switch keyType {
+	case domain.SignInLockoutKeyTypeUsername:
+		return "username"
+	case domain.SignInLockoutKeyTypeIPAddress:
+		return "ip_address"
+	default:
+		return string(keyType)
+	}
+}
+
+// signInLockoutKeyTypeToDomain returns a domain sign-in lockout key type based on the store model.
+func signInLockoutKeyTypeToDomain(keyType string) domain.SignInLockoutKeyType {
+	switch keyType {
+	case "username":
+		return domain.SignInLockoutKeyTypeUsername
+	case "ip_address":
+		return domain.SignInLockoutKeyTypeIPAddress
+	default:
+		return domain.SignInLockoutKeyType(keyType)
+	}
+}
@@ -0,0 +1,76 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/goncalo-marques/ecomap/server/internal/domain"
+)
+
+// CreateSSOAuthorization executes a query to create a single sign-on authorization with the specified data.
+func (s *store) CreateSSOAuthorization(ctx context.Context, tx pgx.Tx, authorization domain.SSOAuthorization) error {
+	_, err := tx.Exec(ctx, `
+		INSERT INTO sso_authorizations (state, nonce, code_verifier, expires_at)
+		VALUES ($1, $2, $3, $4)
+	`,
+		authorization.State,
+		authorization.Nonce,
+		authorization.CodeVerifier,
+		authorization.ExpiresAt,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", descriptionFailedExec, err)
+	}
+
+	return nil
+}
+
+// DeleteSSOAuthorization executes a query to delete the single sign-on authorization with the specified state, and
+// returns it, so that each authorization can only be used once.
+func (s *store) DeleteSSOAuthorization(ctx context.Context, tx pgx.Tx, state string) (domain.SSOAuthorization, error) {
+	row := tx.QueryRow(ctx, `
+		DELETE FROM sso_authorizations
+		WHERE state = $1
+		RETURNING state, nonce, code_verifier, expires_at
+	`,
+		state,
+	)
+
+	var authorization domain.SSOAuthorization
+
+	err := row.Scan(
+		&authorization.State,
+		&authorization.Nonce,
+		&authorization.CodeVerifier,
+		&authorization.ExpiresAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.SSOAuthorization{}, fmt.Errorf("%s: %w", descriptionFailedScanRow, domain.ErrSSOAuthorizationInvalid)
+		}
+
+		return domain.SSOAuthorization{}, fmt.Errorf("%s: %w", descriptionFailedScanRow, err)
+	}
+
+	return authorization, nil
+}
+
+// DeleteExpiredSSOAuthorizations executes a query to delete the single sign-on authorizations that expired before the
+// specified time.
+func (s *store) DeleteExpiredSSOAuthorizations(ctx context.Context, tx pgx.Tx, before time.Time) error {
+	_, err := tx.Exec(ctx, `
+		DELETE FROM sso_authorizations
+		WHERE expires_at < $1
+	`,
+		before,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", descriptionFailedExec, err)
+	}
+
+	return nil
+}
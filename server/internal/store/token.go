@@ -0,0 +1,225 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/goncalo-marques/ecomap/server/internal/domain"
+)
+
+// CreateRefreshToken executes a query to create a refresh token with the specified data.
+func (s *store) CreateRefreshToken(ctx context.Context, tx pgx.Tx, editableRefreshToken domain.EditableRefreshToken) (uuid.UUID, error) {
+	row := tx.QueryRow(ctx, `
+		INSERT INTO refresh_tokens (family_id, subject_type, subject_id, hash, access_token_id, access_token_expires_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id
+	`,
+		editableRefreshToken.FamilyID,
+		subjectTypeFromDomain(editableRefreshToken.SubjectType),
+		editableRefreshToken.SubjectID,
+		editableRefreshToken.Hash,
+		editableRefreshToken.AccessTokenID,
+		editableRefreshToken.AccessTokenExpiresAt,
+		editableRefreshToken.ExpiresAt,
+	)
+
+	var id uuid.UUID
+
+	err := row.Scan(&id)
+	if err != nil {
+		return uuid.UUID{}, fmt.Errorf("%s: %w", descriptionFailedScanRow, err)
+	}
+
+	return id, nil
+}
+
+// GetRefreshTokenByHash executes a query to return the refresh token with the specified hash. The refresh token is
+// locked until the end of the transaction, so that it cannot be rotated concurrently.
+func (s *store) GetRefreshTokenByHash(ctx context.Context, tx pgx.Tx, hash []byte) (domain.RefreshToken, error) {
+	row := tx.QueryRow(ctx, `
+		SELECT rt.id, rt.family_id, rt.subject_type, rt.subject_id, rt.hash, rt.access_token_id, rt.access_token_expires_at, rt.expires_at, rt.used_at, rt.revoked_at, rt.created_at 
+		FROM refresh_tokens AS rt
+		WHERE rt.hash = $1
+		FOR UPDATE
+	`,
+		hash,
+	)
+
+	var refreshToken domain.RefreshToken
+	var subjectType string
+
+	err := row.Scan(
+		&refreshToken.ID,
+		&refreshToken.FamilyID,
+		&subjectType,
+		&refreshToken.SubjectID,
+		&refreshToken.Hash,
+		&refreshToken.AccessTokenID,
+		&refreshToken.AccessTokenExpiresAt,
+		&refreshToken.ExpiresAt,
+		&refreshToken.UsedAt,
+		&refreshToken.RevokedAt,
+		&refreshToken.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.RefreshToken{}, fmt.Errorf("%s: %w", descriptionFailedScanRow, domain.ErrRefreshTokenInvalid)
+		}
+
+		return domain.RefreshToken{}, fmt.Errorf("%s: %w", descriptionFailedScanRow, err)
+	}
+
+	refreshToken.SubjectType = subjectTypeToDomain(subjectType)
+
+	return refreshToken, nil
+}
+
+// UseRefreshToken executes a query to mark the refresh token with the specified identifier as used at the given time.
+func (s *store) UseRefreshToken(ctx context.Context, tx pgx.Tx, id uuid.UUID, usedAt time.Time) error {
+	commandTag, err := tx.Exec(ctx, `
+		UPDATE refresh_tokens SET
+			used_at = $2
+		WHERE id = $1 AND used_at IS NULL
+	`,
+		id,
+		usedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", descriptionFailedExec, err)
+	}
+
+	if commandTag.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", descriptionFailedExec, domain.ErrRefreshTokenInvalid)
+	}
+
+	return nil
+}
+
+// RevokeRefreshTokenFamily executes a query to revoke, at the given time, the refresh tokens of the specified family,
+// along with the access tokens issued with them that have not expired yet.
+func (s *store) RevokeRefreshTokenFamily(ctx context.Context, tx pgx.Tx, familyID uuid.UUID, revokedAt time.Time) error {
+	_, err := tx.Exec(ctx, `
+		WITH revoked AS (
+			UPDATE refresh_tokens SET
+				revoked_at = $2
+			WHERE family_id = $1 AND revoked_at IS NULL
+			RETURNING access_token_id, access_token_expires_at
+		)
+		INSERT INTO revoked_tokens (id, expires_at)
+		SELECT access_token_id, access_token_expires_at 
+		FROM revoked
+		WHERE access_token_expires_at > $2
+		ON CONFLICT DO NOTHING
+	`,
+		familyID,
+		revokedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", descriptionFailedExec, err)
+	}
+
+	return nil
+}
+
+// RevokeSubjectRefreshTokens executes a query to revoke, at the given time, the refresh tokens of the subject with the
+// specified identifier, along with the access tokens issued with them that have not expired yet.
+func (s *store) RevokeSubjectRefreshTokens(ctx context.Context, tx pgx.Tx, subjectID uuid.UUID, revokedAt time.Time) error {
+	_, err := tx.Exec(ctx, `
+		WITH revoked AS (
+			UPDATE refresh_tokens SET
+				revoked_at = $2
+			WHERE subject_id = $1 AND revoked_at IS NULL
+			RETURNING access_token_id, access_token_expires_at
+		)
+		INSERT INTO revoked_tokens (id, expires_at)
+		SELECT access_token_id, access_token_expires_at 
+		FROM revoked
+		WHERE access_token_expires_at > $2
+		ON CONFLICT DO NOTHING
+	`,
+		subjectID,
+		revokedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", descriptionFailedExec, err)
+	}
+
+	return nil
+}
+
+// DeleteExpiredTokens executes a query to delete the refresh tokens and the revoked access tokens that have expired
+// before the given time.
+func (s *store) DeleteExpiredTokens(ctx context.Context, tx pgx.Tx, before time.Time) error {
+	_, err := tx.Exec(ctx, `
+		DELETE FROM refresh_tokens
+		WHERE expires_at < $1
+	`,
+		before,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", descriptionFailedExec, err)
+	}
+
+	_, err = tx.Exec(ctx, `
+		DELETE FROM revoked_tokens
+		WHERE expires_at < $1
+	`,
+		before,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", descriptionFailedExec, err)
+	}
+
+	return nil
+}
+
+// ExistsRevokedToken executes a query to return whether the access token with the specified identifier was revoked.
+func (s *store) ExistsRevokedToken(ctx context.Context, tx pgx.Tx, id uuid.UUID) (bool, error) {
+	row := tx.QueryRow(ctx, `
+		SELECT EXISTS (
+			SELECT 1 
+			FROM revoked_tokens AS rt
+			WHERE rt.id = $1
+		)
+	`,
+		id,
+	)
+
+	var exists bool
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", descriptionFailedScanRow, err)
+	}
+
+	return exists, nil
+}
+
+// subjectTypeFromDomain returns a store subject type based on the domain model.
+func subjectTypeFromDomain(subjectType domain.SubjectType) string {
+	switch subjectType {
+	case domain.SubjectTypeUser:
+		return "user"
+	case domain.SubjectTypeEmployee:
+		return "employee"
+	default:
+		return string(subjectType)
+	}
+}
+
+// subjectTypeToDomain returns a domain subject type based on the store model.
+func subjectTypeToDomain(subjectType string) domain.SubjectType {
+	switch subjectType {
+	case "user":
+		return domain.SubjectTypeUser
+	case "employee":
+		return domain.SubjectTypeEmployee
+	default:
+		return domain.SubjectType(subjectType)
+	}
+}
@@ -11,6 +11,10 @@ import (
 	"github.com/goncalo-marques/ecomap/server/internal/domain"
 )
 
+const (
+	constraintTrucksCompartmentsTruckIDFkey = "trucks_compartments_truck_id_fkey"
+)
+
 // CreateTruck executes a query to create a truck with the specified data.
 func (s *store) CreateTruck(ctx context.Context, tx pgx.Tx, editableTruck domain.EditableTruck, roadID, municipalityID *int) (uuid.UUID, error) {
 	geoJSON, err := jsonMarshalGeoJSONGeometryPoint(editableTruck.GeoJSON)
@@ -19,14 +23,22 @@ func (s *store) CreateTruck(ctx context.Context, tx pgx.Tx, editableTruck domain
 	}
 
 	row := tx.QueryRow(ctx, `
-		INSERT INTO trucks (make, model, license_plate, person_capacity, geom, road_id, municipality_id)
-		VALUES ($1, $2, $3, $4, ST_GeomFromGeoJSON($5), $6, $7) 
+		INSERT INTO trucks (make, model, license_plate, person_capacity, volume_capacity, weight_capacity, status, service_interval_distance, service_interval_period, fuel_type, fuel_consumption, license_category, geom, road_id, municipality_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, ST_GeomFromGeoJSON($13), $14, $15) 
 		RETURNING id
 	`,
 		editableTruck.Make,
 		editableTruck.Model,
 		editableTruck.LicensePlate,
 		editableTruck.PersonCapacity,
+		editableTruck.VolumeCapacity,
+		editableTruck.WeightCapacity,
+		truckStatusFromDomain(editableTruck.Status),
+		editableTruck.ServiceIntervalDistance,
+		editableTruck.ServiceIntervalPeriod,
+		truckFuelTypeFromDomain(editableTruck.FuelType),
+		editableTruck.FuelConsumption,
+		licenseCategoryFromDomain(editableTruck.LicenseCategory),
 		geoJSON,
 		roadID,
 		municipalityID,
@@ -61,6 +73,10 @@ func (s *store) ListTrucks(ctx context.Context, tx pgx.Tx, filter domain.TrucksP
 		filterFields = append(filterFields, "t.license_plate")
 		argsWhere = append(argsWhere, *filter.LicensePlate)
 	}
+	if filter.Status != nil {
+		filterFields = append(filterFields, "t.status::text")
+		argsWhere = append(argsWhere, truckStatusFromDomain(*filter.Status))
+	}
 	if filter.LocationName != nil {
 		filterLocationFields = []string{"rn.osm_name", "m.name"}
 		argsWhere = append(argsWhere, *filter.LocationName)
@@ -100,6 +116,8 @@ func (s *store) ListTrucks(ctx context.Context, tx pgx.Tx, filter domain.TrucksP
 		sortField = "t.license_plate"
 	case domain.TruckPaginatedSortPersonCapacity:
 		sortField = "t.person_capacity"
+	case domain.TruckPaginatedSortStatus:
+		sortField = "t.status"
 	case domain.TruckPaginatedSortWayName:
 		sortField = "rn.osm_name"
 	case domain.TruckPaginatedSortMunicipalityName:
@@ -112,7 +130,7 @@ func (s *store) ListTrucks(ctx context.Context, tx pgx.Tx, filter domain.TrucksP
 
 	// Get rows for the given filter.
 	rows, err := tx.Query(ctx, `
-		SELECT t.id, t.make, t.model, t.license_plate, t.person_capacity, ST_AsGeoJSON(t.geom)::jsonb, rn.osm_name, m.name, t.created_at, t.modified_at
+		SELECT t.id, t.make, t.model, t.license_plate, t.person_capacity, t.volume_capacity, t.weight_capacity, (SELECT coalesce(jsonb_agg(tc.categories ORDER BY tc.position), '[]') FROM trucks_compartments AS tc WHERE tc.truck_id = t.id), t.status, t.service_interval_distance, t.service_interval_period, (SELECT tor.value FROM trucks_odometer_readings AS tor WHERE tor.truck_id = t.id ORDER BY tor.recorded_at DESC LIMIT 1), t.fuel_type, t.fuel_consumption, t.license_category, ST_AsGeoJSON(t.geom)::jsonb, rn.osm_name, m.name, t.created_at, t.modified_at
 		FROM trucks AS t
 		LEFT JOIN road_network AS rn ON t.road_id = rn.id
 		LEFT JOIN municipalities AS m ON t.municipality_id = m.id
@@ -138,7 +156,7 @@ func (s *store) ListTrucks(ctx context.Context, tx pgx.Tx, filter domain.TrucksP
 // GetTruckByID executes a query to return the truck with the specified identifier.
 func (s *store) GetTruckByID(ctx context.Context, tx pgx.Tx, id uuid.UUID) (domain.Truck, error) {
 	row := tx.QueryRow(ctx, `
-		SELECT t.id, t.make, t.model, t.license_plate, t.person_capacity, ST_AsGeoJSON(t.geom)::jsonb, rn.osm_name, m.name, t.created_at, t.modified_at
+		SELECT t.id, t.make, t.model, t.license_plate, t.person_capacity, t.volume_capacity, t.weight_capacity, (SELECT coalesce(jsonb_agg(tc.categories ORDER BY tc.position), '[]') FROM trucks_compartments AS tc WHERE tc.truck_id = t.id), t.status, t.service_interval_distance, t.service_interval_period, (SELECT tor.value FROM trucks_odometer_readings AS tor WHERE tor.truck_id = t.id ORDER BY tor.recorded_at DESC LIMIT 1), t.fuel_type, t.fuel_consumption, t.license_category, ST_AsGeoJSON(t.geom)::jsonb, rn.osm_name, m.name, t.created_at, t.modified_at
 		FROM trucks AS t
 		LEFT JOIN road_network AS rn ON t.road_id = rn.id
 		LEFT JOIN municipalities AS m ON t.municipality_id = m.id
@@ -164,6 +182,24 @@ func (s *store) PatchTruck(ctx context.Context, tx pgx.Tx, id uuid.UUID, editabl
 	var geoJSON []byte
 	var err error
 
+	var status *string
+	if editableTruck.Status != nil {
+		s := truckStatusFromDomain(*editableTruck.Status)
+		status = &s
+	}
+
+	var fuelType *string
+	if editableTruck.FuelType != nil {
+		t := truckFuelTypeFromDomain(*editableTruck.FuelType)
+		fuelType = &t
+	}
+
+	var licenseCategory *string
+	if editableTruck.LicenseCategory != nil {
+		c := licenseCategoryFromDomain(*editableTruck.LicenseCategory)
+		licenseCategory = &c
+	}
+
 	if editableTruck.GeoJSON != nil {
 		geoJSON, err = jsonMarshalGeoJSONGeometryPoint(editableTruck.GeoJSON)
 		if err != nil {
@@ -177,13 +213,21 @@ func (s *store) PatchTruck(ctx context.Context, tx pgx.Tx, id uuid.UUID, editabl
 			model = coalesce($3, model),
 			license_plate = coalesce($4, license_plate),
 			person_capacity = coalesce($5, person_capacity),
-			geom = coalesce(ST_GeomFromGeoJSON($6), geom),
+			volume_capacity = coalesce($6, volume_capacity),
+			weight_capacity = coalesce($7, weight_capacity),
+			status = coalesce($8, status),
+			service_interval_distance = coalesce($9, service_interval_distance),
+			service_interval_period = coalesce($10, service_interval_period),
+			fuel_type = coalesce($11, fuel_type),
+			fuel_consumption = coalesce($12, fuel_consumption),
+			license_category = coalesce($13, license_category),
+			geom = coalesce(ST_GeomFromGeoJSON($14), geom),
 			road_id = CASE 
-					WHEN $6 IS NOT NULL THEN $7 
+					WHEN $14 IS NOT NULL THEN $15 
 					ELSE road_id
 				END,
 			municipality_id = CASE 
-					WHEN $6 IS NOT NULL THEN $8
+					WHEN $14 IS NOT NULL THEN $16
 					ELSE municipality_id
 				END
 		WHERE id = $1
@@ -193,6 +237,14 @@ func (s *store) PatchTruck(ctx context.Context, tx pgx.Tx, id uuid.UUID, editabl
 		editableTruck.Model,
 		editableTruck.LicensePlate,
 		editableTruck.PersonCapacity,
+		editableTruck.VolumeCapacity,
+		editableTruck.WeightCapacity,
+		status,
+		editableTruck.ServiceIntervalDistance,
+		editableTruck.ServiceIntervalPeriod,
+		fuelType,
+		editableTruck.FuelConsumption,
+		licenseCategory,
 		geoJSON,
 		roadID,
 		municipalityID,
@@ -234,9 +286,121 @@ func (s *store) DeleteTruckByID(ctx context.Context, tx pgx.Tx, id uuid.UUID) er
 	return nil
 }
 
+// CreateTruckCompartments executes a query to create the compartments of the truck with the specified identifier.
+// The compartments are stored in the given order.
+func (s *store) CreateTruckCompartments(ctx context.Context, tx pgx.Tx, truckID uuid.UUID, compartments domain.TruckCompartments) error {
+	for i, compartment := range compartments {
+		_, err := tx.Exec(ctx, `
+			INSERT INTO trucks_compartments (truck_id, position, categories)
+			VALUES ($1, $2, $3::text[]::containers_category[])
+		`,
+			truckID,
+			i,
+			containerCategoriesFromDomain(compartment.Categories),
+		)
+		if err != nil {
+			switch constraintNameFromError(err) {
+			case constraintTrucksCompartmentsTruckIDFkey:
+				return fmt.Errorf("%s: %w", descriptionFailedExec, domain.ErrTruckNotFound)
+			}
+
+			return fmt.Errorf("%s: %w", descriptionFailedExec, err)
+		}
+	}
+
+	return nil
+}
+
+// DeleteTruckCompartments executes a query to delete the compartments of the truck with the specified identifier.
+func (s *store) DeleteTruckCompartments(ctx context.Context, tx pgx.Tx, truckID uuid.UUID) error {
+	_, err := tx.Exec(ctx, `
+		DELETE FROM trucks_compartments
+		WHERE truck_id = $1
+	`,
+		truckID,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", descriptionFailedExec, err)
+	}
+
+	return nil
+}
+
+// truckStatusFromDomain returns a store truck status based on the domain model.
+func truckStatusFromDomain(status domain.TruckStatus) string {
+	switch status {
+	case domain.TruckStatusActive:
+		return "active"
+	case domain.TruckStatusMaintenance:
+		return "maintenance"
+	case domain.TruckStatusRetired:
+		return "retired"
+	default:
+		return string(status)
+	}
+}
+
+// truckStatusToDomain returns a domain truck status based on the store model.
+func truckStatusToDomain(status string) domain.TruckStatus {
+	switch status {
+	case "active":
+		return domain.TruckStatusActive
+	case "maintenance":
+		return domain.TruckStatusMaintenance
+	case "retired":
+		return domain.TruckStatusRetired
+	default:
+		return domain.TruckStatus(status)
+	}
+}
+
+// truckFuelTypeFromDomain returns a store truck fuel type based on the domain model.
+func truckFuelTypeFromDomain(fuelType domain.TruckFuelType) string {
+	switch fuelType {
+	case domain.TruckFuelTypeDiesel:
+		return "diesel"
+	case domain.TruckFuelTypeGasoline:
+		return "gasoline"
+	case domain.TruckFuelTypeLPG:
+		return "lpg"
+	default:
+		return string(fuelType)
+	}
+}
+
+// truckFuelTypeToDomain returns a domain truck fuel type based on the store model.
+func truckFuelTypeToDomain(fuelType string) domain.TruckFuelType {
+	switch fuelType {
+	case "diesel":
+		return domain.TruckFuelTypeDiesel
+	case "gasoline":
+		return domain.TruckFuelTypeGasoline
+	case "lpg":
+		return domain.TruckFuelTypeLPG
+	default:
+		return domain.TruckFuelType(fuelType)
+	}
+}
+
+// truckCompartmentsToDomain returns domain truck compartments based on the store model.
+func truckCompartmentsToDomain(compartments [][]string) domain.TruckCompartments {
+	domainCompartments := make(domain.TruckCompartments, len(compartments))
+	for i, categories := range compartments {
+		domainCompartments[i] = domain.TruckCompartment{
+			Categories: containerCategoriesToDomain(categories),
+		}
+	}
+
+	return domainCompartments
+}
+
 // getTruckFromRow returns the truck by scanning the given row.
 func getTruckFromRow(row pgx.Row) (domain.Truck, error) {
 	var truck domain.Truck
+	var compartments [][]string
+	var status string
+	var fuelType string
+	var licenseCategory string
 	var geoJSONPoint domain.GeoJSONGeometryPoint
 	var wayName *string
 	var municipalityName *string
@@ -247,6 +411,16 @@ func getTruckFromRow(row pgx.Row) (domain.Truck, error) {
 		&truck.Model,
 		&truck.LicensePlate,
 		&truck.PersonCapacity,
+		&truck.VolumeCapacity,
+		&truck.WeightCapacity,
+		&compartments,
+		&status,
+		&truck.ServiceIntervalDistance,
+		&truck.ServiceIntervalPeriod,
+		&truck.Odometer,
+		&fuelType,
+		&truck.FuelConsumption,
+		&licenseCategory,
 		&geoJSONPoint,
 		&wayName,
 		&municipalityName,
@@ -257,6 +431,11 @@ func getTruckFromRow(row pgx.Row) (domain.Truck, error) {
 		return domain.Truck{}, err
 	}
 
+	truck.Compartments = truckCompartmentsToDomain(compartments)
+	truck.Status = truckStatusToDomain(status)
+	truck.FuelType = truckFuelTypeToDomain(fuelType)
+	truck.LicenseCategory = licenseCategoryToDomain(licenseCategory)
+
 	geoJSONProperties := make(domain.GeoJSONFeatureProperties)
 	if wayName != nil {
 		geoJSONProperties.SetWayName(*wayName)
@@ -0,0 +1,262 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/goncalo-marques/ecomap/server/internal/domain"
+)
+
+const (
+	constraintTrucksMaintenancesTruckIDFkey = "trucks_maintenances_truck_id_fkey"
+)
+
+// CreateTruckMaintenance executes a query to create a maintenance of the truck with the specified identifier.
+func (s *store) CreateTruckMaintenance(ctx context.Context, tx pgx.Tx, truckID uuid.UUID, editableMaintenance domain.EditableTruckMaintenance) (uuid.UUID, error) {
+	row := tx.QueryRow(ctx, `
+		INSERT INTO trucks_maintenances (truck_id, type, date, cost, notes, odometer)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id
+	`,
+		truckID,
+		truckMaintenanceTypeFromDomain(editableMaintenance.Type),
+		editableMaintenance.Date,
+		editableMaintenance.Cost,
+		editableMaintenance.Notes,
+		editableMaintenance.Odometer,
+	)
+
+	var id uuid.UUID
+
+	err := row.Scan(&id)
+	if err != nil {
+		switch constraintNameFromError(err) {
+		case constraintTrucksMaintenancesTruckIDFkey:
+			return uuid.UUID{}, fmt.Errorf("%s: %w", descriptionFailedScanRow, domain.ErrTruckNotFound)
+		}
+
+		return uuid.UUID{}, fmt.Errorf("%s: %w", descriptionFailedScanRow, err)
+	}
+
+	return id, nil
+}
+
+// ListTruckMaintenances executes a query to return the maintenances of the truck with the specified identifier for the
+// specified filter.
+func (s *store) ListTruckMaintenances(ctx context.Context, tx pgx.Tx, truckID uuid.UUID, filter domain.TruckMaintenancesPaginatedFilter) (domain.PaginatedResponse[domain.TruckMaintenance], error) {
+	var filterFields []string
+	var argsWhere []any
+
+	// Append the optional fields to filter.
+	filterFields = append(filterFields, "tm.truck_id::text")
+	argsWhere = append(argsWhere, truckID)
+	if filter.Type != nil {
+		filterFields = append(filterFields, "tm.type::text")
+		argsWhere = append(argsWhere, truckMaintenanceTypeFromDomain(*filter.Type))
+	}
+
+	sqlWhere := listSQLWhere(filterFields, nil)
+
+	// Get the total number of rows for the given filter.
+	var total int
+	row := tx.QueryRow(ctx, `
+		SELECT count(tm.id) 
+		FROM trucks_maintenances AS tm
+	`+sqlWhere,
+		argsWhere...,
+	)
+
+	err := row.Scan(&total)
+	if err != nil {
+		return domain.PaginatedResponse[domain.TruckMaintenance]{}, fmt.Errorf("%s: %w", descriptionFailedScanRow, err)
+	}
+
+	// Append the field to sort, if provided.
+	var domainSortField domain.TruckMaintenancePaginatedSort
+	if filter.Sort != nil {
+		domainSortField = filter.Sort.Field()
+	}
+
+	sortField := "tm.date"
+	switch domainSortField {
+	case domain.TruckMaintenancePaginatedSortType:
+		sortField = "tm.type"
+	case domain.TruckMaintenancePaginatedSortDate:
+		sortField = "tm.date"
+	case domain.TruckMaintenancePaginatedSortCost:
+		sortField = "tm.cost"
+	case domain.TruckMaintenancePaginatedSortCreatedAt:
+		sortField = "tm.created_at"
+	}
+
+	// Get rows for the given filter.
+	rows, err := tx.Query(ctx, `
+		SELECT tm.id, tm.truck_id, tm.type, tm.date, tm.cost, tm.notes, tm.odometer, tm.created_at, tm.modified_at
+		FROM trucks_maintenances AS tm
+	`+sqlWhere+listSQLOrder(sortField, filter.Order)+listSQLLimitOffset(filter.Limit, filter.Offset),
+		argsWhere...,
+	)
+	if err != nil {
+		return domain.PaginatedResponse[domain.TruckMaintenance]{}, fmt.Errorf("%s: %w", descriptionFailedQuery, err)
+	}
+	defer rows.Close()
+
+	maintenances, err := getTruckMaintenancesFromRows(rows)
+	if err != nil {
+		return domain.PaginatedResponse[domain.TruckMaintenance]{}, fmt.Errorf("%s: %w", descriptionFailedScanRows, err)
+	}
+
+	return domain.PaginatedResponse[domain.TruckMaintenance]{
+		Total:   total,
+		Results: maintenances,
+	}, nil
+}
+
+// GetTruckMaintenanceByID executes a query to return the maintenance with the specified identifier of the truck with
+// the specified identifier.
+func (s *store) GetTruckMaintenanceByID(ctx context.Context, tx pgx.Tx, truckID, id uuid.UUID) (domain.TruckMaintenance, error) {
+	row := tx.QueryRow(ctx, `
+		SELECT tm.id, tm.truck_id, tm.type, tm.date, tm.cost, tm.notes, tm.odometer, tm.created_at, tm.modified_at
+		FROM trucks_maintenances AS tm
+		WHERE tm.truck_id = $1 AND tm.id = $2
+	`,
+		truckID,
+		id,
+	)
+
+	maintenance, err := getTruckMaintenanceFromRow(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.TruckMaintenance{}, fmt.Errorf("%s: %w", descriptionFailedScanRow, domain.ErrTruckMaintenanceNotFound)
+		}
+
+		return domain.TruckMaintenance{}, fmt.Errorf("%s: %w", descriptionFailedScanRow, err)
+	}
+
+	return maintenance, nil
+}
+
+// ListTrucksLastMaintenances executes a query to return the most recent maintenance of the specified type of each
+// truck.
+func (s *store) ListTrucksLastMaintenances(ctx context.Context, tx pgx.Tx, maintenanceType domain.TruckMaintenanceType) ([]domain.TruckMaintenance, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT DISTINCT ON (tm.truck_id) tm.id, tm.truck_id, tm.type, tm.date, tm.cost, tm.notes, tm.odometer, tm.created_at, tm.modified_at
+		FROM trucks_maintenances AS tm
+		WHERE tm.type = $1
+		ORDER BY tm.truck_id, tm.date DESC, tm.created_at DESC
+	`,
+		truckMaintenanceTypeFromDomain(maintenanceType),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", descriptionFailedQuery, err)
+	}
+	defer rows.Close()
+
+	maintenances, err := getTruckMaintenancesFromRows(rows)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", descriptionFailedScanRows, err)
+	}
+
+	return maintenances, nil
+}
+
+// DeleteTruckMaintenanceByID executes a query to delete the maintenance with the specified identifier of the truck with
+// the specified identifier.
+func (s *store) DeleteTruckMaintenanceByID(ctx context.Context, tx pgx.Tx, truckID, id uuid.UUID) error {
+	commandTag, err := tx.Exec(ctx, `
+		DELETE FROM trucks_maintenances
+		WHERE truck_id = $1 AND id = $2
+	`,
+		truckID,
+		id,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", descriptionFailedExec, err)
+	}
+
+	if commandTag.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", descriptionFailedExec, domain.ErrTruckMaintenanceNotFound)
+	}
+
+	return nil
+}
+
+// truckMaintenanceTypeFromDomain returns a store truck maintenance type based on the domain model.
+func truckMaintenanceTypeFromDomain(maintenanceType domain.TruckMaintenanceType) string {
+	switch maintenanceType {
+	case domain.TruckMaintenanceTypeService:
+		return "service"
+	case domain.TruckMaintenanceTypeRepair:
+		return "repair"
+	case domain.TruckMaintenanceTypeInspection:
+		return "inspection"
+	case domain.TruckMaintenanceTypeTires:
+		return "tires"
+	case domain.TruckMaintenanceTypeOther:
+		return "other"
+	default:
+		return string(maintenanceType)
+	}
+}
+
+// truckMaintenanceTypeToDomain returns a domain truck maintenance type based on the store model.
+func truckMaintenanceTypeToDomain(maintenanceType string) domain.TruckMaintenanceType {
+	switch maintenanceType {
+	case "service":
+		return domain.TruckMaintenanceTypeService
+	case "repair":
+		return domain.TruckMaintenanceTypeRepair
+	case "inspection":
+		return domain.TruckMaintenanceTypeInspection
+	case "tires":
+		return domain.TruckMaintenanceTypeTires
+	case "other":
+		return domain.TruckMaintenanceTypeOther
+	default:
+		return domain.TruckMaintenanceType(maintenanceType)
+	}
+}
+
+// getTruckMaintenanceFromRow returns the truck maintenance by scanning the given row.
+func getTruckMaintenanceFromRow(row pgx.Row) (domain.TruckMaintenance, error) {
+	var maintenance domain.TruckMaintenance
+	var maintenanceType string
+
+	err := row.Scan(
+		&maintenance.ID,
+		&maintenance.TruckID,
+		&maintenanceType,
+		&maintenance.Date,
+		&maintenance.Cost,
+		&maintenance.Notes,
+		&maintenance.Odometer,
+		&maintenance.CreatedAt,
+		&maintenance.ModifiedAt,
+	)
+	if err != nil {
+		return domain.TruckMaintenance{}, err
+	}
+
+	maintenance.Type = truckMaintenanceTypeToDomain(maintenanceType)
+
+	return maintenance, nil
+}
+
+// getTruckMaintenancesFromRows returns the truck maintenances by scanning the given rows.
+func getTruckMaintenancesFromRows(rows pgx.Rows) ([]domain.TruckMaintenance, error) {
+	var maintenances []domain.TruckMaintenance
+	for rows.Next() {
+		maintenance, err := getTruckMaintenanceFromRow(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		maintenances = append(maintenances, maintenance)
+	}
+
+	return maintenances, nil
+}
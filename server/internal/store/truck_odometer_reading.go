@@ -0,0 +1,154 @@
+package store
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/goncalo-marques/ecomap/server/internal/domain"
+)
+
+const (
+	constraintTrucksOdometerReadingsPkey        = "trucks_odometer_readings_pkey"
+	constraintTrucksOdometerReadingsTruckIDFkey = "trucks_odometer_readings_truck_id_fkey"
+)
+
+// CreateTruckOdometerReading executes a query to create an odometer reading of the truck with the specified
+// identifier.
+func (s *store) CreateTruckOdometerReading(ctx context.Context, tx pgx.Tx, truckID uuid.UUID, editableReading domain.EditableTruckOdometerReading) (domain.TruckOdometerReading, error) {
+	row := tx.QueryRow(ctx, `
+		INSERT INTO trucks_odometer_readings (truck_id, value, recorded_at)
+		VALUES ($1, $2, $3)
+		RETURNING truck_id, value, recorded_at, created_at
+	`,
+		truckID,
+		editableReading.Value,
+		editableReading.RecordedAt,
+	)
+
+	var reading domain.TruckOdometerReading
+
+	err := row.Scan(
+		&reading.TruckID,
+		&reading.Value,
+		&reading.RecordedAt,
+		&reading.CreatedAt,
+	)
+	if err != nil {
+		switch constraintNameFromError(err) {
+		case constraintTrucksOdometerReadingsPkey:
+			return domain.TruckOdometerReading{}, fmt.Errorf("%s: %w", descriptionFailedScanRow, domain.ErrTruckOdometerReadingAlreadyExists)
+		case constraintTrucksOdometerReadingsTruckIDFkey:
+			return domain.TruckOdometerReading{}, fmt.Errorf("%s: %w", descriptionFailedScanRow, domain.ErrTruckNotFound)
+		}
+
+		return domain.TruckOdometerReading{}, fmt.Errorf("%s: %w", descriptionFailedScanRow, err)
+	}
+
+	return reading, nil
+}
+
+// ExistsTruckOdometerReadingInconsistent executes a query to return whether the truck with the specified identifier
+// has an odometer reading that is inconsistent with the given reading, that is, an earlier reading with a higher value
+// or a later reading with a lower value.
+func (s *store) ExistsTruckOdometerReadingInconsistent(ctx context.Context, tx pgx.Tx, truckID uuid.UUID, editableReading domain.EditableTruckOdometerReading) (bool, error) {
+	row := tx.QueryRow(ctx, `
+		SELECT EXISTS (
+			SELECT 1
+			FROM trucks_odometer_readings AS tor
+			WHERE tor.truck_id = $1 AND (
+				(tor.recorded_at < $2 AND tor.value > $3) OR
+				(tor.recorded_at > $2 AND tor.value < $3)
+			)
+		)
+	`,
+		truckID,
+		editableReading.RecordedAt,
+		editableReading.Value,
+	)
+
+	var exists bool
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", descriptionFailedScanRow, err)
+	}
+
+	return exists, nil
+}
+
+// ListTruckOdometerReadings executes a query to return the odometer readings of the truck with the specified
+// identifier for the specified filter.
+func (s *store) ListTruckOdometerReadings(ctx context.Context, tx pgx.Tx, truckID uuid.UUID, filter domain.TruckOdometerReadingsPaginatedFilter) (domain.PaginatedResponse[domain.TruckOdometerReading], error) {
+	var filterFields []string
+	var argsWhere []any
+
+	filterFields = append(filterFields, "tor.truck_id::text")
+	argsWhere = append(argsWhere, truckID)
+
+	sqlWhere := listSQLWhere(filterFields, nil)
+
+	// Get the total number of rows for the given filter.
+	var total int
+	row := tx.QueryRow(ctx, `
+		SELECT count(tor.truck_id) 
+		FROM trucks_odometer_readings AS tor
+	`+sqlWhere,
+		argsWhere...,
+	)
+
+	err := row.Scan(&total)
+	if err != nil {
+		return domain.PaginatedResponse[domain.TruckOdometerReading]{}, fmt.Errorf("%s: %w", descriptionFailedScanRow, err)
+	}
+
+	// Append the field to sort, if provided.
+	var domainSortField domain.TruckOdometerReadingPaginatedSort
+	if filter.Sort != nil {
+		domainSortField = filter.Sort.Field()
+	}
+
+	sortField := "tor.recorded_at"
+	switch domainSortField {
+	case domain.TruckOdometerReadingPaginatedSortValue:
+		sortField = "tor.value"
+	case domain.TruckOdometerReadingPaginatedSortRecordedAt:
+		sortField = "tor.recorded_at"
+	}
+
+	// Get rows for the given filter.
+	rows, err := tx.Query(ctx, `
+		SELECT tor.truck_id, tor.value, tor.recorded_at, tor.created_at
+		FROM trucks_odometer_readings AS tor
+	`+sqlWhere+listSQLOrder(sortField, filter.Order)+listSQLLimitOffset(filter.Limit, filter.Offset),
+		argsWhere...,
+	)
+	if err != nil {
+		return domain.PaginatedResponse[domain.TruckOdometerReading]{}, fmt.Errorf("%s: %w", descriptionFailedQuery, err)
+	}
+	defer rows.Close()
+
+	var readings []domain.TruckOdometerReading
+	for rows.Next() {
+		var reading domain.TruckOdometerReading
+
+		err := rows.Scan(
+			&reading.TruckID,
+			&reading.Value,
+			&reading.RecordedAt,
+			&reading.CreatedAt,
+		)
+		if err != nil {
+			return domain.PaginatedResponse[domain.TruckOdometerReading]{}, fmt.Errorf("%s: %w", descriptionFailedScanRows, err)
+		}
+
+		readings = append(readings, reading)
+	}
+
+	return domain.PaginatedResponse[domain.TruckOdometerReading]{
+		Total:   total,
+		Results: readings,
+	}, nil
+}
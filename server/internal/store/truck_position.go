@@ -0,0 +1,134 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/goncalo-marques/ecomap/server/internal/domain"
+)
+
+const (
+	constraintTrucksPositionsTruckIDFkey = "trucks_positions_truck_id_fkey"
+)
+
+// CreateTruckPositions executes a query to create the positions of the truck with the specified identifier. Positions
+// already recorded at the same instant are ignored, which allows devices to safely resend a batch.
+func (s *store) CreateTruckPositions(ctx context.Context, tx pgx.Tx, truckID uuid.UUID, positions domain.EditableTruckPositions) error {
+	longitudes := make([]float64, len(positions))
+	latitudes := make([]float64, len(positions))
+	speeds := make([]*float64, len(positions))
+	headings := make([]*float64, len(positions))
+	recordedAts := make([]time.Time, len(positions))
+
+	for i, position := range positions {
+		longitudes[i] = position.Geometry.Coordinates[0]
+		latitudes[i] = position.Geometry.Coordinates[1]
+		speeds[i] = (*float64)(position.Speed)
+		headings[i] = (*float64)(position.Heading)
+		recordedAts[i] = position.RecordedAt
+	}
+
+	_, err := tx.Exec(ctx, `
+		INSERT INTO trucks_positions (truck_id, geom, speed, heading, recorded_at)
+		SELECT $1, ST_SetSRID(ST_MakePoint(p.longitude, p.latitude), 4326), p.speed, p.heading, p.recorded_at
+		FROM unnest($2::double precision[], $3::double precision[], $4::double precision[], $5::double precision[], $6::timestamp[]) AS p (longitude, latitude, speed, heading, recorded_at)
+		ON CONFLICT (truck_id, recorded_at) DO NOTHING
+	`,
+		truckID,
+		longitudes,
+		latitudes,
+		speeds,
+		headings,
+		recordedAts,
+	)
+	if err != nil {
+		switch constraintNameFromError(err) {
+		case constraintTrucksPositionsTruckIDFkey:
+			return fmt.Errorf("%s: %w", descriptionFailedExec, domain.ErrTruckNotFound)
+		}
+
+		return fmt.Errorf("%s: %w", descriptionFailedExec, err)
+	}
+
+	return nil
+}
+
+// GetTruckLatestPosition executes a query to return the most recent position of the truck with the specified
+// identifier.
+func (s *store) GetTruckLatestPosition(ctx context.Context, tx pgx.Tx, truckID uuid.UUID) (domain.TruckPosition, error) {
+	row := tx.QueryRow(ctx, `
+		SELECT tp.truck_id, ST_AsGeoJSON(tp.geom)::jsonb, tp.speed, tp.heading, tp.recorded_at, tp.created_at
+		FROM trucks_positions AS tp
+		WHERE tp.truck_id = $1
+		ORDER BY tp.recorded_at DESC
+		LIMIT 1
+	`,
+		truckID,
+	)
+
+	position, err := getTruckPositionFromRow(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.TruckPosition{}, fmt.Errorf("%s: %w", descriptionFailedScanRow, domain.ErrTruckPositionNotFound)
+		}
+
+		return domain.TruckPosition{}, fmt.Errorf("%s: %w", descriptionFailedScanRow, err)
+	}
+
+	return position, nil
+}
+
+// ListTruckPositions executes a query to return the positions of the truck with the specified identifier recorded in
+// the given period, sorted by the time they were recorded.
+func (s *store) ListTruckPositions(ctx context.Context, tx pgx.Tx, truckID uuid.UUID, from, to time.Time) ([]domain.TruckPosition, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT tp.truck_id, ST_AsGeoJSON(tp.geom)::jsonb, tp.speed, tp.heading, tp.recorded_at, tp.created_at
+		FROM trucks_positions AS tp
+		WHERE tp.truck_id = $1 AND tp.recorded_at >= $2 AND tp.recorded_at <= $3
+		ORDER BY tp.recorded_at
+	`,
+		truckID,
+		from,
+		to,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", descriptionFailedQuery, err)
+	}
+	defer rows.Close()
+
+	var positions []domain.TruckPosition
+	for rows.Next() {
+		position, err := getTruckPositionFromRow(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", descriptionFailedScanRows, err)
+		}
+
+		positions = append(positions, position)
+	}
+
+	return positions, nil
+}
+
+// getTruckPositionFromRow returns the truck position by scanning the given row.
+func getTruckPositionFromRow(row pgx.Row) (domain.TruckPosition, error) {
+	var position domain.TruckPosition
+
+	err := row.Scan(
+		&position.TruckID,
+		&position.Geometry,
+		&position.Speed,
+		&position.Heading,
+		&position.RecordedAt,
+		&position.CreatedAt,
+	)
+	if err != nil {
+		return domain.TruckPosition{}, err
+	}
+
+	return position, nil
+}
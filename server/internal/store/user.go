@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -18,14 +19,15 @@ const (
 // CreateUser executes a query to create a user with the specified data.
 func (s *store) CreateUser(ctx context.Context, tx pgx.Tx, editableUser domain.EditableUserWithPassword) (uuid.UUID, error) {
 	row := tx.QueryRow(ctx, `
-		INSERT INTO users (username, password, first_name, last_name)
-		VALUES ($1, $2, $3, $4) 
+		INSERT INTO users (username, password, first_name, last_name, email)
+		VALUES ($1, $2, $3, $4, $5) 
 		RETURNING id
 	`,
 		editableUser.Username,
 		editableUser.Password,
 		editableUser.FirstName,
 		editableUser.LastName,
+		editableUser.Email,
 	)
 
 	var id uuid.UUID
@@ -99,7 +101,7 @@ func (s *store) ListUsers(ctx context.Context, tx pgx.Tx, filter domain.UsersPag
 
 	// Get rows for the given filter.
 	rows, err := tx.Query(ctx, `
-		SELECT id, username, first_name, last_name, created_at, modified_at 
+		SELECT id, username, first_name, last_name, email, email_verified_at, created_at, modified_at 
 		FROM users 
 	`+sqlWhere+listSQLOrder(sortField, filter.Order)+listSQLLimitOffset(filter.Limit, filter.Offset),
 		argsWhere...,
@@ -123,7 +125,7 @@ func (s *store) ListUsers(ctx context.Context, tx pgx.Tx, filter domain.UsersPag
 // GetUserByID executes a query to return the user with the specified identifier.
 func (s *store) GetUserByID(ctx context.Context, tx pgx.Tx, id uuid.UUID) (domain.User, error) {
 	row := tx.QueryRow(ctx, `
-		SELECT id, username, first_name, last_name, created_at, modified_at 
+		SELECT id, username, first_name, last_name, email, email_verified_at, created_at, modified_at 
 		FROM users 
 		WHERE id = $1 
 	`,
@@ -145,7 +147,7 @@ func (s *store) GetUserByID(ctx context.Context, tx pgx.Tx, id uuid.UUID) (domai
 // GetUserByUsername executes a query to return the user with the specified username.
 func (s *store) GetUserByUsername(ctx context.Context, tx pgx.Tx, username domain.Username) (domain.User, error) {
 	row := tx.QueryRow(ctx, `
-		SELECT id, username, first_name, last_name, created_at, modified_at 
+		SELECT id, username, first_name, last_name, email, email_verified_at, created_at, modified_at 
 		FROM users 
 		WHERE username = $1 
 	`,
@@ -196,13 +198,16 @@ func (s *store) PatchUser(ctx context.Context, tx pgx.Tx, id uuid.UUID, editable
 		UPDATE users SET
 			username = coalesce($2, username),
 			first_name = coalesce($3, first_name),
-			last_name = coalesce($4, last_name)
+			last_name = coalesce($4, last_name),
+			email = coalesce($5, email),
+			email_verified_at = CASE WHEN $5 IS NOT NULL AND $5 IS DISTINCT FROM email THEN NULL ELSE email_verified_at END
 		WHERE id = $1
 	`,
 		id,
 		editableUser.Username,
 		editableUser.FirstName,
 		editableUser.LastName,
+		editableUser.Email,
 	)
 	if err != nil {
 		if constraintNameFromError(err) == constraintUsersUsernameKey {
@@ -240,6 +245,48 @@ func (s *store) UpdateUserPassword(ctx context.Context, tx pgx.Tx, username doma
 	return nil
 }
 
+// UpdateUserPasswordHash executes a query to replace the hashed password of the user with the specified username,
+// as long as it still matches the specified old hash, so that a password changed in the meantime is not overwritten.
+func (s *store) UpdateUserPasswordHash(ctx context.Context, tx pgx.Tx, username domain.Username, oldHash, newHash domain.Password) error {
+	_, err := tx.Exec(ctx, `
+		UPDATE users SET
+			password = $3
+		WHERE username = $1 AND password = $2
+	`,
+		username,
+		oldHash,
+		newHash,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", descriptionFailedExec, err)
+	}
+
+	return nil
+}
+
+// VerifyUserEmail executes a query to mark the email address of the user with the specified identifier as verified, as
+// long as it matches the specified email address.
+func (s *store) VerifyUserEmail(ctx context.Context, tx pgx.Tx, id uuid.UUID, email domain.Email, verifiedAt time.Time) error {
+	commandTag, err := tx.Exec(ctx, `
+		UPDATE users SET
+			email_verified_at = $3
+		WHERE id = $1 AND email = $2
+	`,
+		id,
+		email,
+		verifiedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", descriptionFailedExec, err)
+	}
+
+	if commandTag.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", descriptionFailedExec, domain.ErrUserNotFound)
+	}
+
+	return nil
+}
+
 // DeleteUserByID executes a query to delete the user with the specified identifier.
 func (s *store) DeleteUserByID(ctx context.Context, tx pgx.Tx, id uuid.UUID) error {
 	commandTag, err := tx.Exec(ctx, `
@@ -267,6 +314,8 @@ func getUserFromRow(row pgx.Row) (domain.User, error) {
 		&user.Username,
 		&user.FirstName,
 		&user.LastName,
+		&user.Email,
+		&user.EmailVerifiedAt,
 		&user.CreatedAt,
 		&user.ModifiedAt,
 	)
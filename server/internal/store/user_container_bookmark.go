@@ -99,7 +99,7 @@ func (s *store) ListUserContainerBookmarks(ctx context.Context, tx pgx.Tx, userI
 
 	// Get rows for the given filter.
 	rows, err := tx.Query(ctx, `
-		SELECT c.id, c.category, ST_AsGeoJSON(c.geom)::jsonb, rn.osm_name, m.name, c.created_at, c.modified_at
+		SELECT c.id, c.category, c.volume, c.collection_start_time, c.collection_end_time, ST_AsGeoJSON(c.geom)::jsonb, rn.osm_name, m.name, c.created_at, c.modified_at
 		FROM users_container_bookmarks AS ucb
 		INNER JOIN users AS u ON ucb.user_id = u.id
 		INNER JOIN containers AS c ON ucb.container_id = c.id
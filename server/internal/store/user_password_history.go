@@ -0,0 +1,91 @@
+package store
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/goncalo-marques/ecomap/server/internal/domain"
+)
+
+// AddUserPasswordHistory executes a query to add the hashed password to the password history of the user with the
+// specified username, keeping only the specified amount of most recent passwords.
+func (s *store) AddUserPasswordHistory(ctx context.Context, tx pgx.Tx, username domain.Username, password domain.Password, keep int) error {
+	_, err := tx.Exec(ctx, `
+		INSERT INTO users_password_history (user_id, password)
+		SELECT id, $2
+		FROM users
+		WHERE username = $1
+	`,
+		username,
+		password,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", descriptionFailedExec, err)
+	}
+
+	_, err = tx.Exec(ctx, `
+		DELETE FROM users_password_history
+		WHERE user_id = (SELECT id FROM users WHERE username = $1) AND id NOT IN (
+			SELECT uph.id
+			FROM users_password_history AS uph
+			INNER JOIN users AS u ON u.id = uph.user_id
+			WHERE u.username = $1
+			ORDER BY uph.created_at DESC
+			LIMIT $2
+		)
+	`,
+		username,
+		keep,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", descriptionFailedExec, err)
+	}
+
+	return nil
+}
+
+// ListUserPasswordHistory executes a query to return the specified amount of most recent hashed passwords of the user
+// with the specified username, excluding the current one.
+func (s *store) ListUserPasswordHistory(ctx context.Context, tx pgx.Tx, username domain.Username, limit int) ([]domain.Password, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT uph.password
+		FROM users_password_history AS uph
+		INNER JOIN users AS u ON u.id = uph.user_id
+		WHERE u.username = $1
+		ORDER BY uph.created_at DESC
+		LIMIT $2
+	`,
+		username,
+		limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", descriptionFailedQuery, err)
+	}
+	defer rows.Close()
+
+	passwords, err := getPasswordsFromRows(rows)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", descriptionFailedScanRows, err)
+	}
+
+	return passwords, nil
+}
+
+// getPasswordsFromRows returns the hashed passwords by scanning the given rows.
+func getPasswordsFromRows(rows pgx.Rows) ([]domain.Password, error) {
+	var passwords []domain.Password
+	for rows.Next() {
+		var password domain.Password
+
+		err := rows.Scan(&password)
+		if err != nil {
+			return nil, err
+		}
+
+		passwords = append(passwords, password)
+	}
+
+	return passwords, nil
+}
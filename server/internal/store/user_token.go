@@ -0,0 +1,157 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/goncalo-marques/ecomap/server/internal/domain"
+)
+
+// CreateUserToken executes a query to create a user token with the specified data.
+func (s *store) CreateUserToken(ctx context.Context, tx pgx.Tx, editableUserToken domain.EditableUserToken) (uuid.UUID, error) {
+	row := tx.QueryRow(ctx, `
+		INSERT INTO users_tokens (user_id, purpose, hash, email, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id
+	`,
+		editableUserToken.UserID,
+		userTokenPurposeFromDomain(editableUserToken.Purpose),
+		editableUserToken.Hash,
+		editableUserToken.Email,
+		editableUserToken.ExpiresAt,
+	)
+
+	var id uuid.UUID
+
+	err := row.Scan(&id)
+	if err != nil {
+		return uuid.UUID{}, fmt.Errorf("%s: %w", descriptionFailedScanRow, err)
+	}
+
+	return id, nil
+}
+
+// CountUserTokens executes a query to return the amount of user tokens with the specified purpose that were created
+// for the user within the specified period.
+func (s *store) CountUserTokens(ctx context.Context, tx pgx.Tx, userID uuid.UUID, purpose domain.UserTokenPurpose, period time.Duration) (int, error) {
+	row := tx.QueryRow(ctx, `
+		SELECT count(id) 
+		FROM users_tokens 
+		WHERE user_id = $1 AND purpose = $2 AND created_at >= CURRENT_TIMESTAMP - make_interval(secs => $3)
+	`,
+		userID,
+		userTokenPurposeFromDomain(purpose),
+		period.Seconds(),
+	)
+
+	var count int
+
+	err := row.Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", descriptionFailedScanRow, err)
+	}
+
+	return count, nil
+}
+
+// GetUserTokenByHash executes a query to return the user token with the specified purpose and hash. The user token is
+// locked until the end of the transaction, so that it cannot be used concurrently.
+func (s *store) GetUserTokenByHash(ctx context.Context, tx pgx.Tx, purpose domain.UserTokenPurpose, hash []byte) (domain.UserToken, error) {
+	row := tx.QueryRow(ctx, `
+		SELECT id, user_id, purpose, hash, email, expires_at, used_at, created_at 
+		FROM users_tokens
+		WHERE purpose = $1 AND hash = $2
+		FOR UPDATE
+	`,
+		userTokenPurposeFromDomain(purpose),
+		hash,
+	)
+
+	var userToken domain.UserToken
+	var userTokenPurpose string
+
+	err := row.Scan(
+		&userToken.ID,
+		&userToken.UserID,
+		&userTokenPurpose,
+		&userToken.Hash,
+		&userToken.Email,
+		&userToken.ExpiresAt,
+		&userToken.UsedAt,
+		&userToken.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.UserToken{}, fmt.Errorf("%s: %w", descriptionFailedScanRow, domain.ErrUserTokenInvalid)
+		}
+
+		return domain.UserToken{}, fmt.Errorf("%s: %w", descriptionFailedScanRow, err)
+	}
+
+	userToken.Purpose = userTokenPurposeToDomain(userTokenPurpose)
+
+	return userToken, nil
+}
+
+// UseUserTokens executes a query to mark every unused user token with the specified purpose of the user as used, so
+// that the tokens sent previously cannot be used either.
+func (s *store) UseUserTokens(ctx context.Context, tx pgx.Tx, userID uuid.UUID, purpose domain.UserTokenPurpose, usedAt time.Time) error {
+	_, err := tx.Exec(ctx, `
+		UPDATE users_tokens SET
+			used_at = $3
+		WHERE user_id = $1 AND purpose = $2 AND used_at IS NULL
+	`,
+		userID,
+		userTokenPurposeFromDomain(purpose),
+		usedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", descriptionFailedExec, err)
+	}
+
+	return nil
+}
+
+// DeleteExpiredUserTokens executes a query to delete the user tokens that expired before the specified time.
+func (s *store) DeleteExpiredUserTokens(ctx context.Context, tx pgx.Tx, before time.Time) error {
+	_, err := tx.Exec(ctx, `
+		DELETE FROM users_tokens
+		WHERE expires_at < $1
+	`,
+		before,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", descriptionFailedExec, err)
+	}
+
+	return nil
+}
+
+// userTokenPurposeFromDomain returns a store user token purpose based on the domain model.
+func userTokenPurposeFromDomain(purpose domain.UserTokenPurpose) string {
+	switch purpose {
+	case domain.UserTokenPurposePasswordReset:
+		return "password_reset"
+	case domain.UserTokenPurposeEmailVerification:
+		return "email_verification"
+	default:
+		return string(purpose)
+	}
+}
+
+// userTokenPurposeToDomain returns a domain user token purpose based on the store model.
+func userTokenPurposeToDomain(purpose string) domain.UserTokenPurpose {
+	switch purpose {
+	case "password_reset":
+		return domain.UserTokenPurposePasswordReset
+	case "email_verification":
+		return domain.UserTokenPurposeEmailVerification
+	default:
+		return domain.UserTokenPurpose(purpose)
+	}
+}
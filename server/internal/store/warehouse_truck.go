@@ -17,7 +17,7 @@ const (
 // ListWarehouseTrucks executes a query to return the trucks associated with the warehouse with the specified identifier.
 func (s *store) ListWarehouseTrucks(ctx context.Context, tx pgx.Tx, id uuid.UUID) ([]domain.Truck, error) {
 	rows, err := tx.Query(ctx, `
-		SELECT t.id, t.make, t.model, t.license_plate, t.person_capacity, ST_AsGeoJSON(t.geom)::jsonb, rn.osm_name, m.name, t.created_at, t.modified_at 
+		SELECT t.id, t.make, t.model, t.license_plate, t.person_capacity, t.volume_capacity, t.weight_capacity, (SELECT coalesce(jsonb_agg(tc.categories ORDER BY tc.position), '[]') FROM trucks_compartments AS tc WHERE tc.truck_id = t.id), t.status, t.service_interval_distance, t.service_interval_period, (SELECT tor.value FROM trucks_odometer_readings AS tor WHERE tor.truck_id = t.id ORDER BY tor.recorded_at DESC LIMIT 1), t.fuel_type, t.fuel_consumption, t.license_category, ST_AsGeoJSON(t.geom)::jsonb, rn.osm_name, m.name, t.created_at, t.modified_at 
 		FROM warehouses_trucks AS wt
 		INNER JOIN trucks AS t ON wt.truck_id = t.id
 		LEFT JOIN road_network AS rn ON t.road_id = rn.id
@@ -0,0 +1,231 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/netip"
+
+	spec "github.com/goncalo-marques/ecomap/server/api/ecomap"
+	"github.com/goncalo-marques/ecomap/server/internal/authz"
+	"github.com/goncalo-marques/ecomap/server/internal/domain"
+	"github.com/goncalo-marques/ecomap/server/internal/logging"
+)
+
+const (
+	errAPIKeyNotFound = "api key not found"
+	errAPIKeyInvalid  = "invalid api key"
+)
+
+// CreateAPIKey handles the http request to create an API key.
+func (h *handler) CreateAPIKey(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		badRequest(w, errRequestBodyInvalid)
+		return
+	}
+
+	var apiKeyPost spec.APIKeyPost
+	err = json.Unmarshal(requestBody, &apiKeyPost)
+	if err != nil {
+		badRequest(w, errRequestBodyInvalid)
+		return
+	}
+
+	domainEditableAPIKey := apiKeyPostToDomain(apiKeyPost)
+	domainAPIKey, err := h.service.CreateAPIKey(ctx, domainEditableAPIKey, authz.Permissions(ctx))
+	if err != nil {
+		var domainErrFieldValueInvalid *domain.ErrFieldValueInvalid
+
+		switch {
+		case errors.As(err, &domainErrFieldValueInvalid):
+			badRequest(w, fmt.Sprintf("%s: %s", errFieldValueInvalid, domainErrFieldValueInvalid.FieldName))
+		case errors.Is(err, domain.ErrRoleNotGrantable):
+			forbidden(w, errRoleNotGrantable)
+		default:
+			internalServerError(w)
+		}
+
+		return
+	}
+
+	apiKey := apiKeyCreatedFromDomain(domainAPIKey)
+	responseBody, err := json.Marshal(apiKey)
+	if err != nil {
+		logging.Logger.ErrorContext(ctx, descriptionFailedToMarshalResponseBody, logging.Error(err))
+		internalServerError(w)
+		return
+	}
+
+	writeResponseJSON(w, http.StatusCreated, responseBody)
+}
+
+// ListAPIKeys handles the http request to list API keys.
+func (h *handler) ListAPIKeys(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	domainAPIKeys, err := h.service.ListAPIKeys(ctx)
+	if err != nil {
+		internalServerError(w)
+		return
+	}
+
+	apiKeys := apiKeysFromDomain(domainAPIKeys)
+	responseBody, err := json.Marshal(apiKeys)
+	if err != nil {
+		logging.Logger.ErrorContext(ctx, descriptionFailedToMarshalResponseBody, logging.Error(err))
+		internalServerError(w)
+		return
+	}
+
+	writeResponseJSON(w, http.StatusOK, responseBody)
+}
+
+// GetAPIKeyByID handles the http request to get an API key by ID.
+func (h *handler) GetAPIKeyByID(w http.ResponseWriter, r *http.Request, apiKeyID spec.APIKeyIdPathParam) {
+	ctx := r.Context()
+
+	domainAPIKey, err := h.service.GetAPIKeyByID(ctx, apiKeyID)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrAPIKeyNotFound):
+			notFound(w, errAPIKeyNotFound)
+		default:
+			internalServerError(w)
+		}
+
+		return
+	}
+
+	apiKey := apiKeyFromDomain(domainAPIKey)
+	responseBody, err := json.Marshal(apiKey)
+	if err != nil {
+		logging.Logger.ErrorContext(ctx, descriptionFailedToMarshalResponseBody, logging.Error(err))
+		internalServerError(w)
+		return
+	}
+
+	writeResponseJSON(w, http.StatusOK, responseBody)
+}
+
+// DeleteAPIKeyByID handles the http request to delete an API key by ID.
+func (h *handler) DeleteAPIKeyByID(w http.ResponseWriter, r *http.Request, apiKeyID spec.APIKeyIdPathParam) {
+	ctx := r.Context()
+
+	domainAPIKey, err := h.service.DeleteAPIKeyByID(ctx, apiKeyID)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrAPIKeyNotFound):
+			notFound(w, errAPIKeyNotFound)
+		default:
+			internalServerError(w)
+		}
+
+		return
+	}
+
+	apiKey := apiKeyFromDomain(domainAPIKey)
+	responseBody, err := json.Marshal(apiKey)
+	if err != nil {
+		logging.Logger.ErrorContext(ctx, descriptionFailedToMarshalResponseBody, logging.Error(err))
+		internalServerError(w)
+		return
+	}
+
+	writeResponseJSON(w, http.StatusOK, responseBody)
+}
+
+// apiKeyPostToDomain returns a domain editable API key based on the standardized API key post. Single IP addresses
+// are accepted as prefixes with all bits set, and invalid ones result in invalid prefixes.
+func apiKeyPostToDomain(apiKeyPost spec.APIKeyPost) domain.EditableAPIKey {
+	roles := make(domain.APIKeyRoles, len(apiKeyPost.Roles))
+	for i, role := range apiKeyPost.Roles {
+		roles[i] = domain.RoleName(role)
+	}
+
+	var allowedIPAddresses []netip.Prefix
+	if apiKeyPost.AllowedIpAddresses != nil {
+		allowedIPAddresses = make([]netip.Prefix, len(*apiKeyPost.AllowedIpAddresses))
+		for i, allowedIPAddress := range *apiKeyPost.AllowedIpAddresses {
+			allowedIPAddresses[i] = ipAddressPrefixToDomain(allowedIPAddress)
+		}
+	}
+
+	return domain.EditableAPIKey{
+		Name:               domain.Name(apiKeyPost.Name),
+		Roles:              roles,
+		AllowedIPAddresses: allowedIPAddresses,
+		ExpiresAt:          apiKeyPost.ExpiresAt,
+	}
+}
+
+// apiKeyFromDomain returns a standardized API key based on the domain model.
+func apiKeyFromDomain(apiKey domain.APIKey) spec.APIKey {
+	roles := make([]spec.RoleName, len(apiKey.Roles))
+	for i, role := range apiKey.Roles {
+		roles[i] = string(role)
+	}
+
+	allowedIPAddresses := make([]string, len(apiKey.AllowedIPAddresses))
+	for i, prefix := range apiKey.AllowedIPAddresses {
+		allowedIPAddresses[i] = prefix.String()
+	}
+
+	return spec.APIKey{
+		Id:                 apiKey.ID,
+		Name:               string(apiKey.Name),
+		Roles:              roles,
+		AllowedIpAddresses: allowedIPAddresses,
+		ExpiresAt:          apiKey.ExpiresAt,
+		LastUsedAt:         apiKey.LastUsedAt,
+		CreatedAt:          apiKey.CreatedAt,
+	}
+}
+
+// apiKeyCreatedFromDomain returns a standardized created API key based on the domain model.
+func apiKeyCreatedFromDomain(apiKey domain.APIKeyWithKey) spec.APIKeyCreated {
+	a := apiKeyFromDomain(apiKey.APIKey)
+
+	return spec.APIKeyCreated{
+		Id:                 a.Id,
+		Name:               a.Name,
+		Roles:              a.Roles,
+		AllowedIpAddresses: a.AllowedIpAddresses,
+		ExpiresAt:          a.ExpiresAt,
+		LastUsedAt:         a.LastUsedAt,
+		CreatedAt:          a.CreatedAt,
+		Key:                apiKey.Key,
+	}
+}
+
+// apiKeysFromDomain returns standardized API keys based on the domain model.
+func apiKeysFromDomain(apiKeys []domain.APIKey) spec.APIKeys {
+	a := make([]spec.APIKey, len(apiKeys))
+	for i, apiKey := range apiKeys {
+		a[i] = apiKeyFromDomain(apiKey)
+	}
+
+	return spec.APIKeys{
+		ApiKeys: a,
+	}
+}
+
+// ipAddressPrefixToDomain returns an IP address prefix based on the given IP address or prefix in CIDR notation. The
+// returned prefix is invalid if the value is neither.
+func ipAddressPrefixToDomain(value string) netip.Prefix {
+	prefix, err := netip.ParsePrefix(value)
+	if err == nil {
+		return prefix
+	}
+
+	addr, err := netip.ParseAddr(value)
+	if err != nil {
+		return netip.Prefix{}
+	}
+
+	return netip.PrefixFrom(addr, addr.BitLen())
+}
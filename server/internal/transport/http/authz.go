@@ -1,13 +1,12 @@
 package http
 
 import (
-	"github.com/goncalo-marques/ecomap/server/internal/authn"
 	"github.com/goncalo-marques/ecomap/server/internal/authz"
 	"github.com/goncalo-marques/ecomap/server/internal/domain"
 )
 
-// AuthzRoles defines the authorization roles for the API.
-var AuthzRoles authz.Roles = authz.Roles{
-	AuthzWildcards: []string{domain.FieldParamEmployeeID, domain.FieldParamUserID},
-	AdminRole:      string(authn.SubjectRoleManager),
+// AuthzPolicy defines the authorization policy for the API.
+var AuthzPolicy authz.Policy = authz.Policy{
+	AuthzWildcards:      []string{domain.FieldParamEmployeeID, domain.FieldParamUserID},
+	OwnPermissionSuffix: domain.PermissionOwnSuffix,
 }
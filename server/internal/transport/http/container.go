@@ -189,6 +189,8 @@ func (h *handler) PatchContainerByID(w http.ResponseWriter, r *http.Request, con
 			badRequest(w, fmt.Sprintf("%s: %s", errFieldValueInvalid, domainErrFieldValueInvalid.FieldName))
 		case errors.Is(err, domain.ErrContainerNotFound):
 			notFound(w, errContainerNotFound)
+		case errors.Is(err, domain.ErrRouteContainerCategoryIncompatible):
+			conflict(w, errRouteContainerCategoryIncompatible)
 		default:
 			internalServerError(w)
 		}
@@ -296,14 +298,27 @@ func containerCategoryFromDomain(category domain.ContainerCategory) spec.Contain
 
 // containerPostToDomain returns a domain editable container based on the standardized container post.
 func containerPostToDomain(containerPost spec.ContainerPost) (domain.EditableContainer, error) {
+	collectionStartTime, err := optionalTimeStringToTime(containerPost.CollectionStartTime, domain.FieldCollectionStartTime)
+	if err != nil {
+		return domain.EditableContainer{}, err
+	}
+
+	collectionEndTime, err := optionalTimeStringToTime(containerPost.CollectionEndTime, domain.FieldCollectionEndTime)
+	if err != nil {
+		return domain.EditableContainer{}, err
+	}
+
 	geoJSON, err := geoJSONFeaturePointToDomain(&containerPost.GeoJson)
 	if err != nil {
 		return domain.EditableContainer{}, err
 	}
 
 	return domain.EditableContainer{
-		Category: containerCategoryToDomain(containerPost.Category),
-		GeoJSON:  geoJSON,
+		Category:            containerCategoryToDomain(containerPost.Category),
+		Volume:              (*domain.ContainerVolume)(containerPost.Volume),
+		CollectionStartTime: collectionStartTime,
+		CollectionEndTime:   collectionEndTime,
+		GeoJSON:             geoJSON,
 	}, nil
 }
 
@@ -315,14 +330,27 @@ func containerPatchToDomain(containerPatch spec.ContainerPatch) (domain.Editable
 		category = &c
 	}
 
+	collectionStartTime, err := optionalTimeStringToTime(containerPatch.CollectionStartTime, domain.FieldCollectionStartTime)
+	if err != nil {
+		return domain.EditableContainerPatch{}, err
+	}
+
+	collectionEndTime, err := optionalTimeStringToTime(containerPatch.CollectionEndTime, domain.FieldCollectionEndTime)
+	if err != nil {
+		return domain.EditableContainerPatch{}, err
+	}
+
 	geoJSON, err := geoJSONFeaturePointToDomain(containerPatch.GeoJson)
 	if err != nil {
 		return domain.EditableContainerPatch{}, err
 	}
 
 	return domain.EditableContainerPatch{
-		Category: category,
-		GeoJSON:  geoJSON,
+		Category:            category,
+		Volume:              (*domain.ContainerVolume)(containerPatch.Volume),
+		CollectionStartTime: collectionStartTime,
+		CollectionEndTime:   collectionEndTime,
+		GeoJSON:             geoJSON,
 	}, nil
 }
 
@@ -372,11 +400,14 @@ func containerFromDomain(container domain.Container) (spec.Container, error) {
 	}
 
 	return spec.Container{
-		Id:         container.ID,
-		Category:   containerCategoryFromDomain(container.Category),
-		GeoJson:    geoJSON,
-		CreatedAt:  container.CreatedAt,
-		ModifiedAt: container.ModifiedAt,
+		Id:                  container.ID,
+		Category:            containerCategoryFromDomain(container.Category),
+		Volume:              (*float64)(container.Volume),
+		CollectionStartTime: optionalTimeStringFromTime(container.CollectionStartTime),
+		CollectionEndTime:   optionalTimeStringFromTime(container.CollectionEndTime),
+		GeoJson:             geoJSON,
+		CreatedAt:           container.CreatedAt,
+		ModifiedAt:          container.ModifiedAt,
 	}, nil
 }
 
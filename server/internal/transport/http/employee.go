@@ -9,6 +9,7 @@ import (
 	"time"
 
 	spec "github.com/goncalo-marques/ecomap/server/api/ecomap"
+	"github.com/goncalo-marques/ecomap/server/internal/authz"
 	"github.com/goncalo-marques/ecomap/server/internal/domain"
 	"github.com/goncalo-marques/ecomap/server/internal/logging"
 )
@@ -50,13 +51,18 @@ func (h *handler) CreateEmployee(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	domainEmployee, err := h.service.CreateEmployee(ctx, domainEditableEmployee)
+	domainEmployee, err := h.service.CreateEmployee(ctx, domainEditableEmployee, authz.Permissions(ctx))
 	if err != nil {
+		var domainErrPasswordPolicyViolated *domain.ErrPasswordPolicyViolated
 		var domainErrFieldValueInvalid *domain.ErrFieldValueInvalid
 
 		switch {
+		case errors.As(err, &domainErrPasswordPolicyViolated):
+			badRequest(w, fmt.Sprintf("%s: %s", errPasswordPolicyViolated, domainErrPasswordPolicyViolated.Rule))
 		case errors.As(err, &domainErrFieldValueInvalid):
 			badRequest(w, fmt.Sprintf("%s: %s", errFieldValueInvalid, domainErrFieldValueInvalid.FieldName))
+		case errors.Is(err, domain.ErrRoleNotGrantable):
+			forbidden(w, errRoleNotGrantable)
 		case errors.Is(err, domain.ErrEmployeeAlreadyExists):
 			conflict(w, errEmployeeAlreadyExists)
 		default:
@@ -119,6 +125,55 @@ func (h *handler) ListEmployees(w http.ResponseWriter, r *http.Request, params s
 	writeResponseJSON(w, http.StatusOK, responseBody)
 }
 
+// ListEmployeesAvailable handles the http request to list the employees available.
+func (h *handler) ListEmployeesAvailable(w http.ResponseWriter, r *http.Request, params spec.ListEmployeesAvailableParams) {
+	ctx := r.Context()
+
+	domainAvailabilityFilter, err := listEmployeesAvailableParamsToDomain(params)
+	if err != nil {
+		var domainErrFilterValueInvalid *domain.ErrFilterValueInvalid
+		if errors.As(err, &domainErrFilterValueInvalid) {
+			badRequest(w, fmt.Sprintf("%s: %s", errFilterValueInvalid, domainErrFilterValueInvalid.FilterName))
+			return
+		}
+
+		internalServerError(w)
+		return
+	}
+
+	domainEmployees, err := h.service.ListEmployeesAvailable(ctx, domainAvailabilityFilter)
+	if err != nil {
+		var domainErrFilterValueInvalid *domain.ErrFilterValueInvalid
+
+		switch {
+		case errors.As(err, &domainErrFilterValueInvalid):
+			badRequest(w, fmt.Sprintf("%s: %s", errFilterValueInvalid, domainErrFilterValueInvalid.FilterName))
+		default:
+			internalServerError(w)
+		}
+
+		return
+	}
+
+	employees, err := employeesFromDomain(domainEmployees)
+	if err != nil {
+		logging.Logger.ErrorContext(ctx, descriptionFailedToMapResponseBody, logging.Error(err))
+		internalServerError(w)
+		return
+	}
+
+	responseBody, err := json.Marshal(spec.EmployeesAvailable{
+		Employees: employees,
+	})
+	if err != nil {
+		logging.Logger.ErrorContext(ctx, descriptionFailedToMarshalResponseBody, logging.Error(err))
+		internalServerError(w)
+		return
+	}
+
+	writeResponseJSON(w, http.StatusOK, responseBody)
+}
+
 // GetEmployeeByID handles the http request to get an employee by id.
 func (h *handler) GetEmployeeByID(w http.ResponseWriter, r *http.Request, employeeID spec.EmployeeIdPathParam) {
 	ctx := r.Context()
@@ -183,7 +238,7 @@ func (h *handler) PatchEmployeeByID(w http.ResponseWriter, r *http.Request, empl
 		return
 	}
 
-	domainEmployee, err := h.service.PatchEmployee(ctx, employeeID, domainEditableEmployee)
+	domainEmployee, err := h.service.PatchEmployee(ctx, employeeID, domainEditableEmployee, authz.Permissions(ctx))
 	if err != nil {
 		var domainErrFieldValueInvalid *domain.ErrFieldValueInvalid
 
@@ -192,6 +247,8 @@ func (h *handler) PatchEmployeeByID(w http.ResponseWriter, r *http.Request, empl
 			badRequest(w, fmt.Sprintf("%s: %s", errFieldValueInvalid, domainErrFieldValueInvalid.FieldName))
 		case errors.Is(err, domain.ErrEmployeeNotFound):
 			notFound(w, errEmployeeNotFound)
+		case errors.Is(err, domain.ErrRoleNotGrantable):
+			forbidden(w, errRoleNotGrantable)
 		case errors.Is(err, domain.ErrEmployeeAlreadyExists):
 			conflict(w, errEmployeeAlreadyExists)
 		default:
@@ -270,15 +327,26 @@ func (h *handler) UpdateEmployeePassword(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	err = h.service.UpdateEmployeePassword(ctx, domain.Username(passwordChange.Username), domain.Password(passwordChange.OldPassword), domain.Password(passwordChange.NewPassword))
+	err = h.service.UpdateEmployeePassword(ctx, domain.Username(passwordChange.Username), domain.Password(passwordChange.OldPassword), domain.Password(passwordChange.NewPassword), h.clientIPAddress(r))
 	if err != nil {
+		var domainErrPasswordPolicyViolated *domain.ErrPasswordPolicyViolated
 		var domainErrFieldValueInvalid *domain.ErrFieldValueInvalid
+		var domainErrSignInLocked *domain.ErrSignInLocked
+		var domainErrSignInThrottled *domain.ErrSignInThrottled
 
 		switch {
+		case errors.As(err, &domainErrPasswordPolicyViolated):
+			badRequest(w, fmt.Sprintf("%s: %s", errPasswordPolicyViolated, domainErrPasswordPolicyViolated.Rule))
 		case errors.As(err, &domainErrFieldValueInvalid):
 			badRequest(w, fmt.Sprintf("%s: %s", errFieldValueInvalid, domainErrFieldValueInvalid.FieldName))
 		case errors.Is(err, domain.ErrCredentialsIncorrect):
 			unauthorized(w, errCredentialsIncorrect)
+		case errors.As(err, &domainErrSignInLocked):
+			setHeaderRetryAfter(w, domainErrSignInLocked.LockedUntil)
+			locked(w, errSignInLocked)
+		case errors.As(err, &domainErrSignInThrottled):
+			setHeaderRetryAfter(w, domainErrSignInThrottled.RetryAt)
+			tooManyRequests(w, errSignInThrottled)
 		default:
 			internalServerError(w)
 		}
@@ -308,9 +376,12 @@ func (h *handler) ResetEmployeePassword(w http.ResponseWriter, r *http.Request)
 
 	err = h.service.ResetEmployeePassword(ctx, domain.Username(passwordReset.Username), domain.Password(passwordReset.NewPassword))
 	if err != nil {
+		var domainErrPasswordPolicyViolated *domain.ErrPasswordPolicyViolated
 		var domainErrFieldValueInvalid *domain.ErrFieldValueInvalid
 
 		switch {
+		case errors.As(err, &domainErrPasswordPolicyViolated):
+			badRequest(w, fmt.Sprintf("%s: %s", errPasswordPolicyViolated, domainErrPasswordPolicyViolated.Rule))
 		case errors.As(err, &domainErrFieldValueInvalid):
 			badRequest(w, fmt.Sprintf("%s: %s", errFieldValueInvalid, domainErrFieldValueInvalid.FieldName))
 		case errors.Is(err, domain.ErrEmployeeNotFound):
@@ -342,11 +413,22 @@ func (h *handler) SignInEmployee(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	token, err := h.service.SignInEmployee(ctx, domain.Username(signIn.Username), domain.Password(signIn.Password))
+	result, err := h.service.SignInEmployee(ctx, domain.Username(signIn.Username), domain.Password(signIn.Password), h.clientIPAddress(r))
 	if err != nil {
+		var domainErrSignInLocked *domain.ErrSignInLocked
+		var domainErrSignInThrottled *domain.ErrSignInThrottled
+
 		switch {
 		case errors.Is(err, domain.ErrCredentialsIncorrect):
 			unauthorized(w, errCredentialsIncorrect)
+		case errors.Is(err, domain.ErrPasswordExpired):
+			forbidden(w, errPasswordExpired)
+		case errors.As(err, &domainErrSignInLocked):
+			setHeaderRetryAfter(w, domainErrSignInLocked.LockedUntil)
+			locked(w, errSignInLocked)
+		case errors.As(err, &domainErrSignInThrottled):
+			setHeaderRetryAfter(w, domainErrSignInThrottled.RetryAt)
+			tooManyRequests(w, errSignInThrottled)
 		default:
 			internalServerError(w)
 		}
@@ -354,7 +436,20 @@ func (h *handler) SignInEmployee(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	jwt := jwtFromJWTToken(token)
+	if result.MFAChallenge != nil {
+		mfaChallenge := mfaChallengeFromDomain(*result.MFAChallenge)
+		responseBody, err := json.Marshal(mfaChallenge)
+		if err != nil {
+			logging.Logger.ErrorContext(ctx, descriptionFailedToMarshalResponseBody, logging.Error(err))
+			internalServerError(w)
+			return
+		}
+
+		writeResponseJSON(w, http.StatusAccepted, responseBody)
+		return
+	}
+
+	jwt := jwtFromDomain(result.Tokens)
 	responseBody, err := json.Marshal(jwt)
 	if err != nil {
 		logging.Logger.ErrorContext(ctx, descriptionFailedToMarshalResponseBody, logging.Error(err))
@@ -365,28 +460,135 @@ func (h *handler) SignInEmployee(w http.ResponseWriter, r *http.Request) {
 	writeResponseJSON(w, http.StatusOK, responseBody)
 }
 
+// SignOutEmployee handles the http request to sign out an employee.
+func (h *handler) SignOutEmployee(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		badRequest(w, errRequestBodyInvalid)
+		return
+	}
+
+	var refreshTokenPost spec.RefreshTokenPost
+	err = json.Unmarshal(requestBody, &refreshTokenPost)
+	if err != nil {
+		badRequest(w, errRequestBodyInvalid)
+		return
+	}
+
+	err = h.service.SignOutEmployee(ctx, refreshTokenPost.RefreshToken)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrRefreshTokenInvalid):
+			unauthorized(w, errRefreshTokenInvalid)
+		default:
+			internalServerError(w)
+		}
+
+		return
+	}
+
+	writeResponseJSON(w, http.StatusNoContent, nil)
+}
+
 // employeeRoleToDomain returns a domain employee role based on the standardized model.
 func employeeRoleToDomain(role spec.EmployeeRole) domain.EmployeeRole {
-	switch role {
-	case spec.WasteOperator:
-		return domain.EmployeeRoleWasteOperator
-	case spec.Manager:
-		return domain.EmployeeRoleManager
-	default:
-		return domain.EmployeeRole(role)
-	}
+	return domain.EmployeeRole(role)
 }
 
 // employeeRoleFromDomain returns a standardized employee role based on the domain model.
 func employeeRoleFromDomain(role domain.EmployeeRole) spec.EmployeeRole {
-	switch role {
-	case domain.EmployeeRoleWasteOperator:
-		return spec.WasteOperator
-	case domain.EmployeeRoleManager:
-		return spec.Manager
+	return spec.EmployeeRole(role)
+}
+
+// employeeShiftWeekdayToDomain returns a domain weekday based on the standardized employee shift weekday.
+func employeeShiftWeekdayToDomain(weekday spec.EmployeeShiftWeekday) (time.Weekday, bool) {
+	switch weekday {
+	case spec.Sunday:
+		return time.Sunday, true
+	case spec.Monday:
+		return time.Monday, true
+	case spec.Tuesday:
+		return time.Tuesday, true
+	case spec.Wednesday:
+		return time.Wednesday, true
+	case spec.Thursday:
+		return time.Thursday, true
+	case spec.Friday:
+		return time.Friday, true
+	case spec.Saturday:
+		return time.Saturday, true
+	default:
+		return 0, false
+	}
+}
+
+// employeeShiftWeekdayFromDomain returns a standardized employee shift weekday based on the domain model.
+func employeeShiftWeekdayFromDomain(weekday time.Weekday) spec.EmployeeShiftWeekday {
+	switch weekday {
+	case time.Sunday:
+		return spec.Sunday
+	case time.Monday:
+		return spec.Monday
+	case time.Tuesday:
+		return spec.Tuesday
+	case time.Wednesday:
+		return spec.Wednesday
+	case time.Thursday:
+		return spec.Thursday
+	case time.Friday:
+		return spec.Friday
+	case time.Saturday:
+		return spec.Saturday
 	default:
-		return spec.EmployeeRole(role)
+		return spec.EmployeeShiftWeekday(weekday.String())
+	}
+}
+
+// employeeShiftsToDomain returns domain employee shifts based on the standardized employee shifts.
+func employeeShiftsToDomain(shifts spec.EmployeeShifts) (domain.EmployeeShifts, error) {
+	domainShifts := make(domain.EmployeeShifts, len(shifts))
+
+	for i, shift := range shifts {
+		weekday, ok := employeeShiftWeekdayToDomain(shift.Weekday)
+		if !ok {
+			return nil, &domain.ErrFieldValueInvalid{FieldName: domain.FieldShifts}
+		}
+
+		startTime, err := timeStringToTime(shift.StartTime)
+		if err != nil {
+			return nil, &domain.ErrFieldValueInvalid{FieldName: domain.FieldShifts}
+		}
+
+		endTime, err := timeStringToTime(shift.EndTime)
+		if err != nil {
+			return nil, &domain.ErrFieldValueInvalid{FieldName: domain.FieldShifts}
+		}
+
+		domainShifts[i] = domain.EmployeeShift{
+			Weekday:   weekday,
+			StartTime: startTime,
+			EndTime:   endTime,
+		}
 	}
+
+	return domainShifts, nil
+}
+
+// employeeShiftsFromDomain returns standardized employee shifts based on the domain model.
+func employeeShiftsFromDomain(shifts domain.EmployeeShifts) spec.EmployeeShifts {
+	specShifts := make(spec.EmployeeShifts, len(shifts))
+
+	for i, shift := range shifts {
+		specShifts[i] = spec.EmployeeShift{
+			Weekday:   employeeShiftWeekdayFromDomain(shift.Weekday),
+			StartTime: timeStringFromTime(shift.StartTime),
+			EndTime:   timeStringFromTime(shift.EndTime),
+		}
+	}
+
+	return specShifts
 }
 
 // employeePostToDomain returns a domain editable employee with password based on the standardized employee post.
@@ -406,6 +608,14 @@ func employeePostToDomain(employeePost spec.EmployeePost) (domain.EditableEmploy
 		return domain.EditableEmployeeWithPassword{}, &domain.ErrFieldValueInvalid{FieldName: domain.FieldScheduleEnd}
 	}
 
+	var shifts domain.EmployeeShifts
+	if employeePost.Shifts != nil {
+		shifts, err = employeeShiftsToDomain(*employeePost.Shifts)
+		if err != nil {
+			return domain.EditableEmployeeWithPassword{}, err
+		}
+	}
+
 	return domain.EditableEmployeeWithPassword{
 		EditableEmployee: domain.EditableEmployee{
 			Username:      domain.Username(employeePost.Username),
@@ -417,6 +627,7 @@ func employeePostToDomain(employeePost spec.EmployeePost) (domain.EditableEmploy
 			GeoJSON:       geoJSON,
 			ScheduleStart: scheduleStart,
 			ScheduleEnd:   scheduleEnd,
+			Shifts:        shifts,
 		},
 		Password: domain.Password(employeePost.Password),
 	}, nil
@@ -454,15 +665,33 @@ func employeePatchToDomain(employeePatch spec.EmployeePatch) (domain.EditableEmp
 		scheduleEnd = &schedule
 	}
 
+	var shifts *domain.EmployeeShifts
+	if employeePatch.Shifts != nil {
+		domainShifts, err := employeeShiftsToDomain(*employeePatch.Shifts)
+		if err != nil {
+			return domain.EditableEmployeePatch{}, err
+		}
+
+		shifts = &domainShifts
+	}
+
+	var role *domain.EmployeeRole
+	if employeePatch.Role != nil {
+		domainRole := employeeRoleToDomain(*employeePatch.Role)
+		role = &domainRole
+	}
+
 	return domain.EditableEmployeePatch{
 		Username:      (*domain.Username)(employeePatch.Username),
 		FirstName:     (*domain.Name)(employeePatch.FirstName),
 		LastName:      (*domain.Name)(employeePatch.LastName),
+		Role:          role,
 		DateOfBirth:   dateOfBirth,
 		PhoneNumber:   (*domain.PhoneNumber)(employeePatch.PhoneNumber),
 		GeoJSON:       geoJSON,
 		ScheduleStart: scheduleStart,
 		ScheduleEnd:   scheduleEnd,
+		Shifts:        shifts,
 	}, nil
 }
 
@@ -529,6 +758,33 @@ func listEmployeesParamsToDomain(params spec.ListEmployeesParams) domain.Employe
 	}
 }
 
+// listEmployeesAvailableParamsToDomain returns a domain employees availability filter based on the standardized list
+// employees available parameters.
+func listEmployeesAvailableParamsToDomain(params spec.ListEmployeesAvailableParams) (domain.EmployeesAvailabilityFilter, error) {
+	startTime, err := timeStringToTime(params.StartTime)
+	if err != nil {
+		return domain.EmployeesAvailabilityFilter{}, &domain.ErrFilterValueInvalid{FilterName: domain.FieldFilterStartTime}
+	}
+
+	endTime, err := timeStringToTime(params.EndTime)
+	if err != nil {
+		return domain.EmployeesAvailabilityFilter{}, &domain.ErrFilterValueInvalid{FilterName: domain.FieldFilterEndTime}
+	}
+
+	var domainRole *domain.EmployeeRole
+	if params.Role != nil {
+		role := employeeRoleToDomain(*params.Role)
+		domainRole = &role
+	}
+
+	return domain.EmployeesAvailabilityFilter{
+		Date:      params.Date.Time,
+		StartTime: startTime,
+		EndTime:   endTime,
+		Role:      domainRole,
+	}, nil
+}
+
 // employeeFromDomain returns a standardized employee based on the domain model.
 func employeeFromDomain(employee domain.Employee) (spec.Employee, error) {
 	geoJSON, err := geoJSONFeaturePointFromDomain(employee.GeoJSON)
@@ -547,6 +803,7 @@ func employeeFromDomain(employee domain.Employee) (spec.Employee, error) {
 		GeoJson:       geoJSON,
 		ScheduleStart: timeStringFromTime(employee.ScheduleStart),
 		ScheduleEnd:   timeStringFromTime(employee.ScheduleEnd),
+		Shifts:        employeeShiftsFromDomain(employee.Shifts),
 		CreatedAt:     employee.CreatedAt,
 		ModifiedAt:    employee.ModifiedAt,
 	}, nil
@@ -0,0 +1,239 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	spec "github.com/goncalo-marques/ecomap/server/api/ecomap"
+	"github.com/goncalo-marques/ecomap/server/internal/domain"
+	"github.com/goncalo-marques/ecomap/server/internal/logging"
+)
+
+const (
+	errEmployeeAbsenceNotFound = "employee absence not found"
+	errEmployeeAbsenceOverlap  = "employee absence overlaps another absence"
+)
+
+// CreateEmployeeAbsence handles the http request to create an employee absence.
+func (h *handler) CreateEmployeeAbsence(w http.ResponseWriter, r *http.Request, employeeID spec.EmployeeIdPathParam) {
+	ctx := r.Context()
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		badRequest(w, errRequestBodyInvalid)
+		return
+	}
+
+	var absencePost spec.EmployeeAbsencePost
+	err = json.Unmarshal(requestBody, &absencePost)
+	if err != nil {
+		badRequest(w, errRequestBodyInvalid)
+		return
+	}
+
+	domainEditableAbsence := employeeAbsencePostToDomain(absencePost)
+	domainAbsence, err := h.service.CreateEmployeeAbsence(ctx, employeeID, domainEditableAbsence)
+	if err != nil {
+		var domainErrFieldValueInvalid *domain.ErrFieldValueInvalid
+
+		switch {
+		case errors.As(err, &domainErrFieldValueInvalid):
+			badRequest(w, fmt.Sprintf("%s: %s", errFieldValueInvalid, domainErrFieldValueInvalid.FieldName))
+		case errors.Is(err, domain.ErrEmployeeNotFound):
+			notFound(w, errEmployeeNotFound)
+		case errors.Is(err, domain.ErrEmployeeAbsenceOverlap):
+			conflict(w, errEmployeeAbsenceOverlap)
+		default:
+			internalServerError(w)
+		}
+
+		return
+	}
+
+	absence := employeeAbsenceFromDomain(domainAbsence)
+
+	responseBody, err := json.Marshal(absence)
+	if err != nil {
+		logging.Logger.ErrorContext(ctx, descriptionFailedToMarshalResponseBody, logging.Error(err))
+		internalServerError(w)
+		return
+	}
+
+	writeResponseJSON(w, http.StatusCreated, responseBody)
+}
+
+// ListEmployeeAbsences handles the http request to list employee absences.
+func (h *handler) ListEmployeeAbsences(w http.ResponseWriter, r *http.Request, employeeID spec.EmployeeIdPathParam, params spec.ListEmployeeAbsencesParams) {
+	ctx := r.Context()
+
+	domainAbsencesFilter := listEmployeeAbsencesParamsToDomain(params)
+	domainPaginatedAbsences, err := h.service.ListEmployeeAbsences(ctx, employeeID, domainAbsencesFilter)
+	if err != nil {
+		var domainErrFilterValueInvalid *domain.ErrFilterValueInvalid
+
+		switch {
+		case errors.As(err, &domainErrFilterValueInvalid):
+			badRequest(w, fmt.Sprintf("%s: %s", errFilterValueInvalid, domainErrFilterValueInvalid.FilterName))
+		case errors.Is(err, domain.ErrEmployeeNotFound):
+			notFound(w, errEmployeeNotFound)
+		default:
+			internalServerError(w)
+		}
+
+		return
+	}
+
+	absencesPaginated := employeeAbsencesPaginatedFromDomain(domainPaginatedAbsences)
+
+	responseBody, err := json.Marshal(absencesPaginated)
+	if err != nil {
+		logging.Logger.ErrorContext(ctx, descriptionFailedToMarshalResponseBody, logging.Error(err))
+		internalServerError(w)
+		return
+	}
+
+	writeResponseJSON(w, http.StatusOK, responseBody)
+}
+
+// DeleteEmployeeAbsenceByID handles the http request to delete an employee absence by ID.
+func (h *handler) DeleteEmployeeAbsenceByID(w http.ResponseWriter, r *http.Request, employeeID spec.EmployeeIdPathParam, absenceID spec.EmployeeAbsenceIdPathParam) {
+	ctx := r.Context()
+
+	domainAbsence, err := h.service.DeleteEmployeeAbsenceByID(ctx, employeeID, absenceID)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrEmployeeAbsenceNotFound):
+			notFound(w, errEmployeeAbsenceNotFound)
+		default:
+			internalServerError(w)
+		}
+
+		return
+	}
+
+	absence := employeeAbsenceFromDomain(domainAbsence)
+
+	responseBody, err := json.Marshal(absence)
+	if err != nil {
+		logging.Logger.ErrorContext(ctx, descriptionFailedToMarshalResponseBody, logging.Error(err))
+		internalServerError(w)
+		return
+	}
+
+	writeResponseJSON(w, http.StatusOK, responseBody)
+}
+
+// employeeAbsenceTypeToDomain returns a domain employee absence type based on the standardized model.
+func employeeAbsenceTypeToDomain(absenceType spec.EmployeeAbsenceType) domain.EmployeeAbsenceType {
+	switch absenceType {
+	case spec.EmployeeAbsenceTypeVacation:
+		return domain.EmployeeAbsenceTypeVacation
+	case spec.EmployeeAbsenceTypeSickLeave:
+		return domain.EmployeeAbsenceTypeSickLeave
+	case spec.EmployeeAbsenceTypeTraining:
+		return domain.EmployeeAbsenceTypeTraining
+	case spec.EmployeeAbsenceTypeOther:
+		return domain.EmployeeAbsenceTypeOther
+	default:
+		return domain.EmployeeAbsenceType(absenceType)
+	}
+}
+
+// employeeAbsenceTypeFromDomain returns a standardized employee absence type based on the domain model.
+func employeeAbsenceTypeFromDomain(absenceType domain.EmployeeAbsenceType) spec.EmployeeAbsenceType {
+	switch absenceType {
+	case domain.EmployeeAbsenceTypeVacation:
+		return spec.EmployeeAbsenceTypeVacation
+	case domain.EmployeeAbsenceTypeSickLeave:
+		return spec.EmployeeAbsenceTypeSickLeave
+	case domain.EmployeeAbsenceTypeTraining:
+		return spec.EmployeeAbsenceTypeTraining
+	case domain.EmployeeAbsenceTypeOther:
+		return spec.EmployeeAbsenceTypeOther
+	default:
+		return spec.EmployeeAbsenceType(absenceType)
+	}
+}
+
+// employeeAbsencePostToDomain returns a domain editable employee absence based on the standardized employee absence
+// post.
+func employeeAbsencePostToDomain(absencePost spec.EmployeeAbsencePost) domain.EditableEmployeeAbsence {
+	return domain.EditableEmployeeAbsence{
+		Type:      employeeAbsenceTypeToDomain(absencePost.Type),
+		StartDate: absencePost.StartDate.Time,
+		EndDate:   absencePost.EndDate.Time,
+		Notes:     (*domain.EmployeeAbsenceNotes)(absencePost.Notes),
+	}
+}
+
+// listEmployeeAbsencesParamsToDomain returns a domain employee absences paginated filter based on the standardized
+// list employee absences parameters. The absences are sorted by their start date in descending order by default.
+func listEmployeeAbsencesParamsToDomain(params spec.ListEmployeeAbsencesParams) domain.EmployeeAbsencesPaginatedFilter {
+	domainSort := domain.EmployeeAbsencePaginatedSortStartDate
+	if params.Sort != nil {
+		switch *params.Sort {
+		case spec.ListEmployeeAbsencesParamsSortType:
+			domainSort = domain.EmployeeAbsencePaginatedSortType
+		case spec.ListEmployeeAbsencesParamsSortStartDate:
+			domainSort = domain.EmployeeAbsencePaginatedSortStartDate
+		case spec.ListEmployeeAbsencesParamsSortEndDate:
+			domainSort = domain.EmployeeAbsencePaginatedSortEndDate
+		case spec.ListEmployeeAbsencesParamsSortCreatedAt:
+			domainSort = domain.EmployeeAbsencePaginatedSortCreatedAt
+		default:
+			domainSort = domain.EmployeeAbsencePaginatedSort(*params.Sort)
+		}
+	}
+
+	order := spec.OrderQueryParamDesc
+	if params.Order != nil {
+		order = spec.OrderQueryParam(*params.Order)
+	}
+
+	var absenceType *domain.EmployeeAbsenceType
+	if params.Type != nil {
+		t := employeeAbsenceTypeToDomain(*params.Type)
+		absenceType = &t
+	}
+
+	return domain.EmployeeAbsencesPaginatedFilter{
+		PaginatedRequest: paginatedRequestToDomain(
+			domainSort,
+			&order,
+			params.Limit,
+			params.Offset,
+		),
+		Type: absenceType,
+	}
+}
+
+// employeeAbsenceFromDomain returns a standardized employee absence based on the domain model.
+func employeeAbsenceFromDomain(absence domain.EmployeeAbsence) spec.EmployeeAbsence {
+	return spec.EmployeeAbsence{
+		Id:         absence.ID,
+		EmployeeId: absence.EmployeeID,
+		Type:       employeeAbsenceTypeFromDomain(absence.Type),
+		StartDate:  dateFromTime(absence.StartDate),
+		EndDate:    dateFromTime(absence.EndDate),
+		Notes:      (*string)(absence.Notes),
+		CreatedAt:  absence.CreatedAt,
+		ModifiedAt: absence.ModifiedAt,
+	}
+}
+
+// employeeAbsencesPaginatedFromDomain returns a standardized employee absences paginated response based on the domain
+// paginated response model.
+func employeeAbsencesPaginatedFromDomain(paginatedResponse domain.PaginatedResponse[domain.EmployeeAbsence]) spec.EmployeeAbsencesPaginated {
+	absences := make([]spec.EmployeeAbsence, len(paginatedResponse.Results))
+	for i, absence := range paginatedResponse.Results {
+		absences[i] = employeeAbsenceFromDomain(absence)
+	}
+
+	return spec.EmployeeAbsencesPaginated{
+		Total:    paginatedResponse.Total,
+		Absences: absences,
+	}
+}
@@ -0,0 +1,110 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	spec "github.com/goncalo-marques/ecomap/server/api/ecomap"
+	"github.com/goncalo-marques/ecomap/server/internal/domain"
+	"github.com/goncalo-marques/ecomap/server/internal/logging"
+)
+
+// GetEmployeeAgenda handles the http request to get an employee agenda.
+func (h *handler) GetEmployeeAgenda(w http.ResponseWriter, r *http.Request, employeeID spec.EmployeeIdPathParam, params spec.GetEmployeeAgendaParams) {
+	ctx := r.Context()
+
+	date := time.Now().UTC()
+	if params.Date != nil {
+		date = params.Date.Time
+	}
+
+	domainAgenda, err := h.service.GetEmployeeAgenda(ctx, employeeID, date)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrEmployeeNotFound):
+			notFound(w, errEmployeeNotFound)
+		case errors.Is(err, domain.ErrRouteLandfillNotFound):
+			conflict(w, errRouteLandfillNotFound)
+		case errors.Is(err, domain.ErrRouteStopUnreachable):
+			conflict(w, errRouteStopUnreachable)
+		case errors.Is(err, domain.ErrRoutePayloadCapacityExceeded):
+			conflict(w, errRoutePayloadCapacityExceeded)
+		default:
+			internalServerError(w)
+		}
+
+		return
+	}
+
+	agenda, err := employeeAgendaFromDomain(domainAgenda)
+	if err != nil {
+		logging.Logger.ErrorContext(ctx, descriptionFailedToMapResponseBody, logging.Error(err))
+		internalServerError(w)
+		return
+	}
+
+	responseBody, err := json.Marshal(agenda)
+	if err != nil {
+		logging.Logger.ErrorContext(ctx, descriptionFailedToMarshalResponseBody, logging.Error(err))
+		internalServerError(w)
+		return
+	}
+
+	writeResponseJSON(w, http.StatusOK, responseBody)
+}
+
+// employeeAgendaRouteFromDomain returns a standardized employee agenda route based on the domain model.
+func employeeAgendaRouteFromDomain(agendaRoute domain.EmployeeAgendaRoute) (spec.EmployeeAgendaRoute, error) {
+	route, err := routeFromDomain(agendaRoute.Route)
+	if err != nil {
+		return spec.EmployeeAgendaRoute{}, err
+	}
+
+	stops, err := routeStopsFromDomain(agendaRoute.Stops)
+	if err != nil {
+		return spec.EmployeeAgendaRoute{}, err
+	}
+
+	roads, err := geoJSONFeatureCollectionLineStringFromDomain(agendaRoute.Roads)
+	if err != nil {
+		return spec.EmployeeAgendaRoute{}, err
+	}
+
+	return spec.EmployeeAgendaRoute{
+		Id:                 route.Id,
+		Name:               route.Name,
+		Truck:              route.Truck,
+		DepartureWarehouse: route.DepartureWarehouse,
+		ArrivalWarehouse:   route.ArrivalWarehouse,
+		DepartureTime:      route.DepartureTime,
+		StartedAt:          route.StartedAt,
+		FinishedAt:         route.FinishedAt,
+		CreatedAt:          route.CreatedAt,
+		ModifiedAt:         route.ModifiedAt,
+		RouteRole:          routeEmployeeRoleFromDomain(agendaRoute.RouteRole),
+		DepartureAt:        agendaRoute.DepartureAt,
+		Stops:              stops.Stops,
+		Roads:              roads,
+	}, nil
+}
+
+// employeeAgendaFromDomain returns a standardized employee agenda based on the domain model.
+func employeeAgendaFromDomain(agenda domain.EmployeeAgenda) (spec.EmployeeAgenda, error) {
+	routes := make([]spec.EmployeeAgendaRoute, len(agenda.Routes))
+	var err error
+
+	for i, route := range agenda.Routes {
+		routes[i], err = employeeAgendaRouteFromDomain(route)
+		if err != nil {
+			return spec.EmployeeAgenda{}, err
+		}
+	}
+
+	return spec.EmployeeAgenda{
+		Date:   dateFromTime(agenda.Date),
+		Absent: agenda.Absent,
+		Routes: routes,
+	}, nil
+}
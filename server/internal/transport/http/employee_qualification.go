@@ -0,0 +1,313 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	spec "github.com/goncalo-marques/ecomap/server/api/ecomap"
+	"github.com/goncalo-marques/ecomap/server/internal/domain"
+	"github.com/goncalo-marques/ecomap/server/internal/logging"
+)
+
+const (
+	errEmployeeQualificationNotFound      = "employee qualification not found"
+	errEmployeeQualificationAlreadyExists = "employee already holds a qualification of the same type and license category"
+)
+
+// Employees qualifications expiring default values.
+const (
+	employeesQualificationsExpiringWithinDaysDefaultValue = 30
+)
+
+// CreateEmployeeQualification handles the http request to create an employee qualification.
+func (h *handler) CreateEmployeeQualification(w http.ResponseWriter, r *http.Request, employeeID spec.EmployeeIdPathParam) {
+	ctx := r.Context()
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		badRequest(w, errRequestBodyInvalid)
+		return
+	}
+
+	var qualificationPost spec.EmployeeQualificationPost
+	err = json.Unmarshal(requestBody, &qualificationPost)
+	if err != nil {
+		badRequest(w, errRequestBodyInvalid)
+		return
+	}
+
+	domainEditableQualification := employeeQualificationPostToDomain(qualificationPost)
+	domainQualification, err := h.service.CreateEmployeeQualification(ctx, employeeID, domainEditableQualification)
+	if err != nil {
+		var domainErrFieldValueInvalid *domain.ErrFieldValueInvalid
+
+		switch {
+		case errors.As(err, &domainErrFieldValueInvalid):
+			badRequest(w, fmt.Sprintf("%s: %s", errFieldValueInvalid, domainErrFieldValueInvalid.FieldName))
+		case errors.Is(err, domain.ErrEmployeeNotFound):
+			notFound(w, errEmployeeNotFound)
+		case errors.Is(err, domain.ErrEmployeeQualificationAlreadyExists):
+			conflict(w, errEmployeeQualificationAlreadyExists)
+		default:
+			internalServerError(w)
+		}
+
+		return
+	}
+
+	qualification := employeeQualificationFromDomain(domainQualification)
+
+	responseBody, err := json.Marshal(qualification)
+	if err != nil {
+		logging.Logger.ErrorContext(ctx, descriptionFailedToMarshalResponseBody, logging.Error(err))
+		internalServerError(w)
+		return
+	}
+
+	writeResponseJSON(w, http.StatusCreated, responseBody)
+}
+
+// ListEmployeeQualifications handles the http request to list employee qualifications.
+func (h *handler) ListEmployeeQualifications(w http.ResponseWriter, r *http.Request, employeeID spec.EmployeeIdPathParam) {
+	ctx := r.Context()
+
+	domainQualifications, err := h.service.ListEmployeeQualifications(ctx, employeeID)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrEmployeeNotFound):
+			notFound(w, errEmployeeNotFound)
+		default:
+			internalServerError(w)
+		}
+
+		return
+	}
+
+	qualifications := employeeQualificationsFromDomain(domainQualifications)
+
+	responseBody, err := json.Marshal(qualifications)
+	if err != nil {
+		logging.Logger.ErrorContext(ctx, descriptionFailedToMarshalResponseBody, logging.Error(err))
+		internalServerError(w)
+		return
+	}
+
+	writeResponseJSON(w, http.StatusOK, responseBody)
+}
+
+// ListEmployeesQualificationsExpiring handles the http request to list the expiring employee qualifications.
+func (h *handler) ListEmployeesQualificationsExpiring(w http.ResponseWriter, r *http.Request, params spec.ListEmployeesQualificationsExpiringParams) {
+	ctx := r.Context()
+
+	domainExpiringFilter := listEmployeesQualificationsExpiringParamsToDomain(params)
+	domainQualificationsExpiring, err := h.service.ListEmployeesQualificationsExpiring(ctx, domainExpiringFilter)
+	if err != nil {
+		var domainErrFilterValueInvalid *domain.ErrFilterValueInvalid
+
+		switch {
+		case errors.As(err, &domainErrFilterValueInvalid):
+			badRequest(w, fmt.Sprintf("%s: %s", errFilterValueInvalid, domainErrFilterValueInvalid.FilterName))
+		default:
+			internalServerError(w)
+		}
+
+		return
+	}
+
+	qualificationsExpiring, err := employeesQualificationsExpiringFromDomain(domainQualificationsExpiring)
+	if err != nil {
+		logging.Logger.ErrorContext(ctx, descriptionFailedToMapResponseBody, logging.Error(err))
+		internalServerError(w)
+		return
+	}
+
+	responseBody, err := json.Marshal(qualificationsExpiring)
+	if err != nil {
+		logging.Logger.ErrorContext(ctx, descriptionFailedToMarshalResponseBody, logging.Error(err))
+		internalServerError(w)
+		return
+	}
+
+	writeResponseJSON(w, http.StatusOK, responseBody)
+}
+
+// DeleteEmployeeQualificationByID handles the http request to delete an employee qualification by ID.
+func (h *handler) DeleteEmployeeQualificationByID(w http.ResponseWriter, r *http.Request, employeeID spec.EmployeeIdPathParam, qualificationID spec.EmployeeQualificationIdPathParam) {
+	ctx := r.Context()
+
+	domainQualification, err := h.service.DeleteEmployeeQualificationByID(ctx, employeeID, qualificationID)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrEmployeeQualificationNotFound):
+			notFound(w, errEmployeeQualificationNotFound)
+		default:
+			internalServerError(w)
+		}
+
+		return
+	}
+
+	qualification := employeeQualificationFromDomain(domainQualification)
+
+	responseBody, err := json.Marshal(qualification)
+	if err != nil {
+		logging.Logger.ErrorContext(ctx, descriptionFailedToMarshalResponseBody, logging.Error(err))
+		internalServerError(w)
+		return
+	}
+
+	writeResponseJSON(w, http.StatusOK, responseBody)
+}
+
+// licenseCategoryToDomain returns a domain license category based on the standardized model.
+func licenseCategoryToDomain(category spec.LicenseCategory) domain.LicenseCategory {
+	switch category {
+	case spec.B:
+		return domain.LicenseCategoryB
+	case spec.C1:
+		return domain.LicenseCategoryC1
+	case spec.C1E:
+		return domain.LicenseCategoryC1E
+	case spec.C:
+		return domain.LicenseCategoryC
+	case spec.CE:
+		return domain.LicenseCategoryCE
+	default:
+		return domain.LicenseCategory(category)
+	}
+}
+
+// licenseCategoryFromDomain returns a standardized license category based on the domain model.
+func licenseCategoryFromDomain(category domain.LicenseCategory) spec.LicenseCategory {
+	switch category {
+	case domain.LicenseCategoryB:
+		return spec.B
+	case domain.LicenseCategoryC1:
+		return spec.C1
+	case domain.LicenseCategoryC1E:
+		return spec.C1E
+	case domain.LicenseCategoryC:
+		return spec.C
+	case domain.LicenseCategoryCE:
+		return spec.CE
+	default:
+		return spec.LicenseCategory(category)
+	}
+}
+
+// employeeQualificationTypeToDomain returns a domain employee qualification type based on the standardized model.
+func employeeQualificationTypeToDomain(qualificationType spec.EmployeeQualificationType) domain.EmployeeQualificationType {
+	switch qualificationType {
+	case spec.License:
+		return domain.EmployeeQualificationTypeLicense
+	case spec.HazardousMaterials:
+		return domain.EmployeeQualificationTypeHazardousMaterials
+	default:
+		return domain.EmployeeQualificationType(qualificationType)
+	}
+}
+
+// employeeQualificationTypeFromDomain returns a standardized employee qualification type based on the domain model.
+func employeeQualificationTypeFromDomain(qualificationType domain.EmployeeQualificationType) spec.EmployeeQualificationType {
+	switch qualificationType {
+	case domain.EmployeeQualificationTypeLicense:
+		return spec.License
+	case domain.EmployeeQualificationTypeHazardousMaterials:
+		return spec.HazardousMaterials
+	default:
+		return spec.EmployeeQualificationType(qualificationType)
+	}
+}
+
+// employeeQualificationPostToDomain returns a domain editable employee qualification based on the standardized
+// employee qualification post.
+func employeeQualificationPostToDomain(qualificationPost spec.EmployeeQualificationPost) domain.EditableEmployeeQualification {
+	var licenseCategory *domain.LicenseCategory
+	if qualificationPost.LicenseCategory != nil {
+		c := licenseCategoryToDomain(*qualificationPost.LicenseCategory)
+		licenseCategory = &c
+	}
+
+	return domain.EditableEmployeeQualification{
+		Type:            employeeQualificationTypeToDomain(qualificationPost.Type),
+		LicenseCategory: licenseCategory,
+		ExpiryDate:      qualificationPost.ExpiryDate.Time,
+	}
+}
+
+// listEmployeesQualificationsExpiringParamsToDomain returns a domain expiring employee qualifications filter based on
+// the standardized list employees qualifications expiring parameters.
+func listEmployeesQualificationsExpiringParamsToDomain(params spec.ListEmployeesQualificationsExpiringParams) domain.EmployeeQualificationsExpiringFilter {
+	withinDays := employeesQualificationsExpiringWithinDaysDefaultValue
+	if params.WithinDays != nil {
+		withinDays = *params.WithinDays
+	}
+
+	var qualificationType *domain.EmployeeQualificationType
+	if params.Type != nil {
+		t := employeeQualificationTypeToDomain(*params.Type)
+		qualificationType = &t
+	}
+
+	return domain.EmployeeQualificationsExpiringFilter{
+		WithinDays: withinDays,
+		Type:       qualificationType,
+	}
+}
+
+// employeeQualificationFromDomain returns a standardized employee qualification based on the domain model.
+func employeeQualificationFromDomain(qualification domain.EmployeeQualification) spec.EmployeeQualification {
+	var licenseCategory *spec.LicenseCategory
+	if qualification.LicenseCategory != nil {
+		c := licenseCategoryFromDomain(*qualification.LicenseCategory)
+		licenseCategory = &c
+	}
+
+	return spec.EmployeeQualification{
+		Id:              qualification.ID,
+		EmployeeId:      qualification.EmployeeID,
+		Type:            employeeQualificationTypeFromDomain(qualification.Type),
+		LicenseCategory: licenseCategory,
+		ExpiryDate:      dateFromTime(qualification.ExpiryDate),
+		CreatedAt:       qualification.CreatedAt,
+		ModifiedAt:      qualification.ModifiedAt,
+	}
+}
+
+// employeeQualificationsFromDomain returns standardized employee qualifications based on the domain model.
+func employeeQualificationsFromDomain(qualifications domain.EmployeeQualifications) spec.EmployeeQualifications {
+	specQualifications := make([]spec.EmployeeQualification, len(qualifications))
+	for i, qualification := range qualifications {
+		specQualifications[i] = employeeQualificationFromDomain(qualification)
+	}
+
+	return spec.EmployeeQualifications{
+		Qualifications: specQualifications,
+	}
+}
+
+// employeesQualificationsExpiringFromDomain returns the standardized expiring employee qualifications based on the
+// domain model.
+func employeesQualificationsExpiringFromDomain(qualificationsExpiring []domain.EmployeeQualificationExpiring) (spec.EmployeesQualificationsExpiring, error) {
+	specQualificationsExpiring := make([]spec.EmployeeQualificationExpiring, len(qualificationsExpiring))
+
+	for i, qualificationExpiring := range qualificationsExpiring {
+		employee, err := employeeFromDomain(qualificationExpiring.Employee)
+		if err != nil {
+			return spec.EmployeesQualificationsExpiring{}, err
+		}
+
+		specQualificationsExpiring[i] = spec.EmployeeQualificationExpiring{
+			Qualification: employeeQualificationFromDomain(qualificationExpiring.Qualification),
+			Employee:      employee,
+			RemainingDays: qualificationExpiring.RemainingDays,
+		}
+	}
+
+	return spec.EmployeesQualificationsExpiring{
+		Qualifications: specQualificationsExpiring,
+	}, nil
+}
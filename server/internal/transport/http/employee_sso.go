@@ -0,0 +1,119 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	spec "github.com/goncalo-marques/ecomap/server/api/ecomap"
+	"github.com/goncalo-marques/ecomap/server/internal/domain"
+	"github.com/goncalo-marques/ecomap/server/internal/logging"
+)
+
+const (
+	errSSONotConfigured        = "single sign-on not configured"
+	errSSOAuthorizationInvalid = "invalid single sign-on authorization"
+	errSSORoleNotMapped        = "identity provider groups not mapped to any role"
+)
+
+// CreateEmployeeSSOAuthorization handles the http request to create an employee single sign-on authorization.
+func (h *handler) CreateEmployeeSSOAuthorization(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	authorizationURL, err := h.service.CreateEmployeeSSOAuthorization(ctx)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrSSONotConfigured):
+			notFound(w, errSSONotConfigured)
+		default:
+			internalServerError(w)
+		}
+
+		return
+	}
+
+	authorization := spec.SSOAuthorization{
+		Url: authorizationURL,
+	}
+	responseBody, err := json.Marshal(authorization)
+	if err != nil {
+		logging.Logger.ErrorContext(ctx, descriptionFailedToMarshalResponseBody, logging.Error(err))
+		internalServerError(w)
+		return
+	}
+
+	writeResponseJSON(w, http.StatusOK, responseBody)
+}
+
+// SignInEmployeeSSO handles the http request to sign in an employee with single sign-on.
+func (h *handler) SignInEmployeeSSO(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		badRequest(w, errRequestBodyInvalid)
+		return
+	}
+
+	var ssoSignIn spec.SSOSignIn
+	err = json.Unmarshal(requestBody, &ssoSignIn)
+	if err != nil {
+		badRequest(w, errRequestBodyInvalid)
+		return
+	}
+
+	result, err := h.service.SignInEmployeeSSO(ctx, ssoSignIn.Code, ssoSignIn.State, h.clientIPAddress(r))
+	if err != nil {
+		var domainErrFieldValueInvalid *domain.ErrFieldValueInvalid
+		var domainErrSignInLocked *domain.ErrSignInLocked
+		var domainErrSignInThrottled *domain.ErrSignInThrottled
+
+		switch {
+		case errors.As(err, &domainErrFieldValueInvalid):
+			badRequest(w, fmt.Sprintf("%s: %s", errFieldValueInvalid, domainErrFieldValueInvalid.FieldName))
+		case errors.Is(err, domain.ErrSSOAuthorizationInvalid):
+			unauthorized(w, errSSOAuthorizationInvalid)
+		case errors.Is(err, domain.ErrSSORoleNotMapped):
+			forbidden(w, errSSORoleNotMapped)
+		case errors.Is(err, domain.ErrSSONotConfigured):
+			notFound(w, errSSONotConfigured)
+		case errors.Is(err, domain.ErrEmployeeAlreadyExists):
+			conflict(w, errEmployeeAlreadyExists)
+		case errors.As(err, &domainErrSignInLocked):
+			setHeaderRetryAfter(w, domainErrSignInLocked.LockedUntil)
+			locked(w, errSignInLocked)
+		case errors.As(err, &domainErrSignInThrottled):
+			setHeaderRetryAfter(w, domainErrSignInThrottled.RetryAt)
+			tooManyRequests(w, errSignInThrottled)
+		default:
+			internalServerError(w)
+		}
+
+		return
+	}
+
+	if result.MFAChallenge != nil {
+		mfaChallenge := mfaChallengeFromDomain(*result.MFAChallenge)
+		responseBody, err := json.Marshal(mfaChallenge)
+		if err != nil {
+			logging.Logger.ErrorContext(ctx, descriptionFailedToMarshalResponseBody, logging.Error(err))
+			internalServerError(w)
+			return
+		}
+
+		writeResponseJSON(w, http.StatusAccepted, responseBody)
+		return
+	}
+
+	jwt := jwtFromDomain(result.Tokens)
+	responseBody, err := json.Marshal(jwt)
+	if err != nil {
+		logging.Logger.ErrorContext(ctx, descriptionFailedToMarshalResponseBody, logging.Error(err))
+		internalServerError(w)
+		return
+	}
+
+	writeResponseJSON(w, http.StatusOK, responseBody)
+}
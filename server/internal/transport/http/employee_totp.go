@@ -0,0 +1,261 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	spec "github.com/goncalo-marques/ecomap/server/api/ecomap"
+	"github.com/goncalo-marques/ecomap/server/internal/domain"
+	"github.com/goncalo-marques/ecomap/server/internal/logging"
+)
+
+const (
+	errEmployeeTOTPNotFound       = "two-factor authentication not enabled"
+	errEmployeeTOTPAlreadyEnabled = "two-factor authentication already enabled"
+	errEmployeeTOTPCodeInvalid    = "invalid two-factor authentication code"
+	errMFAChallengeInvalid        = "invalid or expired two-factor authentication challenge"
+)
+
+// CreateEmployeeTOTP handles the http request to enrol an employee in two-factor authentication.
+func (h *handler) CreateEmployeeTOTP(w http.ResponseWriter, r *http.Request, employeeID spec.EmployeeIdPathParam) {
+	ctx := r.Context()
+
+	domainEnrolment, err := h.service.CreateEmployeeTOTP(ctx, employeeID)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrEmployeeNotFound):
+			notFound(w, errEmployeeNotFound)
+		case errors.Is(err, domain.ErrEmployeeTOTPAlreadyEnabled):
+			conflict(w, errEmployeeTOTPAlreadyEnabled)
+		default:
+			internalServerError(w)
+		}
+
+		return
+	}
+
+	enrolment := totpEnrolmentFromDomain(domainEnrolment)
+	responseBody, err := json.Marshal(enrolment)
+	if err != nil {
+		logging.Logger.ErrorContext(ctx, descriptionFailedToMarshalResponseBody, logging.Error(err))
+		internalServerError(w)
+		return
+	}
+
+	writeResponseJSON(w, http.StatusCreated, responseBody)
+}
+
+// DeleteEmployeeTOTP handles the http request to disable employee two-factor authentication.
+func (h *handler) DeleteEmployeeTOTP(w http.ResponseWriter, r *http.Request, employeeID spec.EmployeeIdPathParam) {
+	ctx := r.Context()
+
+	err := h.service.DeleteEmployeeTOTP(ctx, employeeID)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrEmployeeTOTPNotFound):
+			notFound(w, errEmployeeTOTPNotFound)
+		default:
+			internalServerError(w)
+		}
+
+		return
+	}
+
+	writeResponseJSON(w, http.StatusNoContent, nil)
+}
+
+// ConfirmEmployeeTOTP handles the http request to confirm employee two-factor authentication.
+func (h *handler) ConfirmEmployeeTOTP(w http.ResponseWriter, r *http.Request, employeeID spec.EmployeeIdPathParam) {
+	ctx := r.Context()
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		badRequest(w, errRequestBodyInvalid)
+		return
+	}
+
+	var confirmation spec.TOTPConfirmation
+	err = json.Unmarshal(requestBody, &confirmation)
+	if err != nil {
+		badRequest(w, errRequestBodyInvalid)
+		return
+	}
+
+	recoveryCodes, err := h.service.ConfirmEmployeeTOTP(ctx, employeeID, confirmation.Code)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrEmployeeTOTPCodeInvalid):
+			badRequest(w, errEmployeeTOTPCodeInvalid)
+		case errors.Is(err, domain.ErrEmployeeTOTPNotFound):
+			notFound(w, errEmployeeTOTPNotFound)
+		case errors.Is(err, domain.ErrEmployeeTOTPAlreadyEnabled):
+			conflict(w, errEmployeeTOTPAlreadyEnabled)
+		default:
+			internalServerError(w)
+		}
+
+		return
+	}
+
+	responseBody, err := json.Marshal(spec.RecoveryCodes{RecoveryCodes: recoveryCodes})
+	if err != nil {
+		logging.Logger.ErrorContext(ctx, descriptionFailedToMarshalResponseBody, logging.Error(err))
+		internalServerError(w)
+		return
+	}
+
+	writeResponseJSON(w, http.StatusOK, responseBody)
+}
+
+// CreateEmployeeRecoveryCodes handles the http request to create employee recovery codes.
+func (h *handler) CreateEmployeeRecoveryCodes(w http.ResponseWriter, r *http.Request, employeeID spec.EmployeeIdPathParam) {
+	ctx := r.Context()
+
+	recoveryCodes, err := h.service.CreateEmployeeRecoveryCodes(ctx, employeeID)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrEmployeeTOTPNotFound):
+			notFound(w, errEmployeeTOTPNotFound)
+		default:
+			internalServerError(w)
+		}
+
+		return
+	}
+
+	responseBody, err := json.Marshal(spec.RecoveryCodes{RecoveryCodes: recoveryCodes})
+	if err != nil {
+		logging.Logger.ErrorContext(ctx, descriptionFailedToMarshalResponseBody, logging.Error(err))
+		internalServerError(w)
+		return
+	}
+
+	writeResponseJSON(w, http.StatusCreated, responseBody)
+}
+
+// CreateEmployeeSignInTOTP handles the http request to enrol an employee in two-factor authentication during sign-in.
+func (h *handler) CreateEmployeeSignInTOTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		badRequest(w, errRequestBodyInvalid)
+		return
+	}
+
+	var challengePost spec.MFAChallengePost
+	err = json.Unmarshal(requestBody, &challengePost)
+	if err != nil {
+		badRequest(w, errRequestBodyInvalid)
+		return
+	}
+
+	domainEnrolment, err := h.service.CreateEmployeeSignInTOTP(ctx, challengePost.MfaToken)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrMFAChallengeInvalid):
+			unauthorized(w, errMFAChallengeInvalid)
+		case errors.Is(err, domain.ErrEmployeeTOTPAlreadyEnabled):
+			conflict(w, errEmployeeTOTPAlreadyEnabled)
+		default:
+			internalServerError(w)
+		}
+
+		return
+	}
+
+	enrolment := totpEnrolmentFromDomain(domainEnrolment)
+	responseBody, err := json.Marshal(enrolment)
+	if err != nil {
+		logging.Logger.ErrorContext(ctx, descriptionFailedToMarshalResponseBody, logging.Error(err))
+		internalServerError(w)
+		return
+	}
+
+	writeResponseJSON(w, http.StatusCreated, responseBody)
+}
+
+// SignInEmployeeMFA handles the http request to sign in an employee with two-factor authentication.
+func (h *handler) SignInEmployeeMFA(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		badRequest(w, errRequestBodyInvalid)
+		return
+	}
+
+	var mfaSignIn spec.MFASignIn
+	err = json.Unmarshal(requestBody, &mfaSignIn)
+	if err != nil {
+		badRequest(w, errRequestBodyInvalid)
+		return
+	}
+
+	result, err := h.service.SignInEmployeeMFA(ctx, mfaSignIn.MfaToken, mfaSignIn.Code, h.clientIPAddress(r))
+	if err != nil {
+		var domainErrSignInLocked *domain.ErrSignInLocked
+		var domainErrSignInThrottled *domain.ErrSignInThrottled
+
+		switch {
+		case errors.Is(err, domain.ErrEmployeeTOTPCodeInvalid):
+			unauthorized(w, errEmployeeTOTPCodeInvalid)
+		case errors.Is(err, domain.ErrMFAChallengeInvalid):
+			unauthorized(w, errMFAChallengeInvalid)
+		case errors.As(err, &domainErrSignInLocked):
+			setHeaderRetryAfter(w, domainErrSignInLocked.LockedUntil)
+			locked(w, errSignInLocked)
+		case errors.As(err, &domainErrSignInThrottled):
+			setHeaderRetryAfter(w, domainErrSignInThrottled.RetryAt)
+			tooManyRequests(w, errSignInThrottled)
+		default:
+			internalServerError(w)
+		}
+
+		return
+	}
+
+	mfaJWT := mfaJWTFromDomain(result)
+	responseBody, err := json.Marshal(mfaJWT)
+	if err != nil {
+		logging.Logger.ErrorContext(ctx, descriptionFailedToMarshalResponseBody, logging.Error(err))
+		internalServerError(w)
+		return
+	}
+
+	writeResponseJSON(w, http.StatusOK, responseBody)
+}
+
+// mfaJWTFromDomain returns a standardized two-factor authentication sign-in result based on the domain model.
+func mfaJWTFromDomain(result domain.EmployeeMFASignInResult) spec.MFAJWT {
+	var recoveryCodes *[]string
+	if len(result.RecoveryCodes) != 0 {
+		recoveryCodes = &result.RecoveryCodes
+	}
+
+	return spec.MFAJWT{
+		Token:         result.Tokens.AccessToken,
+		ExpiresAt:     result.Tokens.AccessTokenExpiresAt,
+		RefreshToken:  result.Tokens.RefreshToken,
+		RecoveryCodes: recoveryCodes,
+	}
+}
+
+// mfaChallengeFromDomain returns a standardized two-factor authentication challenge based on the domain model.
+func mfaChallengeFromDomain(challenge domain.MFAChallenge) spec.MFAChallenge {
+	return spec.MFAChallenge{
+		MfaToken:          challenge.Token,
+		ExpiresAt:         challenge.ExpiresAt,
+		EnrolmentRequired: challenge.EnrolmentRequired,
+	}
+}
+
+// totpEnrolmentFromDomain returns a standardized time-based one-time password enrolment based on the domain model.
+func totpEnrolmentFromDomain(enrolment domain.TOTPEnrolment) spec.TOTPEnrolment {
+	return spec.TOTPEnrolment{
+		Secret: enrolment.Secret,
+		Uri:    enrolment.URI,
+	}
+}
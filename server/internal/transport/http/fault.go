@@ -11,19 +11,23 @@ import (
 const (
 	errAuthorizationHeaderInvalid = "invalid authorization header"
 	errJWTInvalid                 = "invalid jwt"
-	errRolesInvalid               = "invalid subject roles"
+	errJWTRevoked                 = "revoked jwt"
+	errPermissionsInvalid         = "insufficient subject permissions"
 	errAuthorizationInvalid       = "unauthorized subject"
 	errParamInvalidFormat         = "invalid parameter format"
 	errRequestBodyInvalid         = "invalid request body"
 	errFieldValueInvalid          = "invalid field value"
 	errFilterValueInvalid         = "invalid filter value"
 	errCredentialsIncorrect       = "incorrect credentials"
+	errPasswordPolicyViolated     = "password policy violated"
+	errPasswordExpired            = "password expired"
 )
 
 // Common fault descriptions.
 const (
 	descriptionFailedToMarshalResponseBody = "http: failed to marshal response body"
 	descriptionFailedToMapResponseBody     = "http: failed to map response body"
+	descriptionFailedToWriteResponseBody   = "http: failed to write response body"
 )
 
 // badRequest writes an error response and sets the header with the bad request status code.
@@ -51,6 +55,16 @@ func conflict(w http.ResponseWriter, message string) {
 	_ = fault(w, http.StatusConflict, spec.ErrorCodeConflict, &message)
 }
 
+// locked writes an error response and sets the header with the locked status code.
+func locked(w http.ResponseWriter, message string) {
+	_ = fault(w, http.StatusLocked, spec.ErrorCodeLocked, &message)
+}
+
+// tooManyRequests writes an error response and sets the header with the too many requests status code.
+func tooManyRequests(w http.ResponseWriter, message string) {
+	_ = fault(w, http.StatusTooManyRequests, spec.ErrorCodeTooManyRequests, &message)
+}
+
 // internalServerError sets the header with the internal server error status code.
 func internalServerError(w http.ResponseWriter) {
 	_ = fault(w, http.StatusInternalServerError, spec.ErrorCodeInternalServerError, nil)
@@ -4,9 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"net/netip"
 	"path"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -35,6 +38,11 @@ const (
 	requestHeaderValueAcceptHTML = "text/html"
 )
 
+// Response header const.
+const (
+	responseHeaderKeyRetryAfter = "Retry-After"
+)
+
 // AuthorizationService defines the authorization service interface.
 type AuthorizationService interface {
 	Middleware(options authz.MiddlewareOptions) func(http.Handler) http.Handler
@@ -46,23 +54,48 @@ type Service interface {
 	ListUsers(ctx context.Context, filter domain.UsersPaginatedFilter) (domain.PaginatedResponse[domain.User], error)
 	GetUserByID(ctx context.Context, id uuid.UUID) (domain.User, error)
 	PatchUser(ctx context.Context, id uuid.UUID, editableUser domain.EditableUserPatch) (domain.User, error)
-	UpdateUserPassword(ctx context.Context, username domain.Username, oldPassword, newPassword domain.Password) error
+	UpdateUserPassword(ctx context.Context, username domain.Username, oldPassword, newPassword domain.Password, ipAddress netip.Addr) error
 	ResetUserPassword(ctx context.Context, username domain.Username, newPassword domain.Password) error
 	DeleteUserByID(ctx context.Context, id uuid.UUID) (domain.User, error)
-	SignInUser(ctx context.Context, username domain.Username, password domain.Password) (string, error)
+	SignInUser(ctx context.Context, username domain.Username, password domain.Password, ipAddress netip.Addr) (domain.Tokens, error)
+	SignOutUser(ctx context.Context, refreshToken string) error
+	ForgotUserPassword(ctx context.Context, username domain.Username) error
+	ConfirmUserPasswordReset(ctx context.Context, token string, newPassword domain.Password) error
+	ConfirmUserEmail(ctx context.Context, token string) error
 
 	CreateUserContainerBookmark(ctx context.Context, userID, containerID uuid.UUID) error
 	ListUserContainerBookmarks(ctx context.Context, userID uuid.UUID, filter domain.UserContainerBookmarksPaginatedFilter) (domain.PaginatedResponse[domain.Container], error)
 	DeleteUserContainerBookmark(ctx context.Context, userID, containerID uuid.UUID) error
 
-	CreateEmployee(ctx context.Context, editableEmployee domain.EditableEmployeeWithPassword) (domain.Employee, error)
+	CreateEmployee(ctx context.Context, editableEmployee domain.EditableEmployeeWithPassword, grantorPermissions []domain.Permission) (domain.Employee, error)
 	ListEmployees(ctx context.Context, filter domain.EmployeesPaginatedFilter) (domain.PaginatedResponse[domain.Employee], error)
+	ListEmployeesAvailable(ctx context.Context, filter domain.EmployeesAvailabilityFilter) ([]domain.Employee, error)
+	GetEmployeeAgenda(ctx context.Context, employeeID uuid.UUID, date time.Time) (domain.EmployeeAgenda, error)
 	GetEmployeeByID(ctx context.Context, id uuid.UUID) (domain.Employee, error)
-	PatchEmployee(ctx context.Context, id uuid.UUID, editableEmployee domain.EditableEmployeePatch) (domain.Employee, error)
-	UpdateEmployeePassword(ctx context.Context, username domain.Username, oldPassword, newPassword domain.Password) error
+	PatchEmployee(ctx context.Context, id uuid.UUID, editableEmployee domain.EditableEmployeePatch, grantorPermissions []domain.Permission) (domain.Employee, error)
+	UpdateEmployeePassword(ctx context.Context, username domain.Username, oldPassword, newPassword domain.Password, ipAddress netip.Addr) error
 	ResetEmployeePassword(ctx context.Context, username domain.Username, newPassword domain.Password) error
 	DeleteEmployeeByID(ctx context.Context, id uuid.UUID) (domain.Employee, error)
-	SignInEmployee(ctx context.Context, username domain.Username, password domain.Password) (string, error)
+	SignInEmployee(ctx context.Context, username domain.Username, password domain.Password, ipAddress netip.Addr) (domain.EmployeeSignInResult, error)
+	SignOutEmployee(ctx context.Context, refreshToken string) error
+	CreateEmployeeSSOAuthorization(ctx context.Context) (string, error)
+	SignInEmployeeSSO(ctx context.Context, code, state string, ipAddress netip.Addr) (domain.EmployeeSignInResult, error)
+
+	CreateEmployeeTOTP(ctx context.Context, employeeID uuid.UUID) (domain.TOTPEnrolment, error)
+	ConfirmEmployeeTOTP(ctx context.Context, employeeID uuid.UUID, code string) ([]string, error)
+	DeleteEmployeeTOTP(ctx context.Context, employeeID uuid.UUID) error
+	CreateEmployeeRecoveryCodes(ctx context.Context, employeeID uuid.UUID) ([]string, error)
+	CreateEmployeeSignInTOTP(ctx context.Context, challengeToken string) (domain.TOTPEnrolment, error)
+	SignInEmployeeMFA(ctx context.Context, challengeToken, code string, ipAddress netip.Addr) (domain.EmployeeMFASignInResult, error)
+
+	CreateEmployeeAbsence(ctx context.Context, employeeID uuid.UUID, editableAbsence domain.EditableEmployeeAbsence) (domain.EmployeeAbsence, error)
+	ListEmployeeAbsences(ctx context.Context, employeeID uuid.UUID, filter domain.EmployeeAbsencesPaginatedFilter) (domain.PaginatedResponse[domain.EmployeeAbsence], error)
+	DeleteEmployeeAbsenceByID(ctx context.Context, employeeID, id uuid.UUID) (domain.EmployeeAbsence, error)
+
+	CreateEmployeeQualification(ctx context.Context, employeeID uuid.UUID, editableQualification domain.EditableEmployeeQualification) (domain.EmployeeQualification, error)
+	ListEmployeeQualifications(ctx context.Context, employeeID uuid.UUID) (domain.EmployeeQualifications, error)
+	ListEmployeesQualificationsExpiring(ctx context.Context, filter domain.EmployeeQualificationsExpiringFilter) ([]domain.EmployeeQualificationExpiring, error)
+	DeleteEmployeeQualificationByID(ctx context.Context, employeeID, id uuid.UUID) (domain.EmployeeQualification, error)
 
 	CreateContainer(ctx context.Context, editableContainer domain.EditableContainer) (domain.Container, error)
 	ListContainers(ctx context.Context, filter domain.ContainersPaginatedFilter) (domain.PaginatedResponse[domain.Container], error)
@@ -76,6 +109,17 @@ type Service interface {
 	PatchTruck(ctx context.Context, id uuid.UUID, editableTruck domain.EditableTruckPatch) (domain.Truck, error)
 	DeleteTruckByID(ctx context.Context, id uuid.UUID) (domain.Truck, error)
 
+	CreateTruckPositions(ctx context.Context, truckID uuid.UUID, positions domain.EditableTruckPositions) error
+	SubscribeTruckPositions(ctx context.Context) (<-chan domain.TruckPosition, func())
+	GetTruckTrack(ctx context.Context, truckID uuid.UUID, filter domain.TruckTrackFilter) (domain.TruckTrack, error)
+
+	CreateTruckMaintenance(ctx context.Context, truckID uuid.UUID, editableMaintenance domain.EditableTruckMaintenance) (domain.TruckMaintenance, error)
+	ListTruckMaintenances(ctx context.Context, truckID uuid.UUID, filter domain.TruckMaintenancesPaginatedFilter) (domain.PaginatedResponse[domain.TruckMaintenance], error)
+	DeleteTruckMaintenanceByID(ctx context.Context, truckID, id uuid.UUID) (domain.TruckMaintenance, error)
+	ListTrucksServiceDue(ctx context.Context, filter domain.TruckServiceDueFilter) ([]domain.TruckServiceDue, error)
+	CreateTruckOdometerReading(ctx context.Context, truckID uuid.UUID, editableReading domain.EditableTruckOdometerReading) (domain.TruckOdometerReading, error)
+	ListTruckOdometerReadings(ctx context.Context, truckID uuid.UUID, filter domain.TruckOdometerReadingsPaginatedFilter) (domain.PaginatedResponse[domain.TruckOdometerReading], error)
+
 	CreateWarehouse(ctx context.Context, editableWarehouse domain.EditableWarehouse) (domain.Warehouse, error)
 	ListWarehouses(ctx context.Context, filter domain.WarehousesPaginatedFilter) (domain.PaginatedResponse[domain.Warehouse], error)
 	GetWarehouseByID(ctx context.Context, id uuid.UUID) (domain.Warehouse, error)
@@ -94,32 +138,73 @@ type Service interface {
 	PatchRoute(ctx context.Context, id uuid.UUID, editableRoute domain.EditableRoutePatch) (domain.Route, error)
 	DeleteRouteByID(ctx context.Context, id uuid.UUID) (domain.Route, error)
 	GetRouteRoads(ctx context.Context, id uuid.UUID) (domain.GeoJSON, error)
+	GetRouteStops(ctx context.Context, id uuid.UUID) ([]domain.RouteStop, error)
+	GetRouteCategories(ctx context.Context, id uuid.UUID) ([]domain.RouteCategory, error)
+	GetRouteEmissions(ctx context.Context, id uuid.UUID) (domain.RouteEmissions, error)
+	StartRoute(ctx context.Context, id uuid.UUID) (domain.Route, error)
+	FinishRoute(ctx context.Context, id uuid.UUID) (domain.Route, error)
 
 	CreateRouteContainer(ctx context.Context, routeID, containerID uuid.UUID) error
 	ListRouteContainers(ctx context.Context, routeID uuid.UUID, filter domain.RouteContainersPaginatedFilter) (domain.PaginatedResponse[domain.Container], error)
 	DeleteRouteContainer(ctx context.Context, routeID, containerID uuid.UUID) error
 
+	ListRouteContainerCollections(ctx context.Context, routeID uuid.UUID) ([]domain.RouteContainerCollection, error)
+	PutRouteContainerCollection(ctx context.Context, routeID, containerID uuid.UUID, editableCollection domain.EditableRouteContainerCollection) (domain.RouteContainerCollection, error)
+
+	PutRouteTrack(ctx context.Context, routeID uuid.UUID, date time.Time, editableTrack domain.EditableRouteTrack) (domain.RouteTrack, error)
+	GetRouteTrack(ctx context.Context, routeID uuid.UUID, date time.Time) (domain.RouteTrack, error)
+	ListRouteTracks(ctx context.Context, filter domain.RouteTracksPaginatedFilter) (domain.PaginatedResponse[domain.RouteTrack], error)
+
 	CreateRouteEmployee(ctx context.Context, routeID, employeeID uuid.UUID, editableRouteEmployee domain.EditableRouteEmployee) error
 	ListRouteEmployees(ctx context.Context, routeID uuid.UUID, filter domain.RouteEmployeesPaginatedFilter) (domain.PaginatedResponse[domain.RouteEmployee], error)
 	DeleteRouteEmployee(ctx context.Context, routeID, employeeID uuid.UUID) error
+	AutoAssignRouteCrew(ctx context.Context, routeID uuid.UUID, preview bool) ([]domain.RouteCrewMember, error)
 
 	GetRoadByGeometry(ctx context.Context, geometry domain.GeoJSONGeometryPoint) (domain.Road, error)
 
+	GetRoutingMatrix(ctx context.Context, request domain.RoutingMatrixRequest) (domain.RoutingMatrix, error)
+
 	GetMunicipalityByGeometry(ctx context.Context, geometry domain.GeoJSONGeometryPoint) (domain.Municipality, error)
+	ListMunicipalitiesEmissions(ctx context.Context) ([]domain.MunicipalityEmissions, error)
+
+	RefreshTokens(ctx context.Context, refreshToken string) (domain.Tokens, error)
+	ListJSONWebKeys(ctx context.Context) []domain.JSONWebKey
+
+	ListSignInLockouts(ctx context.Context, filter domain.SignInLockoutsFilter) ([]domain.SignInLockout, error)
+	DeleteSignInLockoutByID(ctx context.Context, id uuid.UUID) (domain.SignInLockout, error)
+
+	CreateAPIKey(ctx context.Context, editableAPIKey domain.EditableAPIKey, grantorPermissions []domain.Permission) (domain.APIKeyWithKey, error)
+	ListAPIKeys(ctx context.Context) ([]domain.APIKey, error)
+	GetAPIKeyByID(ctx context.Context, id uuid.UUID) (domain.APIKey, error)
+	DeleteAPIKeyByID(ctx context.Context, id uuid.UUID) (domain.APIKey, error)
+
+	CreateRole(ctx context.Context, editableRole domain.EditableRole, grantorPermissions []domain.Permission) (domain.Role, error)
+	ListRoles(ctx context.Context) ([]domain.Role, error)
+	GetRoleByID(ctx context.Context, id uuid.UUID) (domain.Role, error)
+	PatchRole(ctx context.Context, id uuid.UUID, editableRole domain.EditableRolePatch, grantorPermissions []domain.Permission) (domain.Role, error)
+	DeleteRoleByID(ctx context.Context, id uuid.UUID) (domain.Role, error)
+}
+
+// Options defines the http handler options structure.
+type Options struct {
+	ClientIPHeader string // Header set by the trusted proxy with the IP address of the client, such as X-Forwarded-For.
 }
 
 // handler defines the http handler structure.
 type handler struct {
 	authzService AuthorizationService
 	service      Service
+	options      Options
 	handler      http.Handler
 }
 
-// New returns a new http handler.
-func New(authzService AuthorizationService, service Service) *handler {
+// New returns a new http handler. When the client IP header is not defined, the IP address of the client is the remote
+// address of the connection.
+func New(authzService AuthorizationService, service Service, options Options) *handler {
 	h := &handler{
 		authzService: authzService,
 		service:      service,
+		options:      options,
 	}
 
 	router := http.NewServeMux()
@@ -141,24 +226,29 @@ func New(authzService AuthorizationService, service Service) *handler {
 	authzMiddleware := authzService.Middleware(authz.MiddlewareOptions{
 		UnauthorizedHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
 			switch {
+			case errors.Is(err, domain.ErrAPIKeyInvalid):
+				unauthorized(w, errAPIKeyInvalid)
 			case errors.Is(err, authz.ErrAuthorizationHeaderInvalid):
 				unauthorized(w, errAuthorizationHeaderInvalid)
 			case errors.Is(err, authz.ErrJWTInvalid):
 				unauthorized(w, errJWTInvalid)
+			case errors.Is(err, authz.ErrJWTRevoked):
+				unauthorized(w, errJWTRevoked)
 			default:
 				unauthorized(w, err.Error())
 			}
 		},
 		ForbiddenHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
 			switch {
-			case errors.Is(err, authz.ErrRolesInvalid):
-				forbidden(w, errRolesInvalid)
+			case errors.Is(err, authz.ErrPermissionsInvalid):
+				forbidden(w, errPermissionsInvalid)
 			case errors.Is(err, authz.ErrAuthorizationInvalid):
 				forbidden(w, errAuthorizationInvalid)
 			default:
 				forbidden(w, err.Error())
 			}
 		},
+		ClientIPAddressFunc: h.clientIPAddress,
 	})
 
 	h.handler = spec.HandlerWithOptions(h, spec.StdHTTPServerOptions{
@@ -177,6 +267,9 @@ func New(authzService AuthorizationService, service Service) *handler {
 		},
 	})
 
+	// Handle JSON Web Key Set at the well-known location of the server.
+	router.HandleFunc(pathWellKnownJWKS, h.GetJWKS)
+
 	// Handle swagger documentation.
 	swaggerFS := http.FileServer(http.Dir(dirSwaggerUI))
 	router.Handle(baseURLDocs, http.StripPrefix(baseURLDocs, swaggerFS))
@@ -194,6 +287,25 @@ func setHeaderJSON(w http.ResponseWriter) {
 	w.Header().Set("Content-Type", "application/json; charset=utf-8")
 }
 
+// setHeaderEventStream sets the header with the content type event stream.
+func setHeaderEventStream(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+}
+
+// writeEventStreamEvent writes an event with the given name and data to the event stream.
+func writeEventStreamEvent(w http.ResponseWriter, event string, data []byte) error {
+	_, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, data)
+	return err
+}
+
+// writeEventStreamComment writes an empty comment to the event stream, which is ignored by the clients.
+func writeEventStreamComment(w http.ResponseWriter) error {
+	_, err := io.WriteString(w, ":\n\n")
+	return err
+}
+
 // writeResponseJSON writes the data to the response and sets the header with the provided status code and content type
 // json.
 func writeResponseJSON(w http.ResponseWriter, statusCode int, data []byte) {
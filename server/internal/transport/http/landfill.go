@@ -49,7 +49,15 @@ func (h *handler) CreateLandfill(w http.ResponseWriter, r *http.Request) {
 
 	domainLandfill, err := h.service.CreateLandfill(ctx, domainEditableLandfill)
 	if err != nil {
-		internalServerError(w)
+		var domainErrFieldValueInvalid *domain.ErrFieldValueInvalid
+
+		switch {
+		case errors.As(err, &domainErrFieldValueInvalid):
+			badRequest(w, fmt.Sprintf("%s: %s", errFieldValueInvalid, domainErrFieldValueInvalid.FieldName))
+		default:
+			internalServerError(w)
+		}
+
 		return
 	}
 
@@ -172,7 +180,11 @@ func (h *handler) PatchLandfillByID(w http.ResponseWriter, r *http.Request, land
 
 	domainLandfill, err := h.service.PatchLandfill(ctx, landfillID, domainEditableLandfill)
 	if err != nil {
+		var domainErrFieldValueInvalid *domain.ErrFieldValueInvalid
+
 		switch {
+		case errors.As(err, &domainErrFieldValueInvalid):
+			badRequest(w, fmt.Sprintf("%s: %s", errFieldValueInvalid, domainErrFieldValueInvalid.FieldName))
 		case errors.Is(err, domain.ErrLandfillNotFound):
 			notFound(w, errLandfillNotFound)
 		default:
@@ -234,33 +246,89 @@ func (h *handler) DeleteLandfillByID(w http.ResponseWriter, r *http.Request, lan
 
 // landfillPostToDomain returns a domain editable landfill based on the standardized landfill post.
 func landfillPostToDomain(landfillPost spec.LandfillPost) (domain.EditableLandfill, error) {
+	openingTime, err := optionalTimeStringToTime(landfillPost.OpeningTime, domain.FieldOpeningTime)
+	if err != nil {
+		return domain.EditableLandfill{}, err
+	}
+
+	closingTime, err := optionalTimeStringToTime(landfillPost.ClosingTime, domain.FieldClosingTime)
+	if err != nil {
+		return domain.EditableLandfill{}, err
+	}
+
 	geoJSON, err := geoJSONFeaturePointToDomain(&landfillPost.GeoJson)
 	if err != nil {
 		return domain.EditableLandfill{}, err
 	}
 
 	return domain.EditableLandfill{
-		GeoJSON: geoJSON,
+		Name:               (*domain.Name)(landfillPost.Name),
+		AcceptedCategories: landfillAcceptedCategoriesToDomain(landfillPost.AcceptedCategories),
+		OpeningTime:        openingTime,
+		ClosingTime:        closingTime,
+		DailyCapacity:      (*domain.LandfillDailyCapacity)(landfillPost.DailyCapacity),
+		GeoJSON:            geoJSON,
 	}, nil
 }
 
 // landfillPatchToDomain returns a domain patchable landfill based on the standardized landfill patch.
 func landfillPatchToDomain(landfillPatch spec.LandfillPatch) (domain.EditableLandfillPatch, error) {
+	openingTime, err := optionalTimeStringToTime(landfillPatch.OpeningTime, domain.FieldOpeningTime)
+	if err != nil {
+		return domain.EditableLandfillPatch{}, err
+	}
+
+	closingTime, err := optionalTimeStringToTime(landfillPatch.ClosingTime, domain.FieldClosingTime)
+	if err != nil {
+		return domain.EditableLandfillPatch{}, err
+	}
+
 	geoJSON, err := geoJSONFeaturePointToDomain(landfillPatch.GeoJson)
 	if err != nil {
 		return domain.EditableLandfillPatch{}, err
 	}
 
 	return domain.EditableLandfillPatch{
-		GeoJSON: geoJSON,
+		Name:               (*domain.Name)(landfillPatch.Name),
+		AcceptedCategories: landfillAcceptedCategoriesToDomain(landfillPatch.AcceptedCategories),
+		OpeningTime:        openingTime,
+		ClosingTime:        closingTime,
+		DailyCapacity:      (*domain.LandfillDailyCapacity)(landfillPatch.DailyCapacity),
+		GeoJSON:            geoJSON,
 	}, nil
 }
 
+// landfillAcceptedCategoriesToDomain returns a domain landfill accepted categories based on the standardized model.
+func landfillAcceptedCategoriesToDomain(categories *spec.LandfillAcceptedCategories) domain.LandfillAcceptedCategories {
+	if categories == nil {
+		return nil
+	}
+
+	domainCategories := make(domain.LandfillAcceptedCategories, len(*categories))
+	for i, category := range *categories {
+		domainCategories[i] = containerCategoryToDomain(category)
+	}
+
+	return domainCategories
+}
+
+// landfillAcceptedCategoriesFromDomain returns a standardized landfill accepted categories based on the domain model.
+func landfillAcceptedCategoriesFromDomain(categories domain.LandfillAcceptedCategories) spec.LandfillAcceptedCategories {
+	specCategories := make(spec.LandfillAcceptedCategories, len(categories))
+	for i, category := range categories {
+		specCategories[i] = containerCategoryFromDomain(category)
+	}
+
+	return specCategories
+}
+
 // listLandfillsParamsToDomain returns a domain landfills paginated filter based on the standardized list landfills parameters.
 func listLandfillsParamsToDomain(params spec.ListLandfillsParams) domain.LandfillsPaginatedFilter {
 	domainSort := domain.LandfillPaginatedSortCreatedAt
 	if params.Sort != nil {
 		switch *params.Sort {
+		case spec.ListLandfillsParamsSortName:
+			domainSort = domain.LandfillPaginatedSortName
 		case spec.ListLandfillsParamsSortWayName:
 			domainSort = domain.LandfillPaginatedSortWayName
 		case spec.ListLandfillsParamsSortMunicipalityName:
@@ -293,10 +361,15 @@ func landfillFromDomain(landfill domain.Landfill) (spec.Landfill, error) {
 	}
 
 	return spec.Landfill{
-		Id:         landfill.ID,
-		GeoJson:    geoJSON,
-		CreatedAt:  landfill.CreatedAt,
-		ModifiedAt: landfill.ModifiedAt,
+		Id:                 landfill.ID,
+		Name:               (*string)(landfill.Name),
+		AcceptedCategories: landfillAcceptedCategoriesFromDomain(landfill.AcceptedCategories),
+		OpeningTime:        optionalTimeStringFromTime(landfill.OpeningTime),
+		ClosingTime:        optionalTimeStringFromTime(landfill.ClosingTime),
+		DailyCapacity:      (*float64)(landfill.DailyCapacity),
+		GeoJson:            geoJSON,
+		CreatedAt:          landfill.CreatedAt,
+		ModifiedAt:         landfill.ModifiedAt,
 	}, nil
 }
 
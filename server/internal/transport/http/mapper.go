@@ -45,11 +45,29 @@ func timeStringToTime(timeString string) (time.Time, error) {
 	return time.Parse(timeFormatTimeOnly, timeString)
 }
 
-// jwtFromJWTToken returns a standardized JWT based on the JWT token.
-func jwtFromJWTToken(token string) spec.JWT {
-	return spec.JWT{
-		Token: token,
+// optionalTimeStringFromTime returns an optional standardized time string based on the optional time model.
+func optionalTimeStringFromTime(time *time.Time) *string {
+	if time == nil {
+		return nil
 	}
+
+	timeString := timeStringFromTime(*time)
+	return &timeString
+}
+
+// optionalTimeStringToTime returns an optional time structure based on the optional standardized time model. The field
+// name is used to identify the invalid field.
+func optionalTimeStringToTime(timeString *string, fieldName string) (*time.Time, error) {
+	if timeString == nil {
+		return nil, nil
+	}
+
+	t, err := timeStringToTime(*timeString)
+	if err != nil {
+		return nil, &domain.ErrFieldValueInvalid{FieldName: fieldName}
+	}
+
+	return &t, nil
 }
 
 // geoJSONFeaturePointToDomain returns a domain GeoJSON based on the standardized GeoJSON feature point model.
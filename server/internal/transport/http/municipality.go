@@ -56,6 +56,27 @@ func (h *handler) GetMunicipalityByReverseGeocoding(w http.ResponseWriter, r *ht
 	writeResponseJSON(w, http.StatusOK, responseBody)
 }
 
+// ListMunicipalitiesEmissions handles the http request to list the emissions of the municipalities.
+func (h *handler) ListMunicipalitiesEmissions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	domainMunicipalitiesEmissions, err := h.service.ListMunicipalitiesEmissions(ctx)
+	if err != nil {
+		internalServerError(w)
+		return
+	}
+
+	municipalitiesEmissions := municipalitiesEmissionsFromDomain(domainMunicipalitiesEmissions)
+	responseBody, err := json.Marshal(municipalitiesEmissions)
+	if err != nil {
+		logging.Logger.ErrorContext(ctx, descriptionFailedToMarshalResponseBody, logging.Error(err))
+		internalServerError(w)
+		return
+	}
+
+	writeResponseJSON(w, http.StatusOK, responseBody)
+}
+
 // municipalityFromDomain returns a standardized municipality based on the domain model.
 func municipalityFromDomain(municipality domain.Municipality) spec.Municipality {
 	return spec.Municipality{
@@ -70,3 +91,22 @@ func municipalityFromDomain(municipality domain.Municipality) spec.Municipality
 		Perimeter: municipality.Perimeter,
 	}
 }
+
+// municipalitiesEmissionsFromDomain returns standardized municipalities emissions based on the domain models.
+func municipalitiesEmissionsFromDomain(municipalitiesEmissions []domain.MunicipalityEmissions) spec.MunicipalitiesEmissions {
+	specMunicipalitiesEmissions := make([]spec.MunicipalityEmissions, len(municipalitiesEmissions))
+	for i, emissions := range municipalitiesEmissions {
+		specMunicipalitiesEmissions[i] = spec.MunicipalityEmissions{
+			Municipality:    municipalityFromDomain(emissions.Municipality),
+			Routes:          emissions.Routes,
+			EstimatedRoutes: emissions.EstimatedRoutes,
+			Distance:        emissions.Distance,
+			Fuel:            emissions.Fuel,
+			Co2:             emissions.CO2,
+		}
+	}
+
+	return spec.MunicipalitiesEmissions{
+		Municipalities: specMunicipalitiesEmissions,
+	}
+}
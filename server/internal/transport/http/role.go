@@ -0,0 +1,260 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	spec "github.com/goncalo-marques/ecomap/server/api/ecomap"
+	"github.com/goncalo-marques/ecomap/server/internal/authz"
+	"github.com/goncalo-marques/ecomap/server/internal/domain"
+	"github.com/goncalo-marques/ecomap/server/internal/logging"
+)
+
+const (
+	errRoleNotFound      = "role not found"
+	errRoleAlreadyExists = "role already exists"
+	errRoleBuiltIn       = "built-in role cannot be deleted"
+	errRoleAssigned      = "role granted to api key or employee"
+	errRoleNotGrantable  = "role grants permissions that are not held"
+)
+
+// CreateRole handles the http request to create a role.
+func (h *handler) CreateRole(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		badRequest(w, errRequestBodyInvalid)
+		return
+	}
+
+	var rolePost spec.RolePost
+	err = json.Unmarshal(requestBody, &rolePost)
+	if err != nil {
+		badRequest(w, errRequestBodyInvalid)
+		return
+	}
+
+	domainEditableRole := rolePostToDomain(rolePost)
+	domainRole, err := h.service.CreateRole(ctx, domainEditableRole, authz.Permissions(ctx))
+	if err != nil {
+		var domainErrFieldValueInvalid *domain.ErrFieldValueInvalid
+
+		switch {
+		case errors.As(err, &domainErrFieldValueInvalid):
+			badRequest(w, fmt.Sprintf("%s: %s", errFieldValueInvalid, domainErrFieldValueInvalid.FieldName))
+		case errors.Is(err, domain.ErrRoleNotGrantable):
+			forbidden(w, errRoleNotGrantable)
+		case errors.Is(err, domain.ErrRoleAlreadyExists):
+			conflict(w, errRoleAlreadyExists)
+		default:
+			internalServerError(w)
+		}
+
+		return
+	}
+
+	role := roleFromDomain(domainRole)
+	responseBody, err := json.Marshal(role)
+	if err != nil {
+		logging.Logger.ErrorContext(ctx, descriptionFailedToMarshalResponseBody, logging.Error(err))
+		internalServerError(w)
+		return
+	}
+
+	writeResponseJSON(w, http.StatusCreated, responseBody)
+}
+
+// ListRoles handles the http request to list roles.
+func (h *handler) ListRoles(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	domainRoles, err := h.service.ListRoles(ctx)
+	if err != nil {
+		internalServerError(w)
+		return
+	}
+
+	roles := rolesFromDomain(domainRoles)
+	responseBody, err := json.Marshal(roles)
+	if err != nil {
+		logging.Logger.ErrorContext(ctx, descriptionFailedToMarshalResponseBody, logging.Error(err))
+		internalServerError(w)
+		return
+	}
+
+	writeResponseJSON(w, http.StatusOK, responseBody)
+}
+
+// GetRoleByID handles the http request to get a role by ID.
+func (h *handler) GetRoleByID(w http.ResponseWriter, r *http.Request, roleID spec.RoleIdPathParam) {
+	ctx := r.Context()
+
+	domainRole, err := h.service.GetRoleByID(ctx, roleID)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrRoleNotFound):
+			notFound(w, errRoleNotFound)
+		default:
+			internalServerError(w)
+		}
+
+		return
+	}
+
+	role := roleFromDomain(domainRole)
+	responseBody, err := json.Marshal(role)
+	if err != nil {
+		logging.Logger.ErrorContext(ctx, descriptionFailedToMarshalResponseBody, logging.Error(err))
+		internalServerError(w)
+		return
+	}
+
+	writeResponseJSON(w, http.StatusOK, responseBody)
+}
+
+// PatchRoleByID handles the http request to modify a role by ID.
+func (h *handler) PatchRoleByID(w http.ResponseWriter, r *http.Request, roleID spec.RoleIdPathParam) {
+	ctx := r.Context()
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		badRequest(w, errRequestBodyInvalid)
+		return
+	}
+
+	var rolePatch spec.RolePatch
+	err = json.Unmarshal(requestBody, &rolePatch)
+	if err != nil {
+		badRequest(w, errRequestBodyInvalid)
+		return
+	}
+
+	domainEditableRole := rolePatchToDomain(rolePatch)
+	domainRole, err := h.service.PatchRole(ctx, roleID, domainEditableRole, authz.Permissions(ctx))
+	if err != nil {
+		var domainErrFieldValueInvalid *domain.ErrFieldValueInvalid
+
+		switch {
+		case errors.As(err, &domainErrFieldValueInvalid):
+			badRequest(w, fmt.Sprintf("%s: %s", errFieldValueInvalid, domainErrFieldValueInvalid.FieldName))
+		case errors.Is(err, domain.ErrRoleNotGrantable):
+			forbidden(w, errRoleNotGrantable)
+		case errors.Is(err, domain.ErrRoleNotFound):
+			notFound(w, errRoleNotFound)
+		default:
+			internalServerError(w)
+		}
+
+		return
+	}
+
+	role := roleFromDomain(domainRole)
+	responseBody, err := json.Marshal(role)
+	if err != nil {
+		logging.Logger.ErrorContext(ctx, descriptionFailedToMarshalResponseBody, logging.Error(err))
+		internalServerError(w)
+		return
+	}
+
+	writeResponseJSON(w, http.StatusOK, responseBody)
+}
+
+// DeleteRoleByID handles the http request to delete a role by ID.
+func (h *handler) DeleteRoleByID(w http.ResponseWriter, r *http.Request, roleID spec.RoleIdPathParam) {
+	ctx := r.Context()
+
+	domainRole, err := h.service.DeleteRoleByID(ctx, roleID)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrRoleNotFound):
+			notFound(w, errRoleNotFound)
+		case errors.Is(err, domain.ErrRoleBuiltIn):
+			conflict(w, errRoleBuiltIn)
+		case errors.Is(err, domain.ErrRoleAssigned):
+			conflict(w, errRoleAssigned)
+		default:
+			internalServerError(w)
+		}
+
+		return
+	}
+
+	role := roleFromDomain(domainRole)
+	responseBody, err := json.Marshal(role)
+	if err != nil {
+		logging.Logger.ErrorContext(ctx, descriptionFailedToMarshalResponseBody, logging.Error(err))
+		internalServerError(w)
+		return
+	}
+
+	writeResponseJSON(w, http.StatusOK, responseBody)
+}
+
+// rolePostToDomain returns a domain editable role based on the standardized role post.
+func rolePostToDomain(rolePost spec.RolePost) domain.EditableRole {
+	return domain.EditableRole{
+		Name:        domain.RoleName(rolePost.Name),
+		Permissions: rolePermissionsToDomain(rolePost.Permissions),
+	}
+}
+
+// rolePatchToDomain returns a domain patchable role based on the standardized role patch.
+func rolePatchToDomain(rolePatch spec.RolePatch) domain.EditableRolePatch {
+	var permissions *domain.RolePermissions
+	if rolePatch.Permissions != nil {
+		p := rolePermissionsToDomain(*rolePatch.Permissions)
+		permissions = &p
+	}
+
+	return domain.EditableRolePatch{
+		Permissions: permissions,
+	}
+}
+
+// roleFromDomain returns a standardized role based on the domain model.
+func roleFromDomain(role domain.Role) spec.Role {
+	return spec.Role{
+		Id:          role.ID,
+		Name:        string(role.Name),
+		Permissions: rolePermissionsFromDomain(role.Permissions),
+		BuiltIn:     role.BuiltIn,
+		CreatedAt:   role.CreatedAt,
+		ModifiedAt:  role.ModifiedAt,
+	}
+}
+
+// rolesFromDomain returns standardized roles based on the domain model.
+func rolesFromDomain(roles []domain.Role) spec.Roles {
+	r := make([]spec.Role, len(roles))
+	for i, role := range roles {
+		r[i] = roleFromDomain(role)
+	}
+
+	return spec.Roles{
+		Roles: r,
+	}
+}
+
+// rolePermissionsToDomain returns domain role permissions based on the standardized model.
+func rolePermissionsToDomain(permissions spec.RolePermissions) domain.RolePermissions {
+	domainPermissions := make(domain.RolePermissions, len(permissions))
+	for i, permission := range permissions {
+		domainPermissions[i] = domain.Permission(permission)
+	}
+
+	return domainPermissions
+}
+
+// rolePermissionsFromDomain returns standardized role permissions based on the domain model.
+func rolePermissionsFromDomain(permissions domain.RolePermissions) spec.RolePermissions {
+	specPermissions := make(spec.RolePermissions, len(permissions))
+	for i, permission := range permissions {
+		specPermissions[i] = spec.Permission(permission)
+	}
+
+	return specPermissions
+}
@@ -13,11 +13,19 @@ import (
 )
 
 const (
-	errRouteNotFound                    = "route not found"
-	errRouteDepartureWarehouseNotFound  = "route departure warehouse not found"
-	errRouteArrivalWarehouseNotFound    = "route arrival warehouse not found"
-	errRouteTruckPersonCapacityMinLimit = "route already has more employees than the new truck has capacity"
-	errRouteTruckPersonCapacityMaxLimit = "route cannot have more employees than the truck has capacity"
+	errRouteNotFound                               = "route not found"
+	errRouteDepartureWarehouseNotFound             = "route departure warehouse not found"
+	errRouteArrivalWarehouseNotFound               = "route arrival warehouse not found"
+	errRouteTruckPersonCapacityMinLimit            = "route already has more employees than the new truck has capacity"
+	errRouteTruckPersonCapacityMaxLimit            = "route cannot have more employees than the truck has capacity"
+	errRouteLandfillNotFound                       = "no landfill accepts every container category of the route"
+	errRouteStopUnreachable                        = "a stop of the route cannot be reached by road"
+	errRoutePayloadCapacityExceeded                = "the load of the route exceeds the payload capacity of the truck"
+	errRouteTruckCompartmentsIncompatible          = "the new truck compartments do not accept every container category of the route"
+	errRouteInProgress                             = "route already in progress"
+	errRouteNotInProgress                          = "route not in progress"
+	errRouteTruckUnavailable                       = "the route truck is in maintenance or retired"
+	errRouteHazardousMaterialsCertificationMissing = "the route collects hazardous containers and no crew member holds a valid hazardous materials certification"
 )
 
 // CreateRoute handles the http request to create a route.
@@ -37,7 +45,20 @@ func (h *handler) CreateRoute(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	domainEditableRoute := routePostToDomain(routePost)
+	domainEditableRoute, err := routePostToDomain(routePost)
+	if err != nil {
+		var domainErrFieldValueInvalid *domain.ErrFieldValueInvalid
+
+		switch {
+		case errors.As(err, &domainErrFieldValueInvalid):
+			badRequest(w, fmt.Sprintf("%s: %s", errFieldValueInvalid, domainErrFieldValueInvalid.FieldName))
+		default:
+			internalServerError(w)
+		}
+
+		return
+	}
+
 	domainRoute, err := h.service.CreateRoute(ctx, domainEditableRoute)
 	if err != nil {
 		var domainErrFieldValueInvalid *domain.ErrFieldValueInvalid
@@ -51,6 +72,8 @@ func (h *handler) CreateRoute(w http.ResponseWriter, r *http.Request) {
 			conflict(w, errRouteDepartureWarehouseNotFound)
 		case errors.Is(err, domain.ErrRouteArrivalWarehouseNotFound):
 			conflict(w, errRouteArrivalWarehouseNotFound)
+		case errors.Is(err, domain.ErrRouteTruckUnavailable):
+			conflict(w, errRouteTruckUnavailable)
 		default:
 			internalServerError(w)
 		}
@@ -161,7 +184,20 @@ func (h *handler) PatchRouteByID(w http.ResponseWriter, r *http.Request, routeID
 		return
 	}
 
-	domainEditableRoute := routePatchToDomain(routePatch)
+	domainEditableRoute, err := routePatchToDomain(routePatch)
+	if err != nil {
+		var domainErrFieldValueInvalid *domain.ErrFieldValueInvalid
+
+		switch {
+		case errors.As(err, &domainErrFieldValueInvalid):
+			badRequest(w, fmt.Sprintf("%s: %s", errFieldValueInvalid, domainErrFieldValueInvalid.FieldName))
+		default:
+			internalServerError(w)
+		}
+
+		return
+	}
+
 	domainRoute, err := h.service.PatchRoute(ctx, routeID, domainEditableRoute)
 	if err != nil {
 		var domainErrFieldValueInvalid *domain.ErrFieldValueInvalid
@@ -179,6 +215,10 @@ func (h *handler) PatchRouteByID(w http.ResponseWriter, r *http.Request, routeID
 			conflict(w, errRouteArrivalWarehouseNotFound)
 		case errors.Is(err, domain.ErrRouteTruckPersonCapacityMinLimit):
 			conflict(w, errRouteTruckPersonCapacityMinLimit)
+		case errors.Is(err, domain.ErrRouteTruckCompartmentsIncompatible):
+			conflict(w, errRouteTruckCompartmentsIncompatible)
+		case errors.Is(err, domain.ErrRouteTruckUnavailable):
+			conflict(w, errRouteTruckUnavailable)
 		default:
 			internalServerError(w)
 		}
@@ -245,6 +285,12 @@ func (h *handler) GetRouteWays(w http.ResponseWriter, r *http.Request, routeID s
 		switch {
 		case errors.Is(err, domain.ErrRouteNotFound):
 			notFound(w, errRouteNotFound)
+		case errors.Is(err, domain.ErrRouteLandfillNotFound):
+			conflict(w, errRouteLandfillNotFound)
+		case errors.Is(err, domain.ErrRouteStopUnreachable):
+			conflict(w, errRouteStopUnreachable)
+		case errors.Is(err, domain.ErrRoutePayloadCapacityExceeded):
+			conflict(w, errRoutePayloadCapacityExceeded)
 		default:
 			internalServerError(w)
 		}
@@ -269,24 +315,211 @@ func (h *handler) GetRouteWays(w http.ResponseWriter, r *http.Request, routeID s
 	writeResponseJSON(w, http.StatusOK, responseBody)
 }
 
+// GetRouteCategories handles the http request to get the container categories collected by a route.
+func (h *handler) GetRouteCategories(w http.ResponseWriter, r *http.Request, routeID spec.RouteIdPathParam) {
+	ctx := r.Context()
+
+	domainRouteCategories, err := h.service.GetRouteCategories(ctx, routeID)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrRouteNotFound):
+			notFound(w, errRouteNotFound)
+		default:
+			internalServerError(w)
+		}
+
+		return
+	}
+
+	routeCategories := routeCategoriesFromDomain(domainRouteCategories)
+	responseBody, err := json.Marshal(routeCategories)
+	if err != nil {
+		logging.Logger.ErrorContext(ctx, descriptionFailedToMarshalResponseBody, logging.Error(err))
+		internalServerError(w)
+		return
+	}
+
+	writeResponseJSON(w, http.StatusOK, responseBody)
+}
+
+// GetRouteEmissions handles the http request to get the emissions of a route.
+func (h *handler) GetRouteEmissions(w http.ResponseWriter, r *http.Request, routeID spec.RouteIdPathParam) {
+	ctx := r.Context()
+
+	domainRouteEmissions, err := h.service.GetRouteEmissions(ctx, routeID)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrRouteNotFound):
+			notFound(w, errRouteNotFound)
+		case errors.Is(err, domain.ErrRouteLandfillNotFound):
+			conflict(w, errRouteLandfillNotFound)
+		case errors.Is(err, domain.ErrRouteStopUnreachable):
+			conflict(w, errRouteStopUnreachable)
+		case errors.Is(err, domain.ErrRoutePayloadCapacityExceeded):
+			conflict(w, errRoutePayloadCapacityExceeded)
+		default:
+			internalServerError(w)
+		}
+
+		return
+	}
+
+	routeEmissions := routeEmissionsFromDomain(domainRouteEmissions)
+	responseBody, err := json.Marshal(routeEmissions)
+	if err != nil {
+		logging.Logger.ErrorContext(ctx, descriptionFailedToMarshalResponseBody, logging.Error(err))
+		internalServerError(w)
+		return
+	}
+
+	writeResponseJSON(w, http.StatusOK, responseBody)
+}
+
+// GetRouteStops handles the http request to get the stops of a route.
+func (h *handler) GetRouteStops(w http.ResponseWriter, r *http.Request, routeID spec.RouteIdPathParam) {
+	ctx := r.Context()
+
+	domainRouteStops, err := h.service.GetRouteStops(ctx, routeID)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrRouteNotFound):
+			notFound(w, errRouteNotFound)
+		case errors.Is(err, domain.ErrRouteLandfillNotFound):
+			conflict(w, errRouteLandfillNotFound)
+		case errors.Is(err, domain.ErrRouteStopUnreachable):
+			conflict(w, errRouteStopUnreachable)
+		case errors.Is(err, domain.ErrRoutePayloadCapacityExceeded):
+			conflict(w, errRoutePayloadCapacityExceeded)
+		default:
+			internalServerError(w)
+		}
+
+		return
+	}
+
+	routeStops, err := routeStopsFromDomain(domainRouteStops)
+	if err != nil {
+		logging.Logger.ErrorContext(ctx, descriptionFailedToMapResponseBody, logging.Error(err))
+		internalServerError(w)
+		return
+	}
+
+	responseBody, err := json.Marshal(routeStops)
+	if err != nil {
+		logging.Logger.ErrorContext(ctx, descriptionFailedToMarshalResponseBody, logging.Error(err))
+		internalServerError(w)
+		return
+	}
+
+	writeResponseJSON(w, http.StatusOK, responseBody)
+}
+
+// StartRoute handles the http request to start a route.
+func (h *handler) StartRoute(w http.ResponseWriter, r *http.Request, routeID spec.RouteIdPathParam) {
+	ctx := r.Context()
+
+	domainRoute, err := h.service.StartRoute(ctx, routeID)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrRouteNotFound):
+			notFound(w, errRouteNotFound)
+		case errors.Is(err, domain.ErrRouteInProgress):
+			conflict(w, errRouteInProgress)
+		case errors.Is(err, domain.ErrRouteTruckUnavailable):
+			conflict(w, errRouteTruckUnavailable)
+		case errors.Is(err, domain.ErrRouteEmployeeLicenseMissing):
+			conflict(w, errRouteEmployeeLicenseMissing)
+		case errors.Is(err, domain.ErrRouteHazardousMaterialsCertificationMissing):
+			conflict(w, errRouteHazardousMaterialsCertificationMissing)
+		default:
+			internalServerError(w)
+		}
+
+		return
+	}
+
+	route, err := routeFromDomain(domainRoute)
+	if err != nil {
+		logging.Logger.ErrorContext(ctx, descriptionFailedToMapResponseBody, logging.Error(err))
+		internalServerError(w)
+		return
+	}
+
+	responseBody, err := json.Marshal(route)
+	if err != nil {
+		logging.Logger.ErrorContext(ctx, descriptionFailedToMarshalResponseBody, logging.Error(err))
+		internalServerError(w)
+		return
+	}
+
+	writeResponseJSON(w, http.StatusOK, responseBody)
+}
+
+// FinishRoute handles the http request to finish a route.
+func (h *handler) FinishRoute(w http.ResponseWriter, r *http.Request, routeID spec.RouteIdPathParam) {
+	ctx := r.Context()
+
+	domainRoute, err := h.service.FinishRoute(ctx, routeID)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrRouteNotFound):
+			notFound(w, errRouteNotFound)
+		case errors.Is(err, domain.ErrRouteNotInProgress):
+			conflict(w, errRouteNotInProgress)
+		default:
+			internalServerError(w)
+		}
+
+		return
+	}
+
+	route, err := routeFromDomain(domainRoute)
+	if err != nil {
+		logging.Logger.ErrorContext(ctx, descriptionFailedToMapResponseBody, logging.Error(err))
+		internalServerError(w)
+		return
+	}
+
+	responseBody, err := json.Marshal(route)
+	if err != nil {
+		logging.Logger.ErrorContext(ctx, descriptionFailedToMarshalResponseBody, logging.Error(err))
+		internalServerError(w)
+		return
+	}
+
+	writeResponseJSON(w, http.StatusOK, responseBody)
+}
+
 // routePostToDomain returns a domain editable route based on the standardized route post.
-func routePostToDomain(routePost spec.RoutePost) domain.EditableRoute {
+func routePostToDomain(routePost spec.RoutePost) (domain.EditableRoute, error) {
+	departureTime, err := optionalTimeStringToTime(routePost.DepartureTime, domain.FieldDepartureTime)
+	if err != nil {
+		return domain.EditableRoute{}, err
+	}
+
 	return domain.EditableRoute{
 		Name:                 domain.RouteName(routePost.Name),
 		TruckID:              routePost.TruckId,
 		DepartureWarehouseID: routePost.DepartureWarehouseId,
 		ArrivalWarehouseID:   routePost.ArrivalWarehouseId,
-	}
+		DepartureTime:        departureTime,
+	}, nil
 }
 
 // routePatchToDomain returns a domain patchable route based on the standardized route patch.
-func routePatchToDomain(routePatch spec.RoutePatch) domain.EditableRoutePatch {
+func routePatchToDomain(routePatch spec.RoutePatch) (domain.EditableRoutePatch, error) {
+	departureTime, err := optionalTimeStringToTime(routePatch.DepartureTime, domain.FieldDepartureTime)
+	if err != nil {
+		return domain.EditableRoutePatch{}, err
+	}
+
 	return domain.EditableRoutePatch{
 		Name:                 (*domain.RouteName)(routePatch.Name),
 		TruckID:              routePatch.TruckId,
 		DepartureWarehouseID: routePatch.DepartureWarehouseId,
 		ArrivalWarehouseID:   routePatch.ArrivalWarehouseId,
-	}
+		DepartureTime:        departureTime,
+	}, nil
 }
 
 // listRoutesParamsToDomain returns a domain routes paginated filter based on the standardized list routes parameters.
@@ -348,6 +581,9 @@ func routeFromDomain(route domain.Route) (spec.Route, error) {
 		Truck:              truck,
 		DepartureWarehouse: departureWarehouse,
 		ArrivalWarehouse:   arrivalWarehouse,
+		DepartureTime:      optionalTimeStringFromTime(route.DepartureTime),
+		StartedAt:          route.StartedAt,
+		FinishedAt:         route.FinishedAt,
 		CreatedAt:          route.CreatedAt,
 		ModifiedAt:         route.ModifiedAt,
 	}, nil
@@ -380,3 +616,74 @@ func routesPaginatedFromDomain(paginatedResponse domain.PaginatedResponse[domain
 		Routes: routes,
 	}, nil
 }
+
+// routeCategoriesFromDomain returns standardized route categories based on the domain models.
+func routeCategoriesFromDomain(categories []domain.RouteCategory) spec.RouteCategories {
+	specCategories := make([]spec.RouteCategory, len(categories))
+	for i, category := range categories {
+		specCategories[i] = spec.RouteCategory{
+			Category:         containerCategoryFromDomain(category.Category),
+			CompartmentIndex: category.CompartmentIndex,
+			Containers:       category.Containers,
+			Volume:           category.Volume,
+			Weight:           category.Weight,
+		}
+	}
+
+	return spec.RouteCategories{
+		Categories: specCategories,
+	}
+}
+
+// routeEmissionsFromDomain returns standardized route emissions based on the domain model.
+func routeEmissionsFromDomain(emissions domain.RouteEmissions) spec.RouteEmissions {
+	return spec.RouteEmissions{
+		FuelType:        truckFuelTypeFromDomain(emissions.FuelType),
+		FuelConsumption: (*float64)(emissions.FuelConsumption),
+		Distance:        emissions.Distance,
+		Fuel:            emissions.Fuel,
+		Co2:             emissions.CO2,
+	}
+}
+
+// routeStopTypeFromDomain returns a standardized route stop type based on the domain model.
+func routeStopTypeFromDomain(stopType domain.RouteStopType) spec.RouteStopType {
+	switch stopType {
+	case domain.RouteStopTypeDepartureWarehouse:
+		return spec.RouteStopTypeDepartureWarehouse
+	case domain.RouteStopTypeContainer:
+		return spec.RouteStopTypeContainer
+	case domain.RouteStopTypeLandfill:
+		return spec.RouteStopTypeLandfill
+	case domain.RouteStopTypeArrivalWarehouse:
+		return spec.RouteStopTypeArrivalWarehouse
+	default:
+		return spec.RouteStopType(stopType)
+	}
+}
+
+// routeStopsFromDomain returns standardized route stops based on the domain models.
+func routeStopsFromDomain(stops []domain.RouteStop) (spec.RouteStops, error) {
+	specStops := make([]spec.RouteStop, len(stops))
+
+	for i, stop := range stops {
+		geoJSON, err := geoJSONFeaturePointFromDomain(stop.GeoJSON)
+		if err != nil {
+			return spec.RouteStops{}, err
+		}
+
+		specStops[i] = spec.RouteStop{
+			Type:                     routeStopTypeFromDomain(stop.Type),
+			Id:                       stop.ID,
+			GeoJson:                  geoJSON,
+			LoadVolume:               stop.LoadVolume,
+			LoadWeight:               stop.LoadWeight,
+			ArrivalTime:              optionalTimeStringFromTime(stop.ArrivalTime),
+			CollectionWindowViolated: stop.CollectionWindowViolated,
+		}
+	}
+
+	return spec.RouteStops{
+		Stops: specStops,
+	}, nil
+}
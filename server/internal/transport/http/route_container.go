@@ -12,8 +12,9 @@ import (
 )
 
 const (
-	errRouteContainerAlreadyExists = "route container association already exists"
-	errRouteContainerNotFound      = "route container association does not exist"
+	errRouteContainerAlreadyExists        = "route container association already exists"
+	errRouteContainerNotFound             = "route container association does not exist"
+	errRouteContainerCategoryIncompatible = "no compartment of the route truck accepts the container category"
 )
 
 // ListRouteContainers handles the http request to list route containers.
@@ -65,6 +66,8 @@ func (h *handler) CreateRouteContainer(w http.ResponseWriter, r *http.Request, r
 			notFound(w, errContainerNotFound)
 		case errors.Is(err, domain.ErrRouteContainerAlreadyExists):
 			conflict(w, errRouteContainerAlreadyExists)
+		case errors.Is(err, domain.ErrRouteContainerCategoryIncompatible):
+			conflict(w, errRouteContainerCategoryIncompatible)
 		default:
 			internalServerError(w)
 		}
@@ -0,0 +1,133 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	spec "github.com/goncalo-marques/ecomap/server/api/ecomap"
+	"github.com/goncalo-marques/ecomap/server/internal/domain"
+	"github.com/goncalo-marques/ecomap/server/internal/logging"
+)
+
+// ListRouteContainerCollections handles the http request to list route container collections.
+func (h *handler) ListRouteContainerCollections(w http.ResponseWriter, r *http.Request, routeID spec.RouteIdPathParam) {
+	ctx := r.Context()
+
+	domainCollections, err := h.service.ListRouteContainerCollections(ctx, routeID)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrRouteNotFound):
+			notFound(w, errRouteNotFound)
+		default:
+			internalServerError(w)
+		}
+
+		return
+	}
+
+	collections := routeContainerCollectionsFromDomain(domainCollections)
+
+	responseBody, err := json.Marshal(collections)
+	if err != nil {
+		logging.Logger.ErrorContext(ctx, descriptionFailedToMarshalResponseBody, logging.Error(err))
+		internalServerError(w)
+		return
+	}
+
+	writeResponseJSON(w, http.StatusOK, responseBody)
+}
+
+// PutRouteContainerCollection handles the http request to put a route container collection.
+func (h *handler) PutRouteContainerCollection(w http.ResponseWriter, r *http.Request, routeID spec.RouteIdPathParam, containerID spec.ContainerIdPathParam) {
+	ctx := r.Context()
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		badRequest(w, errRequestBodyInvalid)
+		return
+	}
+
+	var collectionPut spec.RouteContainerCollectionPut
+	err = json.Unmarshal(requestBody, &collectionPut)
+	if err != nil {
+		badRequest(w, errRequestBodyInvalid)
+		return
+	}
+
+	domainEditableCollection := routeContainerCollectionPutToDomain(collectionPut)
+	domainCollection, err := h.service.PutRouteContainerCollection(ctx, routeID, containerID, domainEditableCollection)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrRouteNotFound):
+			notFound(w, errRouteNotFound)
+		case errors.Is(err, domain.ErrRouteContainerNotFound):
+			notFound(w, errRouteContainerNotFound)
+		case errors.Is(err, domain.ErrRouteNotInProgress):
+			conflict(w, errRouteNotInProgress)
+		default:
+			internalServerError(w)
+		}
+
+		return
+	}
+
+	collection := routeContainerCollectionFromDomain(domainCollection)
+
+	responseBody, err := json.Marshal(collection)
+	if err != nil {
+		logging.Logger.ErrorContext(ctx, descriptionFailedToMarshalResponseBody, logging.Error(err))
+		internalServerError(w)
+		return
+	}
+
+	writeResponseJSON(w, http.StatusOK, responseBody)
+}
+
+// routeContainerCollectionPutToDomain returns a domain editable route container collection based on the standardized
+// route container collection put.
+func routeContainerCollectionPutToDomain(collectionPut spec.RouteContainerCollectionPut) domain.EditableRouteContainerCollection {
+	return domain.EditableRouteContainerCollection{
+		Collected: collectionPut.Collected,
+	}
+}
+
+// routeContainerCollectionSourceFromDomain returns a standardized route container collection source based on the
+// domain model.
+func routeContainerCollectionSourceFromDomain(source domain.RouteContainerCollectionSource) spec.RouteContainerCollectionSource {
+	switch source {
+	case domain.RouteContainerCollectionSourceAutomatic:
+		return spec.Automatic
+	case domain.RouteContainerCollectionSourceManual:
+		return spec.Manual
+	default:
+		return spec.RouteContainerCollectionSource(source)
+	}
+}
+
+// routeContainerCollectionFromDomain returns a standardized route container collection based on the domain model.
+func routeContainerCollectionFromDomain(collection domain.RouteContainerCollection) spec.RouteContainerCollection {
+	return spec.RouteContainerCollection{
+		ContainerId:    collection.ContainerID,
+		RouteStartedAt: collection.RouteStartedAt,
+		Collected:      collection.Collected,
+		Confidence:     collection.Confidence,
+		Source:         routeContainerCollectionSourceFromDomain(collection.Source),
+		CollectedAt:    collection.CollectedAt,
+		CreatedAt:      collection.CreatedAt,
+		ModifiedAt:     collection.ModifiedAt,
+	}
+}
+
+// routeContainerCollectionsFromDomain returns standardized route container collections based on the domain model.
+func routeContainerCollectionsFromDomain(collections []domain.RouteContainerCollection) spec.RouteContainerCollections {
+	specCollections := make([]spec.RouteContainerCollection, len(collections))
+	for i, collection := range collections {
+		specCollections[i] = routeContainerCollectionFromDomain(collection)
+	}
+
+	return spec.RouteContainerCollections{
+		Collections: specCollections,
+	}
+}
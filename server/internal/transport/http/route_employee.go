@@ -13,8 +13,12 @@ import (
 )
 
 const (
-	errRouteEmployeeAlreadyExists = "route employee association already exists"
-	errRouteEmployeeNotFound      = "route employee association does not exist"
+	errRouteEmployeeAlreadyExists  = "route employee association already exists"
+	errRouteEmployeeNotFound       = "route employee association does not exist"
+	errRouteEmployeeAbsent         = "employee is absent at the next route departure"
+	errRouteEmployeeOffShift       = "employee is off shift at the next route departure"
+	errRouteEmployeeLicenseMissing = "driver does not hold a valid license of the category required by the route truck"
+	errRouteCrewDriverNotFound     = "no available employee holds a valid license of the category required by the route truck"
 )
 
 // ListRouteEmployees handles the http request to list route employees.
@@ -86,6 +90,14 @@ func (h *handler) CreateRouteEmployee(w http.ResponseWriter, r *http.Request, ro
 			conflict(w, errRouteTruckPersonCapacityMaxLimit)
 		case errors.Is(err, domain.ErrRouteEmployeeAlreadyExists):
 			conflict(w, errRouteEmployeeAlreadyExists)
+		case errors.Is(err, domain.ErrRouteEmployeeAbsent):
+			conflict(w, errRouteEmployeeAbsent)
+		case errors.Is(err, domain.ErrRouteEmployeeOffShift):
+			conflict(w, errRouteEmployeeOffShift)
+		case errors.Is(err, domain.ErrRouteEmployeeLicenseMissing):
+			conflict(w, errRouteEmployeeLicenseMissing)
+		case errors.Is(err, domain.ErrRouteHazardousMaterialsCertificationMissing):
+			conflict(w, errRouteHazardousMaterialsCertificationMissing)
 		default:
 			internalServerError(w)
 		}
@@ -115,6 +127,48 @@ func (h *handler) DeleteRouteEmployee(w http.ResponseWriter, r *http.Request, ro
 	writeResponseJSON(w, http.StatusNoContent, nil)
 }
 
+// AutoAssignRouteCrew handles the http request to auto assign the route crew.
+func (h *handler) AutoAssignRouteCrew(w http.ResponseWriter, r *http.Request, routeID spec.RouteIdPathParam, params spec.AutoAssignRouteCrewParams) {
+	ctx := r.Context()
+
+	preview := false
+	if params.Preview != nil {
+		preview = *params.Preview
+	}
+
+	domainRouteCrewMembers, err := h.service.AutoAssignRouteCrew(ctx, routeID, preview)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrRouteNotFound):
+			notFound(w, errRouteNotFound)
+		case errors.Is(err, domain.ErrRouteCrewDriverNotFound):
+			conflict(w, errRouteCrewDriverNotFound)
+		case errors.Is(err, domain.ErrRouteHazardousMaterialsCertificationMissing):
+			conflict(w, errRouteHazardousMaterialsCertificationMissing)
+		default:
+			internalServerError(w)
+		}
+
+		return
+	}
+
+	routeCrew, err := routeCrewFromDomain(domainRouteCrewMembers)
+	if err != nil {
+		logging.Logger.ErrorContext(ctx, descriptionFailedToMapResponseBody, logging.Error(err))
+		internalServerError(w)
+		return
+	}
+
+	responseBody, err := json.Marshal(routeCrew)
+	if err != nil {
+		logging.Logger.ErrorContext(ctx, descriptionFailedToMarshalResponseBody, logging.Error(err))
+		internalServerError(w)
+		return
+	}
+
+	writeResponseJSON(w, http.StatusOK, responseBody)
+}
+
 // routeEmployeeRoleToDomain returns a domain route employee role based on the standardized model.
 func routeEmployeeRoleToDomain(role spec.RouteEmployeeRole) domain.RouteEmployeeRole {
 	switch role {
@@ -196,6 +250,7 @@ func routeEmployeeFromDomain(routeEmployee domain.RouteEmployee) (spec.RouteEmpl
 		GeoJson:       geoJSON,
 		ScheduleStart: timeStringFromTime(routeEmployee.ScheduleStart),
 		ScheduleEnd:   timeStringFromTime(routeEmployee.ScheduleEnd),
+		Shifts:        employeeShiftsFromDomain(routeEmployee.Shifts),
 		CreatedAt:     routeEmployee.CreatedAt,
 		ModifiedAt:    routeEmployee.ModifiedAt,
 		RouteRole:     routeEmployeeRoleFromDomain(routeEmployee.RouteRole),
@@ -229,3 +284,37 @@ func routeEmployeesPaginatedFromDomain(paginatedResponse domain.PaginatedRespons
 		Employees: routeEmployees,
 	}, nil
 }
+
+// routeCrewFromDomain returns a standardized route crew based on the domain model.
+func routeCrewFromDomain(routeCrewMembers []domain.RouteCrewMember) (spec.RouteCrew, error) {
+	specRouteCrewMembers := make([]spec.RouteCrewMember, len(routeCrewMembers))
+
+	for i, member := range routeCrewMembers {
+		routeEmployee, err := routeEmployeeFromDomain(member.RouteEmployee)
+		if err != nil {
+			return spec.RouteCrew{}, err
+		}
+
+		specRouteCrewMembers[i] = spec.RouteCrewMember{
+			Id:            routeEmployee.Id,
+			Username:      routeEmployee.Username,
+			FirstName:     routeEmployee.FirstName,
+			LastName:      routeEmployee.LastName,
+			Role:          routeEmployee.Role,
+			DateOfBirth:   routeEmployee.DateOfBirth,
+			PhoneNumber:   routeEmployee.PhoneNumber,
+			GeoJson:       routeEmployee.GeoJson,
+			ScheduleStart: routeEmployee.ScheduleStart,
+			ScheduleEnd:   routeEmployee.ScheduleEnd,
+			Shifts:        routeEmployee.Shifts,
+			CreatedAt:     routeEmployee.CreatedAt,
+			ModifiedAt:    routeEmployee.ModifiedAt,
+			RouteRole:     routeEmployee.RouteRole,
+			Distance:      member.Distance,
+		}
+	}
+
+	return spec.RouteCrew{
+		Employees: specRouteCrewMembers,
+	}, nil
+}
@@ -0,0 +1,299 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+
+	spec "github.com/goncalo-marques/ecomap/server/api/ecomap"
+	"github.com/goncalo-marques/ecomap/server/internal/domain"
+	"github.com/goncalo-marques/ecomap/server/internal/logging"
+)
+
+const (
+	errRouteTrackNotFound = "route track not found"
+)
+
+// ListRouteTracks handles the http request to list route tracks.
+func (h *handler) ListRouteTracks(w http.ResponseWriter, r *http.Request, params spec.ListRouteTracksParams) {
+	ctx := r.Context()
+
+	domainRouteTracksFilter := listRouteTracksParamsToDomain(params)
+	domainPaginatedRouteTracks, err := h.service.ListRouteTracks(ctx, domainRouteTracksFilter)
+	if err != nil {
+		var domainErrFilterValueInvalid *domain.ErrFilterValueInvalid
+
+		switch {
+		case errors.As(err, &domainErrFilterValueInvalid):
+			badRequest(w, fmt.Sprintf("%s: %s", errFilterValueInvalid, domainErrFilterValueInvalid.FilterName))
+		default:
+			internalServerError(w)
+		}
+
+		return
+	}
+
+	routeDeviationsPaginated := routeDeviationsPaginatedFromDomain(domainPaginatedRouteTracks)
+
+	responseBody, err := json.Marshal(routeDeviationsPaginated)
+	if err != nil {
+		logging.Logger.ErrorContext(ctx, descriptionFailedToMarshalResponseBody, logging.Error(err))
+		internalServerError(w)
+		return
+	}
+
+	writeResponseJSON(w, http.StatusOK, responseBody)
+}
+
+// PutRouteTrack handles the http request to put a route track.
+func (h *handler) PutRouteTrack(w http.ResponseWriter, r *http.Request, routeID spec.RouteIdPathParam, date spec.RouteTrackDatePathParam) {
+	ctx := r.Context()
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		badRequest(w, errRequestBodyInvalid)
+		return
+	}
+
+	var routeTrackPut spec.RouteTrackPut
+	err = json.Unmarshal(requestBody, &routeTrackPut)
+	if err != nil {
+		badRequest(w, errRequestBodyInvalid)
+		return
+	}
+
+	domainEditableRouteTrack, err := routeTrackPutToDomain(routeTrackPut)
+	if err != nil {
+		var domainErrFieldValueInvalid *domain.ErrFieldValueInvalid
+
+		switch {
+		case errors.As(err, &domainErrFieldValueInvalid):
+			badRequest(w, fmt.Sprintf("%s: %s", errFieldValueInvalid, domainErrFieldValueInvalid.FieldName))
+		default:
+			internalServerError(w)
+		}
+
+		return
+	}
+
+	domainRouteTrack, err := h.service.PutRouteTrack(ctx, routeID, date.Time, domainEditableRouteTrack)
+	if err != nil {
+		var domainErrFieldValueInvalid *domain.ErrFieldValueInvalid
+
+		switch {
+		case errors.As(err, &domainErrFieldValueInvalid):
+			badRequest(w, fmt.Sprintf("%s: %s", errFieldValueInvalid, domainErrFieldValueInvalid.FieldName))
+		case errors.Is(err, domain.ErrRouteNotFound):
+			notFound(w, errRouteNotFound)
+		case errors.Is(err, domain.ErrRouteLandfillNotFound):
+			conflict(w, errRouteLandfillNotFound)
+		case errors.Is(err, domain.ErrRouteStopUnreachable):
+			conflict(w, errRouteStopUnreachable)
+		case errors.Is(err, domain.ErrRoutePayloadCapacityExceeded):
+			conflict(w, errRoutePayloadCapacityExceeded)
+		default:
+			internalServerError(w)
+		}
+
+		return
+	}
+
+	routeTrack, err := routeTrackFromDomain(domainRouteTrack)
+	if err != nil {
+		logging.Logger.ErrorContext(ctx, descriptionFailedToMapResponseBody, logging.Error(err))
+		internalServerError(w)
+		return
+	}
+
+	responseBody, err := json.Marshal(routeTrack)
+	if err != nil {
+		logging.Logger.ErrorContext(ctx, descriptionFailedToMarshalResponseBody, logging.Error(err))
+		internalServerError(w)
+		return
+	}
+
+	writeResponseJSON(w, http.StatusOK, responseBody)
+}
+
+// GetRouteTrack handles the http request to get a route track.
+func (h *handler) GetRouteTrack(w http.ResponseWriter, r *http.Request, routeID spec.RouteIdPathParam, date spec.RouteTrackDatePathParam) {
+	ctx := r.Context()
+
+	domainRouteTrack, err := h.service.GetRouteTrack(ctx, routeID, date.Time)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrRouteTrackNotFound):
+			notFound(w, errRouteTrackNotFound)
+		default:
+			internalServerError(w)
+		}
+
+		return
+	}
+
+	routeTrack, err := routeTrackFromDomain(domainRouteTrack)
+	if err != nil {
+		logging.Logger.ErrorContext(ctx, descriptionFailedToMapResponseBody, logging.Error(err))
+		internalServerError(w)
+		return
+	}
+
+	responseBody, err := json.Marshal(routeTrack)
+	if err != nil {
+		logging.Logger.ErrorContext(ctx, descriptionFailedToMarshalResponseBody, logging.Error(err))
+		internalServerError(w)
+		return
+	}
+
+	writeResponseJSON(w, http.StatusOK, responseBody)
+}
+
+// listRouteTracksParamsToDomain returns a domain route tracks paginated filter based on the standardized list route
+// tracks parameters. The route tracks are sorted by their deviation in descending order by default.
+func listRouteTracksParamsToDomain(params spec.ListRouteTracksParams) domain.RouteTracksPaginatedFilter {
+	domainSort := domain.RouteTrackPaginatedSortDeviation
+	if params.Sort != nil {
+		switch *params.Sort {
+		case spec.ListRouteTracksParamsSortDeviation:
+			domainSort = domain.RouteTrackPaginatedSortDeviation
+		case spec.ListRouteTracksParamsSortExtraDistance:
+			domainSort = domain.RouteTrackPaginatedSortExtraDistance
+		case spec.ListRouteTracksParamsSortExtraDuration:
+			domainSort = domain.RouteTrackPaginatedSortExtraDuration
+		case spec.ListRouteTracksParamsSortDate:
+			domainSort = domain.RouteTrackPaginatedSortDate
+		default:
+			domainSort = domain.RouteTrackPaginatedSort(*params.Sort)
+		}
+	}
+
+	order := spec.OrderQueryParamDesc
+	if params.Order != nil {
+		order = spec.OrderQueryParam(*params.Order)
+	}
+
+	var date *time.Time
+	if params.Date != nil {
+		date = &params.Date.Time
+	}
+
+	return domain.RouteTracksPaginatedFilter{
+		PaginatedRequest: paginatedRequestToDomain(
+			domainSort,
+			&order,
+			params.Limit,
+			params.Offset,
+		),
+		RouteID: params.RouteId,
+		Date:    date,
+	}
+}
+
+// routeTrackPutToDomain returns a domain editable route track based on the standardized route track put.
+func routeTrackPutToDomain(routeTrackPut spec.RouteTrackPut) (domain.EditableRouteTrack, error) {
+	coordinates := make([][2]float64, len(routeTrackPut.Geometry.Coordinates))
+	for i, c := range routeTrackPut.Geometry.Coordinates {
+		if len(c) != 2 {
+			return domain.EditableRouteTrack{}, &domain.ErrFieldValueInvalid{FieldName: domain.FieldGeometry}
+		}
+
+		coordinates[i] = [2]float64(c)
+	}
+
+	return domain.EditableRouteTrack{
+		Geometry: domain.GeoJSONGeometryLineString{
+			Coordinates: coordinates,
+		},
+		Timestamps: routeTrackPut.Timestamps,
+	}, nil
+}
+
+// routeDeviationFromDomain returns a standardized route deviation based on the domain route track model.
+func routeDeviationFromDomain(track domain.RouteTrack) spec.RouteDeviation {
+	missedContainers := make([]uuid.UUID, len(track.Deviation.MissedContainerIDs))
+	copy(missedContainers, track.Deviation.MissedContainerIDs)
+
+	return spec.RouteDeviation{
+		RouteId:          track.RouteID,
+		Date:             dateFromTime(track.Date),
+		PlannedDistance:  track.Deviation.PlannedDistance,
+		ActualDistance:   track.Deviation.ActualDistance,
+		ExtraDistance:    track.Deviation.ExtraDistance(),
+		OffRouteDistance: track.Deviation.OffRouteDistance,
+		PlannedDuration:  int(track.Deviation.PlannedDuration.Seconds()),
+		ActualDuration:   int(track.Deviation.ActualDuration.Seconds()),
+		ExtraDuration:    int(track.Deviation.ExtraDuration().Seconds()),
+		Deviation:        track.Deviation.Ratio(),
+		MissedContainers: missedContainers,
+		CreatedAt:        track.CreatedAt,
+		ModifiedAt:       track.ModifiedAt,
+	}
+}
+
+// routeDeviationsPaginatedFromDomain returns a standardized route deviations paginated response based on the domain
+// paginated response model.
+func routeDeviationsPaginatedFromDomain(paginatedResponse domain.PaginatedResponse[domain.RouteTrack]) spec.RouteDeviationsPaginated {
+	deviations := make([]spec.RouteDeviation, len(paginatedResponse.Results))
+	for i, track := range paginatedResponse.Results {
+		deviations[i] = routeDeviationFromDomain(track)
+	}
+
+	return spec.RouteDeviationsPaginated{
+		Total:      paginatedResponse.Total,
+		Deviations: deviations,
+	}
+}
+
+// routeTrackFromDomain returns a standardized route track based on the domain model.
+func routeTrackFromDomain(track domain.RouteTrack) (spec.RouteTrack, error) {
+	offRouteFeatures := make([]domain.GeoJSONFeature, len(track.Deviation.OffRouteSegments))
+	for i, segment := range track.Deviation.OffRouteSegments {
+		offRouteFeatures[i] = domain.GeoJSONFeature{
+			Geometry: segment,
+		}
+	}
+
+	offRouteSegments, err := geoJSONFeatureCollectionLineStringFromDomain(domain.GeoJSONFeatureCollection{
+		Features: offRouteFeatures,
+	})
+	if err != nil {
+		return spec.RouteTrack{}, err
+	}
+
+	coordinates := make([][]float64, len(track.Geometry.Coordinates))
+	for i, c := range track.Geometry.Coordinates {
+		coordinates[i] = c[:]
+	}
+
+	timestamps := make([]time.Time, len(track.Timestamps))
+	copy(timestamps, track.Timestamps)
+
+	deviation := routeDeviationFromDomain(track)
+
+	return spec.RouteTrack{
+		RouteId:          deviation.RouteId,
+		Date:             deviation.Date,
+		PlannedDistance:  deviation.PlannedDistance,
+		ActualDistance:   deviation.ActualDistance,
+		ExtraDistance:    deviation.ExtraDistance,
+		OffRouteDistance: deviation.OffRouteDistance,
+		PlannedDuration:  deviation.PlannedDuration,
+		ActualDuration:   deviation.ActualDuration,
+		ExtraDuration:    deviation.ExtraDuration,
+		Deviation:        deviation.Deviation,
+		MissedContainers: deviation.MissedContainers,
+		CreatedAt:        deviation.CreatedAt,
+		ModifiedAt:       deviation.ModifiedAt,
+		Geometry: spec.GeoJSONGeometryLineString{
+			Type:        spec.LineString,
+			Coordinates: coordinates,
+		},
+		Timestamps:       timestamps,
+		OffRouteSegments: offRouteSegments,
+	}, nil
+}
@@ -0,0 +1,159 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	spec "github.com/goncalo-marques/ecomap/server/api/ecomap"
+	"github.com/goncalo-marques/ecomap/server/internal/domain"
+	"github.com/goncalo-marques/ecomap/server/internal/logging"
+)
+
+// GetRoutingMatrix handles the http request to get a routing matrix.
+func (h *handler) GetRoutingMatrix(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		badRequest(w, errRequestBodyInvalid)
+		return
+	}
+
+	var routingMatrixPost spec.RoutingMatrixPost
+	err = json.Unmarshal(requestBody, &routingMatrixPost)
+	if err != nil {
+		badRequest(w, errRequestBodyInvalid)
+		return
+	}
+
+	domainRoutingMatrixRequest, err := routingMatrixPostToDomain(routingMatrixPost)
+	if err != nil {
+		var domainErrFieldValueInvalid *domain.ErrFieldValueInvalid
+
+		switch {
+		case errors.As(err, &domainErrFieldValueInvalid):
+			badRequest(w, fmt.Sprintf("%s: %s", errFieldValueInvalid, domainErrFieldValueInvalid.FieldName))
+		default:
+			internalServerError(w)
+		}
+
+		return
+	}
+
+	domainRoutingMatrix, err := h.service.GetRoutingMatrix(ctx, domainRoutingMatrixRequest)
+	if err != nil {
+		var domainErrFieldValueInvalid *domain.ErrFieldValueInvalid
+
+		switch {
+		case errors.As(err, &domainErrFieldValueInvalid):
+			badRequest(w, fmt.Sprintf("%s: %s", errFieldValueInvalid, domainErrFieldValueInvalid.FieldName))
+		case errors.Is(err, domain.ErrContainerNotFound):
+			conflict(w, errContainerNotFound)
+		case errors.Is(err, domain.ErrWarehouseNotFound):
+			conflict(w, errWarehouseNotFound)
+		case errors.Is(err, domain.ErrLandfillNotFound):
+			conflict(w, errLandfillNotFound)
+		default:
+			internalServerError(w)
+		}
+
+		return
+	}
+
+	routingMatrix := routingMatrixFromDomain(domainRoutingMatrix)
+	responseBody, err := json.Marshal(routingMatrix)
+	if err != nil {
+		logging.Logger.ErrorContext(ctx, descriptionFailedToMarshalResponseBody, logging.Error(err))
+		internalServerError(w)
+		return
+	}
+
+	writeResponseJSON(w, http.StatusOK, responseBody)
+}
+
+// routingMatrixPostToDomain returns a domain routing matrix request based on the standardized routing matrix post.
+func routingMatrixPostToDomain(routingMatrixPost spec.RoutingMatrixPost) (domain.RoutingMatrixRequest, error) {
+	sources, err := routingLocationsToDomain(routingMatrixPost.Sources, domain.FieldSources)
+	if err != nil {
+		return domain.RoutingMatrixRequest{}, err
+	}
+
+	targets, err := routingLocationsToDomain(routingMatrixPost.Targets, domain.FieldTargets)
+	if err != nil {
+		return domain.RoutingMatrixRequest{}, err
+	}
+
+	return domain.RoutingMatrixRequest{
+		Sources: sources,
+		Targets: targets,
+	}, nil
+}
+
+// routingLocationsToDomain returns domain routing locations based on the standardized routing locations. The field
+// name is used to identify the invalid field.
+func routingLocationsToDomain(locations []spec.RoutingLocation, fieldName string) (domain.RoutingLocations, error) {
+	domainLocations := make(domain.RoutingLocations, len(locations))
+
+	for i, location := range locations {
+		var geometry *domain.GeoJSONGeometryPoint
+		if location.Geometry != nil {
+			if len(location.Geometry.Coordinates) != 2 {
+				return nil, &domain.ErrFieldValueInvalid{FieldName: fieldName}
+			}
+
+			geometry = &domain.GeoJSONGeometryPoint{
+				Coordinates: [2]float64(location.Geometry.Coordinates),
+			}
+		}
+
+		domainLocations[i] = domain.RoutingLocation{
+			Geometry:    geometry,
+			ContainerID: location.ContainerId,
+			WarehouseID: location.WarehouseId,
+			LandfillID:  location.LandfillId,
+		}
+	}
+
+	return domainLocations, nil
+}
+
+// routingMatrixFromDomain returns a standardized routing matrix based on the domain model.
+func routingMatrixFromDomain(routingMatrix domain.RoutingMatrix) spec.RoutingMatrix {
+	entries := make([]spec.RoutingMatrixEntry, len(routingMatrix.Entries))
+	for i, entry := range routingMatrix.Entries {
+		var duration *float64
+		if entry.Duration != nil {
+			seconds := entry.Duration.Seconds()
+			duration = &seconds
+		}
+
+		entries[i] = spec.RoutingMatrixEntry{
+			SourceIndex: entry.SourceIndex,
+			TargetIndex: entry.TargetIndex,
+			Distance:    entry.Distance,
+			Duration:    duration,
+		}
+	}
+
+	return spec.RoutingMatrix{
+		Sources: geoJSONGeometryPointsFromDomain(routingMatrix.Sources),
+		Targets: geoJSONGeometryPointsFromDomain(routingMatrix.Targets),
+		Entries: entries,
+	}
+}
+
+// geoJSONGeometryPointsFromDomain returns standardized GeoJSON geometry points based on the domain models.
+func geoJSONGeometryPointsFromDomain(geometries []domain.GeoJSONGeometryPoint) []spec.GeoJSONGeometryPoint {
+	specGeometries := make([]spec.GeoJSONGeometryPoint, len(geometries))
+	for i, geometry := range geometries {
+		specGeometries[i] = spec.GeoJSONGeometryPoint{
+			Type:        spec.Point,
+			Coordinates: geometry.Coordinates[:],
+		}
+	}
+
+	return specGeometries
+}
@@ -0,0 +1,222 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"strings"
+	"time"
+
+	spec "github.com/goncalo-marques/ecomap/server/api/ecomap"
+	"github.com/goncalo-marques/ecomap/server/internal/domain"
+	"github.com/goncalo-marques/ecomap/server/internal/logging"
+)
+
+const (
+	errSignInLockoutNotFound = "sign-in lockout not found"
+	errSignInLocked          = "account temporarily locked after too many failed sign-in attempts"
+	errSignInThrottled       = "too many failed sign-in attempts"
+)
+
+// ListSignInLockouts handles the http request to list sign-in lockouts.
+func (h *handler) ListSignInLockouts(w http.ResponseWriter, r *http.Request, params spec.ListSignInLockoutsParams) {
+	ctx := r.Context()
+
+	domainFilter := listSignInLockoutsParamsToDomain(params)
+	domainLockouts, err := h.service.ListSignInLockouts(ctx, domainFilter)
+	if err != nil {
+		var domainErrFilterValueInvalid *domain.ErrFilterValueInvalid
+
+		switch {
+		case errors.As(err, &domainErrFilterValueInvalid):
+			badRequest(w, fmt.Sprintf("%s: %s", errFilterValueInvalid, domainErrFilterValueInvalid.FilterName))
+		default:
+			internalServerError(w)
+		}
+
+		return
+	}
+
+	lockouts := signInLockoutsFromDomain(domainLockouts)
+	responseBody, err := json.Marshal(lockouts)
+	if err != nil {
+		logging.Logger.ErrorContext(ctx, descriptionFailedToMarshalResponseBody, logging.Error(err))
+		internalServerError(w)
+		return
+	}
+
+	writeResponseJSON(w, http.StatusOK, responseBody)
+}
+
+// DeleteSignInLockoutByID handles the http request to delete a sign-in lockout by ID.
+func (h *handler) DeleteSignInLockoutByID(w http.ResponseWriter, r *http.Request, lockoutID spec.SignInLockoutIdPathParam) {
+	ctx := r.Context()
+
+	domainLockout, err := h.service.DeleteSignInLockoutByID(ctx, lockoutID)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrSignInLockoutNotFound):
+			notFound(w, errSignInLockoutNotFound)
+		default:
+			internalServerError(w)
+		}
+
+		return
+	}
+
+	lockout := signInLockoutFromDomain(domainLockout)
+	responseBody, err := json.Marshal(lockout)
+	if err != nil {
+		logging.Logger.ErrorContext(ctx, descriptionFailedToMarshalResponseBody, logging.Error(err))
+		internalServerError(w)
+		return
+	}
+
+	writeResponseJSON(w, http.StatusOK, responseBody)
+}
+
+// setHeaderRetryAfter sets the header with the amount of seconds, rounded up, until the given time.
+func setHeaderRetryAfter(w http.ResponseWriter, t time.Time) {
+	seconds := int(math.Ceil(time.Until(t).Seconds()))
+	w.Header().Set(responseHeaderKeyRetryAfter, strconv.Itoa(max(seconds, 1)))
+}
+
+// clientIPAddress returns the IP address of the client of the request. When the client IP header is configured, the
+// last address of the header is used, since it is the one added by the trusted proxy in front of the server. An invalid
+// address is returned when it cannot be determined.
+func (h *handler) clientIPAddress(r *http.Request) netip.Addr {
+	if len(h.options.ClientIPHeader) != 0 {
+		values := r.Header.Values(h.options.ClientIPHeader)
+		if len(values) == 0 {
+			return netip.Addr{}
+		}
+
+		addresses := strings.Split(values[len(values)-1], ",")
+		ipAddress, err := netip.ParseAddr(strings.TrimSpace(addresses[len(addresses)-1]))
+		if err != nil {
+			return netip.Addr{}
+		}
+
+		return ipAddress.Unmap()
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+
+	ipAddress, err := netip.ParseAddr(host)
+	if err != nil {
+		return netip.Addr{}
+	}
+
+	return ipAddress.Unmap()
+}
+
+// signInLockoutSubjectTypeToDomain returns a domain subject type based on the standardized sign-in lockout subject
+// type.
+func signInLockoutSubjectTypeToDomain(subjectType spec.SignInLockoutSubjectType) domain.SubjectType {
+	switch subjectType {
+	case spec.SignInLockoutSubjectTypeUser:
+		return domain.SubjectTypeUser
+	case spec.SignInLockoutSubjectTypeEmployee:
+		return domain.SubjectTypeEmployee
+	default:
+		return domain.SubjectType(subjectType)
+	}
+}
+
+// signInLockoutSubjectTypeFromDomain returns a standardized sign-in lockout subject type based on the domain model.
+func signInLockoutSubjectTypeFromDomain(subjectType domain.SubjectType) spec.SignInLockoutSubjectType {
+	switch subjectType {
+	case domain.SubjectTypeUser:
+		return spec.SignInLockoutSubjectTypeUser
+	case domain.SubjectTypeEmployee:
+		return spec.SignInLockoutSubjectTypeEmployee
+	default:
+		return spec.SignInLockoutSubjectType(subjectType)
+	}
+}
+
+// signInLockoutKeyTypeToDomain returns a domain sign-in lockout key type based on the standardized model.
+func signInLockoutKeyTypeToDomain(keyType spec.SignInLockoutKeyType) domain.SignInLockoutKeyType {
+	switch keyType {
+	case spec.SignInLockoutKeyTypeUsername:
+		return domain.SignInLockoutKeyTypeUsername
+	case spec.SignInLockoutKeyTypeIpAddress:
+		return domain.SignInLockoutKeyTypeIPAddress
+	default:
+		return domain.SignInLockoutKeyType(keyType)
+	}
+}
+
+// signInLockoutKeyTypeFromDomain returns a standardized sign-in lockout key type based on the domain model.
+func signInLockoutKeyTypeFromDomain(keyType domain.SignInLockoutKeyType) spec.SignInLockoutKeyType {
+	switch keyType {
+	case domain.SignInLockoutKeyTypeUsername:
+		return spec.SignInLockoutKeyTypeUsername
+	case domain.SignInLockoutKeyTypeIPAddress:
+		return spec.SignInLockoutKeyTypeIpAddress
+	default:
+		return spec.SignInLockoutKeyType(keyType)
+	}
+}
+
+// listSignInLockoutsParamsToDomain returns a domain sign-in lockouts filter based on the standardized list sign-in
+// lockouts parameters.
+func listSignInLockoutsParamsToDomain(params spec.ListSignInLockoutsParams) domain.SignInLockoutsFilter {
+	var subjectType *domain.SubjectType
+	if params.SubjectType != nil {
+		t := signInLockoutSubjectTypeToDomain(*params.SubjectType)
+		subjectType = &t
+	}
+
+	var keyType *domain.SignInLockoutKeyType
+	if params.KeyType != nil {
+		t := signInLockoutKeyTypeToDomain(*params.KeyType)
+		keyType = &t
+	}
+
+	var activeOnly bool
+	if params.Active != nil {
+		activeOnly = *params.Active
+	}
+
+	return domain.SignInLockoutsFilter{
+		SubjectType: subjectType,
+		KeyType:     keyType,
+		ActiveOnly:  activeOnly,
+	}
+}
+
+// signInLockoutFromDomain returns a standardized sign-in lockout based on the domain model.
+func signInLockoutFromDomain(lockout domain.SignInLockout) spec.SignInLockout {
+	return spec.SignInLockout{
+		Id:            lockout.ID,
+		SubjectType:   signInLockoutSubjectTypeFromDomain(lockout.SubjectType),
+		KeyType:       signInLockoutKeyTypeFromDomain(lockout.KeyType),
+		Key:           lockout.Key,
+		Failures:      lockout.Failures,
+		LastFailureAt: lockout.LastFailureAt,
+		BlockedUntil:  lockout.BlockedUntil,
+		LockedUntil:   lockout.LockedUntil,
+		CreatedAt:     lockout.CreatedAt,
+	}
+}
+
+// signInLockoutsFromDomain returns standardized sign-in lockouts based on the domain model.
+func signInLockoutsFromDomain(lockouts []domain.SignInLockout) spec.SignInLockouts {
+	specLockouts := make([]spec.SignInLockout, len(lockouts))
+	for i, lockout := range lockouts {
+		specLockouts[i] = signInLockoutFromDomain(lockout)
+	}
+
+	return spec.SignInLockouts{
+		Lockouts: specLockouts,
+	}
+}
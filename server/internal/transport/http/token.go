@@ -0,0 +1,110 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	spec "github.com/goncalo-marques/ecomap/server/api/ecomap"
+	"github.com/goncalo-marques/ecomap/server/internal/domain"
+	"github.com/goncalo-marques/ecomap/server/internal/logging"
+)
+
+const (
+	pathWellKnownJWKS = "GET /.well-known/jwks.json"
+
+	errRefreshTokenInvalid = "invalid refresh token"
+)
+
+// RefreshTokens handles the http request to refresh tokens.
+func (h *handler) RefreshTokens(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		badRequest(w, errRequestBodyInvalid)
+		return
+	}
+
+	var refreshTokenPost spec.RefreshTokenPost
+	err = json.Unmarshal(requestBody, &refreshTokenPost)
+	if err != nil {
+		badRequest(w, errRequestBodyInvalid)
+		return
+	}
+
+	tokens, err := h.service.RefreshTokens(ctx, refreshTokenPost.RefreshToken)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrRefreshTokenInvalid):
+			unauthorized(w, errRefreshTokenInvalid)
+		default:
+			internalServerError(w)
+		}
+
+		return
+	}
+
+	jwt := jwtFromDomain(tokens)
+	responseBody, err := json.Marshal(jwt)
+	if err != nil {
+		logging.Logger.ErrorContext(ctx, descriptionFailedToMarshalResponseBody, logging.Error(err))
+		internalServerError(w)
+		return
+	}
+
+	writeResponseJSON(w, http.StatusOK, responseBody)
+}
+
+// GetJWKS handles the http request to get the JSON Web Key Set.
+func (h *handler) GetJWKS(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	keys := h.service.ListJSONWebKeys(ctx)
+
+	jwks := jwksFromDomain(keys)
+	responseBody, err := json.Marshal(jwks)
+	if err != nil {
+		logging.Logger.ErrorContext(ctx, descriptionFailedToMarshalResponseBody, logging.Error(err))
+		internalServerError(w)
+		return
+	}
+
+	writeResponseJSON(w, http.StatusOK, responseBody)
+}
+
+// jwtFromDomain returns a standardized JWT based on the domain tokens.
+func jwtFromDomain(tokens domain.Tokens) spec.JWT {
+	return spec.JWT{
+		Token:        tokens.AccessToken,
+		ExpiresAt:    tokens.AccessTokenExpiresAt,
+		RefreshToken: tokens.RefreshToken,
+	}
+}
+
+// jwkFromDomain returns a standardized JSON Web Key based on the domain model.
+func jwkFromDomain(key domain.JSONWebKey) spec.JWK {
+	return spec.JWK{
+		Kty: key.KeyType,
+		Kid: key.ID,
+		Use: key.Use,
+		Alg: key.Algorithm,
+		N:   key.Modulus,
+		E:   key.Exponent,
+		Crv: key.Curve,
+		X:   key.X,
+	}
+}
+
+// jwksFromDomain returns a standardized JSON Web Key Set based on the domain model.
+func jwksFromDomain(keys []domain.JSONWebKey) spec.JWKS {
+	jwks := make([]spec.JWK, len(keys))
+	for i, key := range keys {
+		jwks[i] = jwkFromDomain(key)
+	}
+
+	return spec.JWKS{
+		Keys: jwks,
+	}
+}
@@ -16,6 +16,7 @@ const (
 	errTruckNotFound                     = "truck not found"
 	errTruckAssociatedWithWarehouseTruck = "truck associated with warehouse"
 	errTruckAssociatedWithRoute          = "truck associated with route"
+	errTruckCompartmentsIncompatible     = "truck compartments do not accept every container category of its routes"
 )
 
 // CreateTruck handles the http request to create a truck.
@@ -189,6 +190,8 @@ func (h *handler) PatchTruckByID(w http.ResponseWriter, r *http.Request, truckID
 			badRequest(w, fmt.Sprintf("%s: %s", errFieldValueInvalid, domainErrFieldValueInvalid.FieldName))
 		case errors.Is(err, domain.ErrTruckNotFound):
 			notFound(w, errTruckNotFound)
+		case errors.Is(err, domain.ErrTruckCompartmentsIncompatible):
+			conflict(w, errTruckCompartmentsIncompatible)
 		default:
 			internalServerError(w)
 		}
@@ -257,12 +260,36 @@ func truckPostToDomain(truckPost spec.TruckPost) (domain.EditableTruck, error) {
 		return domain.EditableTruck{}, err
 	}
 
+	status := domain.TruckStatusActive
+	if truckPost.Status != nil {
+		status = truckStatusToDomain(*truckPost.Status)
+	}
+
+	fuelType := domain.TruckFuelTypeDiesel
+	if truckPost.FuelType != nil {
+		fuelType = truckFuelTypeToDomain(*truckPost.FuelType)
+	}
+
+	licenseCategory := domain.LicenseCategoryC
+	if truckPost.LicenseCategory != nil {
+		licenseCategory = licenseCategoryToDomain(*truckPost.LicenseCategory)
+	}
+
 	return domain.EditableTruck{
-		Make:           domain.TruckMake(truckPost.Make),
-		Model:          domain.TruckModel(truckPost.Model),
-		LicensePlate:   domain.TruckLicensePlate(truckPost.LicensePlate),
-		PersonCapacity: domain.TruckPersonCapacity(truckPost.PersonCapacity),
-		GeoJSON:        geoJSON,
+		Make:                    domain.TruckMake(truckPost.Make),
+		Model:                   domain.TruckModel(truckPost.Model),
+		LicensePlate:            domain.TruckLicensePlate(truckPost.LicensePlate),
+		PersonCapacity:          domain.TruckPersonCapacity(truckPost.PersonCapacity),
+		VolumeCapacity:          (*domain.TruckVolumeCapacity)(truckPost.VolumeCapacity),
+		WeightCapacity:          (*domain.TruckWeightCapacity)(truckPost.WeightCapacity),
+		Compartments:            truckCompartmentsToDomain(truckPost.Compartments),
+		Status:                  status,
+		ServiceIntervalDistance: (*domain.TruckServiceIntervalDistance)(truckPost.ServiceIntervalDistance),
+		ServiceIntervalPeriod:   (*domain.TruckServiceIntervalPeriod)(truckPost.ServiceIntervalPeriod),
+		FuelType:                fuelType,
+		FuelConsumption:         (*domain.TruckFuelConsumption)(truckPost.FuelConsumption),
+		LicenseCategory:         licenseCategory,
+		GeoJSON:                 geoJSON,
 	}, nil
 }
 
@@ -273,15 +300,147 @@ func truckPatchToDomain(truckPatch spec.TruckPatch) (domain.EditableTruckPatch,
 		return domain.EditableTruckPatch{}, err
 	}
 
+	var status *domain.TruckStatus
+	if truckPatch.Status != nil {
+		s := truckStatusToDomain(*truckPatch.Status)
+		status = &s
+	}
+
+	var fuelType *domain.TruckFuelType
+	if truckPatch.FuelType != nil {
+		t := truckFuelTypeToDomain(*truckPatch.FuelType)
+		fuelType = &t
+	}
+
+	var licenseCategory *domain.LicenseCategory
+	if truckPatch.LicenseCategory != nil {
+		c := licenseCategoryToDomain(*truckPatch.LicenseCategory)
+		licenseCategory = &c
+	}
+
 	return domain.EditableTruckPatch{
-		Make:           (*domain.TruckMake)(truckPatch.Make),
-		Model:          (*domain.TruckModel)(truckPatch.Model),
-		LicensePlate:   (*domain.TruckLicensePlate)(truckPatch.LicensePlate),
-		PersonCapacity: (*domain.TruckPersonCapacity)(truckPatch.PersonCapacity),
-		GeoJSON:        geoJSON,
+		Make:                    (*domain.TruckMake)(truckPatch.Make),
+		Model:                   (*domain.TruckModel)(truckPatch.Model),
+		LicensePlate:            (*domain.TruckLicensePlate)(truckPatch.LicensePlate),
+		PersonCapacity:          (*domain.TruckPersonCapacity)(truckPatch.PersonCapacity),
+		VolumeCapacity:          (*domain.TruckVolumeCapacity)(truckPatch.VolumeCapacity),
+		WeightCapacity:          (*domain.TruckWeightCapacity)(truckPatch.WeightCapacity),
+		Compartments:            truckCompartmentsPatchToDomain(truckPatch.Compartments),
+		Status:                  status,
+		ServiceIntervalDistance: (*domain.TruckServiceIntervalDistance)(truckPatch.ServiceIntervalDistance),
+		ServiceIntervalPeriod:   (*domain.TruckServiceIntervalPeriod)(truckPatch.ServiceIntervalPeriod),
+		FuelType:                fuelType,
+		FuelConsumption:         (*domain.TruckFuelConsumption)(truckPatch.FuelConsumption),
+		LicenseCategory:         licenseCategory,
+		GeoJSON:                 geoJSON,
 	}, nil
 }
 
+// truckStatusToDomain returns a domain truck status based on the standardized model.
+func truckStatusToDomain(status spec.TruckStatus) domain.TruckStatus {
+	switch status {
+	case spec.Active:
+		return domain.TruckStatusActive
+	case spec.Maintenance:
+		return domain.TruckStatusMaintenance
+	case spec.Retired:
+		return domain.TruckStatusRetired
+	default:
+		return domain.TruckStatus(status)
+	}
+}
+
+// truckStatusFromDomain returns a standardized truck status based on the domain model.
+func truckStatusFromDomain(status domain.TruckStatus) spec.TruckStatus {
+	switch status {
+	case domain.TruckStatusActive:
+		return spec.Active
+	case domain.TruckStatusMaintenance:
+		return spec.Maintenance
+	case domain.TruckStatusRetired:
+		return spec.Retired
+	default:
+		return spec.TruckStatus(status)
+	}
+}
+
+// truckFuelTypeToDomain returns a domain truck fuel type based on the standardized model.
+func truckFuelTypeToDomain(fuelType spec.TruckFuelType) domain.TruckFuelType {
+	switch fuelType {
+	case spec.Diesel:
+		return domain.TruckFuelTypeDiesel
+	case spec.Gasoline:
+		return domain.TruckFuelTypeGasoline
+	case spec.Lpg:
+		return domain.TruckFuelTypeLPG
+	default:
+		return domain.TruckFuelType(fuelType)
+	}
+}
+
+// truckFuelTypeFromDomain returns a standardized truck fuel type based on the domain model.
+func truckFuelTypeFromDomain(fuelType domain.TruckFuelType) spec.TruckFuelType {
+	switch fuelType {
+	case domain.TruckFuelTypeDiesel:
+		return spec.Diesel
+	case domain.TruckFuelTypeGasoline:
+		return spec.Gasoline
+	case domain.TruckFuelTypeLPG:
+		return spec.Lpg
+	default:
+		return spec.TruckFuelType(fuelType)
+	}
+}
+
+// truckCompartmentsToDomain returns domain truck compartments based on the standardized truck compartments.
+func truckCompartmentsToDomain(compartments *spec.TruckCompartments) domain.TruckCompartments {
+	if compartments == nil {
+		return nil
+	}
+
+	domainCompartments := make(domain.TruckCompartments, len(*compartments))
+	for i, compartment := range *compartments {
+		categories := make([]domain.ContainerCategory, len(compartment.Categories))
+		for j, category := range compartment.Categories {
+			categories[j] = containerCategoryToDomain(category)
+		}
+
+		domainCompartments[i] = domain.TruckCompartment{
+			Categories: categories,
+		}
+	}
+
+	return domainCompartments
+}
+
+// truckCompartmentsPatchToDomain returns patchable domain truck compartments based on the standardized truck
+// compartments. A nil value is returned when the compartments are not defined.
+func truckCompartmentsPatchToDomain(compartments *spec.TruckCompartments) *domain.TruckCompartments {
+	if compartments == nil {
+		return nil
+	}
+
+	domainCompartments := truckCompartmentsToDomain(compartments)
+	return &domainCompartments
+}
+
+// truckCompartmentsFromDomain returns standardized truck compartments based on the domain model.
+func truckCompartmentsFromDomain(compartments domain.TruckCompartments) spec.TruckCompartments {
+	specCompartments := make(spec.TruckCompartments, len(compartments))
+	for i, compartment := range compartments {
+		categories := make([]spec.ContainerCategory, len(compartment.Categories))
+		for j, category := range compartment.Categories {
+			categories[j] = containerCategoryFromDomain(category)
+		}
+
+		specCompartments[i] = spec.TruckCompartment{
+			Categories: categories,
+		}
+	}
+
+	return specCompartments
+}
+
 // listTrucksParamsToDomain returns a domain trucks paginated filter based on the standardized list trucks parameters.
 func listTrucksParamsToDomain(params spec.ListTrucksParams) domain.TrucksPaginatedFilter {
 	domainSort := domain.TruckPaginatedSortCreatedAt
@@ -295,6 +454,8 @@ func listTrucksParamsToDomain(params spec.ListTrucksParams) domain.TrucksPaginat
 			domainSort = domain.TruckPaginatedSortLicensePlate
 		case spec.ListTrucksParamsSortPersonCapacity:
 			domainSort = domain.TruckPaginatedSortPersonCapacity
+		case spec.ListTrucksParamsSortStatus:
+			domainSort = domain.TruckPaginatedSortStatus
 		case spec.ListTrucksParamsSortWayName:
 			domainSort = domain.TruckPaginatedSortWayName
 		case spec.ListTrucksParamsSortMunicipalityName:
@@ -308,6 +469,12 @@ func listTrucksParamsToDomain(params spec.ListTrucksParams) domain.TrucksPaginat
 		}
 	}
 
+	var status *domain.TruckStatus
+	if params.Status != nil {
+		s := truckStatusToDomain(*params.Status)
+		status = &s
+	}
+
 	return domain.TrucksPaginatedFilter{
 		PaginatedRequest: paginatedRequestToDomain(
 			domainSort,
@@ -318,6 +485,7 @@ func listTrucksParamsToDomain(params spec.ListTrucksParams) domain.TrucksPaginat
 		Make:         (*domain.TruckMake)(params.Make),
 		Model:        (*domain.TruckModel)(params.Model),
 		LicensePlate: (*domain.TruckLicensePlate)(params.LicensePlate),
+		Status:       status,
 		LocationName: params.LocationName,
 	}
 }
@@ -330,14 +498,24 @@ func truckFromDomain(truck domain.Truck) (spec.Truck, error) {
 	}
 
 	return spec.Truck{
-		Id:             truck.ID,
-		Make:           string(truck.Make),
-		Model:          string(truck.Model),
-		LicensePlate:   string(truck.LicensePlate),
-		PersonCapacity: int(truck.PersonCapacity),
-		GeoJson:        geoJSON,
-		CreatedAt:      truck.CreatedAt,
-		ModifiedAt:     truck.ModifiedAt,
+		Id:                      truck.ID,
+		Make:                    string(truck.Make),
+		Model:                   string(truck.Model),
+		LicensePlate:            string(truck.LicensePlate),
+		PersonCapacity:          int(truck.PersonCapacity),
+		VolumeCapacity:          (*float64)(truck.VolumeCapacity),
+		WeightCapacity:          (*float64)(truck.WeightCapacity),
+		Compartments:            truckCompartmentsFromDomain(truck.Compartments),
+		Status:                  truckStatusFromDomain(truck.Status),
+		ServiceIntervalDistance: (*float64)(truck.ServiceIntervalDistance),
+		ServiceIntervalPeriod:   (*int)(truck.ServiceIntervalPeriod),
+		Odometer:                (*float64)(truck.Odometer),
+		FuelType:                truckFuelTypeFromDomain(truck.FuelType),
+		FuelConsumption:         (*float64)(truck.FuelConsumption),
+		LicenseCategory:         licenseCategoryFromDomain(truck.LicenseCategory),
+		GeoJson:                 geoJSON,
+		CreatedAt:               truck.CreatedAt,
+		ModifiedAt:              truck.ModifiedAt,
 	}, nil
 }
 
@@ -0,0 +1,352 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	spec "github.com/goncalo-marques/ecomap/server/api/ecomap"
+	"github.com/goncalo-marques/ecomap/server/internal/domain"
+	"github.com/goncalo-marques/ecomap/server/internal/logging"
+)
+
+const (
+	errTruckMaintenanceNotFound = "truck maintenance not found"
+)
+
+// Trucks service due default values.
+const (
+	trucksServiceDueWithinDistanceDefaultValue = 1000
+	trucksServiceDueWithinDaysDefaultValue     = 30
+)
+
+// CreateTruckMaintenance handles the http request to create a truck maintenance.
+func (h *handler) CreateTruckMaintenance(w http.ResponseWriter, r *http.Request, truckID spec.TruckIdPathParam) {
+	ctx := r.Context()
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		badRequest(w, errRequestBodyInvalid)
+		return
+	}
+
+	var maintenancePost spec.TruckMaintenancePost
+	err = json.Unmarshal(requestBody, &maintenancePost)
+	if err != nil {
+		badRequest(w, errRequestBodyInvalid)
+		return
+	}
+
+	domainEditableMaintenance := truckMaintenancePostToDomain(maintenancePost)
+	domainMaintenance, err := h.service.CreateTruckMaintenance(ctx, truckID, domainEditableMaintenance)
+	if err != nil {
+		var domainErrFieldValueInvalid *domain.ErrFieldValueInvalid
+
+		switch {
+		case errors.As(err, &domainErrFieldValueInvalid):
+			badRequest(w, fmt.Sprintf("%s: %s", errFieldValueInvalid, domainErrFieldValueInvalid.FieldName))
+		case errors.Is(err, domain.ErrTruckNotFound):
+			notFound(w, errTruckNotFound)
+		default:
+			internalServerError(w)
+		}
+
+		return
+	}
+
+	maintenance := truckMaintenanceFromDomain(domainMaintenance)
+
+	responseBody, err := json.Marshal(maintenance)
+	if err != nil {
+		logging.Logger.ErrorContext(ctx, descriptionFailedToMarshalResponseBody, logging.Error(err))
+		internalServerError(w)
+		return
+	}
+
+	writeResponseJSON(w, http.StatusCreated, responseBody)
+}
+
+// ListTruckMaintenances handles the http request to list truck maintenances.
+func (h *handler) ListTruckMaintenances(w http.ResponseWriter, r *http.Request, truckID spec.TruckIdPathParam, params spec.ListTruckMaintenancesParams) {
+	ctx := r.Context()
+
+	domainMaintenancesFilter := listTruckMaintenancesParamsToDomain(params)
+	domainPaginatedMaintenances, err := h.service.ListTruckMaintenances(ctx, truckID, domainMaintenancesFilter)
+	if err != nil {
+		var domainErrFilterValueInvalid *domain.ErrFilterValueInvalid
+
+		switch {
+		case errors.As(err, &domainErrFilterValueInvalid):
+			badRequest(w, fmt.Sprintf("%s: %s", errFilterValueInvalid, domainErrFilterValueInvalid.FilterName))
+		case errors.Is(err, domain.ErrTruckNotFound):
+			notFound(w, errTruckNotFound)
+		default:
+			internalServerError(w)
+		}
+
+		return
+	}
+
+	maintenancesPaginated := truckMaintenancesPaginatedFromDomain(domainPaginatedMaintenances)
+
+	responseBody, err := json.Marshal(maintenancesPaginated)
+	if err != nil {
+		logging.Logger.ErrorContext(ctx, descriptionFailedToMarshalResponseBody, logging.Error(err))
+		internalServerError(w)
+		return
+	}
+
+	writeResponseJSON(w, http.StatusOK, responseBody)
+}
+
+// DeleteTruckMaintenanceByID handles the http request to delete a truck maintenance by ID.
+func (h *handler) DeleteTruckMaintenanceByID(w http.ResponseWriter, r *http.Request, truckID spec.TruckIdPathParam, maintenanceID spec.TruckMaintenanceIdPathParam) {
+	ctx := r.Context()
+
+	domainMaintenance, err := h.service.DeleteTruckMaintenanceByID(ctx, truckID, maintenanceID)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrTruckMaintenanceNotFound):
+			notFound(w, errTruckMaintenanceNotFound)
+		default:
+			internalServerError(w)
+		}
+
+		return
+	}
+
+	maintenance := truckMaintenanceFromDomain(domainMaintenance)
+
+	responseBody, err := json.Marshal(maintenance)
+	if err != nil {
+		logging.Logger.ErrorContext(ctx, descriptionFailedToMarshalResponseBody, logging.Error(err))
+		internalServerError(w)
+		return
+	}
+
+	writeResponseJSON(w, http.StatusOK, responseBody)
+}
+
+// ListTrucksServiceDue handles the http request to list the trucks with service due.
+func (h *handler) ListTrucksServiceDue(w http.ResponseWriter, r *http.Request, params spec.ListTrucksServiceDueParams) {
+	ctx := r.Context()
+
+	domainServiceDueFilter := listTrucksServiceDueParamsToDomain(params)
+	domainServicesDue, err := h.service.ListTrucksServiceDue(ctx, domainServiceDueFilter)
+	if err != nil {
+		var domainErrFilterValueInvalid *domain.ErrFilterValueInvalid
+
+		switch {
+		case errors.As(err, &domainErrFilterValueInvalid):
+			badRequest(w, fmt.Sprintf("%s: %s", errFilterValueInvalid, domainErrFilterValueInvalid.FilterName))
+		default:
+			internalServerError(w)
+		}
+
+		return
+	}
+
+	servicesDue, err := trucksServiceDueFromDomain(domainServicesDue)
+	if err != nil {
+		logging.Logger.ErrorContext(ctx, descriptionFailedToMapResponseBody, logging.Error(err))
+		internalServerError(w)
+		return
+	}
+
+	responseBody, err := json.Marshal(servicesDue)
+	if err != nil {
+		logging.Logger.ErrorContext(ctx, descriptionFailedToMarshalResponseBody, logging.Error(err))
+		internalServerError(w)
+		return
+	}
+
+	writeResponseJSON(w, http.StatusOK, responseBody)
+}
+
+// truckMaintenanceTypeToDomain returns a domain truck maintenance type based on the standardized model.
+func truckMaintenanceTypeToDomain(maintenanceType spec.TruckMaintenanceType) domain.TruckMaintenanceType {
+	switch maintenanceType {
+	case spec.TruckMaintenanceTypeService:
+		return domain.TruckMaintenanceTypeService
+	case spec.TruckMaintenanceTypeRepair:
+		return domain.TruckMaintenanceTypeRepair
+	case spec.TruckMaintenanceTypeInspection:
+		return domain.TruckMaintenanceTypeInspection
+	case spec.TruckMaintenanceTypeTires:
+		return domain.TruckMaintenanceTypeTires
+	case spec.TruckMaintenanceTypeOther:
+		return domain.TruckMaintenanceTypeOther
+	default:
+		return domain.TruckMaintenanceType(maintenanceType)
+	}
+}
+
+// truckMaintenanceTypeFromDomain returns a standardized truck maintenance type based on the domain model.
+func truckMaintenanceTypeFromDomain(maintenanceType domain.TruckMaintenanceType) spec.TruckMaintenanceType {
+	switch maintenanceType {
+	case domain.TruckMaintenanceTypeService:
+		return spec.TruckMaintenanceTypeService
+	case domain.TruckMaintenanceTypeRepair:
+		return spec.TruckMaintenanceTypeRepair
+	case domain.TruckMaintenanceTypeInspection:
+		return spec.TruckMaintenanceTypeInspection
+	case domain.TruckMaintenanceTypeTires:
+		return spec.TruckMaintenanceTypeTires
+	case domain.TruckMaintenanceTypeOther:
+		return spec.TruckMaintenanceTypeOther
+	default:
+		return spec.TruckMaintenanceType(maintenanceType)
+	}
+}
+
+// truckMaintenancePostToDomain returns a domain editable truck maintenance based on the standardized truck maintenance
+// post.
+func truckMaintenancePostToDomain(maintenancePost spec.TruckMaintenancePost) domain.EditableTruckMaintenance {
+	return domain.EditableTruckMaintenance{
+		Type:     truckMaintenanceTypeToDomain(maintenancePost.Type),
+		Date:     maintenancePost.Date.Time,
+		Cost:     (*domain.TruckMaintenanceCost)(maintenancePost.Cost),
+		Notes:    (*domain.TruckMaintenanceNotes)(maintenancePost.Notes),
+		Odometer: (*domain.TruckOdometer)(maintenancePost.Odometer),
+	}
+}
+
+// listTruckMaintenancesParamsToDomain returns a domain truck maintenances paginated filter based on the standardized
+// list truck maintenances parameters. The maintenances are sorted by their date in descending order by default.
+func listTruckMaintenancesParamsToDomain(params spec.ListTruckMaintenancesParams) domain.TruckMaintenancesPaginatedFilter {
+	domainSort := domain.TruckMaintenancePaginatedSortDate
+	if params.Sort != nil {
+		switch *params.Sort {
+		case spec.ListTruckMaintenancesParamsSortType:
+			domainSort = domain.TruckMaintenancePaginatedSortType
+		case spec.ListTruckMaintenancesParamsSortDate:
+			domainSort = domain.TruckMaintenancePaginatedSortDate
+		case spec.ListTruckMaintenancesParamsSortCost:
+			domainSort = domain.TruckMaintenancePaginatedSortCost
+		case spec.ListTruckMaintenancesParamsSortCreatedAt:
+			domainSort = domain.TruckMaintenancePaginatedSortCreatedAt
+		default:
+			domainSort = domain.TruckMaintenancePaginatedSort(*params.Sort)
+		}
+	}
+
+	order := spec.OrderQueryParamDesc
+	if params.Order != nil {
+		order = spec.OrderQueryParam(*params.Order)
+	}
+
+	var maintenanceType *domain.TruckMaintenanceType
+	if params.Type != nil {
+		t := truckMaintenanceTypeToDomain(*params.Type)
+		maintenanceType = &t
+	}
+
+	return domain.TruckMaintenancesPaginatedFilter{
+		PaginatedRequest: paginatedRequestToDomain(
+			domainSort,
+			&order,
+			params.Limit,
+			params.Offset,
+		),
+		Type: maintenanceType,
+	}
+}
+
+// listTrucksServiceDueParamsToDomain returns a domain truck service due filter based on the standardized list trucks
+// service due parameters. When no threshold is specified, the default of both thresholds applies.
+func listTrucksServiceDueParamsToDomain(params spec.ListTrucksServiceDueParams) domain.TruckServiceDueFilter {
+	withinDistance := params.WithinDistance
+	withinDays := params.WithinDays
+	if withinDistance == nil && withinDays == nil {
+		defaultWithinDistance := float64(trucksServiceDueWithinDistanceDefaultValue)
+		defaultWithinDays := trucksServiceDueWithinDaysDefaultValue
+
+		withinDistance = &defaultWithinDistance
+		withinDays = &defaultWithinDays
+	}
+
+	domainSort := domain.TruckServiceDueSortRemainingDistance
+	if params.Sort != nil {
+		switch *params.Sort {
+		case spec.RemainingDistance:
+			domainSort = domain.TruckServiceDueSortRemainingDistance
+		case spec.RemainingDays:
+			domainSort = domain.TruckServiceDueSortRemainingDays
+		default:
+			domainSort = domain.TruckServiceDueSort(*params.Sort)
+		}
+	}
+
+	return domain.TruckServiceDueFilter{
+		WithinDistance: withinDistance,
+		WithinDays:     withinDays,
+		Sort:           domainSort,
+	}
+}
+
+// truckMaintenanceFromDomain returns a standardized truck maintenance based on the domain model.
+func truckMaintenanceFromDomain(maintenance domain.TruckMaintenance) spec.TruckMaintenance {
+	return spec.TruckMaintenance{
+		Id:         maintenance.ID,
+		TruckId:    maintenance.TruckID,
+		Type:       truckMaintenanceTypeFromDomain(maintenance.Type),
+		Date:       dateFromTime(maintenance.Date),
+		Cost:       (*float64)(maintenance.Cost),
+		Notes:      (*string)(maintenance.Notes),
+		Odometer:   (*float64)(maintenance.Odometer),
+		CreatedAt:  maintenance.CreatedAt,
+		ModifiedAt: maintenance.ModifiedAt,
+	}
+}
+
+// truckMaintenancesPaginatedFromDomain returns a standardized truck maintenances paginated response based on the domain
+// paginated response model.
+func truckMaintenancesPaginatedFromDomain(paginatedResponse domain.PaginatedResponse[domain.TruckMaintenance]) spec.TruckMaintenancesPaginated {
+	maintenances := make([]spec.TruckMaintenance, len(paginatedResponse.Results))
+	for i, maintenance := range paginatedResponse.Results {
+		maintenances[i] = truckMaintenanceFromDomain(maintenance)
+	}
+
+	return spec.TruckMaintenancesPaginated{
+		Total:        paginatedResponse.Total,
+		Maintenances: maintenances,
+	}
+}
+
+// optionalDateFromTime returns an optional standardized date based on the optional time model.
+func optionalDateFromTime(t *time.Time) *spec.Date {
+	if t == nil {
+		return nil
+	}
+
+	date := dateFromTime(*t)
+	return &date
+}
+
+// trucksServiceDueFromDomain returns the standardized trucks service due based on the domain model.
+func trucksServiceDueFromDomain(servicesDue []domain.TruckServiceDue) (spec.TrucksServiceDue, error) {
+	specServicesDue := make([]spec.TruckServiceDue, len(servicesDue))
+	for i, serviceDue := range servicesDue {
+		truck, err := truckFromDomain(serviceDue.Truck)
+		if err != nil {
+			return spec.TrucksServiceDue{}, err
+		}
+
+		specServicesDue[i] = spec.TruckServiceDue{
+			Truck:               truck,
+			LastServiceDate:     optionalDateFromTime(serviceDue.LastServiceDate),
+			LastServiceOdometer: serviceDue.LastServiceOdometer,
+			DueOdometer:         serviceDue.DueOdometer,
+			DueDate:             optionalDateFromTime(serviceDue.DueDate),
+			RemainingDistance:   serviceDue.RemainingDistance,
+			RemainingDays:       serviceDue.RemainingDays,
+		}
+	}
+
+	return spec.TrucksServiceDue{
+		Trucks: specServicesDue,
+	}, nil
+}
@@ -0,0 +1,165 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	spec "github.com/goncalo-marques/ecomap/server/api/ecomap"
+	"github.com/goncalo-marques/ecomap/server/internal/domain"
+	"github.com/goncalo-marques/ecomap/server/internal/logging"
+)
+
+const (
+	errTruckOdometerReadingInconsistent  = "odometer reading is lower than an earlier reading or higher than a later reading"
+	errTruckOdometerReadingAlreadyExists = "odometer reading already recorded at the same time"
+)
+
+// CreateTruckOdometerReading handles the http request to create a truck odometer reading.
+func (h *handler) CreateTruckOdometerReading(w http.ResponseWriter, r *http.Request, truckID spec.TruckIdPathParam) {
+	ctx := r.Context()
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		badRequest(w, errRequestBodyInvalid)
+		return
+	}
+
+	var readingPost spec.TruckOdometerReadingPost
+	err = json.Unmarshal(requestBody, &readingPost)
+	if err != nil {
+		badRequest(w, errRequestBodyInvalid)
+		return
+	}
+
+	domainEditableReading := truckOdometerReadingPostToDomain(readingPost)
+	domainReading, err := h.service.CreateTruckOdometerReading(ctx, truckID, domainEditableReading)
+	if err != nil {
+		var domainErrFieldValueInvalid *domain.ErrFieldValueInvalid
+
+		switch {
+		case errors.As(err, &domainErrFieldValueInvalid):
+			badRequest(w, fmt.Sprintf("%s: %s", errFieldValueInvalid, domainErrFieldValueInvalid.FieldName))
+		case errors.Is(err, domain.ErrTruckNotFound):
+			notFound(w, errTruckNotFound)
+		case errors.Is(err, domain.ErrTruckOdometerReadingInconsistent):
+			conflict(w, errTruckOdometerReadingInconsistent)
+		case errors.Is(err, domain.ErrTruckOdometerReadingAlreadyExists):
+			conflict(w, errTruckOdometerReadingAlreadyExists)
+		default:
+			internalServerError(w)
+		}
+
+		return
+	}
+
+	reading := truckOdometerReadingFromDomain(domainReading)
+
+	responseBody, err := json.Marshal(reading)
+	if err != nil {
+		logging.Logger.ErrorContext(ctx, descriptionFailedToMarshalResponseBody, logging.Error(err))
+		internalServerError(w)
+		return
+	}
+
+	writeResponseJSON(w, http.StatusCreated, responseBody)
+}
+
+// ListTruckOdometerReadings handles the http request to list truck odometer readings.
+func (h *handler) ListTruckOdometerReadings(w http.ResponseWriter, r *http.Request, truckID spec.TruckIdPathParam, params spec.ListTruckOdometerReadingsParams) {
+	ctx := r.Context()
+
+	domainReadingsFilter := listTruckOdometerReadingsParamsToDomain(params)
+	domainPaginatedReadings, err := h.service.ListTruckOdometerReadings(ctx, truckID, domainReadingsFilter)
+	if err != nil {
+		var domainErrFilterValueInvalid *domain.ErrFilterValueInvalid
+
+		switch {
+		case errors.As(err, &domainErrFilterValueInvalid):
+			badRequest(w, fmt.Sprintf("%s: %s", errFilterValueInvalid, domainErrFilterValueInvalid.FilterName))
+		case errors.Is(err, domain.ErrTruckNotFound):
+			notFound(w, errTruckNotFound)
+		default:
+			internalServerError(w)
+		}
+
+		return
+	}
+
+	readingsPaginated := truckOdometerReadingsPaginatedFromDomain(domainPaginatedReadings)
+
+	responseBody, err := json.Marshal(readingsPaginated)
+	if err != nil {
+		logging.Logger.ErrorContext(ctx, descriptionFailedToMarshalResponseBody, logging.Error(err))
+		internalServerError(w)
+		return
+	}
+
+	writeResponseJSON(w, http.StatusOK, responseBody)
+}
+
+// truckOdometerReadingPostToDomain returns a domain editable truck odometer reading based on the standardized truck
+// odometer reading post.
+func truckOdometerReadingPostToDomain(readingPost spec.TruckOdometerReadingPost) domain.EditableTruckOdometerReading {
+	return domain.EditableTruckOdometerReading{
+		Value:      domain.TruckOdometer(readingPost.Value),
+		RecordedAt: readingPost.RecordedAt,
+	}
+}
+
+// listTruckOdometerReadingsParamsToDomain returns a domain truck odometer readings paginated filter based on the
+// standardized list truck odometer readings parameters. The readings are sorted by the time they were recorded in
+// descending order by default.
+func listTruckOdometerReadingsParamsToDomain(params spec.ListTruckOdometerReadingsParams) domain.TruckOdometerReadingsPaginatedFilter {
+	domainSort := domain.TruckOdometerReadingPaginatedSortRecordedAt
+	if params.Sort != nil {
+		switch *params.Sort {
+		case spec.ListTruckOdometerReadingsParamsSortValue:
+			domainSort = domain.TruckOdometerReadingPaginatedSortValue
+		case spec.ListTruckOdometerReadingsParamsSortRecordedAt:
+			domainSort = domain.TruckOdometerReadingPaginatedSortRecordedAt
+		default:
+			domainSort = domain.TruckOdometerReadingPaginatedSort(*params.Sort)
+		}
+	}
+
+	order := spec.OrderQueryParamDesc
+	if params.Order != nil {
+		order = spec.OrderQueryParam(*params.Order)
+	}
+
+	return domain.TruckOdometerReadingsPaginatedFilter{
+		PaginatedRequest: paginatedRequestToDomain(
+			domainSort,
+			&order,
+			params.Limit,
+			params.Offset,
+		),
+	}
+}
+
+// truckOdometerReadingFromDomain returns a standardized truck odometer reading based on the domain model.
+func truckOdometerReadingFromDomain(reading domain.TruckOdometerReading) spec.TruckOdometerReading {
+	return spec.TruckOdometerReading{
+		TruckId:    reading.TruckID,
+		Value:      float64(reading.Value),
+		RecordedAt: reading.RecordedAt,
+		CreatedAt:  reading.CreatedAt,
+	}
+}
+
+// truckOdometerReadingsPaginatedFromDomain returns a standardized truck odometer readings paginated response based on
+// the domain paginated response model.
+func truckOdometerReadingsPaginatedFromDomain(paginatedResponse domain.PaginatedResponse[domain.TruckOdometerReading]) spec.TruckOdometerReadingsPaginated {
+	readings := make([]spec.TruckOdometerReading, len(paginatedResponse.Results))
+	for i, reading := range paginatedResponse.Results {
+		readings[i] = truckOdometerReadingFromDomain(reading)
+	}
+
+	return spec.TruckOdometerReadingsPaginated{
+		Total:    paginatedResponse.Total,
+		Readings: readings,
+	}
+}
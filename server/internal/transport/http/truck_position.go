@@ -0,0 +1,168 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	spec "github.com/goncalo-marques/ecomap/server/api/ecomap"
+	"github.com/goncalo-marques/ecomap/server/internal/domain"
+	"github.com/goncalo-marques/ecomap/server/internal/logging"
+)
+
+const (
+	// truckPositionsStreamKeepAliveInterval defines the interval between the keep-alive comments of the truck
+	// positions stream, which prevent intermediaries from closing idle connections.
+	truckPositionsStreamKeepAliveInterval = 30 * time.Second
+
+	// truckPositionsStreamEvent defines the name of the truck positions stream events.
+	truckPositionsStreamEvent = "position"
+)
+
+// CreateTruckPositions handles the http request to create truck positions.
+func (h *handler) CreateTruckPositions(w http.ResponseWriter, r *http.Request, truckID spec.TruckIdPathParam) {
+	ctx := r.Context()
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		badRequest(w, errRequestBodyInvalid)
+		return
+	}
+
+	var truckPositionsPost spec.TruckPositionsPost
+	err = json.Unmarshal(requestBody, &truckPositionsPost)
+	if err != nil {
+		badRequest(w, errRequestBodyInvalid)
+		return
+	}
+
+	domainEditableTruckPositions, err := truckPositionsPostToDomain(truckPositionsPost)
+	if err != nil {
+		var domainErrFieldValueInvalid *domain.ErrFieldValueInvalid
+
+		switch {
+		case errors.As(err, &domainErrFieldValueInvalid):
+			badRequest(w, fmt.Sprintf("%s: %s", errFieldValueInvalid, domainErrFieldValueInvalid.FieldName))
+		default:
+			internalServerError(w)
+		}
+
+		return
+	}
+
+	err = h.service.CreateTruckPositions(ctx, truckID, domainEditableTruckPositions)
+	if err != nil {
+		var domainErrFieldValueInvalid *domain.ErrFieldValueInvalid
+
+		switch {
+		case errors.As(err, &domainErrFieldValueInvalid):
+			badRequest(w, fmt.Sprintf("%s: %s", errFieldValueInvalid, domainErrFieldValueInvalid.FieldName))
+		case errors.Is(err, domain.ErrTruckNotFound):
+			notFound(w, errTruckNotFound)
+		default:
+			internalServerError(w)
+		}
+
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// StreamTruckPositions handles the http request to stream the truck positions.
+func (h *handler) StreamTruckPositions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	responseController := http.NewResponseController(w)
+
+	// The stream is long-lived, so it must not be bound by the server write timeout.
+	err := responseController.SetWriteDeadline(time.Time{})
+	if err != nil && !errors.Is(err, http.ErrNotSupported) {
+		logging.Logger.ErrorContext(ctx, descriptionFailedToWriteResponseBody, logging.Error(err))
+		internalServerError(w)
+		return
+	}
+
+	positions, unsubscribe := h.service.SubscribeTruckPositions(ctx)
+	defer unsubscribe()
+
+	setHeaderEventStream(w)
+	w.WriteHeader(http.StatusOK)
+
+	err = responseController.Flush()
+	if err != nil {
+		logging.Logger.ErrorContext(ctx, descriptionFailedToWriteResponseBody, logging.Error(err))
+		return
+	}
+
+	keepAliveTicker := time.NewTicker(truckPositionsStreamKeepAliveInterval)
+	defer keepAliveTicker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-keepAliveTicker.C:
+			err = writeEventStreamComment(w)
+		case domainPosition, ok := <-positions:
+			if !ok {
+				return
+			}
+
+			var data []byte
+			data, err = json.Marshal(truckPositionFromDomain(domainPosition))
+			if err != nil {
+				logging.Logger.ErrorContext(ctx, descriptionFailedToMarshalResponseBody, logging.Error(err))
+				continue
+			}
+
+			err = writeEventStreamEvent(w, truckPositionsStreamEvent, data)
+		}
+		if err != nil {
+			return
+		}
+
+		err = responseController.Flush()
+		if err != nil {
+			return
+		}
+	}
+}
+
+// truckPositionsPostToDomain returns domain editable truck positions based on the standardized truck positions post.
+func truckPositionsPostToDomain(truckPositionsPost spec.TruckPositionsPost) (domain.EditableTruckPositions, error) {
+	domainPositions := make(domain.EditableTruckPositions, len(truckPositionsPost.Positions))
+
+	for i, position := range truckPositionsPost.Positions {
+		if len(position.Geometry.Coordinates) != 2 {
+			return nil, &domain.ErrFieldValueInvalid{FieldName: domain.FieldPositions}
+		}
+
+		domainPositions[i] = domain.EditableTruckPosition{
+			Geometry: domain.GeoJSONGeometryPoint{
+				Coordinates: [2]float64(position.Geometry.Coordinates),
+			},
+			Speed:      (*domain.TruckPositionSpeed)(position.Speed),
+			Heading:    (*domain.TruckPositionHeading)(position.Heading),
+			RecordedAt: position.RecordedAt,
+		}
+	}
+
+	return domainPositions, nil
+}
+
+// truckPositionFromDomain returns a standardized truck position based on the domain model.
+func truckPositionFromDomain(position domain.TruckPosition) spec.TruckPosition {
+	return spec.TruckPosition{
+		TruckId: position.TruckID,
+		Geometry: spec.GeoJSONGeometryPoint{
+			Type:        spec.Point,
+			Coordinates: position.Geometry.Coordinates[:],
+		},
+		Speed:      (*float64)(position.Speed),
+		Heading:    (*float64)(position.Heading),
+		RecordedAt: position.RecordedAt,
+	}
+}
@@ -0,0 +1,118 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	spec "github.com/goncalo-marques/ecomap/server/api/ecomap"
+	"github.com/goncalo-marques/ecomap/server/internal/domain"
+	"github.com/goncalo-marques/ecomap/server/internal/logging"
+)
+
+// Truck track default values.
+const (
+	truckTrackStopMinDurationDefaultValue = 120
+	truckTrackToleranceDefaultValue       = 10
+)
+
+// GetTruckTrack handles the http request to get the track of a truck.
+func (h *handler) GetTruckTrack(w http.ResponseWriter, r *http.Request, truckID spec.TruckIdPathParam, params spec.GetTruckTrackParams) {
+	ctx := r.Context()
+
+	domainTruckTrackFilter := getTruckTrackParamsToDomain(params)
+	domainTruckTrack, err := h.service.GetTruckTrack(ctx, truckID, domainTruckTrackFilter)
+	if err != nil {
+		var domainErrFilterValueInvalid *domain.ErrFilterValueInvalid
+
+		switch {
+		case errors.As(err, &domainErrFilterValueInvalid):
+			badRequest(w, fmt.Sprintf("%s: %s", errFilterValueInvalid, domainErrFilterValueInvalid.FilterName))
+		case errors.Is(err, domain.ErrTruckNotFound):
+			notFound(w, errTruckNotFound)
+		default:
+			internalServerError(w)
+		}
+
+		return
+	}
+
+	truckTrack, err := truckTrackFromDomain(domainTruckTrack)
+	if err != nil {
+		logging.Logger.ErrorContext(ctx, descriptionFailedToMapResponseBody, logging.Error(err))
+		internalServerError(w)
+		return
+	}
+
+	responseBody, err := json.Marshal(truckTrack)
+	if err != nil {
+		logging.Logger.ErrorContext(ctx, descriptionFailedToMarshalResponseBody, logging.Error(err))
+		internalServerError(w)
+		return
+	}
+
+	writeResponseJSON(w, http.StatusOK, responseBody)
+}
+
+// getTruckTrackParamsToDomain returns a domain truck track filter based on the standardized get truck track
+// parameters.
+func getTruckTrackParamsToDomain(params spec.GetTruckTrackParams) domain.TruckTrackFilter {
+	stopMinDuration := truckTrackStopMinDurationDefaultValue
+	if params.StopMinDuration != nil {
+		stopMinDuration = *params.StopMinDuration
+	}
+
+	tolerance := float64(truckTrackToleranceDefaultValue)
+	if params.Tolerance != nil {
+		tolerance = *params.Tolerance
+	}
+
+	return domain.TruckTrackFilter{
+		From:            params.From,
+		To:              params.To,
+		StopMinDuration: time.Duration(stopMinDuration) * time.Second,
+		Tolerance:       tolerance,
+	}
+}
+
+// truckTrackFromDomain returns a standardized truck track based on the domain model.
+func truckTrackFromDomain(track domain.TruckTrack) (spec.TruckTrack, error) {
+	roads, err := geoJSONFeatureCollectionLineStringFromDomain(track.Roads)
+	if err != nil {
+		return spec.TruckTrack{}, err
+	}
+
+	coordinates := make([][]float64, len(track.Geometry.Coordinates))
+	for i, c := range track.Geometry.Coordinates {
+		coordinates[i] = c[:]
+	}
+
+	timestamps := make([]time.Time, len(track.Timestamps))
+	copy(timestamps, track.Timestamps)
+
+	stops := make([]spec.TruckTrackStop, len(track.Stops))
+	for i, stop := range track.Stops {
+		stops[i] = spec.TruckTrackStop{
+			Geometry: spec.GeoJSONGeometryPoint{
+				Type:        spec.Point,
+				Coordinates: stop.Geometry.Coordinates[:],
+			},
+			ArrivedAt:  stop.ArrivedAt,
+			DepartedAt: stop.DepartedAt,
+			Duration:   int(stop.Duration().Seconds()),
+		}
+	}
+
+	return spec.TruckTrack{
+		Geometry: spec.GeoJSONGeometryLineString{
+			Type:        spec.LineString,
+			Coordinates: coordinates,
+		},
+		Timestamps: timestamps,
+		Distance:   track.Distance,
+		Stops:      stops,
+		Roads:      roads,
+	}, nil
+}
@@ -37,9 +37,12 @@ func (h *handler) CreateUser(w http.ResponseWriter, r *http.Request) {
 	domainEditableUser := userPostToDomain(userPost)
 	domainUser, err := h.service.CreateUser(ctx, domainEditableUser)
 	if err != nil {
+		var domainErrPasswordPolicyViolated *domain.ErrPasswordPolicyViolated
 		var domainErrFieldValueInvalid *domain.ErrFieldValueInvalid
 
 		switch {
+		case errors.As(err, &domainErrPasswordPolicyViolated):
+			badRequest(w, fmt.Sprintf("%s: %s", errPasswordPolicyViolated, domainErrPasswordPolicyViolated.Rule))
 		case errors.As(err, &domainErrFieldValueInvalid):
 			badRequest(w, fmt.Sprintf("%s: %s", errFieldValueInvalid, domainErrFieldValueInvalid.FieldName))
 		case errors.Is(err, domain.ErrUserAlreadyExists):
@@ -210,15 +213,26 @@ func (h *handler) UpdateUserPassword(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = h.service.UpdateUserPassword(ctx, domain.Username(passwordChange.Username), domain.Password(passwordChange.OldPassword), domain.Password(passwordChange.NewPassword))
+	err = h.service.UpdateUserPassword(ctx, domain.Username(passwordChange.Username), domain.Password(passwordChange.OldPassword), domain.Password(passwordChange.NewPassword), h.clientIPAddress(r))
 	if err != nil {
+		var domainErrPasswordPolicyViolated *domain.ErrPasswordPolicyViolated
 		var domainErrFieldValueInvalid *domain.ErrFieldValueInvalid
+		var domainErrSignInLocked *domain.ErrSignInLocked
+		var domainErrSignInThrottled *domain.ErrSignInThrottled
 
 		switch {
+		case errors.As(err, &domainErrPasswordPolicyViolated):
+			badRequest(w, fmt.Sprintf("%s: %s", errPasswordPolicyViolated, domainErrPasswordPolicyViolated.Rule))
 		case errors.As(err, &domainErrFieldValueInvalid):
 			badRequest(w, fmt.Sprintf("%s: %s", errFieldValueInvalid, domainErrFieldValueInvalid.FieldName))
 		case errors.Is(err, domain.ErrCredentialsIncorrect):
 			unauthorized(w, errCredentialsIncorrect)
+		case errors.As(err, &domainErrSignInLocked):
+			setHeaderRetryAfter(w, domainErrSignInLocked.LockedUntil)
+			locked(w, errSignInLocked)
+		case errors.As(err, &domainErrSignInThrottled):
+			setHeaderRetryAfter(w, domainErrSignInThrottled.RetryAt)
+			tooManyRequests(w, errSignInThrottled)
 		default:
 			internalServerError(w)
 		}
@@ -248,9 +262,12 @@ func (h *handler) ResetUserPassword(w http.ResponseWriter, r *http.Request) {
 
 	err = h.service.ResetUserPassword(ctx, domain.Username(passwordReset.Username), domain.Password(passwordReset.NewPassword))
 	if err != nil {
+		var domainErrPasswordPolicyViolated *domain.ErrPasswordPolicyViolated
 		var domainErrFieldValueInvalid *domain.ErrFieldValueInvalid
 
 		switch {
+		case errors.As(err, &domainErrPasswordPolicyViolated):
+			badRequest(w, fmt.Sprintf("%s: %s", errPasswordPolicyViolated, domainErrPasswordPolicyViolated.Rule))
 		case errors.As(err, &domainErrFieldValueInvalid):
 			badRequest(w, fmt.Sprintf("%s: %s", errFieldValueInvalid, domainErrFieldValueInvalid.FieldName))
 		case errors.Is(err, domain.ErrUserNotFound):
@@ -282,11 +299,20 @@ func (h *handler) SignInUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	token, err := h.service.SignInUser(ctx, domain.Username(signIn.Username), domain.Password(signIn.Password))
+	tokens, err := h.service.SignInUser(ctx, domain.Username(signIn.Username), domain.Password(signIn.Password), h.clientIPAddress(r))
 	if err != nil {
+		var domainErrSignInLocked *domain.ErrSignInLocked
+		var domainErrSignInThrottled *domain.ErrSignInThrottled
+
 		switch {
 		case errors.Is(err, domain.ErrCredentialsIncorrect):
 			unauthorized(w, errCredentialsIncorrect)
+		case errors.As(err, &domainErrSignInLocked):
+			setHeaderRetryAfter(w, domainErrSignInLocked.LockedUntil)
+			locked(w, errSignInLocked)
+		case errors.As(err, &domainErrSignInThrottled):
+			setHeaderRetryAfter(w, domainErrSignInThrottled.RetryAt)
+			tooManyRequests(w, errSignInThrottled)
 		default:
 			internalServerError(w)
 		}
@@ -294,7 +320,7 @@ func (h *handler) SignInUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	jwt := jwtFromJWTToken(token)
+	jwt := jwtFromDomain(tokens)
 	responseBody, err := json.Marshal(jwt)
 	if err != nil {
 		logging.Logger.ErrorContext(ctx, descriptionFailedToMarshalResponseBody, logging.Error(err))
@@ -305,6 +331,38 @@ func (h *handler) SignInUser(w http.ResponseWriter, r *http.Request) {
 	writeResponseJSON(w, http.StatusOK, responseBody)
 }
 
+// SignOutUser handles the http request to sign out a user.
+func (h *handler) SignOutUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		badRequest(w, errRequestBodyInvalid)
+		return
+	}
+
+	var refreshTokenPost spec.RefreshTokenPost
+	err = json.Unmarshal(requestBody, &refreshTokenPost)
+	if err != nil {
+		badRequest(w, errRequestBodyInvalid)
+		return
+	}
+
+	err = h.service.SignOutUser(ctx, refreshTokenPost.RefreshToken)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrRefreshTokenInvalid):
+			unauthorized(w, errRefreshTokenInvalid)
+		default:
+			internalServerError(w)
+		}
+
+		return
+	}
+
+	writeResponseJSON(w, http.StatusNoContent, nil)
+}
+
 // userPostToDomain returns a domain editable user with password based on the standardized user post.
 func userPostToDomain(userPost spec.UserPost) domain.EditableUserWithPassword {
 	return domain.EditableUserWithPassword{
@@ -312,6 +370,7 @@ func userPostToDomain(userPost spec.UserPost) domain.EditableUserWithPassword {
 			Username:  domain.Username(userPost.Username),
 			FirstName: domain.Name(userPost.FirstName),
 			LastName:  domain.Name(userPost.LastName),
+			Email:     (*domain.Email)(userPost.Email),
 		},
 		Password: domain.Password(userPost.Password),
 	}
@@ -323,6 +382,7 @@ func userPatchToDomain(userPatch spec.UserPatch) domain.EditableUserPatch {
 		Username:  (*domain.Username)(userPatch.Username),
 		FirstName: (*domain.Name)(userPatch.FirstName),
 		LastName:  (*domain.Name)(userPatch.LastName),
+		Email:     (*domain.Email)(userPatch.Email),
 	}
 }
 
@@ -362,12 +422,14 @@ func listUsersParamsToDomain(params spec.ListUsersParams) domain.UsersPaginatedF
 // userFromDomain returns a standardized user based on the domain model.
 func userFromDomain(user domain.User) spec.User {
 	return spec.User{
-		Id:         user.ID,
-		Username:   string(user.Username),
-		FirstName:  string(user.FirstName),
-		LastName:   string(user.LastName),
-		CreatedAt:  user.CreatedAt,
-		ModifiedAt: user.ModifiedAt,
+		Id:            user.ID,
+		Username:      string(user.Username),
+		FirstName:     string(user.FirstName),
+		LastName:      string(user.LastName),
+		Email:         (*spec.Email)(user.Email),
+		EmailVerified: user.EmailVerified(),
+		CreatedAt:     user.CreatedAt,
+		ModifiedAt:    user.ModifiedAt,
 	}
 }
 
@@ -0,0 +1,113 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	spec "github.com/goncalo-marques/ecomap/server/api/ecomap"
+	"github.com/goncalo-marques/ecomap/server/internal/domain"
+)
+
+const (
+	errUserTokenInvalid = "invalid or expired token"
+)
+
+// ForgotUserPassword handles the http request to request a user password reset.
+func (h *handler) ForgotUserPassword(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		badRequest(w, errRequestBodyInvalid)
+		return
+	}
+
+	var passwordForgot spec.PasswordForgot
+	err = json.Unmarshal(requestBody, &passwordForgot)
+	if err != nil {
+		badRequest(w, errRequestBodyInvalid)
+		return
+	}
+
+	err = h.service.ForgotUserPassword(ctx, domain.Username(passwordForgot.Username))
+	if err != nil {
+		internalServerError(w)
+		return
+	}
+
+	writeResponseJSON(w, http.StatusAccepted, nil)
+}
+
+// ConfirmUserPasswordReset handles the http request to confirm a user password reset.
+func (h *handler) ConfirmUserPasswordReset(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		badRequest(w, errRequestBodyInvalid)
+		return
+	}
+
+	var passwordResetConfirm spec.PasswordResetConfirm
+	err = json.Unmarshal(requestBody, &passwordResetConfirm)
+	if err != nil {
+		badRequest(w, errRequestBodyInvalid)
+		return
+	}
+
+	err = h.service.ConfirmUserPasswordReset(ctx, passwordResetConfirm.Token, domain.Password(passwordResetConfirm.NewPassword))
+	if err != nil {
+		var domainErrPasswordPolicyViolated *domain.ErrPasswordPolicyViolated
+		var domainErrFieldValueInvalid *domain.ErrFieldValueInvalid
+
+		switch {
+		case errors.As(err, &domainErrPasswordPolicyViolated):
+			badRequest(w, fmt.Sprintf("%s: %s", errPasswordPolicyViolated, domainErrPasswordPolicyViolated.Rule))
+		case errors.As(err, &domainErrFieldValueInvalid):
+			badRequest(w, fmt.Sprintf("%s: %s", errFieldValueInvalid, domainErrFieldValueInvalid.FieldName))
+		case errors.Is(err, domain.ErrUserTokenInvalid):
+			unauthorized(w, errUserTokenInvalid)
+		default:
+			internalServerError(w)
+		}
+
+		return
+	}
+
+	writeResponseJSON(w, http.StatusNoContent, nil)
+}
+
+// ConfirmUserEmail handles the http request to confirm a user email address.
+func (h *handler) ConfirmUserEmail(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		badRequest(w, errRequestBodyInvalid)
+		return
+	}
+
+	var emailVerificationConfirm spec.EmailVerificationConfirm
+	err = json.Unmarshal(requestBody, &emailVerificationConfirm)
+	if err != nil {
+		badRequest(w, errRequestBodyInvalid)
+		return
+	}
+
+	err = h.service.ConfirmUserEmail(ctx, emailVerificationConfirm.Token)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrUserTokenInvalid):
+			unauthorized(w, errUserTokenInvalid)
+		default:
+			internalServerError(w)
+		}
+
+		return
+	}
+
+	writeResponseJSON(w, http.StatusNoContent, nil)
+}